@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/metrics"
 	"google.golang.org/grpc"
@@ -53,11 +54,25 @@ func (svc *Service) Append(stream pb.Journal_AppendServer) (err error) {
 	case stateFinished:
 		metrics.CommitsTotal.WithLabelValues(metrics.Ok).Inc()
 
-		return stream.SendAndClose(&pb.AppendResponse{
+		var resp = &pb.AppendResponse{
 			Status: pb.Status_OK,
 			Header: fsm.resolved.Header,
 			Commit: fsm.clientFragment,
-		})
+		}
+		if stores := fsm.resolved.journalSpec.Fragment.Stores; req.SignatureTTL != nil && len(stores) != 0 {
+			// The Persister will write the rolled |commit| Fragment to stores[0].
+			resp.Commit.BackingStore = stores[0]
+
+			// The Append has committed, and must not fail for want of a URL.
+			var signErr error
+			var ttl = fragment.BoundSignatureTTL(*req.SignatureTTL)
+
+			if resp.FragmentUrl, signErr = signGetURL(*resp.Commit, ttl); signErr != nil {
+				log.WithFields(log.Fields{"err": signErr, "journal": req.Journal, "store": stores[0]}).
+					Warn("failed to sign committed fragment URL")
+			}
+		}
+		return stream.SendAndClose(resp)
 	case stateError:
 		if fsm.resolved.status != pb.Status_OK {
 			metrics.CommitsTotal.WithLabelValues(fsm.resolved.status.String()).Inc()
@@ -105,3 +120,6 @@ func proxyAppend(stream grpc.ServerStream, req *pb.AppendRequest, jc pb.JournalC
 		return stream.SendMsg(resp)
 	}
 }
+
+// signGetURL signs Fragment URLs of AppendResponses. It's a test hook.
+var signGetURL = fragment.SignGetURL
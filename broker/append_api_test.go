@@ -3,10 +3,13 @@ package broker
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.gazette.dev/core/broker/client"
 	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/etcdtest"
@@ -95,6 +98,207 @@ func TestAppendBadlyBehavedClientCases(t *testing.T) {
 	broker.cleanup()
 }
 
+func TestAppendIdempotencyKey(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+	broker.initialFragmentLoad()
+
+	var doAppend = func(key string, content string) *pb.Fragment {
+		var stream, _ = broker.client().Append(ctx)
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal", IdempotencyKey: key}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte(content)}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+		assert.NoError(t, stream.CloseSend())
+
+		var resp, err = stream.CloseAndRecv()
+		assert.NoError(t, err)
+		assert.Equal(t, pb.Status_OK, resp.Status)
+		return resp.Commit
+	}
+	var expect = &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            0,
+		End:              3,
+		Sum:              pb.SHA1SumOf("foo"),
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+
+	// Case: first Append of a key commits its content.
+	assert.Equal(t, expect, doAppend("key-one", "foo"))
+	// Case: a retried Append of the key returns the prior commit, and its
+	// content is discarded rather than appended again.
+	assert.Equal(t, expect, doAppend("key-one", "foo"))
+
+	// Case: a retry which rolls back (by closing without a commit chunk)
+	// is still an error.
+	var stream, _ = broker.client().Append(ctx)
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal", IdempotencyKey: "key-one"}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte("foo")}))
+	assert.NoError(t, stream.CloseSend())
+
+	var _, err = stream.CloseAndRecv()
+	assert.EqualError(t, err,
+		`rpc error: code = Unknown desc = append stream: unexpected EOF`)
+
+	// Case: Appends having a different key, or no key, are appended as usual.
+	expect.Begin, expect.End, expect.Sum = 3, 6, pb.SHA1SumOf("bar")
+	assert.Equal(t, expect, doAppend("key-two", "bar"))
+	expect.Begin, expect.End = 6, 9
+	assert.Equal(t, expect, doAppend("", "bar"))
+	expect.Begin, expect.End = 9, 12
+	assert.Equal(t, expect, doAppend("", "bar"))
+
+	// Case: an Append of a key reserved by a pending Append awaits its outcome.
+	// If the pending Append commits, its commit is returned.
+	var keys = broker.svc.resolver.replicas["a/journal"].replica.appendKeys
+	var pending, reserved = keys.reserve("key-three", time.Now())
+	assert.True(t, reserved)
+
+	var doneCh = make(chan *pb.Fragment)
+	go func() { doneCh <- doAppend("key-three", "baz") }()
+
+	select {
+	case <-doneCh:
+		t.Fatal("expected Append to await the pending Append")
+	case <-time.After(50 * time.Millisecond):
+	}
+	var pendingCommit = pb.Fragment{Journal: "a/journal", Begin: 12, End: 15, Sum: pb.SHA1SumOf("baz")}
+	keys.record("key-three", pendingCommit, time.Now())
+	assert.Equal(t, &pendingCommit, <-doneCh)
+	var _, ok = <-pending.resolved
+	assert.False(t, ok)
+
+	// Case: if the pending Append fails, the awaiting Append is appended.
+	pending, reserved = keys.reserve("key-four", time.Now())
+	assert.True(t, reserved)
+
+	go func() { doneCh <- doAppend("key-four", "baz") }()
+	time.Sleep(10 * time.Millisecond)
+	keys.release(pending)
+
+	expect.Begin, expect.End, expect.Sum = 12, 15, pb.SHA1SumOf("baz")
+	assert.Equal(t, expect, <-doneCh)
+
+	// And its commit is recorded.
+	var commit, found = keys.lookup("key-four", time.Now())
+	assert.True(t, found)
+	assert.Equal(t, *expect, commit)
+
+	broker.cleanup()
+}
+
+func TestAppendSignedFragmentURL(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var tmpDir, err = ioutil.TempDir("", "append-signed")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(tmpDir)) }()
+
+	defer func(s string) { fragment.FileSystemStoreRoot = s }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = tmpDir
+	defer client.InstallFileTransport(tmpDir)()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Replication: 1,
+		Fragment:    pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{"file:///"}},
+	}, broker.id)
+	broker.initialFragmentLoad()
+
+	var doAppend = func(content string, ttl *time.Duration) *pb.AppendResponse {
+		var stream, _ = broker.client().Append(ctx)
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal", SignatureTTL: ttl}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte(content)}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+		assert.NoError(t, stream.CloseSend())
+
+		var resp, err = stream.CloseAndRecv()
+		assert.NoError(t, err)
+		assert.Equal(t, pb.Status_OK, resp.Status)
+		return resp
+	}
+	// Append content which remains in the current Fragment.
+	assert.Equal(t, "", doAppend("foo", nil).FragmentUrl)
+	assert.Equal(t, "", doAppend("bar", nil).FragmentUrl)
+
+	// Case: the Fragment is rolled ahead of a signed Append, and again after,
+	// such that the returned URL is of a Fragment holding exactly its content.
+	var ttl = time.Minute
+	var resp = doAppend("signed content", &ttl)
+
+	assert.Equal(t, &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            6,
+		End:              20,
+		Sum:              pb.SHA1SumOf("signed content"),
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+		BackingStore:     "file:///",
+	}, resp.Commit)
+	assert.Equal(t, "file:///"+resp.Commit.ContentPath(), resp.FragmentUrl)
+
+	// The rolled Fragment is persisted asynchronously. Expect the URL becomes
+	// readable, and reads back the appended content.
+	var fr *client.FragmentReader
+	for fr == nil {
+		if fr, err = client.OpenFragmentURL(ctx, *resp.Commit, resp.Commit.Begin, resp.FragmentUrl); err != nil {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	content, err := ioutil.ReadAll(fr)
+	assert.NoError(t, err)
+	assert.Equal(t, "signed content", string(content))
+	assert.NoError(t, fr.Close())
+
+	// Case: a SignatureTTL larger than MaxSignatureTTL is truncated.
+	defer func(fn func(pb.Fragment, time.Duration) (string, error)) { signGetURL = fn }(signGetURL)
+
+	var signedTTL time.Duration
+	signGetURL = func(frag pb.Fragment, d time.Duration) (string, error) {
+		signedTTL = d
+		return fragment.SignGetURL(frag, d)
+	}
+	ttl = fragment.MaxSignatureTTL + time.Hour
+	assert.NotEqual(t, "", doAppend("more content", &ttl).FragmentUrl)
+	assert.Equal(t, fragment.MaxSignatureTTL, signedTTL)
+
+	broker.cleanup()
+}
+
+func TestAppendSignedFragmentURLFailure(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Replication: 1,
+		// The store has an unknown argument, and its URLs can't be signed.
+		Fragment: pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{"file:///?unknown=arg"}},
+	}, broker.id)
+	broker.initialFragmentLoad()
+
+	var ttl = time.Minute
+	var stream, _ = broker.client().Append(ctx)
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal", SignatureTTL: &ttl}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte("content")}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+
+	// Expect the committed Append succeeds, without a FragmentUrl.
+	var resp, err = stream.CloseAndRecv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, resp.Status)
+	assert.Equal(t, int64(7), resp.Commit.End)
+	assert.Equal(t, pb.FragmentStore("file:///?unknown=arg"), resp.Commit.BackingStore)
+	assert.Equal(t, "", resp.FragmentUrl)
+
+	broker.cleanup()
+}
+
 func TestAppendRequestErrorCases(t *testing.T) {
 	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -158,6 +362,21 @@ func TestAppendRequestErrorCases(t *testing.T) {
 		Header: *broker.header("read/only"),
 	}, resp)
 
+	// Case: Journal which is sealed.
+	setTestJournal(broker, pb.JournalSpec{Name: "sealed/journal", Replication: 1,
+		Seal: &pb.JournalSpec_Seal{Offset: 0}}, broker.id)
+	broker.initialFragmentLoad()
+	stream, _ = broker.client().Append(ctx)
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "sealed/journal"}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte("foo")}))
+
+	resp, err = stream.CloseAndRecv()
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.AppendResponse{
+		Status: pb.Status_JOURNAL_SEALED,
+		Header: *broker.header("sealed/journal"),
+	}, resp)
+
 	// Case: incorrect request Offset.
 	setTestJournal(broker, pb.JournalSpec{Name: "valid/journal", Replication: 1}, broker.id)
 	// Initial fragment index load with a non-empty Fragment fixture.
@@ -0,0 +1,40 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// AppendsDebugHandler returns an http.Handler which lists appends currently
+// being evaluated by the broker, with the appendFSM state of each and the
+// time at which it was entered. It's intended to aid operators in diagnosing
+// appends which are stuck (eg, awaiting desired replicas). Requests are GETs,
+// and the response is a JSON array ordered on ascending state entry time,
+// such that the longest-stuck appends are listed first.
+func (svc *Service) AppendsDebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "expected GET", http.StatusMethodNotAllowed)
+			return
+		}
+		var out = svc.inFlightAppendStates()
+
+		w.Header().Set("Content-Type", "application/json")
+		var enc = json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	})
+}
+
+// inFlightAppendStates returns a snapshot of in-flight appends, ordered on
+// the time at which they entered their current state.
+func (svc *Service) inFlightAppendStates() []inFlightAppend {
+	var out = make([]inFlightAppend, 0)
+	svc.inFlightAppends.Range(func(_, v interface{}) bool {
+		out = append(out, v.(inFlightAppend))
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].Since.Before(out[j].Since) })
+	return out
+}
@@ -12,6 +12,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/metrics"
 )
 
 // appendChunkTimeout is the maximum duration a single call to read an
@@ -25,6 +26,9 @@ import (
 // window with new data. Very long lived clients and append streams are still
 // permitted (though not recommended), so long as the client is consistently
 // responsive to requests for more data.
+//
+// appendChunkTimeout is the default, which individual journals may override
+// through JournalSpec.AppendChunkTimeout.
 var appendChunkTimeout = time.Second
 
 // appendFSM is a state machine which models the steps, constraints and
@@ -43,9 +47,13 @@ type appendFSM struct {
 	readThroughRev int64            // Etcd revision we must read through to proceed.
 	rollToOffset   int64            // Journal write offset we must synchronize on to proceed.
 	clientCommit   bool             // Did we see a commit chunk from the client?
+	clientDupe     bool             // Is the client's Append a duplicate of a committed one?
+	clientKey      *appendKey       // Reservation of the request IdempotencyKey, if held.
 	clientFragment *pb.Fragment     // Journal Fragment holding the client's content.
 	clientSummer   hash.Hash        // Summer over the client's content.
+	ackSentAt      time.Time        // Time at which the last acknowledged proposal was scattered.
 	state          appendState      // Current FSM state.
+	stateTimer     appendStateTimer // Time spent in each FSM state.
 	err            error            // Error encountered during FSM execution.
 }
 
@@ -70,10 +78,12 @@ const (
 // run the appendFSM until a terminal state is reached. Upon state
 // stateStreamContent, |recv| is repeatedly invoked to read content from the
 // client. A timer is used to enforce that a call to |recv| not take more
-// than 2 x appendChunkTimeout. If this timeout elapses, a
+// than 2 x the journal's append chunk timeout. If this timeout elapses, a
 // context.DeadlineExceeded read error is injected to abort the stream.
 func (b *appendFSM) run(recv func() (*pb.AppendRequest, error)) {
+	defer b.observeStateTimes()
 	defer b.returnPipeline()
+	defer b.releaseAppendKey()
 
 	// Run until we're ready to stream content, or we fail.
 	if !b.runTo(stateStreamContent) {
@@ -81,7 +91,7 @@ func (b *appendFSM) run(recv func() (*pb.AppendRequest, error)) {
 	}
 
 	var (
-		ticker  = time.NewTicker(appendChunkTimeout)
+		ticker  = time.NewTicker(b.chunkTimeout())
 		chunkCh = make(chan appendChunk, 8)
 	)
 
@@ -120,14 +130,25 @@ func (b *appendFSM) run(recv func() (*pb.AppendRequest, error)) {
 	}
 	ticker.Stop()
 
-	b.onReadAcknowledgements()
+	if b.state == stateReadAcknowledgements {
+		b.onReadAcknowledgements()
+	}
+}
+
+// chunkTimeout returns the append chunk timeout of the resolved journal,
+// or appendChunkTimeout if the JournalSpec doesn't set one.
+func (b *appendFSM) chunkTimeout() time.Duration {
+	if d := b.resolved.journalSpec.AppendChunkTimeout; d != 0 {
+		return d
+	}
+	return appendChunkTimeout
 }
 
 // runTo evaluates appendFSM until |state| is reached and returns true.
 // If another terminal state is instead reached first, it returns false.
 func (b *appendFSM) runTo(state appendState) bool {
 	for {
-		if b.state == state {
+		if b.updateStateTimer(); b.state == state {
 			return true
 		}
 		switch b.state {
@@ -194,10 +215,25 @@ func (b *appendFSM) onResolve() {
 }
 
 // onAcquirePipeline performs a blocking acquisition of the exclusively-owned
-// replica pipeline.
+// replica pipeline. If the journal has a MaxAppendRate, it first waits until
+// the replica's appendLimiter is no longer in debt.
 func (b *appendFSM) onAcquirePipeline() {
 	b.mustState(stateAcquirePipeline)
 
+	if rate := b.resolved.journalSpec.MaxAppendRate; rate != 0 {
+		if delay := b.resolved.replica.appendLimiter.delay(rate, timeNow()); delay != 0 {
+			addTrace(b.ctx, " ... throttling append for %s (MaxAppendRate %d)", delay, rate)
+
+			select {
+			case <-time.After(delay):
+			case <-b.ctx.Done():
+				goto contextCanceled
+			case <-b.resolved.invalidateCh:
+				goto resolutionInvalidated
+			}
+		}
+	}
+
 	// Attempt to obtain exclusive ownership of the replica's pipeline.
 	select {
 	case b.pln = <-b.resolved.replica.pipelineCh:
@@ -241,10 +277,11 @@ resolutionInvalidated:
 func (b *appendFSM) onStartPipeline() {
 	b.mustState(stateStartPipeline)
 
-	// Do we have an extant pipeline matching our resolved Route? If so, by
-	// construction we also know that it's been synchronized. Otherwise tear
-	// down an older pipeline and start anew.
-	if b.pln != nil && b.pln.Route.Equivalent(&b.resolved.Route) {
+	// Do we have an extant pipeline matching our resolved Route (and written
+	// replicas)? If so, by construction we also know that it's been synchronized.
+	// Otherwise tear down an older pipeline and start anew.
+	if b.pln != nil && b.pln.Route.Equivalent(&b.resolved.Route) &&
+		b.pln.writeReplication == int(b.resolved.journalSpec.WriteReplication) {
 		b.state = stateUpdateAssignments
 		return
 	} else if b.pln != nil {
@@ -271,7 +308,9 @@ func (b *appendFSM) onStartPipeline() {
 
 	// Build a pipeline around |spool|. Note the pipeline Context is bound
 	// to the replica (rather than our |b.args.ctx|).
-	b.pln = newPipeline(b.resolved.replica.ctx, b.resolved.Header, spool, b.resolved.replica.spoolCh, b.svc.jc)
+	b.pln = newPipeline(b.resolved.replica.ctx, b.resolved.Header, spool, b.resolved.replica.spoolCh, b.svc.jc,
+		int(b.resolved.journalSpec.WriteReplication))
+	b.pln.history = b.resolved.replica.history
 	b.state = stateSendPipelineSync
 }
 
@@ -279,7 +318,7 @@ func (b *appendFSM) onStartPipeline() {
 func (b *appendFSM) onSendPipelineSync() {
 	b.mustState(stateSendPipelineSync)
 
-	var proposal = nextProposal(b.pln.spool, b.rollToOffset, b.resolved.journalSpec.Fragment)
+	var proposal = nextProposal(b.pln.spool, b.rollToOffset, b.resolved.replica.fragmentSpec(b.resolved.journalSpec.Fragment))
 	var req = &pb.ReplicateRequest{
 		Proposal:    &proposal,
 		Acknowledge: true,
@@ -291,6 +330,7 @@ func (b *appendFSM) onSendPipelineSync() {
 		req.Journal = b.pln.spool.Journal
 	}
 
+	b.ackSentAt = time.Now()
 	b.pln.scatter(req)
 	b.state = stateRecvPipelineSync
 }
@@ -300,6 +340,7 @@ func (b *appendFSM) onRecvPipelineSync() {
 	b.mustState(stateRecvPipelineSync)
 
 	b.rollToOffset, b.readThroughRev = b.pln.gatherSync()
+	b.pln.observeAcks(b.ackSentAt)
 
 	if b.err = b.pln.recvErr(); b.err == nil {
 		b.err = b.pln.sendErr()
@@ -391,7 +432,7 @@ func (b *appendFSM) onAwaitDesiredReplicas() {
 // the operator is required to craft an AppendRequest which explicitly
 // captures the new, maximum journal offset to use.
 //
-// We do make an exception if the journal is not writable, in which case
+// We do make an exception if the journal is not writable (or is sealed), in which case
 // appendFSM can be used only for issuing zero-byte transaction barriers
 // and there's no risk of double-writes to offsets. In particular this
 // carve-out allows a journal to be a read-only view of a fragment store
@@ -414,7 +455,9 @@ func (b *appendFSM) onValidateOffset() {
 		maxOffset = eo
 	}
 
-	if b.pln.spool.End != maxOffset && b.req.Offset == 0 && b.resolved.journalSpec.Flags.MayWrite() {
+	var mayWrite = b.resolved.journalSpec.Flags.MayWrite() && b.resolved.journalSpec.Seal == nil
+
+	if b.pln.spool.End != maxOffset && b.req.Offset == 0 && mayWrite {
 		b.resolved.status = pb.Status_INDEX_HAS_GREATER_OFFSET
 		b.state = stateError
 	} else if b.req.Offset != 0 && b.req.Offset != maxOffset {
@@ -435,13 +478,44 @@ func (b *appendFSM) onValidateOffset() {
 func (b *appendFSM) onStreamContent(req *pb.AppendRequest, err error) {
 	b.mustState(stateStreamContent)
 
+	if b.clientFragment == nil && b.req.IdempotencyKey != "" && err == nil {
+		// This is our first call to onStreamContent. If the request's
+		// IdempotencyKey matches an already-committed Append, then this Append
+		// is a duplicate (eg, a client retry of an Append which committed, but
+		// for which the client saw an error). Its content is read & discarded,
+		// and the prior commit is returned.
+		if commit, ok, keyErr := b.reserveAppendKey(); keyErr != nil {
+			err = keyErr
+		} else if ok {
+			addTrace(b.ctx, " ... duplicate of committed append %s", commit)
+			b.clientFragment, b.clientDupe = &commit, true
+		}
+	}
+
 	if b.clientFragment == nil {
 		// This is our first call to onStreamContent.
 
 		// Potentially roll the Fragment forward ahead of this append. Our
 		// pipeline is synchronized, so we expect this will always succeed
 		// and don't ask for an acknowledgement.
-		var proposal = nextProposal(b.pln.spool, 0, b.resolved.journalSpec.Fragment)
+		var proposal = nextProposal(b.pln.spool, 0, b.resolved.replica.fragmentSpec(b.resolved.journalSpec.Fragment))
+
+		if proposal.ContentType != b.req.ContentType {
+			// Fragments have a uniform ContentType. Roll to a new Fragment
+			// having the ContentType of this Append.
+			proposal.Begin, proposal.Sum = proposal.End, pb.SHA1Sum{}
+			proposal.CompressionCodec = b.resolved.journalSpec.Fragment.CompressionCodec
+			proposal.PathPostfix = b.resolved.journalSpec.Fragment.PathPostfix(timeNow())
+			proposal.ContentType = b.req.ContentType
+			addTrace(b.ctx, " ... rolling to ContentType %q", proposal.ContentType)
+		}
+		if b.req.SignatureTTL != nil && proposal.ContentLength() != 0 {
+			// Roll to a new Fragment which will hold only this Append.
+			proposal.Begin, proposal.Sum = proposal.End, pb.SHA1Sum{}
+			proposal.CompressionCodec = b.resolved.journalSpec.Fragment.CompressionCodec
+			proposal.PathPostfix = b.resolved.journalSpec.Fragment.PathPostfix(timeNow())
+			addTrace(b.ctx, " ... rolling for signed Append")
+		}
 
 		if b.pln.spool.Fragment.Fragment != proposal {
 			b.pln.scatter(&pb.ReplicateRequest{
@@ -455,6 +529,8 @@ func (b *appendFSM) onStreamContent(req *pb.AppendRequest, err error) {
 			Begin:            b.pln.spool.End,
 			End:              b.pln.spool.End,
 			CompressionCodec: b.pln.spool.CompressionCodec,
+			ContentType:      b.pln.spool.ContentType,
+			PathPostfix:      b.pln.spool.PathPostfix,
 		}
 		b.clientSummer = sha1.New()
 	}
@@ -477,11 +553,20 @@ func (b *appendFSM) onStreamContent(req *pb.AppendRequest, err error) {
 		// Empty chunk indicates an EOF will follow, at which point we commit.
 		b.clientCommit = true
 		return
+	} else if err == nil && b.clientDupe {
+		return // Discard content of a duplicate Append.
+	} else if err == nil && b.resolved.journalSpec.Seal != nil {
+		// Non-empty appends cannot be made to sealed journals.
+		b.resolved.status = pb.Status_JOURNAL_SEALED
 	} else if err == nil && !b.resolved.journalSpec.Flags.MayWrite() {
 		// Non-empty appends cannot be made to non-writable journals.
 		b.resolved.status = pb.Status_NOT_ALLOWED
 	} else if err == nil {
-		// Regular content chunk. Forward it through the pipeline.
+		// Regular content chunk. Charge it to the rate limit of the journal
+		// (which throttles Appends that follow), and forward it through the pipeline.
+		if rate := b.resolved.journalSpec.MaxAppendRate; rate != 0 {
+			b.resolved.replica.appendLimiter.consume(rate, len(req.Content), timeNow())
+		}
 		b.pln.scatter(&pb.ReplicateRequest{
 			Content:      req.Content,
 			ContentDelta: b.clientFragment.ContentLength(),
@@ -495,6 +580,16 @@ func (b *appendFSM) onStreamContent(req *pb.AppendRequest, err error) {
 	}
 
 	// We've errored, or reached end-of-input for this Append stream.
+	if b.clientDupe {
+		// Nothing was written to the pipeline, and there's nothing to roll back.
+		if err == io.EOF && b.resolved.status == pb.Status_OK {
+			b.state = stateFinished
+		} else {
+			b.err = errors.Wrap(err, "append stream") // This may be nil.
+			b.state = stateError
+		}
+		return
+	}
 	b.clientFragment.Sum = pb.SHA1SumFromDigest(b.clientSummer.Sum(nil))
 
 	var proposal = new(pb.Fragment)
@@ -513,10 +608,27 @@ func (b *appendFSM) onStreamContent(req *pb.AppendRequest, err error) {
 		b.err = errors.Wrap(err, "append stream") // This may be nil.
 	}
 
+	b.ackSentAt = time.Now()
 	b.pln.scatter(&pb.ReplicateRequest{
 		Proposal:    proposal,
 		Acknowledge: true,
 	})
+
+	if b.err == nil && b.req.SignatureTTL != nil {
+		// Roll the Fragment holding exactly this Append, so that it's persisted
+		// as-is. As with rolls ahead of an Append, we don't ask for an
+		// acknowledgement: |roll| follows from the commit, and applies to each
+		// peer which applied the commit.
+		var roll = *proposal
+		roll.Begin, roll.Sum = roll.End, pb.SHA1Sum{}
+		roll.CompressionCodec = b.resolved.journalSpec.Fragment.CompressionCodec
+		roll.PathPostfix = b.resolved.journalSpec.Fragment.PathPostfix(timeNow())
+
+		b.pln.scatter(&pb.ReplicateRequest{
+			Proposal:    &roll,
+			Acknowledge: false,
+		})
+	}
 	b.state = stateReadAcknowledgements
 }
 
@@ -525,6 +637,7 @@ func (b *appendFSM) onStreamContent(req *pb.AppendRequest, err error) {
 // reads responses from each replication peer.
 func (b *appendFSM) onReadAcknowledgements() {
 	b.mustState(stateReadAcknowledgements)
+	b.updateStateTimer()
 
 	// Retain sendErr(), as we cannot safely access it upon sending to |releaseCh|.
 	var sendErr = b.pln.sendErr()
@@ -543,20 +656,43 @@ func (b *appendFSM) onReadAcknowledgements() {
 	// read their responses. Block while they do so, until our response is the
 	// next ordered response to be received. When this select completes, we have
 	// sole ownership of the _receive_ side of |pln|.
+	var stallBegan = time.Now()
 	select {
 	case <-waitFor:
 	default:
 		addTrace(b.ctx, " ... stalled in <-waitFor read barrier")
 		<-waitFor
 	}
-	// Defer a close that will signal operations pipelined after ourselves,
-	// that they may in turn read their responses.
-	defer func() { close(closeAfter) }()
-
-	// We expect an acknowledgement from each peer. If we encountered a send
-	// error, we also expect an EOF from remaining non-broken peers.
-	if b.pln.gatherOK(); sendErr != nil {
-		b.pln.gatherEOF()
+	metrics.AppendFSMReadBarrierSeconds.WithLabelValues(boundedJournalLabel(b.req.Journal)).
+		Observe(time.Since(stallBegan).Seconds())
+	var recvErr error
+
+	if quorum := int(b.resolved.journalSpec.AckQuorum); sendErr == nil &&
+		quorum != 0 && quorum < len(b.pln.Route.Members) {
+
+		// We expect an acknowledgement from a quorum of peers. Remaining peers
+		// acknowledge asynchronously, and operations pipelined after ourselves
+		// may read their responses only after they've done so.
+		var doneCh <-chan struct{}
+		doneCh, recvErr = b.pln.gatherQuorumOK(quorum)
+
+		go func(pln *pipeline, sentAt time.Time) {
+			<-doneCh
+			pln.observeAcks(sentAt)
+			close(closeAfter)
+		}(b.pln, b.ackSentAt)
+	} else {
+		// Defer a close that will signal operations pipelined after ourselves,
+		// that they may in turn read their responses.
+		defer func() { close(closeAfter) }()
+
+		// We expect an acknowledgement from each peer. If we encountered a send
+		// error, we also expect an EOF from remaining non-broken peers.
+		if b.pln.gatherOK(); sendErr != nil {
+			b.pln.gatherEOF()
+		}
+		b.pln.observeAcks(b.ackSentAt)
+		recvErr = b.pln.recvErr()
 	}
 
 	// recvErr()s are generally more informational that sendErr()s:
@@ -565,12 +701,51 @@ func (b *appendFSM) onReadAcknowledgements() {
 
 	if b.err != nil || b.resolved.status != pb.Status_OK {
 		b.state = stateError
-	} else if b.err = b.pln.recvErr(); b.err != nil {
+	} else if b.err = recvErr; b.err != nil {
 		b.state = stateError
 	} else if b.err = sendErr; b.err != nil {
 		b.state = stateError
 	} else {
 		b.state = stateFinished
+
+		if b.clientKey != nil {
+			b.resolved.replica.appendKeys.record(b.req.IdempotencyKey, *b.clientFragment, timeNow())
+			b.clientKey = nil
+		}
+	}
+}
+
+// reserveAppendKey reserves the request IdempotencyKey for this Append, or
+// returns the Fragment of an already-committed Append of the key. If another
+// Append holds a reservation of the key, it's awaited: that Append has
+// released the pipeline and is reading its acknowledgements, and a retry of
+// it must not be written until it's known whether it committed.
+func (b *appendFSM) reserveAppendKey() (pb.Fragment, bool, error) {
+	for {
+		var ak, reserved = b.resolved.replica.appendKeys.reserve(b.req.IdempotencyKey, timeNow())
+
+		if reserved {
+			b.clientKey = ak
+			return pb.Fragment{}, false, nil
+		} else if ak.resolved == nil {
+			return ak.commit, true, nil
+		}
+		addTrace(b.ctx, " ... awaiting pending append of IdempotencyKey")
+
+		select {
+		case <-ak.resolved:
+		case <-b.ctx.Done():
+			return pb.Fragment{}, false, b.ctx.Err()
+		}
+	}
+}
+
+// releaseAppendKey releases a held reservation of the request IdempotencyKey,
+// as this Append failed to commit.
+func (b *appendFSM) releaseAppendKey() {
+	if b.clientKey != nil {
+		b.resolved.replica.appendKeys.release(b.clientKey)
+		b.clientKey = nil
 	}
 }
 
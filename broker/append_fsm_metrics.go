@@ -0,0 +1,135 @@
+package broker
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/metrics"
+)
+
+// appendStateTimer tracks the time an appendFSM spends in each of its states.
+type appendStateTimer struct {
+	state appendState // Current state being timed.
+	began time.Time   // Time at which |state| was entered.
+	spent []appendStateSpent
+}
+
+type appendStateSpent struct {
+	state appendState
+	dur   time.Duration
+}
+
+// inFlightAppend describes the current state of an in-flight appendFSM.
+type inFlightAppend struct {
+	Journal pb.Journal `json:"journal"`
+	State   string     `json:"state"`
+	Since   time.Time  `json:"since"` // Time at which |State| was entered.
+}
+
+// updateStateTimer is called as the appendFSM steps, and accounts for time
+// spent in a prior state if the appendFSM has since transitioned. The current
+// state of the appendFSM is also tracked by the Service, for diagnosis of
+// appends which are stuck.
+func (b *appendFSM) updateStateTimer() {
+	var t = &b.stateTimer
+
+	if t.began.IsZero() {
+		t.state, t.began = b.state, time.Now()
+		b.trackState()
+		return
+	} else if t.state == b.state {
+		return
+	}
+	var now = time.Now()
+	metrics.AppendFSMStateTransitionsTotal.WithLabelValues(stateLabel(b.state)).Inc()
+	metrics.AppendFSMStates.WithLabelValues(stateLabel(t.state)).Dec()
+
+	// States may be re-visited; accumulate total time spent in each.
+	var i int
+	for i = 0; i != len(t.spent) && t.spent[i].state != t.state; i++ {
+	}
+	if i == len(t.spent) {
+		t.spent = append(t.spent, appendStateSpent{state: t.state})
+	}
+	t.spent[i].dur += now.Sub(t.began)
+	t.state, t.began = b.state, now
+	b.trackState()
+}
+
+// trackState of the appendFSM, which has just entered |stateTimer.state|.
+func (b *appendFSM) trackState() {
+	var t = &b.stateTimer
+
+	metrics.AppendFSMStates.WithLabelValues(stateLabel(t.state)).Inc()
+	b.svc.inFlightAppends.Store(b, inFlightAppend{
+		Journal: b.req.Journal,
+		State:   stateLabel(t.state),
+		Since:   t.began,
+	})
+	addTrace(b.ctx, "appendFSM state %s", stateLabel(t.state))
+}
+
+// observeStateTimes of a terminated appendFSM, keyed by its terminal outcome.
+func (b *appendFSM) observeStateTimes() {
+	b.updateStateTimer()
+
+	metrics.AppendFSMStates.WithLabelValues(stateLabel(b.stateTimer.state)).Dec()
+	b.svc.inFlightAppends.Delete(b)
+
+	var outcome string
+	switch b.state {
+	case stateFinished:
+		outcome = "finished"
+	case stateError:
+		outcome = "error"
+	case stateProxy:
+		outcome = "proxy"
+	default:
+		return // Not terminated.
+	}
+	var journal = boundedJournalLabel(b.req.Journal)
+
+	for _, s := range b.stateTimer.spent {
+		metrics.AppendFSMStateSeconds.WithLabelValues(
+			stateLabel(s.state), outcome, journal).Observe(s.dur.Seconds())
+	}
+}
+
+// stateLabel maps an appendState to its metric label value.
+func stateLabel(s appendState) string {
+	if s == stateResolve {
+		return "resolve"
+	}
+	return string(s)
+}
+
+// boundedJournalLabel maps a Journal to its metric label value, which is the
+// first component of the journal name (eg, "foo" of "foo/bar/baz"). To bound
+// metric cardinality, at most maxJournalLabels distinct values are used, after
+// which journals having a new first component are labeled "other".
+func boundedJournalLabel(journal pb.Journal) string {
+	var label = journal.String()
+	if ind := strings.IndexByte(label, '/'); ind != -1 {
+		label = label[:ind]
+	}
+
+	journalLabels.mu.Lock()
+	defer journalLabels.mu.Unlock()
+
+	if _, ok := journalLabels.m[label]; ok {
+		return label
+	} else if len(journalLabels.m) >= maxJournalLabels {
+		return "other"
+	}
+	journalLabels.m[label] = struct{}{}
+	return label
+}
+
+var journalLabels = struct {
+	m  map[string]struct{}
+	mu sync.Mutex
+}{m: make(map[string]struct{})}
+
+const maxJournalLabels = 64
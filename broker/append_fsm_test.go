@@ -2,16 +2,23 @@ package broker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/etcdtest"
+	"go.gazette.dev/core/metrics"
 )
 
 func TestFSMResolve(t *testing.T) {
@@ -99,6 +106,58 @@ func TestFSMAcquirePipeline(t *testing.T) {
 	broker.cleanup()
 }
 
+func TestFSMAppendRateLimit(t *testing.T) {
+	var ctx, etcd = context.Background(), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	defer func(fn func() time.Time) { timeNow = fn }(timeNow)
+	var now = time.Unix(1500000000, 0)
+	timeNow = func() time.Time { return now }
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1, MaxAppendRate: 1024}, broker.id)
+	broker.initialFragmentLoad()
+
+	// Case: content beyond the rate limit is admitted, but places it in debt.
+	var fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
+	assert.True(t, fsm.runTo(stateStreamContent))
+	fsm.onStreamContent(&pb.AppendRequest{Content: make([]byte, 3072)}, nil)
+	fsm.onStreamContent(&pb.AppendRequest{}, nil)
+	fsm.onStreamContent(nil, io.EOF)
+	fsm.onReadAcknowledgements()
+	assert.Equal(t, stateFinished, fsm.state)
+
+	var replica = fsm.resolved.replica
+	assert.Equal(t, 2*time.Second, replica.appendLimiter.delay(1024, now))
+
+	// Case: the next append is throttled before it acquires the pipeline,
+	// which remains available to others while the append waits.
+	var timeoutCtx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	fsm = appendFSM{svc: broker.svc, ctx: timeoutCtx, req: pb.AppendRequest{Journal: "a/journal"}}
+	fsm.onResolve()
+	assert.Equal(t, stateAcquirePipeline, fsm.state)
+	fsm.onAcquirePipeline()
+	assert.Equal(t, stateError, fsm.state)
+	assert.EqualError(t, fsm.err, "waiting for pipeline: context deadline exceeded")
+	assert.Len(t, replica.pipelineCh, 1)
+
+	// Case: once the debt is repaid, appends proceed without delay.
+	now = now.Add(2 * time.Second)
+
+	fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
+	assert.True(t, fsm.runTo(stateStreamContent))
+	fsm.onStreamContent(&pb.AppendRequest{Content: []byte("foo")}, nil)
+	fsm.onStreamContent(&pb.AppendRequest{}, nil)
+	fsm.onStreamContent(nil, io.EOF)
+	fsm.onReadAcknowledgements()
+	assert.Equal(t, stateFinished, fsm.state)
+	assert.Equal(t, int64(3075), fsm.clientFragment.End)
+
+	broker.cleanup()
+}
+
 func TestFSMStartAndSync(t *testing.T) {
 	var ctx, etcd = context.Background(), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -639,6 +698,85 @@ func TestFSMStreamAndReadAcknowledgements(t *testing.T) {
 	peerB.Cleanup()
 }
 
+func TestFSMReadAcknowledgementsUnderQuorum(t *testing.T) {
+	var ctx, etcd = context.Background(), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var peerA = newMockBroker(t, etcd, pb.ProcessSpec_ID{Zone: "A", Suffix: "peer"})
+	var peerB = newMockBroker(t, etcd, pb.ProcessSpec_ID{Zone: "B", Suffix: "peer"})
+
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 3, AckQuorum: 2},
+		broker.id, peerA.id, peerB.id)
+	broker.initialFragmentLoad()
+
+	// Read requests of each peer through the next acknowledged proposal.
+	var peerRecv = func() {
+		for _, p := range []mockBroker{peerA, peerB} {
+			for req := <-p.ReplReqCh; !req.Acknowledge; req = <-p.ReplReqCh {
+			}
+		}
+	}
+	var doAppend = func() *appendFSM {
+		var fsm = &appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
+		fsm.onResolve()
+		assert.True(t, fsm.runTo(stateStreamContent))
+
+		fsm.onStreamContent(&pb.AppendRequest{Content: []byte("foo")}, nil)
+		fsm.onStreamContent(&pb.AppendRequest{}, nil)
+		fsm.onStreamContent(nil, io.EOF)
+		peerRecv() // Through the commit proposal.
+		return fsm
+	}
+
+	// Synchronize the pipeline on its first usage.
+	go func() {
+		peerRecv()
+		peerA.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+		peerB.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+	}()
+
+	// Case: the append commits upon acknowledgement of the primary and peerA.
+	var fsm = doAppend()
+	peerA.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+	fsm.onReadAcknowledgements()
+
+	assert.Equal(t, stateFinished, fsm.state)
+	assert.NoError(t, fsm.err)
+
+	// Expect the read barrier of the pipeline remains held until the
+	// acknowledgement of peerB is also read.
+	var barrierCh = fsm.pln.readBarrierCh
+	select {
+	case <-barrierCh:
+		t.Fatal("unexpected barrier release")
+	default:
+	}
+	peerB.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+	<-barrierCh
+
+	// Case: a replica of the quorum fails to acknowledge.
+	fsm = doAppend()
+	peerA.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_WRONG_ROUTE}
+	fsm.onReadAcknowledgements()
+
+	assert.Equal(t, stateError, fsm.state)
+	assert.Regexp(t, `recv from zone:"A" suffix:"peer" : unexpected !OK response: status:WRONG_ROUTE .*`, fsm.err)
+
+	peerB.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+
+	// Expect the pipeline is torn down upon replica shutdown.
+	go func() {
+		for _, p := range []mockBroker{peerA, peerB} {
+			assert.Nil(t, <-p.ReplReqCh) // Read EOF.
+			p.ErrCh <- nil               // Send EOF.
+		}
+	}()
+	broker.cleanup()
+	peerA.Cleanup()
+	peerB.Cleanup()
+}
+
 func TestFSMRunBasicCases(t *testing.T) {
 	var etcd = etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -650,6 +788,8 @@ func TestFSMRunBasicCases(t *testing.T) {
 
 	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
 	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+	setTestJournal(broker, pb.JournalSpec{Name: "b/journal", Replication: 1,
+		AppendChunkTimeout: time.Microsecond}, broker.id)
 	broker.initialFragmentLoad()
 
 	var makeRecv = func(chunks []appendChunk) func() (req *pb.AppendRequest, err error) {
@@ -698,6 +838,22 @@ func TestFSMRunBasicCases(t *testing.T) {
 	assert.EqualError(t, fsm.err, `append stream: context deadline exceeded`)
 	restoreTimeout()
 
+	// Case: the JournalSpec overrides the default timeout.
+	fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "b/journal"}}
+	fsm.run(makeRecv([]appendChunk{
+		{req: &pb.AppendRequest{Content: []byte("bar")}},
+		// Wait indefinitely for next chunk.
+	}))
+
+	assert.Equal(t, stateError, fsm.state)
+	assert.EqualError(t, fsm.err, `append stream: context deadline exceeded`)
+	assert.Equal(t, time.Microsecond, fsm.chunkTimeout())
+
+	fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
+	assert.True(t, fsm.runTo(stateStreamContent))
+	assert.Equal(t, time.Second, fsm.chunkTimeout())
+	fsm.returnPipeline()
+
 	// Case: client read error.
 	fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
 	fsm.run(makeRecv([]appendChunk{
@@ -718,6 +874,150 @@ func TestFSMRunBasicCases(t *testing.T) {
 	broker.cleanup()
 }
 
+func TestFSMStateMetrics(t *testing.T) {
+	var ctx, etcd = context.Background(), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "metrics/journal", Replication: 1}, broker.id)
+	broker.initialFragmentLoad()
+
+	var sampleCount = func(h prometheus.Histogram) uint64 {
+		var m dto.Metric
+		assert.NoError(t, h.Write(&m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	var counter = func(c prometheus.Counter) float64 {
+		var m dto.Metric
+		assert.NoError(t, c.Write(&m))
+		return m.GetCounter().GetValue()
+	}
+	var (
+		readAcks     = metrics.AppendFSMStateTransitionsTotal.WithLabelValues("readAcks")
+		resolveSecs  = metrics.AppendFSMStateSeconds.WithLabelValues("resolve", "finished", "metrics")
+		streamSecs   = metrics.AppendFSMStateSeconds.WithLabelValues("streamContent", "finished", "metrics")
+		barrierSecs  = metrics.AppendFSMReadBarrierSeconds.WithLabelValues("metrics")
+		notFoundSecs = metrics.AppendFSMStateSeconds.WithLabelValues("resolve", "error", "does")
+
+		readAcks0, resolve0, stream0, barrier0, notFound0 = counter(readAcks),
+			sampleCount(resolveSecs), sampleCount(streamSecs), sampleCount(barrierSecs), sampleCount(notFoundSecs)
+	)
+
+	// Case: a successful append observes each state it passed through.
+	var fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "metrics/journal"}}
+	assert.True(t, fsm.runTo(stateStreamContent))
+	fsm.onStreamContent(&pb.AppendRequest{}, nil)
+	fsm.onStreamContent(nil, io.EOF)
+	fsm.onReadAcknowledgements()
+	fsm.returnPipeline()
+	fsm.observeStateTimes()
+
+	assert.Equal(t, stateFinished, fsm.state)
+	assert.Equal(t, readAcks0+1, counter(readAcks))
+	assert.Equal(t, resolve0+1, sampleCount(resolveSecs))
+	assert.Equal(t, stream0+1, sampleCount(streamSecs))
+	assert.Equal(t, barrier0+1, sampleCount(barrierSecs))
+
+	// Case: a failed append is observed under the "error" outcome.
+	fsm = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "does/not/exist"}}
+	fsm.run(nil)
+
+	assert.Equal(t, stateError, fsm.state)
+	assert.Equal(t, notFound0+1, sampleCount(notFoundSecs))
+
+	broker.cleanup()
+}
+
+func TestFSMInFlightStates(t *testing.T) {
+	var ctx, etcd = context.Background(), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+	broker.initialFragmentLoad()
+
+	var gauge = func(state string) float64 {
+		var m dto.Metric
+		assert.NoError(t, metrics.AppendFSMStates.WithLabelValues(state).Write(&m))
+		return m.GetGauge().GetValue()
+	}
+	var acquire0 = gauge("acquire")
+
+	// |fsm1| holds the pipeline, which blocks |fsm2| in stateAcquirePipeline.
+	var fsm1 = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
+	assert.True(t, fsm1.runTo(stateStreamContent))
+
+	var fsm2 = appendFSM{svc: broker.svc, ctx: ctx, req: pb.AppendRequest{Journal: "a/journal"}}
+	var chunks = []appendChunk{{req: &pb.AppendRequest{}}, {err: io.EOF}}
+	var doneCh = make(chan struct{})
+
+	go func() {
+		fsm2.run(func() (req *pb.AppendRequest, err error) {
+			if len(chunks) == 0 {
+				<-broker.tasks.Context().Done() // Block until |broker| shutdown.
+			} else {
+				req, err = chunks[0].req, chunks[0].err
+				chunks = chunks[1:]
+			}
+			return
+		})
+		close(doneCh)
+	}()
+
+	// Expect the blocked request is reflected in counts and in-flight states.
+	for gauge("acquire") < acquire0+1 {
+		time.Sleep(time.Millisecond)
+	}
+	var state = func(fsm *appendFSM) string {
+		if v, ok := broker.svc.inFlightAppends.Load(fsm); ok {
+			return v.(inFlightAppend).State
+		}
+		return ""
+	}
+	assert.Equal(t, "streamContent", state(&fsm1))
+	assert.Equal(t, "acquire", state(&fsm2))
+
+	// Also expect it's served by the debug handler, ordered on state entry.
+	var srv = httptest.NewServer(broker.svc.AppendsDebugHandler())
+	var resp, err = http.Get(srv.URL)
+	assert.NoError(t, err)
+	var served []inFlightAppend
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&served))
+	assert.Equal(t, []string{"streamContent", "acquire"},
+		[]string{served[len(served)-2].State, served[len(served)-1].State})
+	assert.Equal(t, pb.Journal("a/journal"), served[len(served)-1].Journal)
+	srv.Close()
+
+	// Complete |fsm1|, releasing the pipeline. |fsm2| now runs to completion.
+	fsm1.onStreamContent(&pb.AppendRequest{}, nil)
+	fsm1.onStreamContent(nil, io.EOF)
+	fsm1.onReadAcknowledgements()
+	fsm1.returnPipeline()
+	fsm1.observeStateTimes()
+	<-doneCh
+
+	assert.Equal(t, stateFinished, fsm2.state)
+	assert.Equal(t, "", state(&fsm1))
+	assert.Equal(t, "", state(&fsm2))
+
+	broker.cleanup()
+}
+
+func TestBoundedJournalLabel(t *testing.T) {
+	defer func(m map[string]struct{}) { journalLabels.m = m }(journalLabels.m)
+	journalLabels.m = make(map[string]struct{})
+
+	assert.Equal(t, "foo", boundedJournalLabel("foo/bar/baz"))
+	assert.Equal(t, "flat", boundedJournalLabel("flat"))
+
+	for i := len(journalLabels.m); i != maxJournalLabels; i++ {
+		boundedJournalLabel(pb.Journal(fmt.Sprintf("prefix-%d/journal", i)))
+	}
+	// Known labels continue to be used, but new ones are not.
+	assert.Equal(t, "foo", boundedJournalLabel("foo/other"))
+	assert.Equal(t, "other", boundedJournalLabel("new/journal"))
+}
+
 func TestFSMPipelineRace(t *testing.T) {
 	var ctx, etcd = context.Background(), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
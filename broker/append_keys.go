@@ -0,0 +1,128 @@
+package broker
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// appendKeysTTL is the maximum age of a tracked AppendRequest IdempotencyKey,
+// and appendKeysLimit is the maximum number of keys tracked per journal. Keys
+// which are older, or which exceed the limit, are evicted and an Append which
+// re-uses such a key is treated as a new Append.
+var (
+	appendKeysTTL   = 5 * time.Minute
+	appendKeysLimit = 1024
+)
+
+// appendKeys tracks IdempotencyKeys of recently committed Appends of a
+// journal, and the Fragment each Append committed. It also tracks keys
+// reserved by Appends which are yet to commit, so that a concurrent retry
+// of such an Append awaits its outcome rather than appending again.
+type appendKeys struct {
+	entries map[string]*list.Element
+	order   *list.List // Ordered on |appendKey.at|, oldest first.
+	mu      sync.Mutex
+}
+
+// appendKey is a tracked key. Its fields are not modified after it's tracked.
+type appendKey struct {
+	key    string
+	commit pb.Fragment
+	at     time.Time
+	// If non-nil, the key is reserved by an Append which is yet to commit,
+	// and |resolved| is closed as the reservation is untracked.
+	resolved chan struct{}
+}
+
+func newAppendKeys() *appendKeys {
+	return &appendKeys{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// lookup returns the committed Fragment of |key|, if it's tracked and
+// isn't a reservation.
+func (k *appendKeys) lookup(key string, now time.Time) (pb.Fragment, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.evict(now)
+
+	if elem, ok := k.entries[key]; ok && elem.Value.(*appendKey).resolved == nil {
+		return elem.Value.(*appendKey).commit, true
+	}
+	return pb.Fragment{}, false
+}
+
+// reserve |key| for an Append which is about to be written. If |key| is
+// tracked, its appendKey is returned and |reserved| is false: the appendKey
+// is either of a committed Append, or is a reservation of another Append
+// which must be awaited. Otherwise, a new reservation of |key| is returned
+// which the caller must later record or release.
+func (k *appendKeys) reserve(key string, now time.Time) (ak *appendKey, reserved bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.evict(now)
+
+	if elem, ok := k.entries[key]; ok {
+		return elem.Value.(*appendKey), false
+	}
+	ak = &appendKey{key: key, at: now, resolved: make(chan struct{})}
+	k.entries[key] = k.order.PushBack(ak)
+
+	return ak, true
+}
+
+// release a reservation of an Append which failed to commit.
+func (k *appendKeys) release(ak *appendKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if elem, ok := k.entries[ak.key]; ok && elem.Value == ak {
+		k.remove(elem)
+	}
+}
+
+// record |key| as having committed Fragment |commit| at |now|.
+func (k *appendKeys) record(key string, commit pb.Fragment, now time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if elem, ok := k.entries[key]; ok {
+		k.remove(elem)
+	}
+	k.entries[key] = k.order.PushBack(&appendKey{key: key, commit: commit, at: now})
+
+	k.evict(now)
+}
+
+// remove the tracked |elem|, resolving it if it's a reservation.
+// Precondition: |k.mu| is held.
+func (k *appendKeys) remove(elem *list.Element) {
+	var ak = elem.Value.(*appendKey)
+
+	k.order.Remove(elem)
+	delete(k.entries, ak.key)
+
+	if ak.resolved != nil {
+		close(ak.resolved)
+	}
+}
+
+// evict keys which have expired, or which exceed appendKeysLimit.
+// Precondition: |k.mu| is held.
+func (k *appendKeys) evict(now time.Time) {
+	for elem := k.order.Front(); elem != nil; elem = k.order.Front() {
+		var ak = elem.Value.(*appendKey)
+
+		if k.order.Len() <= appendKeysLimit && now.Sub(ak.at) < appendKeysTTL {
+			return
+		}
+		k.remove(elem)
+	}
+}
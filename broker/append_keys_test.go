@@ -0,0 +1,94 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+func TestAppendKeysTrackingAndEviction(t *testing.T) {
+	defer func(ttl time.Duration, limit int) {
+		appendKeysTTL, appendKeysLimit = ttl, limit
+	}(appendKeysTTL, appendKeysLimit)
+	appendKeysTTL, appendKeysLimit = time.Minute, 3
+
+	var keys = newAppendKeys()
+	var t0 = time.Unix(1000, 0)
+	var frag = func(begin int64) pb.Fragment {
+		return pb.Fragment{Journal: "a/journal", Begin: begin, End: begin + 1}
+	}
+
+	keys.record("one", frag(1), t0)
+	keys.record("two", frag(2), t0.Add(time.Second))
+	keys.record("three", frag(3), t0.Add(2*time.Second))
+
+	var commit, ok = keys.lookup("two", t0.Add(2*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, frag(2), commit)
+
+	_, ok = keys.lookup("missing", t0.Add(2*time.Second))
+	assert.False(t, ok)
+
+	// Case: recording beyond the limit evicts the oldest key.
+	keys.record("four", frag(4), t0.Add(3*time.Second))
+	_, ok = keys.lookup("one", t0.Add(3*time.Second))
+	assert.False(t, ok)
+	_, ok = keys.lookup("two", t0.Add(3*time.Second))
+	assert.True(t, ok)
+
+	// Case: re-recording a key refreshes its age.
+	keys.record("two", frag(5), t0.Add(30*time.Second))
+
+	// Case: keys older than the TTL are evicted.
+	var now = t0.Add(time.Minute + 3*time.Second)
+	_, ok = keys.lookup("three", now)
+	assert.False(t, ok)
+	_, ok = keys.lookup("four", now)
+	assert.False(t, ok)
+
+	commit, ok = keys.lookup("two", now)
+	assert.True(t, ok)
+	assert.Equal(t, frag(5), commit)
+	assert.Equal(t, 1, keys.order.Len())
+	assert.Len(t, keys.entries, 1)
+}
+
+func TestAppendKeysReservation(t *testing.T) {
+	var keys = newAppendKeys()
+	var t0 = time.Unix(1000, 0)
+	var frag = pb.Fragment{Journal: "a/journal", Begin: 1, End: 2}
+
+	// Case: a reserved key isn't a committed Append, and further reservations
+	// return the pending reservation.
+	var ak, reserved = keys.reserve("one", t0)
+	assert.True(t, reserved)
+	var _, ok = keys.lookup("one", t0)
+	assert.False(t, ok)
+
+	other, reserved := keys.reserve("one", t0)
+	assert.False(t, reserved)
+	assert.Equal(t, ak, other)
+
+	// Case: releasing the reservation resolves it, and untracks the key.
+	keys.release(ak)
+	<-ak.resolved
+	assert.Len(t, keys.entries, 0)
+
+	// Case: recording the commit of a reservation resolves it.
+	ak, reserved = keys.reserve("one", t0)
+	assert.True(t, reserved)
+	keys.record("one", frag, t0)
+	<-ak.resolved
+
+	other, reserved = keys.reserve("one", t0)
+	assert.False(t, reserved)
+	assert.Nil(t, other.resolved)
+	assert.Equal(t, frag, other.commit)
+
+	// A late release of a resolved reservation is a no-op.
+	keys.release(ak)
+	var commit, _ = keys.lookup("one", t0)
+	assert.Equal(t, frag, commit)
+}
@@ -0,0 +1,55 @@
+package broker
+
+import (
+	"sync"
+	"time"
+)
+
+// appendLimiter is a token bucket which limits the rate of Append content of
+// a journal to its JournalSpec MaxAppendRate. The bucket holds up to one
+// second of tokens (bytes) at that rate. Content is always admitted, but may
+// place the bucket in debt: rather than throttling the content of an Append
+// as it's streamed (and while it holds the exclusively-owned pipeline), the
+// appendFSM waits before acquiring the pipeline for each Append until the
+// debt of its predecessors has been repaid.
+type appendLimiter struct {
+	mu     sync.Mutex
+	tokens float64   // Available tokens, which may be negative.
+	last   time.Time // Time through which |tokens| were refilled.
+}
+
+// consume |n| tokens at |now|, under a limit of |rate| bytes per second.
+func (l *appendLimiter) consume(rate int64, n int, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(rate, now)
+	l.tokens -= float64(n)
+}
+
+// delay returns the duration from |now| until the appendLimiter is no longer
+// in debt, under a limit of |rate| bytes per second, or zero if it isn't.
+func (l *appendLimiter) delay(rate int64, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.refill(rate, now); l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / float64(rate) * float64(time.Second))
+}
+
+// refill the bucket through |now|. |mu| must be held.
+func (l *appendLimiter) refill(rate int64, now time.Time) {
+	if l.last.IsZero() {
+		l.tokens = float64(rate)
+	} else if d := now.Sub(l.last); d > 0 {
+		l.tokens += d.Seconds() * float64(rate)
+	}
+	if l.tokens > float64(rate) {
+		l.tokens = float64(rate)
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+}
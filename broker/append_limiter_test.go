@@ -0,0 +1,35 @@
+package broker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendLimiterDebtAndRefill(t *testing.T) {
+	var l appendLimiter
+	var now = time.Unix(1500000000, 0)
+
+	// A new limiter has a full bucket of one second of tokens.
+	assert.Equal(t, time.Duration(0), l.delay(100, now))
+	l.consume(100, 80, now)
+	assert.Equal(t, time.Duration(0), l.delay(100, now))
+
+	// Content beyond the bucket places it in debt.
+	l.consume(100, 70, now)
+	assert.Equal(t, 500*time.Millisecond, l.delay(100, now))
+	assert.Equal(t, 200*time.Millisecond, l.delay(100, now.Add(300*time.Millisecond)))
+
+	// Debt is repaid over time, and the bucket refills only up to one second.
+	now = now.Add(time.Minute)
+	assert.Equal(t, time.Duration(0), l.delay(100, now))
+	l.consume(100, 150, now)
+	assert.Equal(t, 500*time.Millisecond, l.delay(100, now))
+
+	// Times which precede the last refill don't remove tokens.
+	assert.Equal(t, 500*time.Millisecond, l.delay(100, now.Add(-time.Second)))
+
+	// A changed rate applies to the outstanding debt.
+	assert.Equal(t, 250*time.Millisecond, l.delay(200, now))
+}
@@ -2,7 +2,11 @@ package client
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
@@ -23,18 +27,40 @@ import (
 // interface.
 type AppendService struct {
 	pb.RoutedJournalClient
-	ctx     context.Context
-	appends map[pb.Journal]*AsyncAppend
-	mu      sync.Mutex
+	// MaxInFlight, if non-zero, bounds the number of AsyncAppends of a journal
+	// which may be in flight (returned by StartAppend, but not yet committed).
+	// StartAppend blocks while the bound is met, applying backpressure to
+	// writers of a journal which would otherwise accumulate a long chain of
+	// pending AsyncAppends and their buffered content. Note that StartAppend
+	// blocks even if it would have returned the journal's current AsyncAppend.
+	// By default, in-flight AsyncAppends are unbounded. MaxInFlight must be
+	// set prior to the first StartAppend.
+	MaxInFlight int
+
+	ctx      context.Context
+	appends  map[pb.Journal]*AsyncAppend
+	inFlight map[pb.Journal]int // In-flight AsyncAppends of each journal.
+	closed   bool               // Set by Drain.
+	aborted  int                // Number of AsyncAppends aborted by |ctx|.
+	loops    sync.WaitGroup     // Running serveAppends loops.
+	mu       sync.Mutex
+	cond     *sync.Cond // Signaled on |mu| as an in-flight AsyncAppend completes.
 }
 
+// ErrAppendServiceClosed is the Err of AsyncAppends started after a Drain
+// of their AppendService.
+var ErrAppendServiceClosed = errors.New("append service is closed")
+
 // NewAppendService returns an AppendService with the provided Context and BrokerClient.
 func NewAppendService(ctx context.Context, client pb.RoutedJournalClient) *AppendService {
-	return &AppendService{
+	var s = &AppendService{
 		ctx:                 ctx,
 		RoutedJournalClient: client,
 		appends:             make(map[pb.Journal]*AsyncAppend),
+		inFlight:            make(map[pb.Journal]int),
 	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
 }
 
 // AsyncJournalClient composes a RoutedJournalClient with an API for performing
@@ -53,7 +79,8 @@ type AsyncJournalClient interface {
 	// dispatched to this AppendService, and note the Response.Fragment will reflect
 	// the entire batch written to the broker. In all cases, relative order of
 	// Appends is preserved. One or more dependencies may optionally be supplied.
-	// The Append RPC will not begin until all such dependencies have committed.
+	// The Append RPC will not begin until all such dependencies have committed,
+	// and if a dependency fails then so does the Append (see AsyncAppend.Err).
 	// Dependencies must be ordered on applicable Journal name or StartAppend panics.
 	// They must also be AsyncAppends returned by this client, and not another.
 	// StartAppend may retain the slice, and it must not be subsequently modified.
@@ -76,13 +103,48 @@ type AsyncJournalClient interface {
 
 // StartAppend implements the AsyncJournalClient interface.
 func (s *AppendService) StartAppend(name pb.Journal, dependencies ...*AsyncAppend) *AsyncAppend {
+	return s.startAppend(name, 0, 0, dependencies)
+}
+
+// StartAppendAtOffset begins a new asynchronous Append RPC as does
+// StartAppend, which is dispatched with an AppendRequest Offset of |offset|
+// (see AppendRequest). The returned AsyncAppend isn't batched with other
+// appends of the journal: it's dispatched as its own Append RPC, ordered
+// after prior appends of the journal and before subsequent ones.
+//
+// If the broker responds with WRONG_APPEND_OFFSET, the journal was written
+// through a different offset than |offset|. A blind retry would fail in the
+// same way, and the AppendService instead reads the journal's current write
+// head and re-issues the Append at that offset, up to |maxReissues| times.
+// Once re-issues are exhausted (or if |maxReissues| is zero) the AsyncAppend
+// fails, and its Err is ErrWrongAppendOffset. Otherwise, Response().Commit.Begin
+// is the offset at which the AsyncAppend was ultimately committed, which
+// callers may use to reconcile their expectations of the journal.
+func (s *AppendService) StartAppendAtOffset(name pb.Journal, offset int64, maxReissues int, dependencies ...*AsyncAppend) *AsyncAppend {
+	if offset == 0 {
+		panic("StartAppendAtOffset requires a non-zero offset")
+	}
+	return s.startAppend(name, offset, maxReissues, dependencies)
+}
+
+func (s *AppendService) startAppend(name pb.Journal, offset int64, maxReissues int, dependencies []*AsyncAppend) *AsyncAppend {
 	// Fetch the current AsyncAppend for |name|, or start one if none exists.
 	s.mu.Lock()
+	for s.MaxInFlight != 0 && !s.closed && s.inFlight[name] >= s.MaxInFlight {
+		s.cond.Wait()
+	}
+	if s.closed {
+		s.mu.Unlock()
+		return s.startClosedAppend(name)
+	}
 	var aa, ok = s.appends[name]
 
 	if !ok {
 		aa = &AsyncAppend{
-			app:          *NewAppender(s.ctx, s.RoutedJournalClient, pb.AppendRequest{Journal: name}),
+			app: *NewAppender(s.ctx, s.RoutedJournalClient, pb.AppendRequest{
+				Journal:        name,
+				IdempotencyKey: newIdempotencyKey(),
+			}),
 			dependencies: dependencies,
 			commitCh:     make(chan struct{}),
 			mu:           new(sync.Mutex),
@@ -101,7 +163,11 @@ func (s *AppendService) StartAppend(name pb.Journal, dependencies ...*AsyncAppen
 	// serveAppends starts from the first AsyncAppend of the chain, and
 	// that it blocks until the client completes the first write.
 	if !ok {
-		go serveAppends(s, aa)
+		s.loops.Add(1)
+		go func() {
+			serveAppends(s, aa)
+			s.loops.Done()
+		}()
 	}
 
 	for aa.next != nil && aa.next != tombstoneAsyncAppend {
@@ -112,20 +178,143 @@ func (s *AppendService) StartAppend(name pb.Journal, dependencies ...*AsyncAppen
 		// While we were waiting for |aa.mu|, the serveAppends service loop for this
 		// AsyncAppend exited (and it was cleared from |s.appends|). Try again.
 		aa.mu.Unlock() // Not strictly required as this Mutex was orphaned.
-		return s.StartAppend(name, dependencies...)
+		return s.startAppend(name, offset, maxReissues, dependencies)
 	}
 
-	if aa.checkpoint > appendBufferCutoff || !isSubset(dependencies, aa.dependencies) {
-		// We must chain a new Append RPC, ordered after this one.
+	if aa.checkpoint > appendBufferCutoff || !isSubset(dependencies, aa.dependencies) ||
+		aa.app.Request.Offset != 0 || (offset != 0 && aa.fb != nil) {
+		// We must chain a new Append RPC, ordered after this one. An Append
+		// having a request offset is never batched with other appends.
 		aa = s.chainNewAppend(aa, dependencies)
 	}
+	if offset != 0 {
+		// |aa| has not yet been returned by StartAppend, and can't be in flight.
+		aa.app.Request.Offset, aa.reissues = offset, maxReissues
+	}
 	if aa.fb == nil {
 		// This is the first time this AsyncAppend is being returned by
 		// StartAppend. Initialize its appendBuffer, which also signals that this
 		// |aa| has been returned by StartAppend, and that a client may be
 		// waiting on its RPC response.
 		aa.fb = appendBufferPool.Get().(*appendBuffer)
+
+		s.mu.Lock()
+		s.inFlight[name]++
+		s.mu.Unlock()
+	}
+	return aa
+}
+
+// StartAppendReader begins a new asynchronous Append RPC of the content of
+// |r|, ordered after |dependencies| as with StartAppend. |r| is read until EOF,
+// and its content is written incrementally into the append buffer (which spills
+// to its backing file) rather than being buffered by the caller. The returned
+// AsyncAppend has been released. If reading |r| fails, content written by this
+// call is rolled back and the read error is returned.
+//
+// Other appends of the journal block until |r| is drained. The content of |r| is
+// always part of a single Append RPC, even where it exceeds appendBufferCutoff:
+// as with Writer, the cutoff chains a new RPC only upon the next StartAppend.
+func (s *AppendService) StartAppendReader(name pb.Journal, r io.Reader, dependencies ...*AsyncAppend) (*AsyncAppend, error) {
+	var aa = s.StartAppend(name, dependencies...)
+
+	var _, err = io.Copy(aa.Writer(), r)
+	aa.Require(err)
+
+	return aa, aa.Release()
+}
+
+// PrimeRoutes resolves and caches current Routes of |journals| with the
+// DispatchRouter of the AppendService (see PrimeRoutes), such that initial
+// Appends to those journals are dispatched directly to their primary brokers.
+// If |refresh| is non-zero, Routes are re-primed at that interval until the
+// AppendService Context is done. |refresh| should be shorter than the TTL of
+// cached Routes (eg, of a RouteCache), so that Routes are refreshed before
+// they expire. An error of the initial priming is returned, and errors of
+// later refreshes are logged.
+func (s *AppendService) PrimeRoutes(refresh time.Duration, journals ...pb.Journal) error {
+	if err := PrimeRoutes(s.ctx, s.RoutedJournalClient, journals...); err != nil {
+		return err
+	} else if refresh == 0 {
+		return nil
+	}
+
+	go func(ticker *time.Ticker) {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := PrimeRoutes(s.ctx, s.RoutedJournalClient, journals...); err != nil && s.ctx.Err() == nil {
+					log.WithFields(log.Fields{"err": err, "journals": len(journals)}).
+						Warn("failed to refresh primed journal routes (will retry)")
+				}
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}(time.NewTicker(refresh))
+
+	return nil
+}
+
+// Drain closes the AppendService to new appends, and returns once all pending
+// appends have completed and service loops of the AppendService have exited.
+// AsyncAppends started after Drain is called are already aborted: their Done
+// is closed, Err returns ErrAppendServiceClosed, and their writes are
+// discarded upon Release. Drain returns an error if |ctx| is done before
+// pending appends complete, or if any appends of the AppendService were
+// aborted by cancellation of its Context.
+func (s *AppendService) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast() // Wake StartAppends blocked on MaxInFlight.
+	s.mu.Unlock()
+
+	for _, aa := range s.PendingExcept("") {
+		select {
+		case <-aa.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var exitCh = make(chan struct{})
+	go func() {
+		s.loops.Wait()
+		close(exitCh)
+	}()
+
+	select {
+	case <-exitCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.aborted != 0 {
+		return fmt.Errorf("%d appends were aborted: %s", s.aborted, s.ctx.Err())
+	}
+	return nil
+}
+
+// startClosedAppend returns an AsyncAppend of a closed AppendService. It's
+// already aborted: its Done is closed and its Err is ErrAppendServiceClosed.
+// It's usable by the caller, but its writes are discarded upon Release and
+// it's never dispatched.
+func (s *AppendService) startClosedAppend(name pb.Journal) *AsyncAppend {
+	var aa = &AsyncAppend{
+		app:      *NewAppender(s.ctx, s.RoutedJournalClient, pb.AppendRequest{Journal: name}),
+		commitCh: make(chan struct{}),
+		fb:       appendBufferPool.Get().(*appendBuffer),
+		err:      ErrAppendServiceClosed,
+		closed:   true,
+		mu:       new(sync.Mutex),
 	}
+	aa.mu.Lock() // Held by the caller until Release.
+	close(aa.commitCh)
+
 	return aa
 }
 
@@ -134,14 +323,16 @@ func (s *AppendService) PendingExcept(except pb.Journal) []*AsyncAppend {
 	s.mu.Lock()
 	var out = make([]*AsyncAppend, 0, len(s.appends))
 	for _, aa := range s.appends {
-		if aa.Request().Journal != except {
+		// Access Journal directly, as the Offset of an in-flight Request may be
+		// concurrently updated by a re-issue of StartAppendAtOffset.
+		if aa.app.Request.Journal != except {
 			out = append(out, aa)
 		}
 	}
 	s.mu.Unlock()
 
 	sort.Slice(out, func(i, j int) bool {
-		return out[i].Request().Journal < out[j].Request().Journal
+		return out[i].app.Request.Journal < out[j].app.Request.Journal
 	})
 	return out
 }
@@ -159,8 +350,12 @@ func (s *AppendService) chainNewAppend(aa *AsyncAppend, dependencies []*AsyncApp
 	if aa.next != nil {
 		panic("aa.next != nil")
 	}
+	var req = aa.Request()
+	req.IdempotencyKey = newIdempotencyKey()
+	req.Offset = 0 // Set only by StartAppendAtOffset.
+
 	aa.next = &AsyncAppend{
-		app:          *NewAppender(s.ctx, s.RoutedJournalClient, aa.Request()),
+		app:          *NewAppender(s.ctx, s.RoutedJournalClient, req),
 		dependencies: dependencies,
 		commitCh:     make(chan struct{}),
 		mu:           aa.mu,
@@ -173,6 +368,110 @@ func (s *AppendService) chainNewAppend(aa *AsyncAppend, dependencies []*AsyncApp
 	return aa.next
 }
 
+// StartBatch begins a new AppendBatch of writes to multiple journals, which
+// are started and released together upon AppendBatch.Commit.
+func (s *AppendService) StartBatch() *AppendBatch {
+	return &AppendBatch{svc: s, writers: make(map[pb.Journal]*batchWriter)}
+}
+
+// AppendBatch composes writes to multiple journals, which are buffered by the
+// AppendBatch and then begun as AsyncAppends of each journal upon Commit.
+// An AppendBatch is not safe for concurrent use.
+type AppendBatch struct {
+	svc     *AppendService
+	writers map[pb.Journal]*batchWriter
+}
+
+type batchWriter struct {
+	buf bytes.Buffer
+	bw  *bufio.Writer
+	err error // Retained Require(error).
+}
+
+// Writer returns a bufio.Writer to which content of |journal| may be appended.
+// Writer is valid for use only until Commit is called.
+func (b *AppendBatch) Writer(journal pb.Journal) *bufio.Writer {
+	var w, ok = b.writers[journal]
+	if !ok {
+		w = new(batchWriter)
+		w.bw = bufio.NewWriter(&w.buf)
+		b.writers[journal] = w
+	}
+	return w.bw
+}
+
+// Require the error to be nil. If Require is called with a non-nil error,
+// the error is retained and the writes of |journal| are rolled back upon
+// Commit, which returns the error. Writes of other journals are unaffected.
+func (b *AppendBatch) Require(journal pb.Journal, err error) *AppendBatch {
+	b.Writer(journal) // Ensure a batchWriter of |journal| exists.
+
+	if w := b.writers[journal]; err != nil && w.err == nil {
+		w.err = err
+	}
+	return b
+}
+
+// Commit begins an AsyncAppend of each journal written to by the AppendBatch,
+// writes its buffered content, and releases it. Each AsyncAppend depends upon
+// all AsyncAppends of other journals which were pending at the onset of Commit,
+// and is ordered after AsyncAppends already pending on its own journal.
+// Ie, writes of the batch are guaranteed to be ordered after all writes
+// already pending within the AppendService.
+//
+// AsyncAppends are started and released in journal order. Journals having a
+// Require'd error are skipped entirely, and AsyncAppends which fail to release
+// are rolled back. In either case Commit continues to release the remaining
+// AsyncAppends, and then returns the first encountered error.
+// AsyncAppends which were successfully released are returned, and the caller
+// may select on their Done channels to determine when each has committed.
+func (b *AppendBatch) Commit() ([]*AsyncAppend, error) {
+	var journals = make([]pb.Journal, 0, len(b.writers))
+	for journal := range b.writers {
+		journals = append(journals, journal)
+	}
+	sort.Slice(journals, func(i, j int) bool { return journals[i] < journals[j] })
+
+	// Snapshot dependencies shared by all writes of the batch.
+	var pending = b.svc.PendingExcept("")
+
+	var out = make([]*AsyncAppend, 0, len(journals))
+	var firstErr error
+
+	for _, journal := range journals {
+		var w = b.writers[journal]
+
+		var deps = make([]*AsyncAppend, 0, len(pending))
+		for _, aa := range pending {
+			if aa.Request().Journal != journal {
+				deps = append(deps, aa)
+			}
+		}
+		if w.err == nil {
+			w.err = w.bw.Flush()
+		}
+		if w.err != nil {
+			if firstErr == nil {
+				firstErr = w.err
+			}
+			continue // Don't begin an AsyncAppend of |journal| at all.
+		}
+		var aa = b.svc.StartAppend(journal, deps...)
+
+		var _, err = w.buf.WriteTo(aa.Writer())
+		if err = aa.Require(err).Release(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		out = append(out, aa)
+	}
+	b.writers = nil
+
+	return out, firstErr
+}
+
 // AsyncAppend is an asynchronous Append RPC.
 type AsyncAppend struct {
 	app Appender
@@ -181,7 +480,10 @@ type AsyncAppend struct {
 	commitCh     chan struct{}  // Closed to signal AsyncAppend has committed.
 	fb           *appendBuffer  // Buffer into which writes are queued.
 	checkpoint   int64          // Buffer |fb| offset to append through.
-	err          error          // Retained Require(error) or aborting Context error.
+	ranges       []AppendRange  // Buffer |fb| ranges of writes released by ReleaseWrite.
+	reissues     int            // Remaining re-issues upon WRONG_APPEND_OFFSET.
+	err          error          // Retained Require(error) or aborting error.
+	closed       bool           // Started by a closed AppendService, and already aborted.
 
 	mu   *sync.Mutex  // Shared mutex over all AsyncAppends of the journal.
 	next *AsyncAppend // Next ordered AsyncAppend of the journal.
@@ -213,6 +515,17 @@ func (p *AsyncAppend) Require(err error) *AsyncAppend {
 // rolled back. Otherwise, the caller may then select on Done to determine when
 // the AsyncAppend has committed and its Response may be examined.
 func (p *AsyncAppend) Release() error {
+	var _, err = p.release(false)
+	return err
+}
+
+func (p *AsyncAppend) release(record bool) (int, error) {
+	if p.closed {
+		p.fb.buf.Reset(p.fb) // Discard content buffered but not yet flushed.
+		releaseFileBuffer(p.fb)
+		p.mu.Unlock()
+		return -1, nil
+	}
 	// Require that a bufio.Writer error is not set.
 	var _, err = p.fb.buf.Write(nil)
 	p.Require(err)
@@ -222,14 +535,32 @@ func (p *AsyncAppend) Release() error {
 		// rollback in background, as it may block until an underlying disk
 		// error is resolved. Note |mu| is still held until rollback completes.
 		go p.rollback()
-		return err
+		return -1, err
 	}
+	var begin, index = p.checkpoint, -1
 	p.checkpoint = p.fb.offset + int64(p.fb.buf.Buffered())
+
+	if record {
+		index = len(p.ranges)
+		p.ranges = append(p.ranges, AppendRange{Begin: begin, End: p.checkpoint})
+	}
 	p.mu.Unlock()
 
-	return nil
+	return index, nil
 }
 
+// ReleaseWrite releases the AsyncAppend as does Release, and additionally
+// records the range of content written by the caller since StartAppend.
+// It returns the index of the caller's write within WriteRanges, which may be
+// examined once Done selects. As an AsyncAppend is shared by all writes which
+// are batched into its Append RPC, this allows callers to learn the exact
+// journal offsets of their own write within the committed Fragment (eg, to
+// index logical records of the write). If the write is rolled back, no range
+// is recorded and ReleaseWrite returns -1 and the non-nil error of Release.
+// The write of an AsyncAppend started after Drain is discarded, and ReleaseWrite
+// returns -1 and a nil error.
+func (p *AsyncAppend) ReleaseWrite() (int, error) { return p.release(true) }
+
 // rollback discards all content written to the Writer and releases the AsyncAppend.
 func (p *AsyncAppend) rollback() {
 	// flush as |p.checkpoint| may reference still-buffered content.
@@ -241,20 +572,48 @@ func (p *AsyncAppend) rollback() {
 }
 
 // Request returns the AppendRequest that was or will be made by this AsyncAppend.
-// Request is safe to call at all times.
+// Request is safe to call at all times, except that the Offset of an AsyncAppend
+// of StartAppendAtOffset is updated as it's re-issued, and its Request should
+// be examined only after Done selects.
 func (p *AsyncAppend) Request() pb.AppendRequest { return p.app.Request }
 
 // Response returns the AppendResponse from the broker, and may be called only
 // after Done selects.
 func (p *AsyncAppend) Response() pb.AppendResponse { return p.app.Response }
 
+// AppendRange is a [Begin, End) byte range of journal content.
+type AppendRange struct {
+	Begin, End int64
+}
+
+// WriteRanges returns the committed journal offsets of each write released by
+// ReleaseWrite, indexed by its returned index. Ranges lie within the Response
+// Commit Fragment. WriteRanges may be called only after Done selects, and
+// returns nil if the AsyncAppend was aborted.
+func (p *AsyncAppend) WriteRanges() []AppendRange {
+	if p.err != nil || p.app.Response.Commit == nil {
+		return nil
+	}
+	var begin = p.app.Response.Commit.Begin
+	var out = make([]AppendRange, len(p.ranges))
+
+	for i, r := range p.ranges {
+		out[i] = AppendRange{Begin: begin + r.Begin, End: begin + r.End}
+	}
+	return out
+}
+
 // Done returns a channel which selects when the AsyncAppend has committed
 // or has been aborted along with the AppendService's Context.
 func (p *AsyncAppend) Done() <-chan struct{} { return p.commitCh }
 
 // Err returns nil if Done is not yet closed, or the AsyncAppend committed.
 // Otherwise, this AsyncAppend was aborted along with the AppendService Context,
-// and Err returns the causal context error (Cancelled or DeadlineExceeded).
+// and Err returns the causal context error (Cancelled or DeadlineExceeded),
+// or it was started after a Drain and Err returns ErrAppendServiceClosed,
+// or it was started by StartAppendAtOffset and Err returns ErrWrongAppendOffset.
+// An AsyncAppend having a dependency which failed with ErrWrongAppendOffset
+// is not dispatched, and also fails with ErrWrongAppendOffset.
 func (p *AsyncAppend) Err() error {
 	select {
 	case <-p.Done():
@@ -264,6 +623,19 @@ func (p *AsyncAppend) Err() error {
 	}
 }
 
+// newIdempotencyKey returns a random AppendRequest IdempotencyKey. Each
+// AsyncAppend is assigned a key on creation, which is then re-used across
+// retries of its Append RPC. Should an attempt commit but the client fail to
+// see its AppendResponse, the broker recognizes the retry as a duplicate and
+// returns the prior commit rather than appending its content again.
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err) // crypto/rand Read is not expected to fail.
+	}
+	return hex.EncodeToString(b[:])
+}
+
 // serveAppends executes Append RPCs specified by a (potentially growing) chain
 // of ordered AsyncAppends. Each RPC is retried until successful. Upon reaching
 // the end of the chain, serveAppends marks its exit with tombstoneAsyncAppend
@@ -293,10 +665,15 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 		}
 		aa.mu.Unlock() // Further appends may queue while we dispatch this RPC.
 
+		var depErr error
 		for _, dep := range aa.dependencies {
 			<-dep.Done()
 
-			if dep.Err() != nil && aa.app.ctx.Err() == nil {
+			if err := dep.Err(); err == ErrWrongAppendOffset {
+				// |dep| will never commit. Neither may |aa|, which fails with
+				// the error of its dependency.
+				depErr = err
+			} else if err != nil && aa.app.ctx.Err() == nil {
 				// This can happen only if |dep| and |aa| were created by different
 				// AppendServices having differing contexts, which is disallowed.
 				panic("dependency Err() != nil, but our own context.Err == nil")
@@ -307,17 +684,55 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 		// client can possibly be waiting on its response. We skip performing
 		// an Append RPC altogether in this case.
 
-		if aa.fb != nil {
+		if aa.fb != nil && depErr != nil {
+			retryUntil(aa.fb.flush, aa.app.Request.Journal, "failed to flush appendBuffer")
+			aa.err = depErr // Retain for Err to return.
+		} else if aa.fb != nil {
 			retryUntil(aa.fb.flush, aa.app.Request.Journal, "failed to flush appendBuffer")
 
 			retryUntil(func() error {
-				var _, err = io.Copy(&aa.app, io.NewSectionReader(aa.fb.file, 0, aa.checkpoint))
-				if err == nil {
-					err = aa.app.Close()
+				var err error
+				for redirects := 0; true; {
+					if _, err = io.Copy(&aa.app, io.NewSectionReader(aa.fb.file, 0, aa.checkpoint)); err == nil {
+						err = aa.app.Close()
+					}
+					if isRedirect(&aa.app, err) && redirects != maxAppendRedirects {
+						// The broker named the journal's current primary, and Close
+						// updated our Route. Retry immediately.
+						redirects++
+					} else if err == ErrWrongAppendOffset && aa.reissues != 0 {
+						// The journal was written through a different offset.
+						// Re-issue immediately at its current write head.
+						var head int64
+						if head, err = readWriteHead(aa.app.ctx, s.RoutedJournalClient, aa.app.Request.Journal); err != nil {
+							break
+						}
+						log.WithFields(log.Fields{
+							"journal": aa.app.Request.Journal,
+							"offset":  aa.app.Request.Offset,
+							"head":    head,
+						}).Info("re-issuing append of wrong offset at journal write head")
+
+						aa.app.Request.Offset = head
+						aa.reissues--
+					} else {
+						break
+					}
+					aa.app.Reset()
 				}
 
 				if err == context.Canceled || err == context.DeadlineExceeded {
 					aa.err = err // Retain for Err to return.
+
+					s.mu.Lock()
+					s.aborted++
+					s.mu.Unlock()
+
+					return nil // Break retry loop.
+				} else if err == ErrWrongAppendOffset {
+					// Re-issues of StartAppendAtOffset are exhausted. A retry
+					// at the same offset cannot succeed.
+					aa.err = err // Retain for Err to return.
 					return nil   // Break retry loop.
 				} else if err != nil {
 					aa.app.Reset()
@@ -332,6 +747,13 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 
 		if aa.fb != nil {
 			releaseFileBuffer(aa.fb)
+
+			s.mu.Lock()
+			if s.inFlight[aa.app.Request.Journal]--; s.inFlight[aa.app.Request.Journal] == 0 {
+				delete(s.inFlight, aa.app.Request.Journal)
+			}
+			s.cond.Broadcast()
+			s.mu.Unlock()
 		}
 
 		aa.mu.Lock()
@@ -339,6 +761,21 @@ var serveAppends = func(s *AppendService, aa *AsyncAppend) {
 	}
 }
 
+// readWriteHead returns the current write head of the journal, as reported
+// by a non-blocking, metadata-only read from its end.
+func readWriteHead(ctx context.Context, rjc pb.RoutedJournalClient, journal pb.Journal) (int64, error) {
+	var r = NewReader(ctx, rjc, pb.ReadRequest{
+		Journal:      journal,
+		Offset:       -1,
+		Block:        false,
+		MetadataOnly: true,
+	})
+	if _, err := r.Read(nil); err != nil && err != ErrOffsetNotYetAvailable {
+		return 0, err
+	}
+	return r.Response.WriteHead, nil
+}
+
 // appendBuffer composes a backing File with a bufio.Writer, and additionally
 // tracks the offset through which the file is written.
 type appendBuffer struct {
@@ -6,7 +6,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"sync"
+	"testing/iotest"
+	"time"
 
 	gc "github.com/go-check/check"
 	pb "go.gazette.dev/core/broker/protocol"
@@ -26,7 +29,8 @@ func (s *AppendServiceSuite) TestBasicAppendWithRetry(c *gc.C) {
 	_, _ = aa.Writer().WriteString("hello, world")
 	c.Assert(aa.Release(), gc.IsNil)
 
-	readHelloWorldAppendRequest(c, broker) // RPC is dispatched to broker.
+	var key = readHelloWorldAppendRequest(c, broker) // RPC is dispatched to broker.
+	c.Check(key, gc.Equals, aa.Request().IdempotencyKey)
 
 	// Interlude: expect |aa.mu| remains lockable while |aa| is executed,
 	// and that |aa| was chained with a new & empty AsyncAppend.
@@ -36,16 +40,19 @@ func (s *AppendServiceSuite) TestBasicAppendWithRetry(c *gc.C) {
 	c.Check(aa.next.checkpoint, gc.Equals, int64(0))
 	c.Check(aa.next.fb, gc.IsNil) // |next| has not been returned by StartAppend.
 	c.Check(aa.next.next, gc.IsNil)
+	// |aa.next| was assigned its own IdempotencyKey.
+	c.Check(aa.next.Request().IdempotencyKey, gc.Not(gc.Equals), key)
 
 	// Expect |aa.next| is now indexed by AppendService, rather than |aa|.
 	c.Check(as.PendingExcept(""), gc.DeepEquals, []*AsyncAppend{aa.next})
 	aa.mu.Unlock()
 
 	// First & second attempts fail. Expect RPC is retried until success.
+	// Each retry re-uses the IdempotencyKey of the first attempt.
 	broker.ErrCh <- errors.New("first attempt fails")
-	readHelloWorldAppendRequest(c, broker) // Expect RPC is retried.
+	c.Check(readHelloWorldAppendRequest(c, broker), gc.Equals, key) // Expect RPC is retried.
 	broker.ErrCh <- errors.New("second attempt fails")
-	readHelloWorldAppendRequest(c, broker)
+	c.Check(readHelloWorldAppendRequest(c, broker), gc.Equals, key)
 	broker.AppendRespCh <- buildAppendResponseFixture(broker) // Success.
 
 	<-aa.Done()
@@ -101,7 +108,7 @@ func (s *AppendServiceSuite) TestAppendPipelineWithAborts(c *gc.C) {
 
 	// Start serveAppends, and expect one Append RPC which reflects writes & rollbacks.
 	close(serveCh)
-	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+	recvAppendHeader(c, broker, "a/journal")
 	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("write one write two")})
 	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
 	c.Check(<-broker.AppendReqCh, gc.IsNil)
@@ -114,6 +121,124 @@ func (s *AppendServiceSuite) TestAppendPipelineWithAborts(c *gc.C) {
 	WaitForPendingAppends(as.PendingExcept(""))
 }
 
+func (s *AppendServiceSuite) TestAppendWriteRangesWithAborts(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(context.Background(), rjc)
+
+	var serveCh, cleanup = gateServeAppends()
+	defer cleanup()
+
+	var aa = as.StartAppend("a/journal")
+	aa.fb.buf = bufio.NewWriterSize(aa.fb, 7)
+
+	_, _ = aa.Writer().WriteString("aborted first write")
+	aa.Require(errors.New("whoops"))
+	var ind, err = aa.ReleaseWrite()
+	c.Check(err, gc.ErrorMatches, "whoops")
+	c.Check(ind, gc.Equals, -1)
+
+	aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("write one")
+	ind, err = aa.ReleaseWrite()
+	c.Check(err, gc.IsNil)
+	c.Check(ind, gc.Equals, 0)
+
+	aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("ABT")
+	aa.Require(errors.New("potato"))
+	ind, err = aa.ReleaseWrite()
+	c.Check(err, gc.ErrorMatches, "potato")
+	c.Check(ind, gc.Equals, -1)
+
+	// Writes released by Release are not recorded.
+	aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString(" and ")
+	c.Check(aa.Release(), gc.IsNil)
+
+	aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("write two")
+	ind, err = aa.ReleaseWrite()
+	c.Check(err, gc.IsNil)
+	c.Check(ind, gc.Equals, 1)
+
+	aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("ABORT ABORT")
+	aa.Require(errors.New("tomato"))
+	_, err = aa.ReleaseWrite()
+	c.Check(err, gc.ErrorMatches, "tomato")
+
+	close(serveCh)
+	recvAppendHeader(c, broker, "a/journal")
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("write one and write two")})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	c.Check(<-broker.AppendReqCh, gc.IsNil)
+
+	broker.AppendRespCh <- buildAppendResponseFixture(broker) // Commit.Begin is 100.
+	<-aa.Done()
+
+	c.Check(aa.WriteRanges(), gc.DeepEquals, []AppendRange{
+		{Begin: 100, End: 109},
+		{Begin: 114, End: 123},
+	})
+	WaitForPendingAppends(as.PendingExcept(""))
+}
+
+func (s *AppendServiceSuite) TestMaxInFlightBlocksStartAppend(c *gc.C) {
+	defer func(s int64) { appendBufferCutoff = s }(appendBufferCutoff)
+	appendBufferCutoff = 8
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(context.Background(), rjc)
+	as.MaxInFlight = 2
+
+	var serveCh, cleanup = gateServeAppends()
+	defer cleanup()
+
+	// Start two appends, each chained as a separate RPC due to the cutoff.
+	var chs []<-chan struct{}
+	for i := 0; i != 2; i++ {
+		var aa = as.StartAppend("a/journal")
+		_, _ = aa.Writer().WriteString("hello, world")
+		c.Check(aa.Release(), gc.IsNil)
+		chs = append(chs, aa.Done())
+	}
+
+	// Expect a third StartAppend blocks until an in-flight append commits.
+	var startedCh = make(chan *AsyncAppend)
+	go func() { startedCh <- as.StartAppend("a/journal") }()
+
+	select {
+	case <-startedCh:
+		c.Fatal("expected StartAppend to block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(serveCh)
+	readHelloWorldAppendRequest(c, broker)
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	<-chs[0]
+
+	var aa = <-startedCh
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Check(aa.Release(), gc.IsNil)
+
+	for i := 0; i != 2; i++ {
+		readHelloWorldAppendRequest(c, broker)
+		broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	}
+	<-chs[1]
+	<-aa.Done()
+
+	c.Check(as.Drain(context.Background()), gc.IsNil)
+	c.Check(as.inFlight, gc.HasLen, 0)
+}
+
 func (s *AppendServiceSuite) TestAppendSizeCutoff(c *gc.C) {
 	defer func(s int64) { appendBufferCutoff = s }(appendBufferCutoff)
 	appendBufferCutoff = 8
@@ -238,6 +363,101 @@ func (s *AppendServiceSuite) TestAppendContextCancellation(c *gc.C) {
 	c.Check(aa3.Err(), gc.Equals, context.Canceled)
 }
 
+func (s *AppendServiceSuite) TestDrain(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(context.Background(), rjc)
+
+	var aa1 = as.StartAppend("a/journal")
+	_, _ = aa1.Writer().WriteString("hello, world")
+	c.Check(aa1.Release(), gc.IsNil)
+
+	// Read the request, but don't yet respond.
+	readHelloWorldAppendRequest(c, broker)
+
+	var drainCh = make(chan error)
+	go func() { drainCh <- as.Drain(context.Background()) }()
+
+	// Expect the service is closed, and new appends are aborted.
+	for {
+		as.mu.Lock()
+		var closed = as.closed
+		as.mu.Unlock()
+
+		if closed {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	var aa2 = as.StartAppend("a/journal")
+
+	// Expect |aa2| is already aborted, prior to its Release.
+	select {
+	case <-aa2.Done():
+	default:
+		c.Fatal("expected AsyncAppend to be already aborted")
+	}
+	c.Check(aa2.Err(), gc.Equals, ErrAppendServiceClosed)
+
+	// It's usable by the caller, but its write is discarded.
+	_, _ = aa2.Writer().WriteString("discarded write")
+	c.Check(aa2.Release(), gc.IsNil)
+	c.Check(aa2.Err(), gc.Equals, ErrAppendServiceClosed)
+	c.Check(aa2.WriteRanges(), gc.IsNil)
+
+	var aa3 = as.StartAppend("a/journal")
+	_, _ = aa3.Writer().WriteString("another discarded write")
+	var index, err = aa3.ReleaseWrite()
+	c.Check(index, gc.Equals, -1)
+	c.Check(err, gc.IsNil)
+	c.Check(aa3.Err(), gc.Equals, ErrAppendServiceClosed)
+
+	// Drain blocks until the pending append completes.
+	select {
+	case <-drainCh:
+		c.Fatal("expected Drain to block")
+	case <-time.After(10 * time.Millisecond):
+	}
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+
+	c.Check(<-drainCh, gc.IsNil)
+	c.Check(aa1.Err(), gc.IsNil)
+	c.Check(as.PendingExcept(""), gc.HasLen, 0)
+
+	// Drain may be called again, and returns immediately.
+	c.Check(as.Drain(context.Background()), gc.IsNil)
+}
+
+func (s *AppendServiceSuite) TestDrainWithContextCancellation(c *gc.C) {
+	var ctx, cancel = context.WithCancel(context.Background())
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(ctx, rjc)
+
+	var aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Check(aa.Release(), gc.IsNil)
+
+	// Read the request, but don't respond.
+	readHelloWorldAppendRequest(c, broker)
+
+	// Case: Drain's Context is done before pending appends complete.
+	var drainCtx, drainCancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer drainCancel()
+	c.Check(as.Drain(drainCtx), gc.Equals, context.DeadlineExceeded)
+
+	// Case: the AppendService Context is cancelled, aborting the pending append.
+	cancel()
+	c.Check(as.Drain(context.Background()), gc.ErrorMatches,
+		`1 appends were aborted: context canceled`)
+	c.Check(aa.Err(), gc.Equals, context.Canceled)
+}
+
 func (s *AppendServiceSuite) TestFlushErrorHandlingCases(c *gc.C) {
 	var mf = mockFile{n: 6}
 
@@ -370,6 +590,39 @@ func (s *AppendServiceSuite) TestReleaseChecksForWriteErrorAndRecovers(c *gc.C)
 	<-aa.Done()
 	c.Check(aa.Response(), gc.DeepEquals, *buildAppendResponseFixture(broker))
 
+	// Drain, which returns |mf| to |appendBufferPool|. Then reset the pool so
+	// that |mf| isn't used by later tests.
+	c.Check(as.Drain(context.Background()), gc.IsNil)
+	appendBufferPool = sync.Pool{New: appendBufferPool.New}
+}
+
+func (s *AppendServiceSuite) TestStartAppendReaderRollsBackReadError(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(context.Background(), rjc)
+
+	var serveCh, cleanup = gateServeAppends()
+	defer cleanup()
+
+	// Begin an append of a reader which partially reads, and then fails.
+	var aa, err = as.StartAppendReader("a/journal",
+		iotest.TimeoutReader(strings.NewReader("partial content")))
+	c.Check(err, gc.Equals, iotest.ErrTimeout)
+
+	// Try again. This time the reader is drained.
+	aa, err = as.StartAppendReader("a/journal",
+		iotest.OneByteReader(strings.NewReader("hello, world")))
+	c.Check(err, gc.IsNil)
+
+	close(serveCh)
+	readHelloWorldAppendRequest(c, broker) // RPC is dispatched to broker.
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+
+	<-aa.Done()
+	c.Check(aa.Response(), gc.DeepEquals, *buildAppendResponseFixture(broker))
+
 	WaitForPendingAppends(as.PendingExcept(""))
 }
 
@@ -413,7 +666,7 @@ func (s *AppendServiceSuite) TestAppendOrderingCycle(c *gc.C) {
 
 	// Expect that we properly read Append RPCs in dependency order.
 	for i := range expect {
-		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: expect[i].journal})
+		recvAppendHeader(c, broker, expect[i].journal)
 		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte(expect[i].content + "!")})
 		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
 		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
@@ -424,6 +677,61 @@ func (s *AppendServiceSuite) TestAppendOrderingCycle(c *gc.C) {
 	WaitForPendingAppends(as.PendingExcept("")) // All loops exited.
 }
 
+func (s *AppendServiceSuite) TestAppendBatch(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(context.Background(), rjc)
+
+	var serveCh, cleanup = gateServeAppends()
+	defer cleanup()
+
+	// Precondition: an append of journal/A is already pending.
+	var pending = as.StartAppend("journal/A")
+	_, _ = pending.Writer().WriteString("one")
+	c.Check(pending.Release(), gc.IsNil)
+
+	var batch = as.StartBatch()
+	_, _ = batch.Writer("journal/B").WriteString("three")
+	_, _ = batch.Writer("journal/A").WriteString("two")
+	_, _ = batch.Writer("journal/C").WriteString("aborted")
+	batch.Require("journal/C", errors.New("an error"))
+
+	// Expect the first error is returned, but other appends are released.
+	// journal/C is skipped and never begins an Append RPC.
+	var appends, err = batch.Commit()
+	c.Check(err, gc.ErrorMatches, "an error")
+	c.Assert(appends, gc.HasLen, 2)
+
+	// journal/A was batched with its pending append.
+	c.Check(appends[0], gc.Equals, pending)
+	// journal/B depends on the pending append of journal/A.
+	c.Check(appends[1].Request().Journal, gc.Equals, pb.Journal("journal/B"))
+	c.Check(appends[1].dependencies, gc.DeepEquals, []*AsyncAppend{pending})
+
+	close(serveCh) // Unblock raced service loops for each journal.
+
+	// Expect Append RPCs are read in dependency order.
+	for _, exp := range []struct {
+		journal pb.Journal
+		content string
+	}{
+		{"journal/A", "onetwo"},
+		{"journal/B", "three"},
+	} {
+		recvAppendHeader(c, broker, exp.journal)
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte(exp.content)})
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+		c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+
+		broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	}
+
+	WaitForPendingAppends(appends)
+	WaitForPendingAppends(as.PendingExcept("")) // All loops exited.
+}
+
 func (s *AppendServiceSuite) TestBufferPooling(c *gc.C) {
 	var ab = appendBufferPool.Get().(*appendBuffer)
 
@@ -508,13 +816,273 @@ func (mf *mockFile) Seek(offset int64, whence int) (int64, error) {
 	return offset, nil
 }
 
-func readHelloWorldAppendRequest(c *gc.C, broker *teststub.Broker) {
+// recvAppendHeader reads an initial AppendRequest of |journal|, and returns
+// its (required to be non-empty) IdempotencyKey.
+func recvAppendHeader(c *gc.C, broker *teststub.Broker, journal pb.Journal) string {
+	var req = <-broker.AppendReqCh
+	c.Assert(req, gc.NotNil)
+
+	var key = req.IdempotencyKey
+	c.Check(key, gc.Not(gc.Equals), "")
+	req.IdempotencyKey = ""
+
+	c.Check(req, gc.DeepEquals, &pb.AppendRequest{Journal: journal})
+	return key
+}
+
+func (s *AppendServiceSuite) TestMisroutedAppendIsRedirected(c *gc.C) {
+	var brokerA, brokerB = teststub.NewBroker(c), teststub.NewBroker(c)
+	defer brokerA.Cleanup()
+	defer brokerB.Cleanup()
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	// Broker B is a distinct member from broker A.
+	var hdrB = *buildHeaderFixture(brokerB)
+	hdrB.ProcessId = pb.ProcessSpec_ID{Zone: "b", Suffix: "broker"}
+	hdrB.Route.Members = []pb.ProcessSpec_ID{hdrB.ProcessId}
+
+	var rc = NewRouteCache(10, time.Hour)
+	var rjc = pb.NewRoutedJournalClient(brokerA.Client(), rc)
+	var as = NewAppendService(ctx, rjc)
+
+	var aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Assert(aa.Release(), gc.IsNil)
+
+	// Broker A isn't primary. It responds with a Header naming broker B.
+	readHelloWorldAppendRequest(c, brokerA)
+	brokerA.AppendRespCh <- &pb.AppendResponse{
+		Status: pb.Status_NOT_JOURNAL_PRIMARY_BROKER,
+		Header: hdrB,
+	}
+	// Expect the append is retried against broker B, which commits it.
+	readHelloWorldAppendRequest(c, brokerB)
+	var respB = buildAppendResponseFixture(brokerB)
+	respB.Header = hdrB
+	brokerB.AppendRespCh <- respB
+
+	<-aa.Done()
+	c.Check(aa.Err(), gc.IsNil)
+	c.Check(aa.Response(), gc.DeepEquals, *respB)
+	c.Check(rc.Route(ctx, "a/journal"), gc.DeepEquals, hdrB.Route)
+
+	// Case: the Append function is similarly redirected, and surfaces the
+	// Header of the responding broker alongside a non-OK status.
+	rc.UpdateRoute("a/journal", &buildHeaderFixture(brokerA).Route)
+
+	go func() {
+		readPlainHelloWorldAppendRequest(c, brokerA)
+		brokerA.AppendRespCh <- &pb.AppendResponse{
+			Status: pb.Status_NOT_JOURNAL_PRIMARY_BROKER,
+			Header: hdrB,
+		}
+		readPlainHelloWorldAppendRequest(c, brokerB)
+		brokerB.AppendRespCh <- &pb.AppendResponse{
+			Status: pb.Status_WRONG_APPEND_OFFSET,
+			Header: hdrB,
+		}
+	}()
+
+	resp, err := Append(ctx, rjc, pb.AppendRequest{Journal: "a/journal"},
+		strings.NewReader("hello, world"))
+	c.Check(err, gc.Equals, ErrWrongAppendOffset)
+	c.Check(resp.Header, gc.DeepEquals, hdrB)
+}
+
+func (s *AppendServiceSuite) TestAppendAtOffsetIsReissuedAtWriteHead(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var as = NewAppendService(context.Background(), rjc)
+
+	var aa = as.StartAppendAtOffset("a/journal", 50, 1)
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Assert(aa.Release(), gc.IsNil)
+
+	// A following append isn't batched with |aa|.
+	var bb = as.StartAppend("a/journal")
+	c.Check(bb == aa, gc.Equals, false)
+	_, _ = bb.Writer().WriteString("hello, world")
+	c.Assert(bb.Release(), gc.IsNil)
+
+	// Expect an RPC at offset 50, which fails with WRONG_APPEND_OFFSET.
+	var key = readOffsetHelloWorldAppendRequest(c, broker, 50)
+	broker.AppendRespCh <- &pb.AppendResponse{
+		Status: pb.Status_WRONG_APPEND_OFFSET,
+		Header: *buildHeaderFixture(broker),
+	}
+	// Expect the write head is read.
+	c.Check(<-broker.ReadReqCh, gc.DeepEquals, &pb.ReadRequest{
+		Journal:      "a/journal",
+		Offset:       -1,
+		MetadataOnly: true,
+	})
+	broker.ReadRespCh <- &pb.ReadResponse{
+		Status:    pb.Status_OFFSET_NOT_YET_AVAILABLE,
+		Header:    buildHeaderFixture(broker),
+		Offset:    100,
+		WriteHead: 100,
+	}
+	broker.ErrCh <- nil
+
+	// The RPC is re-issued at the write head, and commits.
+	c.Check(readOffsetHelloWorldAppendRequest(c, broker, 100), gc.Equals, key)
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+
+	<-aa.Done()
+	c.Check(aa.Err(), gc.IsNil)
+	c.Check(aa.Request().Offset, gc.Equals, int64(100))
+	c.Check(aa.Response().Commit.Begin, gc.Equals, int64(100))
+
+	// |bb| is then dispatched without an offset.
+	readHelloWorldAppendRequest(c, broker)
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	<-bb.Done()
+	c.Check(bb.Err(), gc.IsNil)
+
+	// Case: without re-issues, the AsyncAppend fails.
+	aa = as.StartAppendAtOffset("a/journal", 50, 0)
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Assert(aa.Release(), gc.IsNil)
+
+	readOffsetHelloWorldAppendRequest(c, broker, 50)
+	broker.AppendRespCh <- &pb.AppendResponse{
+		Status: pb.Status_WRONG_APPEND_OFFSET,
+		Header: *buildHeaderFixture(broker),
+	}
+	<-aa.Done()
+	c.Check(aa.Err(), gc.Equals, ErrWrongAppendOffset)
+	c.Check(aa.WriteRanges(), gc.IsNil)
+
+	// Case: an append which depends on a failed append isn't dispatched,
+	// and fails with the error of its dependency.
+	aa = as.StartAppendAtOffset("a/journal", 50, 0)
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Assert(aa.Release(), gc.IsNil)
+
+	bb = as.StartAppend("b/journal", aa)
+	_, _ = bb.Writer().WriteString("hello, world")
+	c.Assert(bb.Release(), gc.IsNil)
+
+	readOffsetHelloWorldAppendRequest(c, broker, 50)
+	broker.AppendRespCh <- &pb.AppendResponse{
+		Status: pb.Status_WRONG_APPEND_OFFSET,
+		Header: *buildHeaderFixture(broker),
+	}
+	<-bb.Done()
+	c.Check(bb.Err(), gc.Equals, ErrWrongAppendOffset)
+	c.Check(bb.WriteRanges(), gc.IsNil)
+
+	// |bb| didn't begin an RPC.
+	select {
+	case req := <-broker.AppendReqCh:
+		c.Errorf("unexpected AppendRequest %v", req)
+	default:
+	}
+}
+
+// readOffsetHelloWorldAppendRequest reads an AppendRequest of "hello, world"
+// to "a/journal" at |offset|, and returns its IdempotencyKey.
+func readOffsetHelloWorldAppendRequest(c *gc.C, broker *teststub.Broker, offset int64) (key string) {
+	var req = <-broker.AppendReqCh
+	c.Assert(req, gc.NotNil)
+
+	key, req.IdempotencyKey = req.IdempotencyKey, ""
+	c.Check(key, gc.Not(gc.Equals), "")
+	c.Check(req, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal", Offset: offset})
+
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("hello, world")})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+	return
+}
+
+// readPlainHelloWorldAppendRequest reads an AppendRequest of "hello, world"
+// to "a/journal", which has no IdempotencyKey.
+func readPlainHelloWorldAppendRequest(c *gc.C, broker *teststub.Broker) {
 	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
 	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("hello, world")})
 	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
 	c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
 }
 
+func (s *AppendServiceSuite) TestPrimeRoutes(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var listCh = make(chan *pb.ListRequest, 1)
+	broker.ListFunc = func(_ context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+		listCh <- req
+		var journals = buildListResponseFixture("a/journal", "unassigned/journal")
+		journals[0].Route = buildHeaderFixture(broker).Route
+		journals[1].Route = pb.Route{Primary: -1}
+
+		return &pb.ListResponse{Header: *buildHeaderFixture(broker), Journals: journals}, nil
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var router = &recordingRouter{RouteCache: NewRouteCache(10, time.Hour)}
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), router)
+	var as = NewAppendService(ctx, rjc)
+
+	c.Assert(as.PrimeRoutes(5*time.Millisecond, "a/journal", "unassigned/journal"), gc.IsNil)
+	c.Check((<-listCh).Selector, gc.DeepEquals, pb.LabelSelector{
+		Include: pb.MustLabelSet("name", "a/journal", "name", "unassigned/journal")})
+
+	// Expect the first Append of the primed journal is dispatched with its cached
+	// Route, and issues no other RPC to resolve it.
+	var aa = as.StartAppend("a/journal")
+	_, _ = aa.Writer().WriteString("hello, world")
+	c.Assert(aa.Release(), gc.IsNil)
+
+	readHelloWorldAppendRequest(c, broker)
+	broker.AppendRespCh <- buildAppendResponseFixture(broker)
+	<-aa.Done()
+
+	c.Check(router.routed(), gc.DeepEquals, []pb.Route{buildHeaderFixture(broker).Route})
+
+	// Expect Routes are periodically refreshed.
+	<-listCh
+	<-listCh
+}
+
+// recordingRouter is a RouteCache which records Routes returned to the
+// dispatcher for "a/journal".
+type recordingRouter struct {
+	*RouteCache
+	returned []pb.Route
+	mu       sync.Mutex
+}
+
+func (r *recordingRouter) Route(ctx context.Context, item string) pb.Route {
+	var rt = r.RouteCache.Route(ctx, item)
+	if item == "a/journal" {
+		r.mu.Lock()
+		r.returned = append(r.returned, rt)
+		r.mu.Unlock()
+	}
+	return rt
+}
+
+func (r *recordingRouter) routed() []pb.Route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]pb.Route(nil), r.returned...)
+}
+
+func readHelloWorldAppendRequest(c *gc.C, broker *teststub.Broker) (key string) {
+	key = recvAppendHeader(c, broker, "a/journal")
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte("hello, world")})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+	return
+}
+
 func gateServeAppends() (chan<- struct{}, func()) {
 	var ch = make(chan struct{})
 	var realServeAppends = serveAppends
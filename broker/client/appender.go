@@ -61,7 +61,10 @@ func (a *Appender) Write(p []byte) (n int, err error) {
 
 // Close the Append to complete the transaction, committing previously
 // written content. If Close returns without an error, Append.Response
-// will hold the broker response.
+// will hold the broker response. If Close returns an error of a non-OK
+// broker Status (eg, ErrNotJournalPrimaryBroker), Append.Response will hold
+// the broker's response Header, and its Route has been applied to the
+// RoutedJournalClient.
 func (a *Appender) Close() (err error) {
 	// Send an empty chunk to signal commit of previously written content
 	if err = a.lazyInit(); err != nil {
@@ -77,7 +80,11 @@ func (a *Appender) Close() (err error) {
 	} else if err = a.Response.Validate(); err != nil {
 		// Pass.
 	} else {
-		a.client.UpdateRoute(a.Request.Journal.String(), &a.Response.Header.Route)
+		if len(a.Response.Header.Route.Members) == 0 {
+			a.client.UpdateRoute(a.Request.Journal.String(), nil) // Journal is not assigned.
+		} else {
+			a.client.UpdateRoute(a.Request.Journal.String(), &a.Response.Header.Route)
+		}
 
 		switch a.Response.Status {
 		case pb.Status_OK:
@@ -86,6 +93,8 @@ func (a *Appender) Close() (err error) {
 			err = ErrNotJournalPrimaryBroker
 		case pb.Status_WRONG_APPEND_OFFSET:
 			err = ErrWrongAppendOffset
+		case pb.Status_JOURNAL_SEALED:
+			err = ErrJournalSealed
 		default:
 			err = errors.New(a.Response.Status.String())
 		}
@@ -136,11 +145,16 @@ func (a *Appender) sendMsg(r *pb.AppendRequest) (err error) {
 // Append zero or more ReaderAts of |content| to a journal as a single Append
 // transaction. Append retries on transport or routing errors, but fails
 // on all other errors. If no ReaderAts are provided, an Append RPC with no
-// content is issued.
+// content is issued. Where a returned error is of a non-OK broker Status,
+// the returned AppendResponse holds the broker's response Header.
+//
+// If a broker responds with NOT_JOURNAL_PRIMARY_BROKER and a Header which
+// names the current journal primary, Append is immediately retried (without
+// backoff) against that primary.
 func Append(ctx context.Context, rjc pb.RoutedJournalClient, req pb.AppendRequest,
 	content ...io.ReaderAt) (pb.AppendResponse, error) {
 
-	for attempt := 0; true; attempt++ {
+	for attempt, redirects := 0, 0; true; attempt++ {
 		var a = NewAppender(ctx, rjc, req)
 		var err error
 
@@ -158,10 +172,15 @@ func Append(ctx context.Context, rjc pb.RoutedJournalClient, req pb.AppendReques
 		} else if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
 			// Fallthrough to retry
 		} else if err == ErrNotJournalPrimaryBroker {
+			if isRedirect(a, err) && redirects != maxAppendRedirects {
+				redirects, attempt = redirects+1, attempt-1
+				continue // Retry immediately against the indicated primary.
+			}
 			// Fallthrough.
 		} else {
 			return a.Response, err
 		}
+		redirects = 0
 
 		select {
 		case <-ctx.Done():
@@ -171,3 +190,16 @@ func Append(ctx context.Context, rjc pb.RoutedJournalClient, req pb.AppendReques
 	}
 	panic("not reached")
 }
+
+// isRedirect returns true if |err| of the Appender is NOT_JOURNAL_PRIMARY_BROKER,
+// and its response Header names the current primary of the journal. As Close
+// updates the RoutedJournalClient with this Route, an immediate retry will be
+// dispatched to that primary.
+func isRedirect(a *Appender, err error) bool {
+	return err == ErrNotJournalPrimaryBroker && a.Response.Header.Route.Primary != -1
+}
+
+// maxAppendRedirects is the number of consecutive immediate retries of Append
+// RPCs redirected by NOT_JOURNAL_PRIMARY_BROKER, after which further retries
+// use backoff (eg, because the journal Route is rapidly changing).
+const maxAppendRedirects = 3
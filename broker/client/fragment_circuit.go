@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"go.gazette.dev/core/metrics"
+)
+
+// FragmentCircuitBreaker guards fetches of Fragment URLs by OpenFragmentURL
+// (and by Readers which directly open Fragment URLs), tracking the consecutive
+// failures of fetches from each store host. Upon Threshold consecutive
+// failures the circuit of the host opens, and further fetches fail fast with
+// ErrFragmentCircuitOpen rather than compounding the load of a struggling
+// store. The circuit closes after an open interval which begins at MinOpen,
+// and doubles with each successive trip of the host to at most MaxOpen. A
+// fetch which fails after the circuit closes immediately re-trips it, while a
+// successful fetch resets the host.
+//
+// Failures are transport errors and responses having a 5xx or 429 (Too Many
+// Requests) status. Other responses indicate the store is available, and
+// reset the host.
+type FragmentCircuitBreaker struct {
+	Threshold int
+	MinOpen   time.Duration
+	MaxOpen   time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*fragmentCircuit
+}
+
+// fragmentCircuit is the circuit state of a store host.
+type fragmentCircuit struct {
+	failures  int       // Consecutive failed fetches.
+	trips     int       // Consecutive trips of the circuit.
+	openUntil time.Time // Time until which the circuit is open.
+}
+
+// NewFragmentCircuitBreaker returns a FragmentCircuitBreaker which trips
+// after |threshold| consecutive failures, for an open interval which begins
+// at |minOpen| and backs off to |maxOpen|.
+func NewFragmentCircuitBreaker(threshold int, minOpen, maxOpen time.Duration) *FragmentCircuitBreaker {
+	return &FragmentCircuitBreaker{
+		Threshold: threshold,
+		MinOpen:   minOpen,
+		MaxOpen:   maxOpen,
+		hosts:     make(map[string]*fragmentCircuit),
+	}
+}
+
+// allow returns ErrFragmentCircuitOpen if the circuit of |host| is open.
+func (b *FragmentCircuitBreaker) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.hosts[host]; ok && timeNow().Before(c.openUntil) {
+		metrics.GazetteFragmentCircuitRejectedTotal.WithLabelValues(host).Inc()
+		return ErrFragmentCircuitOpen
+	}
+	return nil
+}
+
+// record the outcome of a fetch from |host|, tripping its circuit if
+// consecutive failures have reached the Threshold.
+func (b *FragmentCircuitBreaker) record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var c, ok = b.hosts[host]
+	if !failed {
+		if ok {
+			delete(b.hosts, host)
+			metrics.GazetteFragmentCircuitOpen.WithLabelValues(host).Set(0)
+		}
+		return
+	} else if !ok {
+		c = new(fragmentCircuit)
+		b.hosts[host] = c
+	}
+
+	if c.failures++; c.failures < b.Threshold {
+		return
+	}
+	var interval = b.MinOpen
+	for i := 0; i != c.trips && interval < b.MaxOpen; i++ {
+		interval *= 2
+	}
+	if interval > b.MaxOpen {
+		interval = b.MaxOpen
+	}
+	c.trips++
+	c.openUntil = timeNow().Add(interval)
+
+	metrics.GazetteFragmentCircuitTripsTotal.WithLabelValues(host).Inc()
+	metrics.GazetteFragmentCircuitOpen.WithLabelValues(host).Set(1)
+}
+
+// isFragmentFetchFailure returns whether a fetch having the given response,
+// or transport error, is a failure of the store.
+func isFragmentFetchFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
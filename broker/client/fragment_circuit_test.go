@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+type FragmentCircuitSuite struct{}
+
+func (s *FragmentCircuitSuite) TestTripsAndResetsCases(c *gc.C) {
+	defer func(f func() time.Time) { timeNow = f }(timeNow)
+	defer func(b *FragmentCircuitBreaker) { FragmentCircuit = b }(FragmentCircuit)
+
+	var fixedtime int64 = 1000
+	timeNow = func() time.Time { return time.Unix(fixedtime, 0) }
+	FragmentCircuit = NewFragmentCircuitBreaker(2, 10*time.Second, 30*time.Second)
+
+	var status, fetches = http.StatusServiceUnavailable, 0
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	var frag = pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            0,
+		End:              5,
+		CompressionCodec: pb.CompressionCodec_NONE,
+	}
+	var open = func() error {
+		var fr, err = OpenFragmentURL(context.Background(), frag, 0, srv.URL+"/fragment")
+		if err == nil {
+			var b, _ = ioutil.ReadAll(fr)
+			c.Check(string(b), gc.Equals, "hello")
+			c.Check(fr.Close(), gc.IsNil)
+		}
+		return err
+	}
+
+	// Case: failures below the threshold are returned, and don't trip.
+	c.Check(open(), gc.ErrorMatches, `!OK fetching \(503 Service Unavailable, .*\)`)
+	// Case: reaching the threshold trips the circuit, which fails fast.
+	c.Check(open(), gc.ErrorMatches, `!OK fetching \(503 Service Unavailable, .*\)`)
+	c.Check(open(), gc.Equals, ErrFragmentCircuitOpen)
+	c.Check(fetches, gc.Equals, 2)
+
+	// Case: after the open interval, a fetch is attempted. Its failure
+	// immediately re-trips the circuit, with a backed-off interval.
+	fixedtime += 10
+	c.Check(open(), gc.ErrorMatches, `!OK fetching \(503 .*\)`)
+	fixedtime += 10
+	c.Check(open(), gc.Equals, ErrFragmentCircuitOpen)
+	c.Check(fetches, gc.Equals, 3)
+
+	// Case: the interval is limited to MaxOpen.
+	fixedtime += 10
+	status = http.StatusTooManyRequests
+	c.Check(open(), gc.ErrorMatches, `!OK fetching \(429 .*\)`)
+	fixedtime += 29
+	c.Check(open(), gc.Equals, ErrFragmentCircuitOpen)
+	c.Check(fetches, gc.Equals, 4)
+
+	// Case: a successful fetch resets the host.
+	fixedtime += 1
+	status = http.StatusOK
+	c.Check(open(), gc.IsNil)
+
+	status = http.StatusInternalServerError
+	c.Check(open(), gc.ErrorMatches, `!OK fetching \(500 .*\)`)
+	c.Check(open(), gc.ErrorMatches, `!OK fetching \(500 .*\)`)
+	fixedtime += 9
+	c.Check(open(), gc.Equals, ErrFragmentCircuitOpen)
+	fixedtime += 1
+	status = http.StatusOK
+	c.Check(open(), gc.IsNil)
+	c.Check(fetches, gc.Equals, 8)
+
+	// Case: other !OK statuses indicate the store is available.
+	status = http.StatusNotFound
+	for i := 0; i != 3; i++ {
+		c.Check(open(), gc.ErrorMatches, `!OK fetching \(404 .*\)`)
+	}
+
+	// Case: the circuit breaker may be disabled.
+	FragmentCircuit = nil
+	status = http.StatusServiceUnavailable
+	for i := 0; i != 3; i++ {
+		c.Check(open(), gc.ErrorMatches, `!OK fetching \(503 .*\)`)
+	}
+	c.Check(fetches, gc.Equals, 14)
+}
+
+var _ = gc.Suite(&FragmentCircuitSuite{})
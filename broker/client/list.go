@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"sync/atomic"
 	"time"
 
@@ -44,6 +46,9 @@ func NewPolledList(ctx context.Context, client pb.JournalClient, dur time.Durati
 }
 
 // List returns the most recent polled & merged ListResponse (see ListAllJournals).
+// If a refresh finds that listed journals are unchanged, the prior ListResponse
+// is retained (and its Header is not updated). Callers may therefore compare
+// ListResponse pointers to cheaply determine whether anything has changed.
 func (pl *PolledList) List() *pb.ListResponse { return pl.resp.Load().(*pb.ListResponse) }
 
 // UpdateCh returns a channel which is signaled with each update of the
@@ -60,6 +65,9 @@ func (pl *PolledList) periodicRefresh(dur time.Duration) {
 			if err != nil {
 				log.WithFields(log.Fields{"err": err, "req": pl.req.String()}).
 					Warn("periodic List refresh failed (will retry)")
+			} else if reflect.DeepEqual(pl.List().Journals, resp.Journals) {
+				// Nothing changed. Retain the prior ListResponse, such that
+				// users may cheaply detect its non-change by pointer equality.
 			} else {
 				pl.resp.Store(resp)
 
@@ -75,11 +83,21 @@ func (pl *PolledList) periodicRefresh(dur time.Duration) {
 	}
 }
 
+// maxListRestarts is the number of times ListAllJournals will restart a listing
+// having pages of differing Etcd revisions, before failing.
+var maxListRestarts = 5
+
 // ListAllJournals performs multiple List RPCs, as required to join across multiple
 // ListResponse pages, and returns the complete ListResponse of the ListRequest.
+// Pages are verified to reflect a consistent Etcd revision: if the revision
+// changes between pages, the listing is restarted from its first page. As
+// List RPCs may be served by brokers at differing revisions, restarts are
+// limited and an error is returned if a consistent listing isn't obtained.
 // Any encountered error is returned.
 func ListAllJournals(ctx context.Context, client pb.JournalClient, req pb.ListRequest) (*pb.ListResponse, error) {
 	var resp *pb.ListResponse
+	var firstToken = req.PageToken
+	var restarts int
 
 	for {
 		// List RPCs may be dispatched to any broker.
@@ -94,6 +112,15 @@ func ListAllJournals(ctx context.Context, client pb.JournalClient, req pb.ListRe
 
 			if resp == nil {
 				resp = r
+			} else if resp.Header.Etcd.Revision != r.Header.Etcd.Revision {
+				if restarts == maxListRestarts {
+					return nil, fmt.Errorf("listing revision changed between pages (from %d to %d) after %d restarts",
+						resp.Header.Etcd.Revision, r.Header.Etcd.Revision, restarts)
+				}
+				// The listing changed between pages. Start over.
+				req.PageToken, resp = firstToken, nil
+				restarts++
+				continue
 			} else {
 				resp.Journals = append(resp.Journals, r.Journals...)
 			}
@@ -105,12 +132,32 @@ func ListAllJournals(ctx context.Context, client pb.JournalClient, req pb.ListRe
 
 	if dr, ok := client.(pb.DispatchRouter); ok {
 		for _, j := range resp.Journals {
-			dr.UpdateRoute(j.Spec.Name.String(), &j.Route)
+			if len(j.Route.Members) == 0 {
+				dr.UpdateRoute(j.Spec.Name.String(), nil) // Journal is not assigned.
+			} else {
+				dr.UpdateRoute(j.Spec.Name.String(), &j.Route)
+			}
 		}
 	}
 	return resp, nil
 }
 
+// PrimeRoutes lists the |journals| and updates the DispatchRouter of the
+// RoutedJournalClient with their current Routes. A subsequent RPC of a primed
+// journal may then be dispatched directly to an appropriate broker, rather than
+// first being proxied by an arbitrary one.
+func PrimeRoutes(ctx context.Context, client pb.RoutedJournalClient, journals ...pb.Journal) error {
+	if len(journals) == 0 {
+		return nil // An empty selector would list all journals.
+	}
+	var req pb.ListRequest
+	for _, journal := range journals {
+		req.Selector.Include.AddValue("name", journal.String())
+	}
+	var _, err = ListAllJournals(ctx, client, req)
+	return err
+}
+
 // ApplyJournals invokes the Apply RPC.
 func ApplyJournals(ctx context.Context, jc pb.JournalClient, req *pb.ApplyRequest) (*pb.ApplyResponse, error) {
 	return ApplyJournalsInBatches(ctx, jc, req, 0)
@@ -122,15 +169,15 @@ func ApplyJournals(ctx context.Context, jc pb.JournalClient, req *pb.ApplyReques
 // |size| is 0 all changes will be attempted as part of a single
 // transaction. This function will return the response of the final
 // ShardClient.Apply call. Response validation or !OK status from Apply RPC are
-// mapped to error.
+// mapped to error. A DryRun |req| is not batched, as it applies no changes.
 func ApplyJournalsInBatches(ctx context.Context, jc pb.JournalClient, req *pb.ApplyRequest, size int) (*pb.ApplyResponse, error) {
 	if len(req.Changes) == 0 {
 		return &pb.ApplyResponse{}, nil
 	}
-	if size == 0 {
+	if size == 0 || req.DryRun {
 		size = len(req.Changes)
 	}
-	var curReq = &pb.ApplyRequest{}
+	var curReq = &pb.ApplyRequest{DryRun: req.DryRun}
 	var offset = 0
 
 	for {
@@ -52,6 +52,50 @@ func (s *ListSuite) TestListAllJournalsCases(c *gc.C) {
 	})
 	c.Check(rc.cache.Len(), gc.Equals, 3)
 
+	// Case: The listing revision changes between pages, and is restarted.
+	var hdr2 = hdr
+	hdr2.Etcd.Revision++
+
+	expect = []pb.ListRequest{
+		{Selector: selector, PageLimit: 10},
+		{Selector: selector, PageLimit: 10, PageToken: "tok-1"},
+		{Selector: selector, PageLimit: 10},
+		{Selector: selector, PageLimit: 10, PageToken: "tok-1"},
+	}
+	responses = []pb.ListResponse{
+		{Header: hdr, Journals: mk("part-one"), NextPageToken: "tok-1"},
+		{Header: hdr2, Journals: mk("part-two"), NextPageToken: "tok-2"},
+		{Header: hdr2, Journals: mk("part-one"), NextPageToken: "tok-1"},
+		{Header: hdr2, Journals: mk("part-two", "part-three")},
+	}
+	resp, err = ListAllJournals(context.Background(), broker.Client(), pb.ListRequest{Selector: selector, PageLimit: 10})
+	c.Check(err, gc.IsNil)
+	c.Check(resp, gc.DeepEquals, &pb.ListResponse{
+		Header:   hdr2,
+		Journals: mk("part-one", "part-two", "part-three"),
+	})
+	c.Check(expect, gc.HasLen, 0)
+
+	// Case: The revision continues to change, and restarts are exhausted.
+	defer func(n int) { maxListRestarts = n }(maxListRestarts)
+	maxListRestarts = 1
+
+	expect = []pb.ListRequest{
+		{Selector: selector, PageLimit: 10},
+		{Selector: selector, PageLimit: 10, PageToken: "tok-1"},
+		{Selector: selector, PageLimit: 10},
+		{Selector: selector, PageLimit: 10, PageToken: "tok-1"},
+	}
+	responses = []pb.ListResponse{
+		{Header: hdr, Journals: mk("part-one"), NextPageToken: "tok-1"},
+		{Header: hdr2, Journals: mk("part-two"), NextPageToken: "tok-2"},
+		{Header: hdr2, Journals: mk("part-one"), NextPageToken: "tok-1"},
+		{Header: hdr, Journals: mk("part-two", "part-three")},
+	}
+	_, err = ListAllJournals(context.Background(), broker.Client(), pb.ListRequest{Selector: selector, PageLimit: 10})
+	c.Check(err, gc.ErrorMatches, `listing revision changed between pages \(from \d+ to \d+\) after 1 restarts`)
+	c.Check(expect, gc.HasLen, 0)
+
 	// Case: A single RPC is required.
 	expect = []pb.ListRequest{{Selector: selector}}
 	responses = []pb.ListResponse{{Header: hdr, Journals: mk("only/one")}}
@@ -120,6 +164,20 @@ func (s *ListSuite) TestPolledList(c *gc.C) {
 	<-pl.UpdateCh()
 
 	c.Check(pl.List(), gc.DeepEquals, &fixture)
+
+	// Expect a poll which finds nothing changed retains the prior ListResponse.
+	var prev = pl.List()
+	fixture.Header.Etcd.Revision++
+	callCh <- struct{}{}
+	callCh <- struct{}{}
+	callCh <- struct{}{} // Blocks until the first poll has completed.
+
+	c.Check(pl.List() == prev, gc.Equals, true)
+	select {
+	case <-pl.UpdateCh():
+		c.Error("unexpected update")
+	default:
+	}
 }
 
 func (s *ListSuite) TestListAllFragments(c *gc.C) {
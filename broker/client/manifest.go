@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// FragmentManifest describes the persisted Fragments of a journal which cover
+// an offset range, and is designed to drive external readers (eg, Spark or
+// Presto jobs) which read Fragments directly from their backing stores,
+// bypassing brokers. A FragmentManifest is encoded as JSON.
+type FragmentManifest struct {
+	// Journal of the manifest.
+	Journal pb.Journal `json:"journal"`
+	// Begin and End offsets of the requested range. An End of zero
+	// indicates the range is unbounded.
+	Begin int64 `json:"begin"`
+	End   int64 `json:"end,omitempty"`
+	// Fragments of the manifest, ordered on Begin offset.
+	Fragments []ManifestFragment `json:"fragments"`
+}
+
+// ManifestFragment is a persisted Fragment of a FragmentManifest.
+type ManifestFragment struct {
+	// Begin (inclusive) and End (exclusive) journal offsets of the Fragment.
+	Begin int64 `json:"begin"`
+	End   int64 `json:"end"`
+	// ContentLength is the size of the uncompressed Fragment content, in bytes.
+	// The size of the stored object differs if the Fragment is compressed.
+	ContentLength int64 `json:"content_length"`
+	// Hex-encoded SHA1 sum of the uncompressed Fragment content.
+	Sum string `json:"sum"`
+	// CompressionCodec of the Fragment, as its enum name (eg "SNAPPY").
+	CompressionCodec string `json:"compression_codec"`
+	// BackingStore of the Fragment.
+	BackingStore pb.FragmentStore `json:"backing_store"`
+	// ContentPath of the Fragment, relative to its BackingStore. Note that
+	// stores may be configured to rewrite this path. SignedURL, if
+	// present, always reflects the precise location of the Fragment.
+	ContentPath string `json:"content_path"`
+	// ModTime of the Fragment within its store, in seconds since the epoch.
+	ModTime int64 `json:"mod_time"`
+	// SignedURL is a temporary URL at which a direct GET of the Fragment may
+	// be issued. Set only if the manifest was built with a signature TTL.
+	SignedURL string `json:"signed_url,omitempty"`
+}
+
+// BuildFragmentManifest lists the Fragments of |journal| from the current
+// broker index, and returns a FragmentManifest of the persisted Fragments
+// which overlap the offset range [begin, end). An |end| of zero indicates
+// the range is unbounded. Fragments which have not yet been persisted to a
+// backing store are not included. If |signatureTTL| is non-zero, each
+// ManifestFragment will include a SignedURL which is valid for that duration.
+func BuildFragmentManifest(ctx context.Context, client pb.RoutedJournalClient, journal pb.Journal, begin, end int64, signatureTTL time.Duration) (*FragmentManifest, error) {
+	var req = pb.FragmentsRequest{Journal: journal}
+	if signatureTTL != 0 {
+		req.SignatureTTL = &signatureTTL
+	}
+
+	var resp, err = ListAllFragments(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest = &FragmentManifest{
+		Journal:   journal,
+		Begin:     begin,
+		End:       end,
+		Fragments: []ManifestFragment{},
+	}
+	for _, f := range resp.Fragments {
+		if f.Spec.BackingStore == "" {
+			continue // Not yet persisted.
+		} else if f.Spec.End <= begin || (end != 0 && f.Spec.Begin >= end) {
+			continue // Doesn't overlap the requested range.
+		}
+		var sum = f.Spec.Sum.ToDigest()
+
+		manifest.Fragments = append(manifest.Fragments, ManifestFragment{
+			Begin:            f.Spec.Begin,
+			End:              f.Spec.End,
+			ContentLength:    f.Spec.ContentLength(),
+			Sum:              hex.EncodeToString(sum[:]),
+			CompressionCodec: f.Spec.CompressionCodec.String(),
+			BackingStore:     f.Spec.BackingStore,
+			ContentPath:      f.Spec.ContentPath(),
+			ModTime:          f.Spec.ModTime,
+			SignedURL:        f.SignedUrl,
+		})
+	}
+	return manifest, nil
+}
+
+// WriteJSON writes the FragmentManifest to the Writer as indented JSON.
+func (m *FragmentManifest) WriteJSON(w io.Writer) error {
+	var enc = json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/broker/teststub"
+)
+
+type ManifestSuite struct{}
+
+func (s *ManifestSuite) TestManifestMatchesIndex(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var fragments = append(buildSignedFragmentsFixture("a/journal", 0),
+		buildSignedFragmentsFixture("a/journal", 30)...)
+	// Add a local Fragment which hasn't yet been persisted.
+	fragments = append(fragments, pb.FragmentsResponse__Fragment{
+		Spec: pb.Fragment{
+			Journal:          "a/journal",
+			Begin:            60,
+			End:              70,
+			CompressionCodec: pb.CompressionCodec_SNAPPY,
+		},
+	})
+	fragments[0].Spec.Sum = pb.SHA1SumOf("fixture")
+
+	broker.ListFragmentsFunc = func(_ context.Context, req *pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
+		c.Check(req.Journal, gc.Equals, pb.Journal("a/journal"))
+		c.Check(*req.SignatureTTL, gc.Equals, time.Minute)
+
+		return &pb.FragmentsResponse{
+			Header:    *buildHeaderFixture(broker),
+			Fragments: fragments,
+		}, nil
+	}
+
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+
+	// Case: unbounded range includes all persisted fragments.
+	var m, err = BuildFragmentManifest(ctx, rjc, "a/journal", 0, 0, time.Minute)
+	c.Check(err, gc.IsNil)
+	c.Check(m.Journal, gc.Equals, pb.Journal("a/journal"))
+	c.Assert(m.Fragments, gc.HasLen, 4)
+
+	for i, mf := range m.Fragments {
+		var f = fragments[i]
+		c.Check(mf.Begin, gc.Equals, f.Spec.Begin)
+		c.Check(mf.End, gc.Equals, f.Spec.End)
+		c.Check(mf.ContentLength, gc.Equals, f.Spec.End-f.Spec.Begin)
+		c.Check(mf.CompressionCodec, gc.Equals, f.Spec.CompressionCodec.String())
+		c.Check(mf.BackingStore, gc.Equals, f.Spec.BackingStore)
+		c.Check(mf.ContentPath, gc.Equals, f.Spec.ContentPath())
+		c.Check(mf.ModTime, gc.Equals, f.Spec.ModTime)
+		c.Check(mf.SignedURL, gc.Equals, f.SignedUrl)
+	}
+	c.Check(m.Fragments[0].Sum, gc.Equals, "51cff3c1f0bc59f6187e7040cc12a4e9b1eca7aa")
+
+	// Case: range is restricted to overlapping fragments.
+	m, err = BuildFragmentManifest(ctx, rjc, "a/journal", 25, 50, time.Minute)
+	c.Check(err, gc.IsNil)
+	c.Assert(m.Fragments, gc.HasLen, 2)
+	c.Check(m.Fragments[0].Begin, gc.Equals, int64(20))
+	c.Check(m.Fragments[1].Begin, gc.Equals, int64(30))
+	c.Check(m.Fragments[0].ContentLength, gc.Equals, int64(10))
+	c.Check(m.Fragments[1].ContentLength, gc.Equals, int64(10))
+
+	// Expect the manifest round-trips through JSON.
+	var buf bytes.Buffer
+	c.Check(m.WriteJSON(&buf), gc.IsNil)
+	c.Check(buf.String(), gc.Matches, `(?s).*"content_length": 10,.*`)
+
+	var decoded FragmentManifest
+	c.Check(json.Unmarshal(buf.Bytes(), &decoded), gc.IsNil)
+	c.Check(&decoded, gc.DeepEquals, m)
+
+	// Case: errors are passed through.
+	broker.ListFragmentsFunc = func(_ context.Context, req *pb.FragmentsRequest) (*pb.FragmentsResponse, error) {
+		return &pb.FragmentsResponse{
+			Header: *buildHeaderFixture(broker),
+			Status: pb.Status_JOURNAL_NOT_FOUND,
+		}, nil
+	}
+	_, err = BuildFragmentManifest(ctx, rjc, "a/journal", 0, 0, 0)
+	c.Check(err, gc.ErrorMatches, pb.Status_JOURNAL_NOT_FOUND.String())
+}
+
+var _ = gc.Suite(&ManifestSuite{})
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	pb "go.gazette.dev/core/broker/protocol"
+	"google.golang.org/grpc"
+)
+
+// MirrorPolicy determines how failures to mirror an Append to the secondary
+// cluster of a MirroringJournalClient are surfaced.
+type MirrorPolicy int
+
+const (
+	// MirrorAsync surfaces the result of the primary Append to the caller,
+	// and reports failures of the mirrored Append asynchronously.
+	MirrorAsync MirrorPolicy = iota
+	// MirrorRequired waits for the mirrored Append to commit before surfacing
+	// the result of the primary Append, and fails the Append if the mirrored
+	// Append fails. Note the primary Append has already committed in this
+	// case: callers which retry the failed Append may write duplicates of its
+	// content to the primary cluster.
+	MirrorRequired
+)
+
+// MirroringJournalClient is a pb.RoutedJournalClient which mirrors Append
+// RPCs to a secondary AppendService, which typically targets a remote cluster
+// (eg, for disaster recovery). Content of each Append is written as it's sent
+// to a mirrored AsyncAppend of the same journal, which is released only if the
+// primary Append commits, and is otherwise rolled back. The buffering, ordering
+// and retry semantics of the AppendService therefore apply to mirrored Appends.
+// Other RPCs are passed through to the primary client.
+//
+// A mirrored AsyncAppend is started with the first request of an Append RPC,
+// and holds the secondary AppendService's ordering of the journal until the
+// RPC completes. As a result, concurrent Append RPCs of a single journal are
+// serialized. An AppendService which uses a MirroringJournalClient already
+// issues such RPCs sequentially.
+type MirroringJournalClient struct {
+	pb.RoutedJournalClient
+	// Secondary AppendService to which Appends are mirrored.
+	Secondary *AppendService
+	// Policy of the MirroringJournalClient.
+	Policy MirrorPolicy
+	// OnMirrorError, if non-nil, is called with failures of mirrored Appends
+	// under the MirrorAsync policy. Otherwise, failures are logged.
+	OnMirrorError func(journal pb.Journal, err error)
+}
+
+// NewMirroringJournalClient returns a MirroringJournalClient which mirrors
+// Appends of the |primary| client to the |secondary| AppendService.
+func NewMirroringJournalClient(primary pb.RoutedJournalClient, secondary *AppendService, policy MirrorPolicy) *MirroringJournalClient {
+	return &MirroringJournalClient{
+		RoutedJournalClient: primary,
+		Secondary:           secondary,
+		Policy:              policy,
+	}
+}
+
+// Append begins an Append RPC of the primary client, which is mirrored to the
+// secondary AppendService.
+func (m *MirroringJournalClient) Append(ctx context.Context, opts ...grpc.CallOption) (pb.Journal_AppendClient, error) {
+	var stream, err = m.RoutedJournalClient.Append(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &mirroredAppendClient{
+		Journal_AppendClient: stream,
+		m:                    m,
+		ctx:                  ctx,
+		doneCh:               make(chan struct{}),
+	}, nil
+}
+
+// mirroredAppendClient is a pb.Journal_AppendClient which tees sent content
+// to a mirrored AsyncAppend.
+type mirroredAppendClient struct {
+	pb.Journal_AppendClient
+
+	m      *MirroringJournalClient
+	ctx    context.Context
+	aa     *AsyncAppend  // Mirrored AsyncAppend, set with the first request.
+	commit bool          // Whether an empty (commit) request has been sent.
+	doneCh chan struct{} // Closed when |aa| has been released or rolled back.
+	mu     sync.Mutex
+}
+
+func (s *mirroredAppendClient) Send(req *pb.AppendRequest) error { return s.SendMsg(req) }
+
+func (s *mirroredAppendClient) SendMsg(msg interface{}) error {
+	var err = s.Journal_AppendClient.SendMsg(msg)
+
+	if req, ok := msg.(*pb.AppendRequest); ok {
+		s.onSend(req, err)
+	}
+	return err
+}
+
+func (s *mirroredAppendClient) CloseAndRecv() (*pb.AppendResponse, error) {
+	var resp, err = s.Journal_AppendClient.CloseAndRecv()
+	if err == nil {
+		err = s.onRecv(resp)
+	} else {
+		s.onRecv(nil)
+	}
+	return resp, err
+}
+
+func (s *mirroredAppendClient) RecvMsg(msg interface{}) error {
+	var err = s.Journal_AppendClient.RecvMsg(msg)
+
+	if resp, ok := msg.(*pb.AppendResponse); !ok {
+		// Pass.
+	} else if err != nil {
+		s.onRecv(nil)
+	} else {
+		err = s.onRecv(resp)
+	}
+	return err
+}
+
+// onSend mirrors a sent AppendRequest.
+func (s *mirroredAppendClient) onSend(req *pb.AppendRequest, err error) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+
+	if s.isDone() {
+		return
+	} else if err != nil {
+		s.rollback(err)
+		return
+	}
+
+	if s.aa == nil {
+		// The first request holds metadata of the Append.
+		s.aa = s.m.Secondary.StartAppend(req.Journal)
+
+		go func() {
+			select {
+			case <-s.ctx.Done():
+				s.mu.Lock()
+				if !s.isDone() {
+					s.rollback(s.ctx.Err())
+				}
+				s.mu.Unlock()
+			case <-s.doneCh:
+			}
+		}()
+	} else if len(req.Content) != 0 {
+		_, _ = s.aa.Writer().Write(req.Content) // Errors are surfaced by Release.
+	} else {
+		s.commit = true
+	}
+}
+
+// onRecv of the primary AppendResponse (or nil, if the RPC failed) releases
+// the mirrored AsyncAppend if the primary Append committed, or rolls it back.
+// Under the MirrorRequired policy, onRecv then awaits the mirrored Append
+// and returns its error.
+func (s *mirroredAppendClient) onRecv(resp *pb.AppendResponse) error {
+	s.mu.Lock()
+
+	if s.aa == nil || s.isDone() {
+		s.mu.Unlock()
+		return nil
+	} else if resp == nil || resp.Status != pb.Status_OK || !s.commit {
+		s.rollback(fmt.Errorf("primary append did not commit"))
+		s.mu.Unlock()
+		return nil
+	}
+
+	var aa = s.aa
+	var err = aa.Release() // Fails only if a Writer error occurred.
+	close(s.doneCh)
+	s.mu.Unlock()
+
+	if err == nil && s.m.Policy == MirrorAsync {
+		go func() {
+			<-aa.Done()
+
+			if err := aa.Err(); err != nil {
+				s.m.reportMirrorError(aa.Request().Journal, err)
+			}
+		}()
+		return nil
+	} else if err == nil {
+		select {
+		case <-aa.Done():
+			err = aa.Err()
+		case <-s.ctx.Done():
+			err = s.ctx.Err()
+		}
+	}
+
+	if err == nil {
+		return nil
+	} else if s.m.Policy == MirrorRequired {
+		return fmt.Errorf("mirroring append to secondary: %s", err)
+	}
+	s.m.reportMirrorError(aa.Request().Journal, err)
+	return nil
+}
+
+// rollback the mirrored AsyncAppend. |s.mu| must be held.
+func (s *mirroredAppendClient) rollback(err error) {
+	if s.aa != nil {
+		_ = s.aa.Require(err).Release()
+	}
+	close(s.doneCh)
+}
+
+// isDone returns whether the mirrored AsyncAppend was released or rolled back.
+// |s.mu| must be held.
+func (s *mirroredAppendClient) isDone() bool {
+	select {
+	case <-s.doneCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *MirroringJournalClient) reportMirrorError(journal pb.Journal, err error) {
+	if m.OnMirrorError != nil {
+		m.OnMirrorError(journal, err)
+	} else {
+		log.WithFields(log.Fields{"journal": journal, "err": err}).
+			Warn("failed to mirror append to secondary")
+	}
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/broker/teststub"
+)
+
+type MirroringJournalClientSuite struct{}
+
+func (s *MirroringJournalClientSuite) TestAsyncMirroring(c *gc.C) {
+	var ctx = context.Background()
+	var primary, secondary = teststub.NewBroker(c), teststub.NewBroker(c)
+	defer primary.Cleanup()
+	defer secondary.Cleanup()
+
+	var secondaryCtx, secondaryCancel = context.WithCancel(ctx)
+	var as = NewAppendService(secondaryCtx,
+		pb.NewRoutedJournalClient(secondary.Client(), pb.NoopDispatchRouter{}))
+
+	var mirrorErrCh = make(chan error, 1)
+	var mjc = NewMirroringJournalClient(
+		pb.NewRoutedJournalClient(primary.Client(), pb.NoopDispatchRouter{}), as, MirrorAsync)
+	mjc.OnMirrorError = func(journal pb.Journal, err error) {
+		c.Check(journal, gc.Equals, pb.Journal("a/journal"))
+		mirrorErrCh <- err
+	}
+
+	// Case: an aborted primary Append is rolled back from the mirrored Append.
+	var a = NewAppender(ctx, mjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("aborted"))
+
+	var doneCh = make(chan struct{})
+	go func() {
+		a.Abort()
+		close(doneCh)
+	}()
+	readPrimaryAppendRequest(c, primary, "aborted", false)
+	primary.ErrCh <- errors.New("aborted")
+	<-doneCh
+
+	// The rolled-back AsyncAppend is served as an empty Append of the secondary.
+	_ = recvAppendHeader(c, secondary, "a/journal")
+	c.Check(<-secondary.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	c.Check(<-secondary.AppendReqCh, gc.IsNil) // Client EOF.
+	secondary.AppendRespCh <- buildAppendResponseFixture(secondary)
+	WaitForPendingAppends(as.PendingExcept(""))
+
+	// Case: a committed primary Append is mirrored.
+	a = NewAppender(ctx, mjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("hello, world"))
+
+	doneCh = make(chan struct{})
+	go func() {
+		c.Check(a.Close(), gc.IsNil)
+		close(doneCh)
+	}()
+	readPrimaryAppendRequest(c, primary, "hello, world", true)
+	primary.AppendRespCh <- buildAppendResponseFixture(primary)
+	<-doneCh
+
+	// Expect the secondary receives only committed content.
+	readHelloWorldAppendRequest(c, secondary)
+	secondary.AppendRespCh <- buildAppendResponseFixture(secondary)
+	WaitForPendingAppends(as.PendingExcept(""))
+
+	// Case: the mirrored Append fails. The primary Append succeeds, and the
+	// failure is reported asynchronously.
+	a = NewAppender(ctx, mjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("hello, world"))
+
+	doneCh = make(chan struct{})
+	go func() {
+		c.Check(a.Close(), gc.IsNil)
+		close(doneCh)
+	}()
+	readPrimaryAppendRequest(c, primary, "hello, world", true)
+	primary.AppendRespCh <- buildAppendResponseFixture(primary)
+	<-doneCh
+
+	_ = recvAppendHeader(c, secondary, "a/journal")
+	secondaryCancel()
+
+	c.Check(<-mirrorErrCh, gc.Equals, context.Canceled)
+}
+
+func (s *MirroringJournalClientSuite) TestRequiredMirroring(c *gc.C) {
+	var ctx = context.Background()
+	var primary, secondary = teststub.NewBroker(c), teststub.NewBroker(c)
+	defer primary.Cleanup()
+	defer secondary.Cleanup()
+
+	var secondaryCtx, secondaryCancel = context.WithCancel(ctx)
+	var as = NewAppendService(secondaryCtx,
+		pb.NewRoutedJournalClient(secondary.Client(), pb.NoopDispatchRouter{}))
+	var mjc = NewMirroringJournalClient(
+		pb.NewRoutedJournalClient(primary.Client(), pb.NoopDispatchRouter{}), as, MirrorRequired)
+
+	// Case: the primary Append completes only after the mirrored Append commits.
+	var a = NewAppender(ctx, mjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("hello, world"))
+
+	var errCh = make(chan error)
+	go func() { errCh <- a.Close() }()
+
+	readPrimaryAppendRequest(c, primary, "hello, world", true)
+	primary.AppendRespCh <- buildAppendResponseFixture(primary)
+
+	readHelloWorldAppendRequest(c, secondary)
+	select {
+	case <-errCh:
+		c.Fatal("expected Close to block on the mirrored Append")
+	default:
+	}
+	secondary.AppendRespCh <- buildAppendResponseFixture(secondary)
+	c.Check(<-errCh, gc.IsNil)
+
+	// Case: the mirrored Append fails, failing the Append.
+	a = NewAppender(ctx, mjc, pb.AppendRequest{Journal: "a/journal"})
+	_, _ = a.Write([]byte("hello, world"))
+	go func() { errCh <- a.Close() }()
+
+	readPrimaryAppendRequest(c, primary, "hello, world", true)
+	primary.AppendRespCh <- buildAppendResponseFixture(primary)
+
+	_ = recvAppendHeader(c, secondary, "a/journal")
+	secondaryCancel()
+
+	c.Check(<-errCh, gc.ErrorMatches, `mirroring append to secondary: context canceled`)
+}
+
+// readPrimaryAppendRequest reads an Append of |content| from the broker,
+// which is committed if |commit|.
+func readPrimaryAppendRequest(c *gc.C, broker *teststub.Broker, content string, commit bool) {
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Journal: "a/journal"})
+	c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{Content: []byte(content)})
+	if commit {
+		c.Check(<-broker.AppendReqCh, gc.DeepEquals, &pb.AppendRequest{})
+	}
+	c.Check(<-broker.AppendReqCh, gc.IsNil) // Client EOF.
+}
+
+var _ = gc.Suite(&MirroringJournalClientSuite{})
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"io"
+	"sync"
+)
+
+// ReadAheadReader wraps a RetryReader with a bounded, background read-ahead
+// buffer. A goroutine of the ReadAheadReader reads from the RetryReader into a
+// ring buffer while the caller is busy processing previously read content,
+// which keeps CPU-bound consumers fed through periods of broker latency. The
+// goroutine blocks when the buffer is full, and resumes as the caller Reads.
+//
+// Errors of the RetryReader (including ErrOffsetJump) are returned by Read in
+// order, after all content which preceded them has been read. The RetryReader
+// must not be directly used while it's wrapped by a ReadAheadReader, with the
+// exception of its Cancel function. Close must be called to release the
+// read-ahead goroutine. A ReadAheadReader is not thread-safe.
+type ReadAheadReader struct {
+	rr *RetryReader
+
+	buf    []byte // Ring buffer of read-ahead content.
+	head   int    // Index of the next byte of |buf| to return.
+	size   int    // Number of buffered bytes, beginning at |head|.
+	offset int64  // Journal offset of the next byte returned by Read.
+
+	err       error // Error to return upon draining |buf|, or nil.
+	errOffset int64 // Journal offset of the RetryReader at |err|.
+	closed    bool
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	doneCh chan struct{}
+}
+
+// NewReadAheadReader returns a ReadAheadReader which buffers up to |size|
+// bytes of content read ahead from |rr|.
+func NewReadAheadReader(rr *RetryReader, size int) *ReadAheadReader {
+	var r = &ReadAheadReader{
+		rr:     rr,
+		buf:    make([]byte, size),
+		offset: rr.Offset(),
+		doneCh: make(chan struct{}),
+	}
+	r.cond = sync.NewCond(&r.mu)
+
+	go r.serveReadAhead()
+	return r
+}
+
+// Offset of the next Journal byte to be returned by Read.
+func (r *ReadAheadReader) Offset() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offset
+}
+
+// Read returns the next bytes of read-ahead journal content, blocking until
+// content or an error is available. See RetryReader.Read for returned errors.
+func (r *ReadAheadReader) Read(p []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for r.size == 0 && r.err == nil && !r.closed {
+		r.cond.Wait()
+	}
+
+	if r.size != 0 {
+		var end = r.head + r.size
+		if end > len(r.buf) {
+			end = len(r.buf) // Content wraps; return the first contiguous portion.
+		}
+		n = copy(p, r.buf[r.head:end])
+
+		r.head = (r.head + n) % len(r.buf)
+		r.size -= n
+		r.offset += int64(n)
+	} else if r.err != nil {
+		// All content preceding the error has been read. Return it.
+		err, r.err = r.err, nil
+		r.offset = r.errOffset
+	} else {
+		err = io.ErrClosedPipe
+	}
+
+	r.cond.Broadcast() // Wake the read-ahead goroutine.
+	return
+}
+
+// Close the ReadAheadReader, cancelling its RetryReader and blocking until
+// its read-ahead goroutine has exited.
+func (r *ReadAheadReader) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.cond.Broadcast()
+	r.mu.Unlock()
+
+	r.rr.Cancel()
+	<-r.doneCh
+
+	return nil
+}
+
+// serveReadAhead reads from the RetryReader into free space of the ring
+// buffer, until the ReadAheadReader is closed. Reads pause while the buffer
+// is full, or while a read error remains to be returned by Read.
+func (r *ReadAheadReader) serveReadAhead() {
+	defer close(r.doneCh)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		for !r.closed && (r.size == len(r.buf) || r.err != nil) {
+			r.cond.Wait()
+		}
+		if r.closed {
+			return
+		}
+		if r.size == 0 {
+			r.head = 0 // Maximize contiguous free space.
+		}
+
+		// Determine the contiguous free region which follows buffered content.
+		var begin, end = r.head + r.size, len(r.buf)
+		if begin >= len(r.buf) {
+			begin, end = begin-len(r.buf), r.head
+		}
+
+		// Read without holding |mu|. Read only accesses the buffered region
+		// of |buf|, which is disjoint from [begin, end).
+		r.mu.Unlock()
+		var n, err = r.rr.Read(r.buf[begin:end])
+		r.mu.Lock()
+
+		r.size += n
+		if err != nil {
+			r.err, r.errOffset = err, r.rr.Offset()
+		}
+		r.cond.Broadcast()
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/broker/teststub"
+)
+
+type ReadAheadSuite struct{}
+
+func (s *ReadAheadSuite) TestContentAndErrorsAreOrdered(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var rr = NewRetryReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: 100})
+
+	go serveReadFixtures(c, broker,
+		readFixture{content: "foo", err: errors.New("whoops")},
+		readFixture{content: "barbazbing", offset: 110},
+		readFixture{status: pb.Status_OFFSET_NOT_YET_AVAILABLE},
+	)
+
+	// Use a small buffer, to exercise wrapping of the ring.
+	var ra = NewReadAheadReader(rr, 4)
+	c.Check(ra.Offset(), gc.Equals, int64(100))
+
+	var b, err = readAllAhead(ra)
+	c.Check(string(b), gc.Equals, "foo")
+	c.Check(err, gc.Equals, ErrOffsetJump)
+	c.Check(ra.Offset(), gc.Equals, int64(110))
+
+	// Content following the offset jump is returned, and then the surfaced
+	// OFFSET_NOT_YET_AVAILABLE of the following non-blocking read.
+	b, err = readAllAhead(ra)
+	c.Check(string(b), gc.Equals, "barbazbing")
+	c.Check(err, gc.Equals, ErrOffsetNotYetAvailable)
+	c.Check(ra.Offset(), gc.Equals, int64(120))
+
+	c.Check(ra.Close(), gc.IsNil)
+
+	_, err = ra.Read(make([]byte, 1))
+	c.Check(err, gc.Equals, io.ErrClosedPipe)
+}
+
+func (s *ReadAheadSuite) TestReadAheadWithSlowConsumer(c *gc.C) {
+	const chunks, chunk, delay = 10, "0123456789", 10 * time.Millisecond
+
+	// consume |chunks| from |r|, sleeping after each to simulate processing.
+	var consume = func(r io.Reader) time.Duration {
+		var start, buf = time.Now(), make([]byte, 1024)
+
+		for i := 0; i != chunks; i++ {
+			var n int
+			var err error
+			for n == 0 && err == nil {
+				n, err = r.Read(buf) // Metadata-only responses read zero bytes.
+			}
+			c.Check(err, gc.IsNil)
+			c.Check(string(buf[:n]), gc.Equals, chunk)
+			time.Sleep(delay)
+		}
+		return time.Since(start)
+	}
+
+	// startReader returns a RetryReader of a slow broker. Each reader uses its
+	// own broker, as the stream of a cancelled RetryReader may otherwise
+	// intercept fixtures intended for the next reader.
+	var startReader = func() (*RetryReader, func()) {
+		var broker = teststub.NewBroker(c)
+		var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+
+		go serveSlowReads(c, broker, chunks, chunk, delay)
+		return NewRetryReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"}), broker.Cleanup
+	}
+
+	// Without read-ahead, broker latency and processing time are additive.
+	var rr, cleanup = startReader()
+	var sequential = consume(rr)
+	rr.Cancel()
+	cleanup()
+
+	// With read-ahead, the next chunk is fetched while the current one is processed.
+	rr, cleanup = startReader()
+	defer cleanup()
+
+	var ra = NewReadAheadReader(rr, 1024)
+	var ahead = consume(ra)
+	c.Check(ra.Offset(), gc.Equals, int64(chunks*len(chunk)))
+	c.Check(ra.Close(), gc.IsNil)
+
+	c.Check(ahead < sequential*3/4, gc.Equals, true,
+		gc.Commentf("ahead %s vs sequential %s", ahead, sequential))
+}
+
+// serveSlowReads serves |chunks| Read RPCs, each of which returns |chunk|
+// content after |delay| and then closes.
+func serveSlowReads(c *gc.C, broker *teststub.Broker, chunks int, chunk string, delay time.Duration) {
+	for i := 0; i != chunks; i++ {
+		var req = <-broker.ReadReqCh
+		c.Check(req.Offset, gc.Equals, int64(i*len(chunk)))
+		time.Sleep(delay)
+
+		broker.ReadRespCh <- &pb.ReadResponse{
+			Status:    pb.Status_OK,
+			Header:    buildHeaderFixture(broker),
+			Offset:    req.Offset,
+			WriteHead: 1024,
+			Fragment: &pb.Fragment{
+				Journal:          "a/journal",
+				Begin:            0,
+				End:              1024,
+				CompressionCodec: pb.CompressionCodec_NONE,
+			},
+		}
+		broker.ReadRespCh <- &pb.ReadResponse{Offset: req.Offset, Content: []byte(chunk)}
+		broker.ErrCh <- nil
+	}
+}
+
+// readAllAhead reads from |ra| until an error is returned.
+func readAllAhead(ra *ReadAheadReader) ([]byte, error) {
+	var out, buf = []byte(nil), make([]byte, 3)
+
+	for {
+		var n, err = ra.Read(buf)
+		out = append(out, buf[:n]...)
+
+		if err != nil {
+			return out, err
+		}
+	}
+}
+
+var _ = gc.Suite(&ReadAheadSuite{})
@@ -2,15 +2,20 @@ package client
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha1"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"go.gazette.dev/core/broker/codecs"
 	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/metrics"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -21,15 +26,60 @@ import (
 // first returned error, with the exception of ErrOffsetJump: this error is
 // returned to notify the client that the next Journal offset to be Read is not
 // the offset that was requested, but the Reader is prepared to continue at the
-// updated offset.
+// updated offset. If SkipOffsetJumps is set, offset jumps are instead followed
+// without returning ErrOffsetJump. If EndOffset is set, the Reader returns
+// io.EOF upon reaching it. If RetryPolicy is set, transient errors are retried
+// by the Reader as directed by the policy, before invalidating it. Where the
+// ReadRequest has a Filter, the broker skips over filtered content and the
+// Reader follows such offset changes without returning ErrOffsetJump.
 type Reader struct {
 	Request  pb.ReadRequest  // ReadRequest of the Reader.
 	Response pb.ReadResponse // Most recent ReadResponse from broker.
 
+	// SkipOffsetJumps, if set, causes Read to transparently advance past offset
+	// jumps rather than returning ErrOffsetJump. Jumps remain observable
+	// through the updated Request & Response Offset, and OffsetJumps.
+	SkipOffsetJumps bool
+	// OffsetJumps is the number of offset jumps skipped by Read.
+	OffsetJumps int
+	// EndOffset, if non-zero, is the exclusive journal offset at which reading
+	// ends. Read never returns content at or beyond EndOffset, and returns
+	// io.EOF once the Request Offset reaches it. A directly read Fragment URL
+	// is closed upon reaching EndOffset. The Read RPC is not, and the caller
+	// should cancel the Reader context when done to release it.
+	EndOffset int64
+	// ReopenOnSeek, if set, causes a Seek which cannot be satisfied by the
+	// current stream or directly read Fragment (eg, because it's backwards) to
+	// tear down the stream, and to restart the Read RPC at the seeked offset.
+	ReopenOnSeek bool
+	// VerifyFragmentSums, if set, causes directly read Fragment URLs to be
+	// verified against their Fragment.Sum. A SHA1 is accumulated over the
+	// decompressed Fragment content, and ErrFragmentSumMismatch is returned
+	// upon reading the Fragment End if it differs.
+	VerifyFragmentSums bool
+	// RetryPolicy, if set, is consulted upon a retriable error of the Read RPC
+	// (ErrNotJournalBroker, or an Unavailable status not caused by the Reader
+	// context) which occurs before any content is returned. If the policy
+	// allows, the Reader waits for the returned delay, re-resolves the journal
+	// route, and restarts the Read RPC at the current offset. Other errors,
+	// such as validation failures, invalidate the Reader immediately.
+	RetryPolicy ReadRetryPolicy
+	// OnProgress, if set, is called with the current journal offset and the
+	// Fragment of each ReadResponse bearing Fragment metadata, and again as a
+	// Fragment URL of such a response is directly opened by the Reader. It
+	// allows for progress reporting of long reads: the write head of the
+	// journal at the time of the response is available from Response.WriteHead.
+	// OnProgress is called synchronously from Read, and must not block.
+	OnProgress func(offset int64, fragment *pb.Fragment)
+
 	ctx    context.Context
 	client pb.RoutedJournalClient // Client against which Read is dispatched.
 	stream pb.Journal_ReadClient  // Server stream.
+	cancel context.CancelFunc     // Cancels |stream|, iff ReopenOnSeek.
 	direct io.ReadCloser          // Directly opened Fragment URL.
+	// Whether an expired Fragment URL has been refreshed by this Reader,
+	// since it last opened a Fragment URL.
+	refreshedURL bool
 }
 
 // NewReader returns a Reader initialized with the given BrokerClient and ReadRequest.
@@ -42,7 +92,88 @@ func NewReader(ctx context.Context, client pb.RoutedJournalClient, req pb.ReadRe
 	return r
 }
 
+// copyOptions copies the options of Reader |from| into this Reader, along with
+// its count of OffsetJumps. The Request, Response, and stream state of this
+// Reader are unchanged. Options added to Reader must also be copied here.
+func (r *Reader) copyOptions(from *Reader) {
+	r.SkipOffsetJumps, r.OffsetJumps = from.SkipOffsetJumps, from.OffsetJumps
+	r.EndOffset, r.ReopenOnSeek = from.EndOffset, from.ReopenOnSeek
+	r.VerifyFragmentSums, r.RetryPolicy = from.VerifyFragmentSums, from.RetryPolicy
+	r.OnProgress = from.OnProgress
+}
+
+// ReadRetryPolicy returns whether a Reader should retry after a retriable
+// error, and the delay to wait before doing so. |attempt| is the zero-based
+// number of retries which have already been made by the current Read.
+type ReadRetryPolicy func(attempt int, err error) (delay time.Duration, retry bool)
+
+// NewBackoffRetryPolicy returns a ReadRetryPolicy which retries up to
+// |maxAttempts| times, with an exponential back-off delay which begins at
+// |base| and doubles with each attempt, to at most |max|.
+func NewBackoffRetryPolicy(maxAttempts int, base, max time.Duration) ReadRetryPolicy {
+	return func(attempt int, _ error) (time.Duration, bool) {
+		if attempt >= maxAttempts {
+			return 0, false
+		}
+		var delay = base
+		for i := 0; i != attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		return delay, true
+	}
+}
+
 func (r *Reader) Read(p []byte) (n int, err error) {
+	n, err = r.read(p)
+
+	for attempt := 0; err != nil && n == 0 && r.RetryPolicy != nil && r.isRetriable(err); attempt++ {
+		var delay, retry = r.RetryPolicy(attempt, err)
+		if !retry {
+			break
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		case <-time.After(delay):
+		}
+
+		// A NOT_JOURNAL_BROKER response Header has already updated the route.
+		// Otherwise, invalidate the cached route so that it's re-resolved.
+		if err != ErrNotJournalBroker {
+			r.client.UpdateRoute(r.Request.Journal.String(), nil)
+		}
+		r.reopen(r.Request.Offset)
+		n, err = r.read(p)
+	}
+	return
+}
+
+// isRetriable returns whether |err| is a transient error of the Read RPC
+// which may be retried under the RetryPolicy.
+func (r *Reader) isRetriable(err error) bool {
+	if r.ctx.Err() != nil {
+		return false
+	}
+	return err == ErrNotJournalBroker || status.Code(err) == codes.Unavailable
+}
+
+func (r *Reader) read(p []byte) (n int, err error) {
+	if r.EndOffset != 0 {
+		if remain := r.EndOffset - r.Request.Offset; remain <= 0 {
+			if r.direct != nil {
+				_ = r.direct.Close()
+				r.direct = nil
+			}
+			return 0, io.EOF
+		} else if int64(len(p)) > remain {
+			p = p[:remain] // Trim to not read beyond EndOffset.
+		}
+	}
+
 	// If we have an open direct reader of a persisted fragment, delegate to it.
 	if r.direct != nil {
 		if n, err = r.direct.Read(p); err != nil {
@@ -61,11 +192,16 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 
 	// Lazy initialization: begin the Read RPC.
 	if r.stream == nil {
+		var ctx = r.ctx
+		if r.ReopenOnSeek {
+			// Use a cancelable Context, so that Seek may tear down the stream.
+			ctx, r.cancel = context.WithCancel(r.ctx)
+		}
 		if r.stream, err = r.client.Read(
-			pb.WithDispatchItemRoute(r.ctx, r.client, r.Request.Journal.String(), false),
+			pb.WithDispatchItemRoute(ctx, r.client, r.Request.Journal.String(), false),
 			&r.Request,
 		); err == nil {
-			n, err = r.Read(p) // Recurse to attempt read against opened |r.stream|.
+			n, err = r.read(p) // Recurse to attempt read against opened |r.stream|.
 		} else {
 			err = mapGRPCCtxErr(r.ctx, err)
 		}
@@ -100,15 +236,27 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 			// Offset jumps are uncommon, but possible if fragments were removed,
 			// or if the requested offset was -1.
 			r.Request.Offset = r.Response.Offset
-			err = ErrOffsetJump
+			// The offset is now resolved, and no longer relative to the write head.
+			r.Request.FragmentsFromHead = 0
+
+			if r.Request.Filter != nil {
+				// Content skipped over by the Filter of the read is expected.
+			} else if r.SkipOffsetJumps {
+				r.OffsetJumps++
+			} else {
+				err = ErrOffsetJump
+			}
 		}
 
 		if r.Response.Status == pb.Status_OK {
 			// Return empty read, to allow inspection of the updated |r.Response|.
+			if r.OnProgress != nil && r.Response.Fragment != nil {
+				r.OnProgress(r.Request.Offset, r.Response.Fragment)
+			}
 		} else {
 			// The broker will send a stream closure following a !OK status.
 			// Recurse to read that closure, and _then_ return a final error.
-			n, err = r.Read(p)
+			n, err = r.read(p)
 		}
 		return
 
@@ -120,10 +268,25 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 	// We read a graceful stream closure (err == io.EOF).
 
 	// If the frame preceding EOF provided a fragment URL, open it directly.
-	if !r.Request.MetadataOnly && r.Response.Status == pb.Status_OK && r.Response.FragmentUrl != "" {
-		if r.direct, err = OpenFragmentURL(r.ctx, *r.Response.Fragment,
-			r.Request.Offset, r.Response.FragmentUrl); err == nil {
-			n, err = r.Read(p) // Recurse to attempt read against opened |r.direct|.
+	// A filtered read is never opened directly, as the broker must filter it.
+	if !r.Request.MetadataOnly && r.Request.Filter == nil &&
+		r.Response.Status == pb.Status_OK && r.Response.FragmentUrl != "" {
+		if r.direct, err = openFragmentURL(r.ctx, *r.Response.Fragment,
+			r.Request.Offset, r.Response.FragmentUrl, r.VerifyFragmentSums); err == nil {
+			r.refreshedURL = false // A later URL may again be refreshed.
+
+			if r.OnProgress != nil {
+				r.OnProgress(r.Request.Offset, r.Response.Fragment)
+			}
+			n, err = r.read(p) // Recurse to attempt read against opened |r.direct|.
+		} else if err == ErrFragmentURLExpired && !r.refreshedURL {
+			// The signature of the URL expired before we could open it (eg,
+			// because the client was slow to Read after receiving metadata).
+			// Restart the Read RPC from the current offset, which will
+			// return a freshly signed URL.
+			r.refreshedURL = true
+			r.direct, r.stream, r.Response = nil, nil, pb.ReadResponse{}
+			n, err = r.read(p)
 		}
 		return
 	}
@@ -153,8 +316,10 @@ func (r *Reader) AdjustedOffset(br *bufio.Reader) int64 {
 // Seek provides a limited form of seeking support. Specifically, iff a
 // Fragment URL is being directly read, the Seek offset is ahead of the current
 // Reader offset, and the Fragment also covers the desired Seek offset, then a
-// seek is performed by reading and discarding to the seeked offset. Seek will
-// otherwise return ErrSeekRequiresNewReader.
+// seek is performed by reading and discarding to the seeked offset. Otherwise,
+// if ReopenOnSeek is set then the current stream or Fragment is closed, and the
+// next Read restarts the Read RPC at the seeked offset, which must be >= 0.
+// Seek will otherwise return ErrSeekRequiresNewReader.
 func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 	switch whence {
 	case io.SeekStart:
@@ -167,39 +332,87 @@ func (r *Reader) Seek(offset int64, whence int) (int64, error) {
 		panic("invalid whence")
 	}
 
-	if r.direct == nil || offset < r.Request.Offset || offset >= r.Response.Fragment.End {
+	if r.direct != nil && offset >= r.Request.Offset && offset < r.Response.Fragment.End {
+		var _, err = io.CopyN(ioutil.Discard, r, offset-r.Request.Offset)
+		return r.Request.Offset, err
+	} else if !r.ReopenOnSeek {
 		return r.Request.Offset, ErrSeekRequiresNewReader
+	} else if offset < 0 {
+		return r.Request.Offset, fmt.Errorf("invalid seek offset (%d; expected >= 0)", offset)
+	} else if offset != r.Request.Offset {
+		r.reopen(offset)
 	}
+	return r.Request.Offset, nil
+}
 
-	var _, err = io.CopyN(ioutil.Discard, r, offset-r.Request.Offset)
-	return r.Request.Offset, err
+// reopen tears down the current stream or directly read Fragment (if any),
+// such that the next Read restarts the Read RPC at |offset|.
+func (r *Reader) reopen(offset int64) {
+	if r.direct != nil {
+		_ = r.direct.Close()
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.stream, r.cancel, r.direct = nil, nil, nil
+	r.Response, r.refreshedURL = pb.ReadResponse{}, false
+	r.Request.Offset, r.Request.FragmentsFromHead = offset, 0
 }
 
 // OpenFragmentURL directly opens |fragment|, which must be available at URL
 // |url|, and returns a *FragmentReader which has been pre-seeked to |offset|.
+// The Fragment is fetched using the http.Client attached to |ctx| by
+// WithHTTPClient, if any, or else the http.Client of the package. If the
+// store host of |url| has repeatedly failed, ErrFragmentCircuitOpen may be
+// returned without a fetch being attempted (see FragmentCircuit).
 func OpenFragmentURL(ctx context.Context, fragment pb.Fragment, offset int64, url string) (*FragmentReader, error) {
+	return openFragmentURL(ctx, fragment, offset, url, false)
+}
+
+func openFragmentURL(ctx context.Context, fragment pb.Fragment, offset int64, url string, verify bool) (*FragmentReader, error) {
 	var req, err = http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if fragment.CompressionCodec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
+	switch fragment.CompressionCodec {
+	case pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION:
 		// Require that the server send us un-encoded content, offloading
 		// decompression onto the storage API. Go's standard `gzip` package is slow,
 		// and we also see a parallelism benefit by offloading decompression work
 		// onto the cloud storage system.
 		req.Header.Set("Accept-Encoding", "identity")
-	} else if fragment.CompressionCodec == pb.CompressionCodec_GZIP {
+	case pb.CompressionCodec_GZIP:
 		// Explicitly request gzip. Doing so disables the http client's transparent
 		// handling for gzip decompression if the Fragment happened to be written with
 		// "Content-Encoding: gzip", and it instead directly surfaces the compressed
 		// bytes to us.
 		req.Header.Set("Accept-Encoding", "gzip")
+	default:
+		// Other codecs (eg, SNAPPY and ZSTANDARD) have no Content-Encoding which
+		// stores will transparently handle. Don't set an Accept-Encoding, and
+		// decompress client-side.
+	}
+
+	if FragmentCircuit != nil {
+		if err = FragmentCircuit.allow(req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := httpClientOf(ctx).Do(req.WithContext(ctx))
+	if FragmentCircuit != nil && ctx.Err() == nil {
+		FragmentCircuit.record(req.URL.Host, isFragmentFetchFailure(resp, err))
 	}
 
-	resp, err := httpClient.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
+	} else if resp.StatusCode == http.StatusForbidden && isExpiredSignature(resp.Body) {
+		// Stores respond with 403 Forbidden to a GET of an expired signed URL.
+		// Other 403s (eg, of a lacking permission) are not expected to resolve
+		// with a refreshed URL, and are returned as any other !OK status.
+		_ = resp.Body.Close()
+		return nil, ErrFragmentURLExpired
 	} else if resp.StatusCode != http.StatusOK {
 		_ = resp.Body.Close()
 		return nil, fmt.Errorf("!OK fetching (%s, %q)", resp.Status, url)
@@ -212,24 +425,54 @@ func OpenFragmentURL(ctx context.Context, fragment pb.Fragment, offset int64, ur
 
 		fragment.CompressionCodec = pb.CompressionCodec_GZIP // Decompress client-side.
 	}
-	return NewFragmentReader(resp.Body, fragment, offset)
+	return newFragmentReader(resp.Body, fragment, offset, verify)
+}
+
+// isExpiredSignature returns whether the body of a 403 Forbidden response
+// attributes the rejection to an expired URL signature. S3 and GCS describe
+// the request as having expired, and Azure details the signature's expiry.
+func isExpiredSignature(body io.Reader) bool {
+	var b, _ = ioutil.ReadAll(io.LimitReader(body, 4096))
+	return bytes.Contains(bytes.ToLower(b), []byte("expir"))
 }
 
 // NewFragmentReader wraps |rc|, which is a io.ReadCloser of raw Fragment bytes,
 // with a returned *FragmentReader which has been pre-seeked to |offset|.
 func NewFragmentReader(rc io.ReadCloser, fragment pb.Fragment, offset int64) (*FragmentReader, error) {
-	var decomp, err = codecs.NewCodecReader(rc, fragment.CompressionCodec)
+	return newFragmentReader(rc, fragment, offset, false)
+}
+
+// newFragmentReader returns a *FragmentReader which, if |verify| and the
+// Fragment has a Sum, verifies its content against Fragment.Sum.
+func newFragmentReader(rc io.ReadCloser, fragment pb.Fragment, offset int64, verify bool) (*FragmentReader, error) {
+	var raw io.Reader = rc
+	var timing *timingReader
+
+	if InstrumentDecompression {
+		timing = &timingReader{r: rc}
+		raw = timing
+	}
+
+	var start = time.Now()
+	var decomp, err = codecs.NewCodecReader(raw, fragment.CompressionCodec)
 	if err != nil {
 		_ = rc.Close()
 		return nil, err
+	} else if timing != nil {
+		// Codecs may read and decode a header upon construction.
+		timing.record(fragment.CompressionCodec, time.Since(start), 0)
 	}
 
 	var fr = &FragmentReader{
 		decomp:   decomp,
 		raw:      rc,
+		timing:   timing,
 		Fragment: fragment,
 		Offset:   fragment.Begin,
 	}
+	if verify && !fragment.Sum.IsZero() {
+		fr.summer = sha1.New()
+	}
 
 	// Attempt to seek to |offset| within the fragment.
 	var delta = offset - fragment.Begin
@@ -247,6 +490,8 @@ type FragmentReader struct {
 
 	decomp io.ReadCloser
 	raw    io.ReadCloser
+	timing *timingReader // Non-nil iff InstrumentDecompression.
+	summer hash.Hash     // Non-nil iff Fragment.Sum is verified.
 }
 
 // Read returns the next bytes of decompressed Fragment content. When Read
@@ -256,7 +501,11 @@ type FragmentReader struct {
 // io.ErrUnexpectedEOF is returned. If it's too long, ErrDidNotReadExpectedEOF
 // is returned.
 func (fr *FragmentReader) Read(p []byte) (n int, err error) {
-	n, err = fr.decomp.Read(p)
+	if fr.timing != nil {
+		n, err = fr.instrumentedRead(p)
+	} else {
+		n, err = fr.decomp.Read(p)
+	}
 	fr.Offset += int64(n)
 
 	if fr.Offset > fr.Fragment.End {
@@ -267,9 +516,57 @@ func (fr *FragmentReader) Read(p []byte) (n int, err error) {
 		// Did we read EOF before the reaching Fragment.End?
 		err = io.ErrUnexpectedEOF
 	}
+
+	if fr.summer != nil {
+		_, _ = fr.summer.Write(p[:n])
+
+		if err == io.EOF && pb.SHA1SumFromDigest(fr.summer.Sum(nil)) != fr.Fragment.Sum {
+			err = ErrFragmentSumMismatch
+		}
+	}
 	return
 }
 
+// instrumentedRead reads from the decompressor and records its metrics.
+func (fr *FragmentReader) instrumentedRead(p []byte) (n int, err error) {
+	var start = time.Now()
+	n, err = fr.decomp.Read(p)
+	fr.timing.record(fr.Fragment.CompressionCodec, time.Since(start), n)
+	return
+}
+
+// timingReader is an io.Reader which tracks the bytes read and the time
+// spent reading from its wrapped Reader.
+type timingReader struct {
+	r       io.Reader
+	n       int
+	elapsed time.Duration
+}
+
+func (tr *timingReader) Read(p []byte) (n int, err error) {
+	var start = time.Now()
+	n, err = tr.r.Read(p)
+	tr.n += n
+	tr.elapsed += time.Since(start)
+	return
+}
+
+// record decompression metrics of an operation which took |total| time and
+// produced |out| decompressed bytes. Time spent reading from the wrapped
+// Reader during the operation is excluded from the recorded decompression time.
+func (tr *timingReader) record(codec pb.CompressionCodec, total time.Duration, out int) {
+	var label = codec.String()
+
+	if total -= tr.elapsed; total < 0 {
+		total = 0
+	}
+	metrics.GazetteDecompressionSecondsTotal.WithLabelValues(label).Add(total.Seconds())
+	metrics.GazetteDecompressionInputBytesTotal.WithLabelValues(label).Add(float64(tr.n))
+	metrics.GazetteDecompressionOutputBytesTotal.WithLabelValues(label).Add(float64(out))
+
+	tr.n, tr.elapsed = 0, 0
+}
+
 // Close closes the underlying ReadCloser and associated
 // decompressor (if any).
 func (fr *FragmentReader) Close() error {
@@ -294,6 +591,27 @@ func InstallFileTransport(root string) (remove func()) {
 	return func() { httpClient = prevClient }
 }
 
+// WithHTTPClient attaches an http.Client to the Context, which is used to
+// fetch Fragment URLs opened with the Context (by OpenFragmentURL, or by a
+// Reader constructed with it). It allows callers to use a client having
+// particular timeouts, proxies, or transport (eg, one which adds headers
+// of a private bucket) without mutating the http.Client of the package,
+// which is otherwise used (see InstallFileTransport).
+func WithHTTPClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, httpClientCtxKey{}, client)
+}
+
+// httpClientOf returns the http.Client attached to |ctx|, or |httpClient|.
+func httpClientOf(ctx context.Context) *http.Client {
+	if c, ok := ctx.Value(httpClientCtxKey{}).(*http.Client); ok && c != nil {
+		return c
+	}
+	return httpClient
+}
+
+// httpClientCtxKey keys an http.Client attached to Contexts.
+type httpClientCtxKey struct{}
+
 // mapGRPCCtxErr returns ctx.Err() iff |err| represents a gRPC error with a
 // status code matching ctx.Err(). Otherwise, it returns |err| unmodified.
 // In other words, this routine "unwraps" gRPC errors which have their root cause
@@ -314,11 +632,28 @@ var (
 	ErrNotJournalPrimaryBroker = errors.New(pb.Status_NOT_JOURNAL_PRIMARY_BROKER.String())
 	ErrOffsetNotYetAvailable   = errors.New(pb.Status_OFFSET_NOT_YET_AVAILABLE.String())
 	ErrWrongAppendOffset       = errors.New(pb.Status_WRONG_APPEND_OFFSET.String())
+	ErrJournalSealed           = errors.New(pb.Status_JOURNAL_SEALED.String())
 
 	ErrOffsetJump            = errors.New("offset jump")
 	ErrSeekRequiresNewReader = errors.New("seek offset requires new Reader")
 	ErrDidNotReadExpectedEOF = errors.New("did not read EOF at expected Fragment.End")
+	ErrFragmentURLExpired    = errors.New("fragment URL signature has expired")
+	ErrFragmentSumMismatch   = errors.New("fragment content doesn't match its expected SHA1 Sum")
+	ErrFragmentCircuitOpen   = errors.New("fragment store circuit is open (too many consecutive failures)")
 
-	// httpClient is the http.Client used by OpenFragmentURL
+	// httpClient is the http.Client used by OpenFragmentURL, unless the
+	// Context has one attached by WithHTTPClient.
 	httpClient = http.DefaultClient
+
+	// FragmentCircuit is the FragmentCircuitBreaker of Fragment URL fetches.
+	// By default, a store host is tripped after five consecutive failures, for
+	// an interval backing off from one second to one minute. It may be
+	// replaced to alter these thresholds, or set to nil to disable.
+	FragmentCircuit = NewFragmentCircuitBreaker(5, time.Second, time.Minute)
+
+	// InstrumentDecompression enables the recording of decompression time and
+	// throughput of FragmentReaders, by CompressionCodec. It's intended for
+	// profiling whether client-side decompression is a bottleneck, and must be
+	// set before FragmentReaders are opened.
+	InstrumentDecompression = false
 )
@@ -2,19 +2,27 @@ package client
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	gc "github.com/go-check/check"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"go.gazette.dev/core/broker/codecs"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/broker/teststub"
+	"go.gazette.dev/core/metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ReaderSuite struct{}
@@ -77,6 +85,260 @@ func (s *ReaderSuite) TestOpenFragmentURLCases(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `snappy: corrupt input`)
 }
 
+func (s *ReaderSuite) TestOpenFragmentURLWithContextHTTPClient(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	// Case: the package http.Client doesn't support file:// URLs.
+	var _, err = OpenFragmentURL(context.Background(), frag, frag.Begin+5, url)
+	c.Check(err, gc.ErrorMatches, `.*unsupported protocol scheme "file"`)
+
+	// Case: an http.Client attached to the Context is used instead.
+	var transport = new(http.Transport)
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir(dir)))
+	var ctx = WithHTTPClient(context.Background(), &http.Client{Transport: transport})
+
+	rc, err := OpenFragmentURL(ctx, frag, frag.Begin+5, url)
+	c.Assert(err, gc.IsNil)
+	b, err := ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(rc.Close(), gc.IsNil)
+
+	// Case: a Reader uses the http.Client of its Context.
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	go serveReadFixtures(c, broker, readFixture{fragment: &frag, fragmentUrl: url})
+
+	var r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: frag.Begin + 5})
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+}
+
+func (s *ReaderSuite) TestVerifyFragmentSums(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+
+	var corrupt = frag
+	corrupt.Sum = pb.SHA1SumOf("something else")
+
+	go serveReadFixtures(c, broker,
+		readFixture{fragment: &frag, fragmentUrl: url},
+		readFixture{fragment: &corrupt, fragmentUrl: url},
+		readFixture{fragment: &corrupt, fragmentUrl: url},
+	)
+
+	var read = func(verify bool) (string, error) {
+		var r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: frag.Begin + 5})
+		r.VerifyFragmentSums = verify
+
+		var b, err = ioutil.ReadAll(r)
+		return string(b), err
+	}
+
+	// Case: content matches the Fragment Sum. Reading EOF of the
+	// directly-read Fragment returns a nil error from ReadAll.
+	var content, err = read(true)
+	c.Check(content, gc.Equals, "hello, world!!!")
+	c.Check(err, gc.IsNil)
+
+	// Case: content doesn't match the Fragment Sum.
+	content, err = read(true)
+	c.Check(content, gc.Equals, "hello, world!!!")
+	c.Check(err, gc.Equals, ErrFragmentSumMismatch)
+
+	// Case: verification isn't enabled.
+	content, err = read(false)
+	c.Check(content, gc.Equals, "hello, world!!!")
+	c.Check(err, gc.IsNil)
+}
+
+func (s *ReaderSuite) TestOpenZstandardFragmentURL(c *gc.C) {
+	if r, err := codecs.NewCodecReader(strings.NewReader(""), pb.CompressionCodec_ZSTANDARD); err != nil {
+		c.Skip("ZSTANDARD was not enabled at compile time")
+	} else {
+		_ = r.Close()
+	}
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	// Re-write the fixture as a ZSTANDARD fragment.
+	frag.CompressionCodec = pb.CompressionCodec_ZSTANDARD
+	url = string(frag.BackingStore) + frag.ContentName()
+
+	file, err := os.Create(filepath.Join(dir, frag.ContentName()))
+	c.Assert(err, gc.IsNil)
+	comp, err := codecs.NewCodecWriterWithOptions(file, frag.CompressionCodec,
+		codecs.CodecOptions{ZstandardLevel: 3})
+	c.Assert(err, gc.IsNil)
+	_, err = comp.Write([]byte("XXXXXhello, world!!!"))
+	c.Assert(err, gc.IsNil)
+	c.Assert(comp.Close(), gc.IsNil)
+	c.Assert(file.Close(), gc.IsNil)
+
+	rc, err := OpenFragmentURL(context.Background(), frag, frag.Begin+5, url)
+	c.Assert(err, gc.IsNil)
+
+	b, err := ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(rc.Offset, gc.Equals, rc.Fragment.End)
+	c.Check(rc.Close(), gc.IsNil)
+}
+
+func (s *ReaderSuite) TestLZ4FragmentReaderCases(c *gc.C) {
+	var buf bytes.Buffer
+	var comp, err = codecs.NewCodecWriter(&buf, pb.CompressionCodec_LZ4)
+	c.Assert(err, gc.IsNil)
+	_, err = comp.Write([]byte("XXXXXhello, world!!!"))
+	c.Assert(err, gc.IsNil)
+	c.Assert(comp.Close(), gc.IsNil)
+
+	var frag = pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            100,
+		End:              120,
+		CompressionCodec: pb.CompressionCodec_LZ4,
+	}
+	var open = func(frag pb.Fragment) *FragmentReader {
+		var fr, err = NewFragmentReader(ioutil.NopCloser(bytes.NewReader(buf.Bytes())), frag, frag.Begin+5)
+		c.Assert(err, gc.IsNil)
+		return fr
+	}
+
+	// Case: end-of-frame is read at precisely Fragment.End.
+	var fr = open(frag)
+	b, err := ioutil.ReadAll(fr)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(fr.Offset, gc.Equals, frag.End)
+
+	// Case: end-of-frame is read before Fragment.End.
+	frag.End += 1
+	b, err = ioutil.ReadAll(open(frag))
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+
+	// Case: frame continues after Fragment.End.
+	frag.End -= 4
+	b, err = ioutil.ReadAll(open(frag))
+	c.Check(err, gc.Equals, ErrDidNotReadExpectedEOF)
+	c.Check(string(b), gc.Equals, "hello, world")
+
+	// Case: frame is truncated within its content block.
+	frag.End += 3
+	buf.Truncate(buf.Len() - 12)
+	_, err = NewFragmentReader(ioutil.NopCloser(bytes.NewReader(buf.Bytes())), frag, frag.Begin+5)
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+}
+
+func (s *ReaderSuite) TestInstrumentedDecompression(c *gc.C) {
+	defer func(v bool) { InstrumentDecompression = v }(InstrumentDecompression)
+	InstrumentDecompression = true
+
+	var buf bytes.Buffer
+	var comp, err = codecs.NewCodecWriter(&buf, pb.CompressionCodec_GZIP)
+	c.Assert(err, gc.IsNil)
+	_, err = comp.Write([]byte(strings.Repeat("hello, world! ", 100)))
+	c.Assert(err, gc.IsNil)
+	c.Assert(comp.Close(), gc.IsNil)
+
+	var value = func(vec *prometheus.CounterVec) float64 {
+		var m dto.Metric
+		c.Assert(vec.WithLabelValues("GZIP").Write(&m), gc.IsNil)
+		return m.GetCounter().GetValue()
+	}
+	var inputBytes, outputBytes, seconds = value(metrics.GazetteDecompressionInputBytesTotal),
+		value(metrics.GazetteDecompressionOutputBytesTotal),
+		value(metrics.GazetteDecompressionSecondsTotal)
+
+	var frag = pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            100,
+		End:              1500,
+		CompressionCodec: pb.CompressionCodec_GZIP,
+	}
+	fr, err := NewFragmentReader(ioutil.NopCloser(bytes.NewReader(buf.Bytes())), frag, frag.Begin)
+	c.Assert(err, gc.IsNil)
+
+	b, err := ioutil.ReadAll(fr)
+	c.Check(err, gc.IsNil)
+	c.Check(len(b), gc.Equals, 1400)
+	c.Check(fr.Close(), gc.IsNil)
+
+	// Expect all compressed and decompressed bytes were recorded, as was time spent.
+	c.Check(value(metrics.GazetteDecompressionInputBytesTotal)-inputBytes, gc.Equals, float64(buf.Len()))
+	c.Check(value(metrics.GazetteDecompressionOutputBytesTotal)-outputBytes, gc.Equals, float64(1400))
+	c.Check(value(metrics.GazetteDecompressionSecondsTotal) > seconds, gc.Equals, true)
+}
+
+func (s *ReaderSuite) TestReaderRefreshesExpiredFragmentURL(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	// Wrap the file transport to reject "expired" URLs as would a store.
+	var prevClient = httpClient
+	httpClient = &http.Client{Transport: expiringTransport{prevClient.Transport}}
+	defer func() { httpClient = prevClient }()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+
+	go serveReadFixtures(c, broker,
+		// Case 1: URL expires before it's opened, and a fresh URL is returned.
+		readFixture{fragment: &frag, fragmentUrl: url + "?expired"},
+		readFixture{fragment: &frag, fragmentUrl: url},
+		// Case 2: a refreshed URL is also expired.
+		readFixture{fragment: &frag, fragmentUrl: url + "?expired"},
+		readFixture{fragment: &frag, fragmentUrl: url + "?expired"},
+		// Case 3: URL is forbidden for a reason other than its expiry.
+		readFixture{fragment: &frag, fragmentUrl: url + "?forbidden"},
+	)
+
+	// Case 1: the Reader transparently refreshes the URL, resuming at its offset.
+	var ttl = time.Hour
+	var r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105, SignatureTTL: &ttl})
+
+	var n, err = r.Read(nil)
+	c.Check(n, gc.Equals, 0)
+	c.Check(err, gc.IsNil)
+
+	b, err := ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(r.Request.Offset, gc.Equals, frag.End)
+	c.Check(*r.Request.SignatureTTL, gc.Equals, ttl)
+
+	// Case 2: the Reader refreshes only once.
+	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105})
+
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.Equals, ErrFragmentURLExpired)
+	c.Check(b, gc.HasLen, 0)
+
+	// Case 3: the Reader doesn't refresh, and fails with the !OK status.
+	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105})
+
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.ErrorMatches, `!OK fetching \(403 Forbidden, .*\?forbidden"\)`)
+	c.Check(b, gc.HasLen, 0)
+}
+
 func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 	var frag, url, dir, cleanup = buildFragmentFixture(c)
 	defer cleanup()
@@ -91,7 +353,7 @@ func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 	go serveReadFixtures(c, broker,
 		// Case 1: fixture returns fragment metadata & URL, then EOF.
 		readFixture{fragment: &frag, fragmentUrl: url},
-		// Case 2: fragment metadata but no URL, at read offset -1.
+		// Case 2: fragment metadata but no URL, at the last fragment from the head.
 		readFixture{fragment: &frag, offset: 110},
 		// Case 3: wrong broker (and it's not instructed to proxy).
 		readFixture{status: pb.Status_NOT_JOURNAL_BROKER},
@@ -133,12 +395,13 @@ func (s *ReaderSuite) TestReaderCases(c *gc.C) {
 	})
 
 	// Case 2: fragment metadata, no URL and the offset is jumped.
-	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: -1})
+	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: -1, FragmentsFromHead: 1})
 	n, err = r.Read(b)
 
 	c.Check(n, gc.Equals, 0)
 	c.Check(err, gc.Equals, ErrOffsetJump)
 	c.Check(r.Request.Offset, gc.Equals, int64(110)) // Updated from Response.
+	c.Check(r.Request.FragmentsFromHead, gc.Equals, int32(0))
 	c.Check(r.Response.Fragment, gc.DeepEquals, &frag)
 
 	n, err = r.Read(b)
@@ -258,6 +521,233 @@ func (s *ReaderSuite) TestBufferedOffsetAdjustment(c *gc.C) {
 	c.Check(r.AdjustedOffset(br), gc.Equals, int64(100+7))
 }
 
+func (s *ReaderSuite) TestSkipOffsetJumps(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	go readFixture{content: "foobar\nbaz\n", offset: 110}.serve(c, broker)
+
+	var r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: 100})
+	r.SkipOffsetJumps = true
+
+	// Expect content is read without an ErrOffsetJump, and the jump is counted.
+	var b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foobar\nbaz\n")
+	c.Check(r.Request.Offset, gc.Equals, int64(110+11))
+	c.Check(r.OffsetJumps, gc.Equals, 1)
+
+	// Expect a restarted RetryReader carries the setting forward.
+	var rr = NewRetryReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"})
+	rr.Reader.SkipOffsetJumps = true
+	rr.Restart(pb.ReadRequest{Journal: "a/journal", Offset: 100})
+	c.Check(rr.Reader.SkipOffsetJumps, gc.Equals, true)
+}
+
+func (s *ReaderSuite) TestFilteredReadsFollowOffsetChanges(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	go readFixture{content: "a:1\na:2\n", offset: 110}.serve(c, broker)
+
+	var r = NewReader(context.Background(), rjc, pb.ReadRequest{
+		Journal: "a/journal",
+		Offset:  100,
+		Filter:  &pb.ReadFilter{Prefix: []byte("a:")},
+	})
+
+	// Expect content is read without an ErrOffsetJump, which isn't counted.
+	var b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "a:1\na:2\n")
+	c.Check(r.Request.Offset, gc.Equals, int64(110+8))
+	c.Check(r.OffsetJumps, gc.Equals, 0)
+}
+
+func (s *ReaderSuite) TestFilteredReadsDontOpenFragmentURLs(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	go readFixture{fragment: &frag, fragmentUrl: url}.serve(c, broker)
+
+	var r = NewReader(context.Background(), rjc, pb.ReadRequest{
+		Journal: "a/journal",
+		Offset:  105,
+		Filter:  &pb.ReadFilter{Prefix: []byte("a:")},
+	})
+
+	// Expect the URL isn't opened, which would bypass the filter.
+	var b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(b, gc.HasLen, 0)
+	c.Check(r.Request.Offset, gc.Equals, int64(105))
+}
+
+func (s *ReaderSuite) TestOnProgress(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	go serveReadFixtures(c, broker,
+		readFixture{content: "foobar", offset: 100},
+		readFixture{fragment: &frag, fragmentUrl: url},
+	)
+
+	type progress struct {
+		offset   int64
+		fragment pb.Fragment
+	}
+	var out []progress
+	var onProgress = func(offset int64, fragment *pb.Fragment) {
+		out = append(out, progress{offset, *fragment})
+	}
+
+	// Case: progress is reported for the ReadResponse bearing metadata,
+	// but not for those of its content.
+	var r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: 90})
+	r.OnProgress = onProgress
+	r.SkipOffsetJumps = true
+
+	var b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foobar")
+	c.Check(out, gc.DeepEquals, []progress{{100, pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            0,
+		End:              1024,
+		CompressionCodec: pb.CompressionCodec_NONE,
+	}}})
+
+	// Case: progress is reported for the metadata response, and again as its
+	// Fragment URL is directly opened.
+	out = nil
+	r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105})
+	r.OnProgress = onProgress
+
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(out, gc.DeepEquals, []progress{{105, frag}, {105, frag}})
+	c.Check(r.Response.WriteHead, gc.Equals, int64(1024))
+
+	// Expect a restarted RetryReader carries the callback forward.
+	var rr = NewRetryReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"})
+	rr.Reader.OnProgress = onProgress
+	rr.Restart(pb.ReadRequest{Journal: "a/journal", Offset: 100})
+	c.Check(rr.Reader.OnProgress, gc.NotNil)
+}
+
+func (s *ReaderSuite) TestReopenOnSeek(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	go serveReadFixtures(c, broker,
+		readFixture{content: "foobar\nbaz\n", offset: 100},
+		readFixture{content: "baz\n"},
+		readFixture{content: "foobar\nbaz\n"},
+	)
+
+	var r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: 100})
+
+	// Without ReopenOnSeek, a backwards seek requires a new Reader.
+	var offset, err = r.Seek(-1, io.SeekCurrent)
+	c.Check(offset, gc.Equals, int64(100))
+	c.Check(err, gc.Equals, ErrSeekRequiresNewReader)
+
+	r.ReopenOnSeek = true
+
+	b, err := ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foobar\nbaz\n")
+	c.Check(r.Request.Offset, gc.Equals, int64(111))
+
+	// Case: seek backwards relative to the current offset.
+	offset, err = r.Seek(-4, io.SeekCurrent)
+	c.Check(offset, gc.Equals, int64(107))
+	c.Check(err, gc.IsNil)
+
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "baz\n")
+	c.Check(r.Request.Offset, gc.Equals, int64(111))
+
+	// Case: seek before the journal beginning is an error.
+	offset, err = r.Seek(-1, io.SeekStart)
+	c.Check(offset, gc.Equals, int64(111))
+	c.Check(err, gc.ErrorMatches, `invalid seek offset \(-1; expected >= 0\)`)
+
+	// Case: seek to an absolute offset.
+	offset, err = r.Seek(100, io.SeekStart)
+	c.Check(offset, gc.Equals, int64(100))
+	c.Check(err, gc.IsNil)
+
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foobar\nbaz\n")
+}
+
+func (s *ReaderSuite) TestEndOffset(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+
+	go serveReadFixtures(c, broker,
+		readFixture{content: "foobar\nbaz\n", offset: 100},
+		readFixture{fragment: &frag, fragmentUrl: url},
+		readFixture{content: "foobar\nbaz\n", offset: 100},
+	)
+
+	// Case: streamed content is trimmed to EndOffset.
+	var r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 100})
+	r.EndOffset = 108
+
+	var b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foobar\nb")
+	c.Check(r.Request.Offset, gc.Equals, int64(108))
+
+	// Case: a directly-read fragment is trimmed to EndOffset, and closed.
+	r = NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 105})
+	r.EndOffset = 112
+
+	b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "hello, ")
+	c.Check(r.Request.Offset, gc.Equals, int64(112))
+	c.Check(r.direct, gc.IsNil)
+
+	// Case: a RetryReader surfaces io.EOF at EndOffset, rather than retrying.
+	var rr = NewRetryReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: 100})
+	rr.Reader.EndOffset = 103
+	defer rr.Cancel()
+
+	b, err = ioutil.ReadAll(rr)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foo")
+	c.Check(rr.Offset(), gc.Equals, int64(103))
+
+	rr.Restart(pb.ReadRequest{Journal: "a/journal", Offset: 100})
+	c.Check(rr.Reader.EndOffset, gc.Equals, int64(103))
+}
+
 func (s *ReaderSuite) TestReaderSeekCases(c *gc.C) {
 	var frag, url, dir, cleanup = buildFragmentFixture(c)
 	defer cleanup()
@@ -335,6 +825,76 @@ func (s *ReaderSuite) TestContextErrorCases(c *gc.C) {
 	c.Check(n, gc.Equals, 0)
 }
 
+func (s *ReaderSuite) TestRetryPolicy(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var attempts []int
+	var policy = func(attempt int, err error) (time.Duration, bool) {
+		attempts = append(attempts, attempt)
+		return 0, true
+	}
+
+	// Case: retriable errors are retried, and the Reader continues.
+	go serveReadFixtures(c, broker,
+		readFixture{status: pb.Status_NOT_JOURNAL_BROKER},
+		readFixture{err: status.Error(codes.Unavailable, "broker is shutting down")},
+		readFixture{content: "foobar\n"},
+	)
+	var r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"})
+	r.RetryPolicy = policy
+
+	var b, err = ioutil.ReadAll(r)
+	c.Check(err, gc.IsNil)
+	c.Check(string(b), gc.Equals, "foobar\n")
+	c.Check(attempts, gc.DeepEquals, []int{0, 0})
+
+	// Case: other errors invalidate the Reader without a retry.
+	attempts = nil
+	go serveReadFixtures(c, broker, readFixture{status: pb.Status_JOURNAL_NOT_FOUND})
+
+	r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"})
+	r.RetryPolicy = policy
+
+	_, err = r.Read(nil)
+	c.Check(err, gc.ErrorMatches, pb.Status_JOURNAL_NOT_FOUND.String())
+	c.Check(attempts, gc.IsNil)
+
+	// Case: the error is returned once the policy is exhausted.
+	go serveReadFixtures(c, broker,
+		readFixture{status: pb.Status_NOT_JOURNAL_BROKER},
+		readFixture{status: pb.Status_NOT_JOURNAL_BROKER},
+	)
+	r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"})
+	r.RetryPolicy = NewBackoffRetryPolicy(1, time.Millisecond, time.Millisecond)
+
+	_, err = r.Read(nil)
+	c.Check(err, gc.Equals, ErrNotJournalBroker)
+
+	// Case: without a RetryPolicy, the first error is returned.
+	go serveReadFixtures(c, broker, readFixture{status: pb.Status_NOT_JOURNAL_BROKER})
+
+	r = NewReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal"})
+	_, err = r.Read(nil)
+	c.Check(err, gc.Equals, ErrNotJournalBroker)
+}
+
+func (s *ReaderSuite) TestBackoffRetryPolicy(c *gc.C) {
+	var policy = NewBackoffRetryPolicy(4, 10*time.Millisecond, 50*time.Millisecond)
+	var delays []time.Duration
+
+	for attempt := 0; true; attempt++ {
+		var delay, retry = policy(attempt, ErrNotJournalBroker)
+		if !retry {
+			break
+		}
+		delays = append(delays, delay)
+	}
+	c.Check(delays, gc.DeepEquals, []time.Duration{
+		10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond})
+}
+
 type readFixture struct {
 	status pb.Status
 	err    error
@@ -431,6 +991,28 @@ func buildFragmentFixture(c *gc.C) (frag pb.Fragment, url string, dir string, cl
 	return
 }
 
+// expiringTransport responds with 403 Forbidden to requests of "expired" and
+// "forbidden" URLs, having response bodies as would an S3 store.
+type expiringTransport struct{ http.RoundTripper }
+
+func (t expiringTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body string
+	switch req.URL.RawQuery {
+	case "expired":
+		body = "<Error><Code>AccessDenied</Code><Message>Request has expired</Message></Error>"
+	case "forbidden":
+		body = "<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>"
+	default:
+		return t.RoundTripper.RoundTrip(req)
+	}
+	return &http.Response{
+		Status:     "403 Forbidden",
+		StatusCode: http.StatusForbidden,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
 func buildHeaderFixture(ep interface{ Endpoint() pb.Endpoint }) *pb.Header {
 	return &pb.Header{
 		ProcessId: pb.ProcessSpec_ID{Zone: "a", Suffix: "broker"},
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/pkg/errors"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// Record is a framed record of a journal, and the [Begin, End) byte offsets
+// of the journal at which it was read.
+type Record struct {
+	Journal    pb.Journal
+	Begin, End int64
+	// Frame is the complete framed record, including any header or delimiter
+	// of the framing.
+	Frame []byte
+}
+
+// UnpackFunc unpacks the next framed record from the bufio.Reader. The Unpack
+// method of a message.Framing is an UnpackFunc.
+type UnpackFunc func(*bufio.Reader) ([]byte, error)
+
+// RecordIterator reads the content of a journal as a sequence of discrete,
+// framed records, each having the offsets at which it was read. It's a
+// lower-level alternative to the decoding of typed messages, useful for
+// generic tooling which must handle framed content of any journal.
+type RecordIterator struct {
+	rr     *RetryReader
+	br     *bufio.Reader
+	unpack UnpackFunc
+}
+
+// NewRecordIterator returns a RecordIterator of records read from the
+// RetryReader, which are unpacked using |unpack|.
+func NewRecordIterator(rr *RetryReader, unpack UnpackFunc) *RecordIterator {
+	return &RecordIterator{
+		rr:     rr,
+		br:     bufio.NewReader(rr),
+		unpack: unpack,
+	}
+}
+
+// Next returns the next Record of the journal. If an offset jump occurs,
+// iteration continues at the jumped-to offset, and callers may detect the
+// jump as a Record having a Begin larger than the End of its predecessor.
+// Otherwise, Next returns errors of the RetryReader (for example,
+// ErrOffsetNotYetAvailable of a non-blocking read which has reached the
+// write head, or io.EOF if the Reader's EndOffset was reached), or of
+// the UnpackFunc.
+func (it *RecordIterator) Next() (Record, error) {
+	for {
+		var begin = it.rr.AdjustedOffset(it.br)
+		var frame, err = it.unpack(it.br)
+
+		// Swallow ErrNoProgress from our bufio.Reader. Reader returns empty reads
+		// to allow for inspection of ReadResponse metadata, and a journal
+		// with no active appends can cause our bufio.Reader to give up.
+		if errors.Cause(err) == io.ErrNoProgress {
+			continue
+		} else if errors.Cause(err) == ErrOffsetJump {
+			continue
+		} else if err != nil {
+			return Record{}, err
+		}
+
+		return Record{
+			Journal: it.rr.Journal(),
+			Begin:   begin,
+			End:     it.rr.AdjustedOffset(it.br),
+			// Copy, as |frame| may reference the internal buffer of |it.br|.
+			Frame: append([]byte(nil), frame...),
+		}, nil
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"bufio"
+	"context"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/broker/teststub"
+)
+
+type RecordIteratorSuite struct{}
+
+func (s *RecordIteratorSuite) TestRecordsWithOffsets(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var rr = NewRetryReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: 100})
+
+	go serveReadFixtures(c, broker,
+		readFixture{content: "{\"one\":1}\n{\"two\":2}\n"},
+		// Content from 120 through 200 was removed.
+		readFixture{content: "{\"three\":3}\n", offset: 200},
+		readFixture{status: pb.Status_OFFSET_NOT_YET_AVAILABLE},
+	)
+
+	var it = NewRecordIterator(rr, unpackLine)
+
+	for _, expect := range []Record{
+		{Journal: "a/journal", Begin: 100, End: 110, Frame: []byte("{\"one\":1}\n")},
+		{Journal: "a/journal", Begin: 110, End: 120, Frame: []byte("{\"two\":2}\n")},
+		{Journal: "a/journal", Begin: 200, End: 212, Frame: []byte("{\"three\":3}\n")},
+	} {
+		var rec, err = it.Next()
+		c.Check(err, gc.IsNil)
+		c.Check(rec, gc.DeepEquals, expect)
+	}
+
+	var _, err = it.Next()
+	c.Check(err, gc.Equals, ErrOffsetNotYetAvailable)
+}
+
+// unpackLine is an UnpackFunc of newline-delimited records.
+func unpackLine(br *bufio.Reader) ([]byte, error) { return br.ReadSlice('\n') }
+
+var _ = gc.Suite(&RecordIteratorSuite{})
@@ -56,6 +56,9 @@ func (rr *RetryReader) Offset() int64 {
 //    for a non-blocking ReadRequest.
 //  * An offset jump occurred (ErrOffsetJump), in which case the client
 //    should inspect the new Offset may continue reading if desired.
+//  * The EndOffset of the Reader was reached (io.EOF).
+//  * Directly read Fragment content didn't match its Sum (ErrFragmentSumMismatch),
+//    as a retry would read the same content.
 // All other errors are retried.
 func (rr *RetryReader) Read(p []byte) (n int, err error) {
 	for attempt := 0; true; attempt++ {
@@ -64,6 +67,8 @@ func (rr *RetryReader) Read(p []byte) (n int, err error) {
 			return // Success.
 		} else if err == ErrOffsetJump {
 			return // Note |rr.Reader| is not invalidated by this error.
+		} else if err == io.EOF && rr.Reader.EndOffset != 0 && rr.Offset() >= rr.Reader.EndOffset {
+			return // The read range is complete.
 		}
 
 		// Our Read failed. Since we're a retrying reader, we consume and mask
@@ -72,10 +77,12 @@ func (rr *RetryReader) Read(p []byte) (n int, err error) {
 
 		// Restart the Reader re-using the same context (note we could be racing
 		// this restart with a concurrent call to |rr.Cancel|).
-		rr.Reader = NewReader(rr.Reader.ctx, rr.Reader.client, rr.Reader.Request)
+		var prev = rr.Reader
+		rr.Reader = NewReader(prev.ctx, prev.client, prev.Request)
+		rr.Reader.copyOptions(prev)
 
 		switch err {
-		case context.DeadlineExceeded, context.Canceled:
+		case context.DeadlineExceeded, context.Canceled, ErrFragmentSumMismatch:
 			return // Surface to caller.
 		case ErrOffsetNotYetAvailable:
 			if rr.Reader.Request.Block {
@@ -130,7 +137,7 @@ func (rr *RetryReader) Seek(offset int64, whence int) (int64, error) {
 		}
 
 		var req = rr.Reader.Request
-		req.Offset = offset
+		req.Offset, req.FragmentsFromHead = offset, 0
 
 		rr.Cancel()
 		rr.Restart(req)
@@ -170,12 +177,46 @@ func (rr *RetryReader) AdjustedSeek(offset int64, whence int, br *bufio.Reader)
 	return n, err
 }
 
-// Restart the RetryReader with a new ReadRequest.
+// Restart the RetryReader with a new ReadRequest. Options of the current
+// Reader (such as SkipOffsetJumps and EndOffset), if any, are carried over to
+// the new Reader.
 func (rr *RetryReader) Restart(req pb.ReadRequest) {
 	var ctx, cancel = context.WithCancel(rr.ctx)
 
+	var prev = rr.Reader
 	rr.Reader = NewReader(ctx, rr.client, req)
 	rr.Cancel = cancel
+
+	if prev != nil {
+		rr.Reader.copyOptions(prev)
+	}
+}
+
+// WriteHeadReader is a RetryReader which tracks the largest write head
+// reported by any ReadResponse, as well as the Fragment most recently
+// reported. Content chunks of a read carry neither, so every response must be
+// inspected as it's read.
+type WriteHeadReader struct {
+	*RetryReader
+	// WriteHead is the largest write head of any ReadResponse read thus far.
+	WriteHead int64
+	// Fragment is that of the last ReadResponse having a Fragment. Note that
+	// it may be ahead of content which is buffered but not yet consumed.
+	Fragment *pb.Fragment
+}
+
+// Read implements io.Reader, and updates WriteHead and Fragment from the
+// current ReadResponse.
+func (r *WriteHeadReader) Read(p []byte) (n int, err error) {
+	n, err = r.RetryReader.Read(p)
+
+	if wh := r.Reader.Response.WriteHead; wh > r.WriteHead {
+		r.WriteHead = wh
+	}
+	if f := r.Reader.Response.Fragment; f != nil {
+		r.Fragment = f
+	}
+	return
 }
 
 func backoff(attempt int) time.Duration {
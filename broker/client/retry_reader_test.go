@@ -6,8 +6,10 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing/iotest"
+	"time"
 
 	gc "github.com/go-check/check"
 	pb "go.gazette.dev/core/broker/protocol"
@@ -61,6 +63,30 @@ func (s *RetrySuite) TestReaderRetries(c *gc.C) {
 	c.Check(err, gc.Equals, context.Canceled)
 }
 
+func (s *RetrySuite) TestWriteHeadReader(c *gc.C) {
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var whr = &WriteHeadReader{RetryReader: NewRetryReader(context.Background(), rjc,
+		pb.ReadRequest{Journal: "a/journal", Offset: 100})}
+
+	go serveReadFixtures(c, broker,
+		readFixture{content: "foobar", status: pb.Status_OFFSET_NOT_YET_AVAILABLE})
+
+	var b, err = ioutil.ReadAll(whr)
+	c.Check(string(b), gc.Equals, "foobar")
+	c.Check(err, gc.Equals, ErrOffsetNotYetAvailable)
+
+	// Expect the write head of the initial ReadResponse is retained, though
+	// later responses don't carry one.
+	c.Check(whr.Reader.Response.WriteHead, gc.Equals, int64(0))
+	c.Check(whr.WriteHead, gc.Equals, int64(1024))
+	// As is its Fragment.
+	c.Check(whr.Reader.Response.Fragment, gc.IsNil)
+	c.Check(whr.Fragment.End, gc.Equals, int64(1024))
+}
+
 func (s *RetrySuite) TestMisbehavingReaderCases(c *gc.C) {
 	var broker = teststub.NewBroker(c)
 	defer broker.Cleanup()
@@ -96,6 +122,73 @@ func (s *RetrySuite) TestMisbehavingReaderCases(c *gc.C) {
 	}
 }
 
+func (s *RetrySuite) TestFragmentSumMismatchIsNotRetried(c *gc.C) {
+	var frag, url, dir, cleanup = buildFragmentFixture(c)
+	defer cleanup()
+	defer InstallFileTransport(dir)()
+
+	var broker = teststub.NewBroker(c)
+	defer broker.Cleanup()
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+
+	var corrupt = frag
+	corrupt.Sum = pb.SHA1SumOf("something else")
+
+	go serveReadFixtures(c, broker,
+		readFixture{fragment: &corrupt, fragmentUrl: url},
+	)
+
+	var rr = NewRetryReader(context.Background(), rjc, pb.ReadRequest{Journal: "a/journal", Offset: frag.Begin + 5})
+	rr.Reader.VerifyFragmentSums = true
+
+	// Expect ErrFragmentSumMismatch is surfaced rather than retried (which
+	// would block, as no further read fixtures are served).
+	var b, err = ioutil.ReadAll(rr)
+	c.Check(string(b), gc.Equals, "hello, world!!!")
+	c.Check(err, gc.Equals, ErrFragmentSumMismatch)
+	c.Check(rr.Offset(), gc.Equals, frag.End)
+
+	// Settings of the Reader are retained across its restart.
+	c.Check(rr.Reader.VerifyFragmentSums, gc.Equals, true)
+}
+
+func (s *RetrySuite) TestRestartRetainsReaderOptions(c *gc.C) {
+	var rr = NewRetryReader(context.Background(), nil, pb.ReadRequest{Journal: "a/journal"})
+	var progress int
+
+	rr.Reader.SkipOffsetJumps, rr.Reader.OffsetJumps = true, 3
+	rr.Reader.EndOffset, rr.Reader.ReopenOnSeek = 1024, true
+	rr.Reader.VerifyFragmentSums = true
+	rr.Reader.RetryPolicy = NewBackoffRetryPolicy(3, time.Millisecond, time.Second)
+	rr.Reader.OnProgress = func(int64, *pb.Fragment) { progress++ }
+
+	rr.Cancel()
+	rr.Restart(pb.ReadRequest{Journal: "a/journal", Offset: 512})
+
+	c.Check(rr.Offset(), gc.Equals, int64(512))
+	c.Check(rr.Reader.SkipOffsetJumps, gc.Equals, true)
+	c.Check(rr.Reader.OffsetJumps, gc.Equals, 3)
+	c.Check(rr.Reader.EndOffset, gc.Equals, int64(1024))
+	c.Check(rr.Reader.ReopenOnSeek, gc.Equals, true)
+	c.Check(rr.Reader.VerifyFragmentSums, gc.Equals, true)
+	c.Check(rr.Reader.RetryPolicy, gc.NotNil)
+
+	rr.Reader.OnProgress(0, nil)
+	c.Check(progress, gc.Equals, 1)
+
+	// Expect the options above are all of the Reader's exported fields (other
+	// than Request & Response). If a Reader option is added, it must also be
+	// copied by Reader.copyOptions and verified here.
+	var exported int
+	for typ, i := reflect.TypeOf(Reader{}), 0; i != typ.NumField(); i++ {
+		if typ.Field(i).PkgPath == "" {
+			exported++
+		}
+	}
+	c.Check(exported, gc.Equals, 2+7)
+}
+
 func (s *RetrySuite) TestSeeking(c *gc.C) {
 	var frag, url, dir, cleanup = buildFragmentFixture(c)
 	defer cleanup()
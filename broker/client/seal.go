@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// SealJournal permanently seals |journal| at its final write head, and returns
+// the sealed JournalSpec. Once sealed, brokers refuse further non-empty Appends
+// to the journal (with status JOURNAL_SEALED) while continuing to serve reads,
+// and refuse Apply requests which would remove the seal.
+//
+// SealJournal determines the write head via an empty Append, which acts as a
+// write barrier, and then applies a JournalSpec.Seal at that offset. It then
+// issues another barrier, resolved at or after the Etcd revision of the applied
+// seal, to confirm that no Appends raced the seal. If one did, the seal offset
+// is moved forward to the new write head and the process repeats. SealJournal
+// may be called on an already-sealed journal, to verify its seal.
+func SealJournal(ctx context.Context, rjc pb.RoutedJournalClient, journal pb.Journal) (*pb.JournalSpec, error) {
+	for {
+		var list, err = ListAllJournals(ctx, rjc, pb.ListRequest{
+			Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", journal.String())},
+		})
+		if err != nil {
+			return nil, err
+		} else if len(list.Journals) != 1 {
+			return nil, errors.New(pb.Status_JOURNAL_NOT_FOUND.String())
+		}
+		var cur = list.Journals[0]
+
+		head, err := appendBarrier(ctx, rjc, journal, cur.ModRevision)
+		if err != nil {
+			return nil, err
+		} else if cur.Spec.Seal != nil && cur.Spec.Seal.Offset == head {
+			return &cur.Spec, nil // Journal is sealed at its write head.
+		}
+
+		var spec = cur.Spec
+		spec.Seal = &pb.JournalSpec_Seal{Offset: head}
+
+		if _, err = ApplyJournals(ctx, rjc, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{{Upsert: &spec, ExpectModRevision: cur.ModRevision}},
+		}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// appendBarrier issues an empty Append to |journal| which is resolved at or
+// after Etcd |revision|, and returns the journal write head.
+func appendBarrier(ctx context.Context, rjc pb.RoutedJournalClient, journal pb.Journal, revision int64) (int64, error) {
+	for attempt := 0; true; attempt++ {
+		var resp, err = Append(ctx, rjc, pb.AppendRequest{Journal: journal})
+		if err != nil {
+			return 0, err
+		} else if resp.Header.Etcd.Revision >= revision {
+			return resp.Commit.End, nil
+		}
+
+		// The serving broker hasn't yet observed |revision|. Try again.
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(backoff(attempt)):
+		}
+	}
+	panic("not reached")
+}
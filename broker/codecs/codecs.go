@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/snappy"
 	"github.com/klauspost/compress/gzip"
+	"github.com/pierrec/lz4"
 	pb "go.gazette.dev/core/broker/protocol"
 )
 
@@ -30,22 +31,68 @@ func NewCodecReader(r io.Reader, codec pb.CompressionCodec) (Decompressor, error
 		return ioutil.NopCloser(snappy.NewReader(r)), nil
 	case pb.CompressionCodec_ZSTANDARD:
 		return zstdNewReader(r)
+	case pb.CompressionCodec_LZ4:
+		// lz4.Reader returns EOF at the end of the final frame, or if the stream
+		// ends on a block boundary. In the latter case FragmentReader detects
+		// the short read against Fragment.End.
+		return ioutil.NopCloser(lz4.NewReader(r)), nil
 	default:
 		return nil, fmt.Errorf("unsupported codec %s", codec.String())
 	}
 }
 
-// NewCodecWriter returns a Compressor wrapping the Writer encoding with CompressionCodec.
+// CodecOptions parameterize the Compressors returned by NewCodecWriterWithOptions.
+// Zero-valued fields select the default behavior of the respective codec.
+type CodecOptions struct {
+	// GzipLevel is the compression level used by GZIP and
+	// GZIP_OFFLOAD_DECOMPRESSION, from 1 (best speed) to 9 (best compression).
+	GzipLevel int
+	// ZstandardLevel is the compression level used by ZSTANDARD,
+	// from 1 (best speed) to 20 (best compression).
+	ZstandardLevel int
+}
+
+// Validate returns an error if the CodecOptions are not well-formed.
+func (o CodecOptions) Validate() error {
+	if o.GzipLevel < 0 || o.GzipLevel > gzip.BestCompression {
+		return fmt.Errorf("invalid GzipLevel (%d; expected 0 <= level <= %d)",
+			o.GzipLevel, gzip.BestCompression)
+	} else if o.ZstandardLevel < 0 || o.ZstandardLevel > zstdBestCompression {
+		return fmt.Errorf("invalid ZstandardLevel (%d; expected 0 <= level <= %d)",
+			o.ZstandardLevel, zstdBestCompression)
+	}
+	return nil
+}
+
+// NewCodecWriter returns a Compressor wrapping the Writer encoding with
+// CompressionCodec, using default CodecOptions.
 func NewCodecWriter(w io.Writer, codec pb.CompressionCodec) (Compressor, error) {
+	return NewCodecWriterWithOptions(w, codec, CodecOptions{})
+}
+
+// NewCodecWriterWithOptions returns a Compressor wrapping the Writer encoding
+// with CompressionCodec, and configured by CodecOptions.
+func NewCodecWriterWithOptions(w io.Writer, codec pb.CompressionCodec, opts CodecOptions) (Compressor, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	switch codec {
 	case pb.CompressionCodec_NONE:
 		return nopWriteCloser{w}, nil
 	case pb.CompressionCodec_GZIP, pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION:
-		return gzip.NewWriter(w), nil
+		if opts.GzipLevel == 0 {
+			return gzip.NewWriter(w), nil
+		}
+		return gzip.NewWriterLevel(w, opts.GzipLevel)
 	case pb.CompressionCodec_SNAPPY:
 		return snappy.NewBufferedWriter(w), nil
 	case pb.CompressionCodec_ZSTANDARD:
-		return zstdNewWriter(w)
+		return zstdNewWriter(w, opts.ZstandardLevel)
+	case pb.CompressionCodec_LZ4:
+		// lz4.Writer emits the standard LZ4 frame format, readable by external
+		// tools. Close writes the frame end mark but doesn't close |w|.
+		return lz4.NewWriter(w), nil
 	default:
 		return nil, fmt.Errorf("unsupported codec %s", codec.String())
 	}
@@ -59,7 +106,10 @@ var (
 	zstdNewReader = func(io.Reader) (io.ReadCloser, error) {
 		return nil, fmt.Errorf("ZSTANDARD was not enabled at compile time")
 	}
-	zstdNewWriter = func(io.Writer) (io.WriteCloser, error) {
+	zstdNewWriter = func(io.Writer, int) (io.WriteCloser, error) {
 		return nil, fmt.Errorf("ZSTANDARD was not enabled at compile time")
 	}
 )
+
+// zstdBestCompression is the maximum compression level of ZSTANDARD.
+const zstdBestCompression = 20
@@ -0,0 +1,77 @@
+package codecs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+type CodecsSuite struct{}
+
+func (s *CodecsSuite) TestRoundTripCases(c *gc.C) {
+	var content = strings.Repeat("hello, world! the quick brown fox. ", 1024)
+
+	for _, tc := range []struct {
+		codec pb.CompressionCodec
+		opts  CodecOptions
+	}{
+		{pb.CompressionCodec_NONE, CodecOptions{}},
+		{pb.CompressionCodec_GZIP, CodecOptions{}},
+		{pb.CompressionCodec_GZIP, CodecOptions{GzipLevel: 1}},
+		{pb.CompressionCodec_GZIP, CodecOptions{GzipLevel: 9}},
+		{pb.CompressionCodec_SNAPPY, CodecOptions{}},
+		{pb.CompressionCodec_ZSTANDARD, CodecOptions{}},
+		{pb.CompressionCodec_ZSTANDARD, CodecOptions{ZstandardLevel: 1}},
+		{pb.CompressionCodec_ZSTANDARD, CodecOptions{ZstandardLevel: 19}},
+		{pb.CompressionCodec_LZ4, CodecOptions{}},
+	} {
+		if tc.codec == pb.CompressionCodec_ZSTANDARD && !zstdEnabled() {
+			continue // Built with the nozstd tag.
+		}
+		var buf bytes.Buffer
+
+		var w, err = NewCodecWriterWithOptions(&buf, tc.codec, tc.opts)
+		c.Assert(err, gc.IsNil)
+		_, err = w.Write([]byte(content))
+		c.Check(err, gc.IsNil)
+		c.Check(w.Close(), gc.IsNil)
+
+		if tc.codec != pb.CompressionCodec_NONE {
+			c.Check(buf.Len() < len(content), gc.Equals, true)
+		}
+
+		r, err := NewCodecReader(&buf, tc.codec)
+		c.Assert(err, gc.IsNil)
+		b, err := ioutil.ReadAll(r)
+		c.Check(err, gc.IsNil)
+		c.Check(string(b), gc.Equals, content)
+		c.Check(r.Close(), gc.IsNil)
+	}
+}
+
+func (s *CodecsSuite) TestOptionValidation(c *gc.C) {
+	var _, err = NewCodecWriterWithOptions(ioutil.Discard, pb.CompressionCodec_GZIP, CodecOptions{GzipLevel: 10})
+	c.Check(err, gc.ErrorMatches, `invalid GzipLevel \(10; expected 0 <= level <= 9\)`)
+	_, err = NewCodecWriterWithOptions(ioutil.Discard, pb.CompressionCodec_ZSTANDARD, CodecOptions{ZstandardLevel: -1})
+	c.Check(err, gc.ErrorMatches, `invalid ZstandardLevel \(-1; expected 0 <= level <= 20\)`)
+
+	_, err = NewCodecWriter(ioutil.Discard, pb.CompressionCodec_INVALID)
+	c.Check(err, gc.ErrorMatches, `unsupported codec INVALID`)
+}
+
+// zstdEnabled returns whether ZSTANDARD was enabled at compile time.
+func zstdEnabled() bool {
+	var w, err = zstdNewWriter(ioutil.Discard, 0)
+	if err == nil {
+		_ = w.Close()
+	}
+	return err == nil
+}
+
+var _ = gc.Suite(&CodecsSuite{})
+
+func Test(t *testing.T) { gc.TestingT(t) }
@@ -10,5 +10,10 @@ import (
 
 func init() {
 	zstdNewReader = func(r io.Reader) (io.ReadCloser, error) { return zstd.NewReader(r), nil }
-	zstdNewWriter = func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w), nil }
+	zstdNewWriter = func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level == 0 {
+			level = zstd.DefaultCompression
+		}
+		return zstd.NewWriterLevel(w, level), nil
+	}
 }
@@ -3,9 +3,12 @@ package broker
 import (
 	"context"
 	"io"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/etcdtest"
 	"google.golang.org/grpc"
@@ -62,6 +65,124 @@ func TestE2EAppendAndReplicatedRead(t *testing.T) {
 	peer.cleanup()
 }
 
+func TestE2EReducedWriteReplicationReadsFromStore(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var tmpDir, err = ioutil.TempDir("", "BrokerSuite")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(tmpDir)) }()
+	defer func(s string) { fragment.FileSystemStoreRoot = s }(fragment.FileSystemStoreRoot)
+	fragment.FileSystemStoreRoot = tmpDir
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var peer = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	// Appends to the journal are replicated only to its primary, |broker|.
+	var stores = []pb.FragmentStore{"file:///"}
+	setTestJournal(broker, pb.JournalSpec{
+		Name:             "a/journal",
+		Replication:      2,
+		WriteReplication: 1,
+		Fragment: pb.JournalSpec_Fragment{
+			CompressionCodec: pb.CompressionCodec_NONE,
+			Stores:           stores,
+		},
+	}, broker.id, peer.id)
+
+	broker.catchUpKeySpace()
+	peer.catchUpKeySpace()
+
+	broker.initialFragmentLoad()
+	peer.initialFragmentLoad()
+
+	var stream, _ = broker.client().Append(ctx)
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal"}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte("hello")}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+
+	resp, err := stream.CloseAndRecv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, resp.Status)
+
+	// Expect the peer's replica received no content.
+	var spool = <-peer.replica("a/journal").spoolCh
+	assert.Equal(t, int64(0), spool.End)
+	peer.replica("a/journal").spoolCh <- spool
+
+	// Persist the Spool of the primary's pipeline, and refresh the peer's
+	// index from the store.
+	var pln = <-broker.replica("a/journal").pipelineCh
+	spool = pln.spool
+	broker.replica("a/journal").pipelineCh <- pln
+
+	assert.Equal(t, int64(5), spool.End)
+	spool.BackingStore = stores[0] // As set by the Persister.
+	assert.NoError(t, fragment.Persist(ctx, spool))
+
+	set, err := fragment.WalkAllStores(ctx, "a/journal", pb.JournalSpec_Fragment{Stores: stores})
+	assert.NoError(t, err)
+	peer.replica("a/journal").index.ReplaceRemote(set)
+
+	// Expect a read served by the peer pulls content from the store.
+	rOne, err := peer.client().Read(ctx, &pb.ReadRequest{Journal: "a/journal"})
+	assert.NoError(t, err)
+
+	readResp, err := rOne.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, peer.id, readResp.Header.ProcessId)
+	assert.Equal(t, pb.FragmentStore("file:///"), readResp.Fragment.BackingStore)
+
+	expectReadResponse(t, rOne, pb.ReadResponse{Content: []byte("hello")})
+
+	broker.cleanup()
+	peer.cleanup()
+}
+
+func TestE2EAppendAndReadOfAlias(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var peer = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "journal/renamed",
+		Aliases:     []pb.Journal{"journal/original"},
+		Replication: 1,
+	}, peer.id)
+	broker.catchUpKeySpace()
+	peer.initialFragmentLoad()
+
+	// Read of the canonical journal is proxied by |broker| to |peer|.
+	var rd, _ = broker.client().Read(ctx, &pb.ReadRequest{Journal: "journal/renamed", Block: true})
+
+	// Append to the alias is also proxied, and is served by the canonical journal.
+	var stream, _ = broker.client().Append(ctx)
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "journal/original"}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte("hello")}))
+	assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+	var resp, err = stream.CloseAndRecv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, resp.Status)
+	assert.Equal(t, pb.Journal("journal/renamed"), resp.Commit.Journal)
+
+	_, err = rd.Recv() // Fragment metadata.
+	assert.NoError(t, err)
+	expectReadResponse(t, rd, pb.ReadResponse{Content: []byte("hello")})
+
+	// A Read of the alias is likewise served by the canonical journal.
+	rd, _ = peer.client().Read(ctx, &pb.ReadRequest{Journal: "journal/original"})
+	readResp, err := rd.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, readResp.Status)
+	assert.Equal(t, pb.Journal("journal/renamed"), readResp.Fragment.Journal)
+	expectReadResponse(t, rd, pb.ReadResponse{Content: []byte("hello")})
+
+	broker.cleanup()
+	peer.cleanup()
+}
+
 func TestE2EShutdownWithOngoingAppend(t *testing.T) {
 	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
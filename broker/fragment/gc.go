@@ -0,0 +1,76 @@
+package fragment
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// ListGarbage lists Fragments of the stores of the JournalSpec which are
+// no longer referenced by |index|, the journal's current Fragment index (as
+// returned by a Fragments RPC), and which may be removed. Such Fragments
+// accumulate in stores after a journal's history is truncated (for example,
+// after its stores are re-configured, or after its content is re-written
+// from a new offset). A listed Fragment:
+//
+//  * Has a content name not referenced by any Fragment of |index|.
+//  * Ends at or before the persisted begin of |index|, the least Begin of an
+//    |index| Fragment having a BackingStore.
+//  * Was last modified before |horizon|.
+//
+// As brokers only persist Fragments at or beyond the persisted begin of the
+// journal index, listed Fragments are never those of a concurrently writing
+// broker. If |index| has no persisted Fragments, nothing is listed.
+func ListGarbage(ctx context.Context, spec pb.JournalSpec, index []pb.Fragment, horizon time.Time) ([]pb.Fragment, error) {
+	var begin int64 = math.MaxInt64
+	var referenced = make(map[string]struct{}, len(index))
+
+	for _, f := range index {
+		referenced[f.ContentName()] = struct{}{}
+
+		if f.BackingStore != "" && f.Begin < begin {
+			begin = f.Begin
+		}
+	}
+
+	if begin == math.MaxInt64 {
+		return nil, nil // No Fragments are persisted.
+	}
+
+	var out []pb.Fragment
+	var postfix = spec.Fragment.PathPostfixPattern()
+
+	for _, store := range spec.Fragment.Stores {
+		var err = List(ctx, store, spec.Name, postfix, func(f pb.Fragment) {
+			if _, ok := referenced[f.ContentName()]; ok {
+				return
+			} else if f.End > begin {
+				return
+			} else if !time.Unix(f.ModTime, 0).Before(horizon) {
+				return
+			}
+			out = append(out, f)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// CollectGarbage removes Fragments listed by ListGarbage, and returns them.
+// If |dryRun|, Fragments are listed but not removed.
+func CollectGarbage(ctx context.Context, spec pb.JournalSpec, index []pb.Fragment, horizon time.Time, dryRun bool) ([]pb.Fragment, error) {
+	var garbage, err = ListGarbage(ctx, spec, index, horizon)
+	if err != nil || dryRun {
+		return garbage, err
+	}
+	for i, f := range garbage {
+		if err = Remove(ctx, f); err != nil {
+			return garbage[:i], err
+		}
+	}
+	return garbage, nil
+}
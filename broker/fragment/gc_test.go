@@ -0,0 +1,92 @@
+package fragment
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+type GCSuite struct{}
+
+func (s *GCSuite) TestListAndCollectGarbage(c *gc.C) {
+	var tmpdir, err = ioutil.TempDir("", "GCSuite")
+	c.Assert(err, gc.IsNil)
+
+	defer func() { os.RemoveAll(tmpdir) }()
+	defer func(s string) { FileSystemStoreRoot = s }(FileSystemStoreRoot)
+	FileSystemStoreRoot = tmpdir
+
+	var now = time.Now()
+	var fixtures = []struct {
+		path string
+		mod  time.Time
+	}{
+		{"root/a/journal/0000000000000000-0000000000000111-0000000000000000000000000000000000000111", now.Add(-2 * time.Hour)},
+		{"root/a/journal/0000000000000111-0000000000000222-0000000000000000000000000000000000000222", now}, // Too recent.
+		{"root/a/journal/0000000000000222-0000000000000333-0000000000000000000000000000000000000333", now.Add(-2 * time.Hour)},
+		{"root/a/journal/0000000000000250-0000000000000300-0000000000000000000000000000000000000444", now.Add(-2 * time.Hour)}, // Covered.
+		{"root/a/journal/0000000000000333-0000000000000444-0000000000000000000000000000000000000555", now.Add(-2 * time.Hour)},
+	}
+	for _, f := range fixtures {
+		var path = filepath.Join(tmpdir, filepath.FromSlash(f.path))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0700), gc.IsNil)
+		c.Assert(ioutil.WriteFile(path, []byte("data"), 0600), gc.IsNil)
+		c.Assert(os.Chtimes(path, f.mod, f.mod), gc.IsNil)
+	}
+
+	var ctx = context.Background()
+	var spec = pb.JournalSpec{
+		Name:     "a/journal",
+		Fragment: pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{"file:///root/"}},
+	}
+	var set, _ = WalkAllStores(ctx, spec.Name, spec.Fragment)
+
+	// Build an index of the journal, having a persisted begin of offset 0x222.
+	var index = []pb.Fragment{set[2].Fragment, set[3].Fragment, {
+		Journal: "a/journal",
+		Begin:   0x444,
+		End:     0x555,
+		Sum:     pb.SHA1Sum{Part1: 0x666},
+
+		CompressionCodec: pb.CompressionCodec_NONE, // Local, and not persisted.
+	}}
+	c.Check(index[0].Begin, gc.Equals, int64(0x222))
+
+	var expect = []pb.Fragment{set[0].Fragment}
+
+	var garbage []pb.Fragment
+	garbage, err = ListGarbage(ctx, spec, index, now.Add(-time.Hour))
+	c.Check(err, gc.IsNil)
+	c.Check(garbage, gc.DeepEquals, expect)
+
+	// Case: without persisted Fragments in the index, nothing is listed.
+	garbage, err = ListGarbage(ctx, spec, index[2:], now.Add(-time.Hour))
+	c.Check(err, gc.IsNil)
+	c.Check(garbage, gc.HasLen, 0)
+
+	// Case: a dry-run lists, but doesn't remove, Fragments.
+	garbage, err = CollectGarbage(ctx, spec, index, now.Add(-time.Hour), true)
+	c.Check(err, gc.IsNil)
+	c.Check(garbage, gc.DeepEquals, expect)
+	_, err = os.Stat(filepath.Join(tmpdir, filepath.FromSlash(fixtures[0].path)))
+	c.Check(err, gc.IsNil)
+
+	// Case: Fragments are removed.
+	garbage, err = CollectGarbage(ctx, spec, index, now.Add(-time.Hour), false)
+	c.Check(err, gc.IsNil)
+	c.Check(garbage, gc.DeepEquals, expect)
+	_, err = os.Stat(filepath.Join(tmpdir, filepath.FromSlash(fixtures[0].path)))
+	c.Check(os.IsNotExist(err), gc.Equals, true)
+
+	// Remaining Fragments are not garbage.
+	garbage, err = ListGarbage(ctx, spec, index, now.Add(-time.Hour))
+	c.Check(err, gc.IsNil)
+	c.Check(garbage, gc.HasLen, 0)
+}
+
+var _ = gc.Suite(&GCSuite{})
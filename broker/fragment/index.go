@@ -2,6 +2,7 @@ package fragment
 
 import (
 	"context"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -15,6 +16,25 @@ const (
 	offsetJumpAgeThreshold = 6 * time.Hour
 )
 
+var (
+	// DefaultSignatureTTL is the lifetime of Fragment URLs signed in response
+	// to ReadRequests which don't specify a SignatureTTL.
+	DefaultSignatureTTL = time.Minute
+	// MaxSignatureTTL bounds the lifetime of Fragment URLs signed in response
+	// to ReadRequests and AppendRequests. Requested SignatureTTLs which are
+	// larger are truncated.
+	MaxSignatureTTL = 24 * time.Hour
+	// MinRefreshInterval is a lower bound on the interval between refreshes of
+	// the Index from remote stores. JournalSpecs having a smaller RefreshInterval
+	// are refreshed at MinRefreshInterval instead. Zero imposes no bound.
+	MinRefreshInterval time.Duration
+	// RefreshJitter is the fraction, in [0, 1), by which each interval between
+	// refreshes of the Index from remote stores is randomly shortened or
+	// lengthened. Jitter keeps a fleet of brokers from synchronizing their
+	// store listings.
+	RefreshJitter = 0.1
+)
+
 // Index maintains a queryable index of local and remote journal Fragments.
 type Index struct {
 	ctx            context.Context // Context over the lifetime of the Index.
@@ -22,7 +42,8 @@ type Index struct {
 	local          CoverSet        // Local Fragments only (having non-nil File).
 	condCh         chan struct{}   // Condition variable; notifies blocked queries on each |set| update.
 	firstRefreshCh chan struct{}   // Closed when the first remote index load has completed.
-	mu             sync.RWMutex    // Guards |set| and |condCh|.
+	refreshTime    time.Time       // Time of the last remote index load.
+	mu             sync.RWMutex    // Guards |set|, |condCh|, and |refreshTime|.
 }
 
 // NewIndex returns a new, empty Index.
@@ -43,14 +64,25 @@ func (fi *Index) Query(ctx context.Context, req *pb.ReadRequest) (*pb.ReadRespon
 		Offset: req.Offset,
 	}
 
-	// Special handling for reads at the Journal Write head.
+	// Special handling for reads at the Journal Write head, or at a Fragment
+	// relative to it.
 	if resp.Offset == -1 {
 		resp.Offset = fi.set.EndOffset()
+
+		if k := int(req.FragmentsFromHead); k > len(fi.set) {
+			resp.Offset = fi.set.BeginOffset()
+		} else if k != 0 {
+			resp.Offset = fi.set[len(fi.set)-k].Begin
+		}
 	}
 
 	for {
 		var ind, found = fi.set.LongestOverlappingFragment(resp.Offset)
 
+		if req.ResolveFreshestIndex && !fi.refreshTime.IsZero() {
+			resp.IndexRefreshTime = fi.refreshTime.UnixNano()
+		}
+
 		var condCh = fi.condCh
 		var err error
 
@@ -77,8 +109,10 @@ func (fi *Index) Query(ctx context.Context, req *pb.ReadRequest) (*pb.ReadRespon
 			resp.Fragment = new(pb.Fragment)
 			*resp.Fragment = fi.set[ind].Fragment
 
-			if resp.Fragment.BackingStore != "" && resp.Fragment.ModTime != 0 {
-				resp.FragmentUrl, err = SignGetURL(*resp.Fragment, time.Minute)
+			// A filtered read isn't given a URL, as a client which read it
+			// directly would bypass the filter.
+			if resp.Fragment.BackingStore != "" && resp.Fragment.ModTime != 0 && req.Filter == nil {
+				resp.FragmentUrl, err = SignGetURL(*resp.Fragment, signatureTTL(req))
 			}
 			addTrace(ctx, "Index.Query(%s) => %s, localFile: %t", req, resp, fi.set[ind].File != nil)
 			return resp, fi.set[ind].File, err
@@ -113,6 +147,23 @@ func (fi *Index) Query(ctx context.Context, req *pb.ReadRequest) (*pb.ReadRespon
 	}
 }
 
+// signatureTTL returns the effective SignatureTTL of the ReadRequest.
+func signatureTTL(req *pb.ReadRequest) time.Duration {
+	if req.SignatureTTL == nil {
+		return DefaultSignatureTTL
+	}
+	return BoundSignatureTTL(*req.SignatureTTL)
+}
+
+// BoundSignatureTTL returns the requested SignatureTTL |ttl|, truncated to
+// MaxSignatureTTL.
+func BoundSignatureTTL(ttl time.Duration) time.Duration {
+	if ttl > MaxSignatureTTL {
+		return MaxSignatureTTL
+	}
+	return ttl
+}
+
 // EndOffset returns the last (largest) End offset in the index.
 func (fi *Index) EndOffset() int64 {
 	defer fi.mu.RUnlock()
@@ -155,6 +206,7 @@ func (fi *Index) ReplaceRemote(set CoverSet) {
 	}
 
 	fi.set = set
+	fi.refreshTime = timeNow()
 	fi.wakeBlockedQueries()
 
 	select {
@@ -196,6 +248,15 @@ func (fi *Index) WaitForFirstRemoteRefresh(ctx context.Context) error {
 	}
 }
 
+// LastRemoteRefresh returns the time at which ReplaceRemote was last called,
+// or the zero-valued time.Time if it hasn't been.
+func (fi *Index) LastRemoteRefresh() time.Time {
+	defer fi.mu.RUnlock()
+	fi.mu.RLock()
+
+	return fi.refreshTime
+}
+
 // Inspect will call |callback| with a CoverSet represeting a snapshot of all the fragments in the index.
 // While |callback| is executing there will be no changes to the fragment set of the index.
 func (fi *Index) Inspect(callback func(CoverSet) error) error {
@@ -204,13 +265,14 @@ func (fi *Index) Inspect(callback func(CoverSet) error) error {
 	return callback(fi.set)
 }
 
-// WalkAllStores enumerates Fragments from each of |stores| into the returned
-// CoverSet, or returns an encountered error.
-func WalkAllStores(ctx context.Context, name pb.Journal, stores []pb.FragmentStore) (CoverSet, error) {
+// WalkAllStores enumerates Fragments of the journal from each of the Stores
+// of |spec| into the returned CoverSet, or returns an encountered error.
+func WalkAllStores(ctx context.Context, name pb.Journal, spec pb.JournalSpec_Fragment) (CoverSet, error) {
 	var set CoverSet
+	var postfix = spec.PathPostfixPattern()
 
-	for _, store := range stores {
-		var err = List(ctx, store, name, func(f pb.Fragment) {
+	for _, store := range spec.Stores {
+		var err = List(ctx, store, name, postfix, func(f pb.Fragment) {
 			set, _ = set.Add(Fragment{Fragment: f})
 		})
 
@@ -221,6 +283,20 @@ func WalkAllStores(ctx context.Context, name pb.Journal, stores []pb.FragmentSto
 	return set, nil
 }
 
+// NextRefreshDelay returns the delay until the next refresh of the Index from
+// remote stores, given the configured RefreshInterval of its JournalSpec.
+// The delay is bounded by MinRefreshInterval, and then jittered by up to
+// RefreshJitter of its value. Only refreshes after the first are delayed:
+// the first refresh should happen immediately, as Append and Read RPCs
+// block until it completes (see WaitForFirstRemoteRefresh).
+func NextRefreshDelay(interval time.Duration) time.Duration {
+	if interval < MinRefreshInterval {
+		interval = MinRefreshInterval
+	}
+	var jitter = RefreshJitter * (2*rand.Float64() - 1)
+	return interval + time.Duration(jitter*float64(interval))
+}
+
 var timeNow = time.Now
 
 func addTrace(ctx context.Context, format string, args ...interface{}) {
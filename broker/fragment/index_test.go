@@ -97,6 +97,41 @@ func (s *IndexSuite) TestQueryAtHead(c *gc.C) {
 	})
 }
 
+func (s *IndexSuite) TestQueryAtFragmentsFromHead(c *gc.C) {
+	var ind = NewIndex(context.Background())
+
+	// Case: the index is empty. The read begins at the write head.
+	var resp, _, err = ind.Query(context.Background(),
+		&pb.ReadRequest{Offset: -1, FragmentsFromHead: 2, Block: false})
+	c.Check(resp, gc.DeepEquals, &pb.ReadResponse{
+		Status: pb.Status_OFFSET_NOT_YET_AVAILABLE,
+	})
+	c.Check(err, gc.IsNil)
+
+	ind.ReplaceRemote(buildSet(c, 100, 150, 150, 200))
+	ind.SpoolCommit(buildSet(c, 200, 250)[0])
+
+	for _, tc := range []struct {
+		k     int32
+		begin int64
+		end   int64
+	}{
+		{1, 200, 250}, // Last fragment, which ends at the write head.
+		{2, 150, 200},
+		{3, 100, 150},
+		{4, 100, 150}, // More fragments than exist: read from the first.
+	} {
+		resp, _, err = ind.Query(context.Background(),
+			&pb.ReadRequest{Offset: -1, FragmentsFromHead: tc.k, Block: false})
+		c.Check(resp, gc.DeepEquals, &pb.ReadResponse{
+			Offset:    tc.begin,
+			WriteHead: 250,
+			Fragment:  &pb.Fragment{Begin: tc.begin, End: tc.end},
+		})
+		c.Check(err, gc.IsNil)
+	}
+}
+
 func (s *IndexSuite) TestQueryAtMissingMiddle(c *gc.C) {
 	var ind = NewIndex(context.Background())
 	var baseTime = time.Unix(1500000000, 0)
@@ -208,16 +243,16 @@ func (s *IndexSuite) TestWalkStoresAndURLSigning(c *gc.C) {
 	var ind = NewIndex(ctx)
 	var set CoverSet
 
-	set, err = WalkAllStores(ctx, "a/journal", []pb.FragmentStore{
+	set, err = WalkAllStores(ctx, "a/journal", pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{
 		pb.FragmentStore("file:///path/does/not/exist/"),
-	})
+	}})
 	c.Check(err, gc.IsNil)
 	c.Check(set, gc.DeepEquals, CoverSet(nil))
 
 	// Gather fixture Fragments from "/root/one/" store.
-	set, err = WalkAllStores(ctx, "a/journal", []pb.FragmentStore{
+	set, err = WalkAllStores(ctx, "a/journal", pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{
 		pb.FragmentStore("file:///root/one/"),
-	})
+	}})
 	c.Check(err, gc.IsNil)
 	ind.ReplaceRemote(set)
 
@@ -233,10 +268,10 @@ func (s *IndexSuite) TestWalkStoresAndURLSigning(c *gc.C) {
 	c.Check(resp.FragmentUrl, gc.Equals,
 		"file:///root/one/a/journal/0000000000000222-0000000000000255-0000000000000000000000000000000000000333.sz")
 
-	set, err = WalkAllStores(ctx, "a/journal", []pb.FragmentStore{
+	set, err = WalkAllStores(ctx, "a/journal", pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{
 		pb.FragmentStore("file:///root/one/"),
 		pb.FragmentStore("file:///root/two/"),
-	})
+	}})
 	c.Check(err, gc.IsNil)
 	ind.ReplaceRemote(set)
 
@@ -250,6 +285,82 @@ func (s *IndexSuite) TestWalkStoresAndURLSigning(c *gc.C) {
 		"file:///root/two/a/journal/0000000000000222-0000000000000333-0000000000000000000000000000000000000444.gz")
 }
 
+func (s *IndexSuite) TestWalkStoresOfPathTemplate(c *gc.C) {
+	var tmpdir, err = ioutil.TempDir("", "IndexSuite.TestWalkStoresOfPathTemplate")
+	c.Assert(err, gc.IsNil)
+
+	defer func() { os.RemoveAll(tmpdir) }()
+	defer func(s string) { FileSystemStoreRoot = s }(FileSystemStoreRoot)
+	FileSystemStoreRoot = tmpdir
+
+	var paths = []string{
+		// Fragment of the flat layout, written before the template was applied.
+		"root/a/journal/0000000000000000-0000000000000111-0000000000000000000000000000000000000111",
+		"root/a/journal/2019/03/0000000000000111-0000000000000222-0000000000000000000000000000000000000222.raw",
+		"root/a/journal/2019/04/0000000000000222-0000000000000333-0000000000000000000000000000000000000333.sz",
+		// Not listed: directories of an unexpected depth.
+		"root/a/journal/2019/0000000000000333-0000000000000444-0000000000000000000000000000000000000444",
+		"root/a/journal/nested/journal/02/0000000000000444-0000000000000555-0000000000000000000000000000000000000555",
+		// Not listed: fragments of nested journals "a/journal/nested/journal"
+		// and "a/journal/nested", which don't match the template's shape.
+		"root/a/journal/nested/journal/0000000000000555-0000000000000666-0000000000000000000000000000000000000666",
+		"root/a/journal/nested/0000000000000666-0000000000000777-0000000000000000000000000000000000000777",
+		// Not listed: directories not of the template's shape.
+		"root/a/journal/2019/3/0000000000000777-0000000000000888-0000000000000000000000000000000000000888",
+	}
+	for _, path := range paths {
+		path = filepath.Join(tmpdir, filepath.FromSlash(path))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0700), gc.IsNil)
+		c.Assert(ioutil.WriteFile(path, []byte("data"), 0600), gc.IsNil)
+	}
+
+	set, err := WalkAllStores(context.Background(), "a/journal", pb.JournalSpec_Fragment{
+		Stores:       []pb.FragmentStore{"file:///root/"},
+		PathTemplate: "{{.Journal}}/{{.Year}}/{{.Month}}/",
+	})
+	c.Check(err, gc.IsNil)
+	c.Assert(set, gc.HasLen, 3)
+
+	c.Check(set[0].PathPostfix, gc.Equals, "")
+	c.Check(set[1].PathPostfix, gc.Equals, "2019/03/")
+	c.Check(set[2].PathPostfix, gc.Equals, "2019/04/")
+	c.Check(set[2].ContentPath(), gc.Equals, "a/journal/2019/04/"+
+		"0000000000000222-0000000000000333-0000000000000000000000000000000000000333.sz")
+
+	// A template of a single directory lists the depth-one Fragment,
+	// but not that of the nested journal "a/journal/nested".
+	set, err = WalkAllStores(context.Background(), "a/journal", pb.JournalSpec_Fragment{
+		Stores:       []pb.FragmentStore{"file:///root/"},
+		PathTemplate: "{{.Journal}}/{{.Year}}/",
+	})
+	c.Check(err, gc.IsNil)
+	c.Assert(set, gc.HasLen, 2)
+
+	c.Check(set[0].PathPostfix, gc.Equals, "")
+	c.Check(set[1].PathPostfix, gc.Equals, "2019/")
+	c.Check(set[1].Begin, gc.Equals, int64(0x333))
+
+	// The nested journal lists only its own Fragment.
+	set, err = WalkAllStores(context.Background(), "a/journal/nested", pb.JournalSpec_Fragment{
+		Stores:       []pb.FragmentStore{"file:///root/"},
+		PathTemplate: "{{.Journal}}/{{.Year}}/",
+	})
+	c.Check(err, gc.IsNil)
+	c.Assert(set, gc.HasLen, 1)
+	c.Check(set[0].Begin, gc.Equals, int64(0x666))
+}
+
+func (s *IndexSuite) TestSignatureTTLBounds(c *gc.C) {
+	var ttl = 5 * time.Minute
+
+	c.Check(signatureTTL(&pb.ReadRequest{}), gc.Equals, DefaultSignatureTTL)
+	c.Check(signatureTTL(&pb.ReadRequest{SignatureTTL: &ttl}), gc.Equals, ttl)
+
+	ttl = MaxSignatureTTL + time.Hour
+	c.Check(signatureTTL(&pb.ReadRequest{SignatureTTL: &ttl}), gc.Equals, MaxSignatureTTL)
+	c.Check(BoundSignatureTTL(ttl), gc.Equals, MaxSignatureTTL)
+}
+
 func buildSet(c *gc.C, offsets ...int64) CoverSet {
 	var set CoverSet
 	var ok bool
@@ -265,4 +376,30 @@ func buildSet(c *gc.C, offsets ...int64) CoverSet {
 	return set
 }
 
+func (s *IndexSuite) TestNextRefreshDelay(c *gc.C) {
+	defer func(d time.Duration, j float64) {
+		MinRefreshInterval, RefreshJitter = d, j
+	}(MinRefreshInterval, RefreshJitter)
+
+	// Without jitter, the interval is used as-is.
+	MinRefreshInterval, RefreshJitter = 0, 0
+	c.Check(NextRefreshDelay(time.Minute), gc.Equals, time.Minute)
+
+	// Intervals smaller than MinRefreshInterval are raised to it.
+	MinRefreshInterval = 5 * time.Minute
+	c.Check(NextRefreshDelay(time.Minute), gc.Equals, 5*time.Minute)
+	c.Check(NextRefreshDelay(time.Hour), gc.Equals, time.Hour)
+
+	// Delays are jittered within the fraction of the bounded interval.
+	RefreshJitter = 0.25
+	var distinct = make(map[time.Duration]struct{})
+
+	for i := 0; i != 100; i++ {
+		var d = NextRefreshDelay(time.Minute)
+		c.Check(d >= 225*time.Second && d <= 375*time.Second, gc.Equals, true)
+		distinct[d] = struct{}{}
+	}
+	c.Check(len(distinct) > 1, gc.Equals, true)
+}
+
 var _ = gc.Suite(&IndexSuite{})
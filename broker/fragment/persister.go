@@ -2,6 +2,10 @@ package fragment
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -9,12 +13,18 @@ import (
 	"go.gazette.dev/core/allocator"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/keyspace"
+	"go.gazette.dev/core/metrics"
 )
 
 const (
 	persistInterval = time.Minute
 )
 
+// MaxPausedBacklog is the maximum number of content bytes of completed Spools
+// which may queue while persistence is paused. Once exceeded, queued Spools
+// are persisted despite the pause, to bound local disk usage. Zero is unlimited.
+var MaxPausedBacklog int64 = 1 << 33 // 8GiB.
+
 type Persister struct {
 	qA, qB, qC []Spool
 	mu         sync.Mutex
@@ -22,6 +32,10 @@ type Persister struct {
 	ks         *keyspace.KeySpace
 	ticker     *time.Ticker
 	persistFn  func(ctx context.Context, spool Spool) error
+
+	paused       map[pb.Journal]struct{} // Paused journals, or "" if all are paused.
+	queuedBytes  int64                   // Content bytes of queued Spools.
+	queuedSpools int                     // Number of queued Spools.
 }
 
 // NewPersister returns an empty, initialized Persister.
@@ -48,11 +62,128 @@ func (p *Persister) Finish() {
 	<-p.doneCh
 }
 
+// Pause persistence of completed Spools of |journal|, or of all journals if
+// |journal| is empty. Spools of paused journals are queued until resumed,
+// and appends to those journals continue to be served. Pauses are lifted by
+// Finish, so that queued Spools are persisted before the Persister exits.
+func (p *Persister) Pause(journal pb.Journal) {
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	if p.paused == nil {
+		p.paused = make(map[pb.Journal]struct{})
+	}
+	p.paused[journal] = struct{}{}
+}
+
+// Resume persistence of |journal|, or of all journals if |journal| is empty.
+// Resuming all journals also clears pauses of individual journals. Queued
+// Spools of the resumed journal(s) are persisted as the queue is processed.
+func (p *Persister) Resume(journal pb.Journal) {
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	if journal == "" {
+		p.paused = nil
+	} else {
+		delete(p.paused, journal)
+	}
+}
+
+// isPaused returns whether persistence of |journal| is paused. A pause
+// is ignored if the queued backlog is larger than MaxPausedBacklog.
+func (p *Persister) isPaused(journal pb.Journal) bool {
+	defer p.mu.Unlock()
+	p.mu.Lock()
+
+	var _, all = p.paused[""]
+	var _, one = p.paused[journal]
+
+	if !all && !one {
+		return false
+	} else if MaxPausedBacklog != 0 && p.queuedBytes > MaxPausedBacklog {
+		log.WithFields(log.Fields{
+			"journal":     journal,
+			"queuedBytes": p.queuedBytes,
+			"maxBacklog":  MaxPausedBacklog,
+		}).Warn("persisting Spool of paused journal (backlog exceeds MaxPausedBacklog)")
+		return false
+	}
+	return true
+}
+
+// ServeHTTP serves the status of the Persister as JSON. A POST having form
+// values "action" of "pause" or "resume", and an optional "journal", pauses
+// or resumes persistence of the journal (or all journals, if not provided).
+// Requests aren't authorized: see broker.PersisterDebugHandler.
+func (p *Persister) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		var journal = pb.Journal(r.FormValue("journal"))
+
+		if journal != "" {
+			if err := journal.Validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		switch action := r.FormValue("action"); action {
+		case "pause":
+			p.Pause(journal)
+		case "resume":
+			p.Resume(journal)
+		default:
+			http.Error(w, fmt.Sprintf("invalid action %q (expected pause or resume)", action),
+				http.StatusBadRequest)
+			return
+		}
+		log.WithFields(log.Fields{
+			"action":  r.FormValue("action"),
+			"journal": journal,
+		}).Info("updated persister pause")
+	} else if r.Method != http.MethodGet {
+		http.Error(w, "expected GET or POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var status struct {
+		Paused       []pb.Journal
+		QueuedBytes  int64
+		QueuedSpools int
+	}
+	p.mu.Lock()
+	for journal := range p.paused {
+		status.Paused = append(status.Paused, journal)
+	}
+	status.QueuedBytes, status.QueuedSpools = p.queuedBytes, p.queuedSpools
+	p.mu.Unlock()
+
+	sort.Slice(status.Paused, func(i, j int) bool { return status.Paused[i] < status.Paused[j] })
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
 func (p *Persister) queue(spool Spool) {
 	defer p.mu.Unlock()
 	p.mu.Lock()
 
 	p.qC = append(p.qC, spool)
+	p.updateQueued()
+}
+
+// updateQueued updates queue accounting and metrics from current queues.
+// Precondition: |p.mu| is held.
+func (p *Persister) updateQueued() {
+	p.queuedBytes, p.queuedSpools = 0, 0
+
+	for _, q := range [][]Spool{p.qA, p.qB, p.qC} {
+		for _, spool := range q {
+			p.queuedBytes += spool.ContentLength()
+		}
+		p.queuedSpools += len(q)
+	}
+	metrics.PersisterQueuedBytes.Set(float64(p.queuedBytes))
+	metrics.PersisterQueuedSpools.Set(float64(p.queuedSpools))
 }
 
 func (p *Persister) Serve() {
@@ -66,6 +197,7 @@ func (p *Persister) Serve() {
 			case <-p.doneCh:
 				exiting = true
 				p.ticker.Stop()
+				p.Resume("") // Persist all queued Spools before exiting.
 			}
 		}
 
@@ -76,6 +208,7 @@ func (p *Persister) Serve() {
 		// Rotate queues.
 		p.mu.Lock()
 		p.qA, p.qB, p.qC = p.qB, p.qC, p.qA[:0]
+		p.updateQueued()
 
 		if exiting && len(p.qA) == 0 && len(p.qB) == 0 {
 			done = true
@@ -91,6 +224,9 @@ func (p *Persister) attemptPersist(spool Spool) {
 	if spool.ContentLength() == 0 {
 		// Persisting an empty Spool is a no-op.
 		return
+	} else if p.isPaused(spool.Journal) {
+		p.queue(spool) // Retain until persistence is resumed.
+		return
 	}
 	// Attach the current BackingStore of the Fragment's JournalSpec.
 	p.ks.Mu.RLock()
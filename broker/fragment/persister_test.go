@@ -2,7 +2,11 @@ package fragment
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"time"
 
@@ -198,6 +202,109 @@ func (p *PersisterSuite) TestServeUpdateBackingStore(c *gc.C) {
 	persister.mu.Unlock()
 }
 
+func (p *PersisterSuite) TestPauseAndResume(c *gc.C) {
+	var specFixture = &pb.JournalSpec{
+		Fragment: pb.JournalSpec_Fragment{
+			Stores: []pb.FragmentStore{"file:///root/"},
+		},
+	}
+	var ks = keyspace.NewKeySpace("/journals", func(kv *mvccpb.KeyValue) (interface{}, error) {
+		return allocator.Item{
+			ID:        "journal-1",
+			ItemValue: specFixture,
+		}, nil
+	})
+	var client, ctx = etcdtest.TestClient(), context.Background()
+	defer etcdtest.Cleanup()
+	var _, err = client.Put(ctx, "/journals/items/journal-1", "")
+	c.Assert(err, gc.IsNil)
+	c.Check(ks.Load(ctx, client, 0), gc.IsNil)
+
+	var persisted int
+	var timeChan = make(chan time.Time)
+	var persister = NewPersister(ks)
+	persister.ticker = &time.Ticker{C: timeChan}
+	persister.persistFn = func(ctx context.Context, spool Spool) error {
+		persisted++
+		return nil
+	}
+
+	var obv testSpoolObserver
+	var spool = NewSpool("journal-1", &obv)
+	spool.BackingStore = pb.FragmentStore("file:///root/")
+	applyAndCommit(&spool, "file:///root/")
+
+	var status = func() (out struct {
+		Paused       []pb.Journal
+		QueuedBytes  int64
+		QueuedSpools int
+	}) {
+		var w = httptest.NewRecorder()
+		persister.ServeHTTP(w, httptest.NewRequest("GET", "/debug/persister", nil))
+		c.Check(w.Code, gc.Equals, http.StatusOK)
+		c.Check(json.NewDecoder(w.Body).Decode(&out), gc.IsNil)
+		return
+	}
+	var post = func(form string) int {
+		var req = httptest.NewRequest("POST", "/debug/persister", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var w = httptest.NewRecorder()
+		persister.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Pause the journal, and complete a Spool. Expect it's queued, not persisted.
+	c.Check(post("action=pause&journal=journal-1"), gc.Equals, http.StatusOK)
+	persister.attemptPersist(spool)
+
+	var st = status()
+	c.Check(st.Paused, gc.DeepEquals, []pb.Journal{"journal-1"})
+	c.Check(st.QueuedBytes, gc.Equals, int64(12))
+	c.Check(st.QueuedSpools, gc.Equals, 1)
+
+	// Spools remain queued as the Persister runs.
+	go persister.Serve()
+	for i := 0; i != 4; i++ {
+		timeChan <- time.Time{}
+	}
+	c.Check(status().QueuedSpools, gc.Equals, 1)
+
+	// Resume, and expect the queued Spool is persisted.
+	// The Spool reaches the head of the queue within three ticks, and the
+	// fourth tick synchronizes with completion of the third.
+	c.Check(post("action=resume&journal=journal-1"), gc.Equals, http.StatusOK)
+	for i := 0; i != 4; i++ {
+		timeChan <- time.Time{}
+	}
+	st = status()
+	c.Check(st.Paused, gc.HasLen, 0)
+	c.Check(st.QueuedSpools, gc.Equals, 0)
+	c.Check(persisted, gc.Equals, 1)
+
+	// Pause all journals. Expect the pause is ignored once the backlog exceeds
+	// MaxPausedBacklog.
+	defer func(m int64) { MaxPausedBacklog = m }(MaxPausedBacklog)
+	MaxPausedBacklog = 1
+
+	c.Check(post("action=pause"), gc.Equals, http.StatusOK)
+	c.Check(status().Paused, gc.DeepEquals, []pb.Journal{""})
+
+	persister.attemptPersist(spool) // Queued.
+	persister.attemptPersist(spool) // Persisted.
+	c.Check(status().QueuedSpools, gc.Equals, 1)
+	c.Check(persisted, gc.Equals, 2)
+
+	// Finish lifts the pause, and persists the remaining Spool.
+	persister.Finish()
+	c.Check(status().QueuedSpools, gc.Equals, 0)
+	c.Check(persisted, gc.Equals, 3)
+
+	// Case: invalid requests are rejected.
+	c.Check(post("action=other"), gc.Equals, http.StatusBadRequest)
+	c.Check(post("action=pause&journal=invalid journal"), gc.Equals, http.StatusBadRequest)
+}
+
 func applyAndCommit(spool *Spool, store string) {
 	spool.applyContent(&pb.ReplicateRequest{
 		Content:      []byte("some content"),
@@ -39,6 +39,19 @@ type Spool struct {
 	observer SpoolObserver
 }
 
+// SpoolCodecOptions configure the compression of Spool content across all
+// journals of the broker. Typically it's set once, at broker startup.
+var SpoolCodecOptions codecs.CodecOptions
+
+// VerifySpoolCommits enables re-verification of spooled content prior to each
+// commit. If set, bytes spooled since the last commit are read back from the
+// Spool file and summed, and the commit fails if the result doesn't match the
+// Sum computed as content was received. This guards against corruption of
+// content between its receipt and its spooling (eg, due to faulty memory or
+// disk), at the cost of an extra read of each committed byte. Typically it's
+// set once, at broker startup.
+var VerifySpoolCommits = false
+
 // SpoolObserver is notified of important events in the Spool lifecycle.
 type SpoolObserver interface {
 	// SpoolCommit is called when the Spool Fragment is extended.
@@ -63,6 +76,11 @@ func NewSpool(journal pb.Journal, observer SpoolObserver) Spool {
 // Apply the ReplicateRequest to the Spool, returning any encountered error.
 func (s *Spool) Apply(r *pb.ReplicateRequest, primary bool) (pb.ReplicateResponse, error) {
 	if r.Proposal != nil {
+		if VerifySpoolCommits {
+			if err := s.verifyCommit(r.Proposal); err != nil {
+				return pb.ReplicateResponse{}, err
+			}
+		}
 		return s.applyCommit(r, primary), nil
 	} else {
 		return pb.ReplicateResponse{}, s.applyContent(r)
@@ -130,6 +148,8 @@ func (s *Spool) applyCommit(r *pb.ReplicateRequest, primary bool) pb.ReplicateRe
 					Begin:            r.Proposal.End,
 					End:              r.Proposal.End,
 					CompressionCodec: r.Proposal.CompressionCodec,
+					ContentType:      r.Proposal.ContentType,
+					PathPostfix:      r.Proposal.PathPostfix,
 				},
 			},
 			summer:   sha1.New(),
@@ -175,6 +195,33 @@ func (s *Spool) applyCommit(r *pb.ReplicateRequest, primary bool) pb.ReplicateRe
 	}
 }
 
+// verifyCommit returns an error if |proposal| would commit content spooled
+// since the last commit, and the Sum of that content as read back from the
+// Spool file doesn't match |proposal|'s Sum.
+func (s *Spool) verifyCommit(proposal *pb.Fragment) error {
+	if s.delta == 0 || s.Next() != *proposal {
+		return nil // |proposal| doesn't commit spooled content.
+	}
+	var summer = sha1.New()
+	if err := summer.(encoding.BinaryUnmarshaler).UnmarshalBinary(s.sumState); err != nil {
+		panic(err.Error()) // Cannot fail.
+	}
+
+	var buf = bufferPool.Get().([]byte)
+	defer bufferPool.Put(buf)
+
+	var section = io.NewSectionReader(s.Fragment.File, s.ContentLength(), s.delta)
+	if _, err := io.CopyBuffer(summer, section, buf); err != nil {
+		return fmt.Errorf("reading spool content for verification: %s", err)
+	}
+
+	if sum := pb.SHA1SumFromDigest(summer.Sum(nil)); sum != proposal.Sum {
+		return fmt.Errorf("spool verification failed (spooled content has Sum %x, expected %x)",
+			sum.ToDigest(), proposal.Sum.ToDigest())
+	}
+	return nil
+}
+
 func (s *Spool) applyContent(r *pb.ReplicateRequest) error {
 	if r.ContentDelta != s.delta {
 		return pb.NewValidationError("invalid ContentDelta (%d; expected %d)", r.ContentDelta, s.delta)
@@ -255,7 +302,7 @@ func (s *Spool) compressThrough(end int64) {
 			err = fmt.Errorf("seeking compressedFile to start: %s", err)
 			continue
 		}
-		if s.compressor, err = codecs.NewCodecWriter(s.compressedFile, s.CompressionCodec); err != nil {
+		if s.compressor, err = codecs.NewCodecWriterWithOptions(s.compressedFile, s.CompressionCodec, SpoolCodecOptions); err != nil {
 			err = fmt.Errorf("initializing compressor: %s", err)
 			continue
 		}
@@ -290,6 +290,57 @@ func (s *SpoolSuite) TestContentDeltaMismatch(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `invalid ContentDelta \(2; expected 3\)`)
 }
 
+func (s *SpoolSuite) TestVerifiedCommits(c *gc.C) {
+	defer func(v bool) { VerifySpoolCommits = v }(VerifySpoolCommits)
+	VerifySpoolCommits = true
+
+	var obv testSpoolObserver
+	var spool = NewSpool("a/journal", &obv)
+
+	var apply = func(content string) {
+		var _, err = spool.Apply(&pb.ReplicateRequest{
+			Content:      []byte(content),
+			ContentDelta: spool.delta,
+		}, true)
+		c.Check(err, gc.IsNil)
+	}
+	var proposal = func(end int64, content string) *pb.Fragment {
+		return &pb.Fragment{
+			Journal:          "a/journal",
+			End:              end,
+			Sum:              pb.SHA1SumOf(content),
+			CompressionCodec: pb.CompressionCodec_NONE,
+		}
+	}
+
+	// Case: intact content verifies and commits.
+	apply("some ")
+	apply("content")
+
+	var resp, err = spool.Apply(&pb.ReplicateRequest{Proposal: proposal(12, "some content")}, true)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+
+	// Case: content spooled after the last commit is corrupted.
+	apply(" and more")
+	_, err = spool.Fragment.File.WriteAt([]byte("M"), 17)
+	c.Check(err, gc.IsNil)
+
+	_, err = spool.Apply(&pb.ReplicateRequest{Proposal: proposal(21, "some content and more")}, true)
+	c.Check(err, gc.ErrorMatches, `spool verification failed \(spooled content has Sum [0-9a-f]+, expected [0-9a-f]+\)`)
+	c.Check(spool.Fragment.End, gc.Equals, int64(12)) // Not committed.
+	c.Check(obv.commits, gc.HasLen, 1)
+
+	// Rolling back the spooled content, and re-applying it, allows a commit.
+	spool.MustApply(&pb.ReplicateRequest{Proposal: &spool.Fragment.Fragment})
+	apply(" and more")
+
+	resp, err = spool.Apply(&pb.ReplicateRequest{Proposal: proposal(21, "some content and more")}, true)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pb.Status_OK)
+	c.Check(obv.commits, gc.HasLen, 2)
+}
+
 func (s *SpoolSuite) TestFileErrorRetries(c *gc.C) {
 	var obv testSpoolObserver
 	var spool = NewSpool("a/journal", &obv)
@@ -0,0 +1,439 @@
+package fragment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/keepalive"
+)
+
+type azureCfg struct {
+	container string
+	prefix    string
+
+	rewriterCfg
+
+	// Endpoint of the Blob service, including any path component (eg, an
+	// emulator endpoint such as "http://127.0.0.1:10000/devstoreaccount1").
+	// If empty, the public Blob service of the storage account is used.
+	Endpoint string
+}
+
+// azureBackend is a backend using the Azure Blob Storage REST API, which is
+// authorized by the Shared Key of a storage account. The account name and key
+// are read from the AZURE_ACCOUNT_NAME and AZURE_ACCOUNT_KEY environment
+// variables, respectively.
+type azureBackend struct {
+	client  *http.Client
+	account string
+	key     []byte
+	mu      sync.Mutex
+}
+
+func (a *azureBackend) Provider() string {
+	return "azure"
+}
+
+func (a *azureBackend) SignGet(ep *url.URL, fragment pb.Fragment, d time.Duration) (string, error) {
+	var cfg, err = a.azureClient(ep)
+	if err != nil {
+		return "", err
+	}
+	var blob = cfg.rewritePath(cfg.prefix, fragment.ContentPath())
+	var expiry = time.Now().Add(d).UTC().Format(azureSASTimeFormat)
+
+	// Build a read-only service SAS of the blob. See:
+	//  https://docs.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+	var toSign = strings.Join([]string{
+		"r",    // Signed permissions.
+		"",     // Signed start.
+		expiry, // Signed expiry.
+		"/blob/" + a.account + "/" + cfg.container + "/" + blob,
+		"",                 // Signed identifier.
+		"",                 // Signed IP.
+		"",                 // Signed protocol.
+		azureAPIVersion,    // Signed version.
+		"b",                // Signed resource (a blob).
+		"",                 // Signed snapshot time.
+		"", "", "", "", "", // Response header overrides (rscc, rscd, rsce, rscl, rsct).
+	}, "\n")
+
+	var u = a.blobURL(cfg, blob)
+	u.RawQuery = url.Values{
+		"sv":  {azureAPIVersion},
+		"sr":  {"b"},
+		"sp":  {"r"},
+		"se":  {expiry},
+		"sig": {a.sign(toSign)},
+	}.Encode()
+
+	return u.String(), nil
+}
+
+func (a *azureBackend) Exists(ctx context.Context, ep *url.URL, fragment pb.Fragment) (bool, error) {
+	var cfg, err = a.azureClient(ep)
+	if err != nil {
+		return false, err
+	}
+	var resp *http.Response
+	resp, err = a.do(ctx, "HEAD", a.blobURL(cfg, cfg.rewritePath(cfg.prefix, fragment.ContentPath())), nil, nil, 0)
+
+	if err == nil {
+		resp.Body.Close()
+		return true, nil
+	} else if azErr, ok := err.(*azureError); ok && azErr.status == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+func (a *azureBackend) Open(ctx context.Context, ep *url.URL, fragment pb.Fragment) (io.ReadCloser, error) {
+	var cfg, err = a.azureClient(ep)
+	if err != nil {
+		return nil, err
+	}
+	var resp *http.Response
+	if resp, err = a.do(ctx, "GET", a.blobURL(cfg, cfg.rewritePath(cfg.prefix, fragment.ContentPath())), nil, nil, 0); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (a *azureBackend) Persist(ctx context.Context, ep *url.URL, spool Spool) error {
+	var cfg, err = a.azureClient(ep)
+	if err != nil {
+		return err
+	}
+	var u = a.blobURL(cfg, cfg.rewritePath(cfg.prefix, spool.ContentPath()))
+
+	var content *io.SectionReader
+	if spool.CompressionCodec != pb.CompressionCodec_NONE {
+		content = io.NewSectionReader(spool.compressedFile, 0, spool.compressedLength)
+	} else {
+		content = io.NewSectionReader(spool.File, 0, spool.ContentLength())
+	}
+
+	var hdr = http.Header{}
+	if spool.CompressionCodec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
+		hdr.Set("x-ms-blob-content-encoding", "gzip")
+	}
+
+	// Content within the maximum size of a single Put Blob is uploaded directly.
+	if content.Size() <= azureMaxPutBlobSize {
+		hdr.Set("x-ms-blob-type", "BlockBlob")
+		return a.doAndClose(ctx, "PUT", u, hdr, content, content.Size())
+	}
+
+	// Otherwise, upload content as a sequence of blocks, and then commit the blob.
+	var blocks bytes.Buffer
+	blocks.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+
+	for i, offset := 0, int64(0); offset < content.Size(); i, offset = i+1, offset+azureBlockSize {
+		var id = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", i)))
+		var size = content.Size() - offset
+		if size > azureBlockSize {
+			size = azureBlockSize
+		}
+
+		var bu = *u
+		bu.RawQuery = url.Values{"comp": {"block"}, "blockid": {id}}.Encode()
+
+		if err = a.doAndClose(ctx, "PUT", &bu, nil, io.NewSectionReader(content, offset, size), size); err != nil {
+			return err
+		}
+		fmt.Fprintf(&blocks, "<Latest>%s</Latest>", id)
+	}
+	blocks.WriteString("</BlockList>")
+
+	u.RawQuery = url.Values{"comp": {"blocklist"}}.Encode()
+	hdr.Set("Content-Type", "application/xml")
+
+	return a.doAndClose(ctx, "PUT", u, hdr, &blocks, int64(blocks.Len()))
+}
+
+func (a *azureBackend) List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, postfix *regexp.Regexp, callback func(pb.Fragment)) error {
+	var cfg, err = a.azureClient(ep)
+	if err != nil {
+		return err
+	}
+	var (
+		prefix = cfg.rewritePath(cfg.prefix, name.String()) + "/"
+		u      = a.blobURL(cfg, "")
+		marker string
+	)
+
+	for {
+		var q = url.Values{
+			"restype": {"container"},
+			"comp":    {"list"},
+			"prefix":  {prefix},
+		}
+		if postfix == nil {
+			// Fragment files of the flat layout are directly within the
+			// journal's directory. Providing a delimiter collapses files of
+			// subdirectories into BlobPrefix entries, which are ignored.
+			q.Set("delimiter", "/")
+		}
+		if marker != "" {
+			q.Set("marker", marker)
+		}
+		u.RawQuery = q.Encode()
+
+		var resp *http.Response
+		if resp, err = a.do(ctx, "GET", u, nil, nil, 0); err != nil {
+			return err
+		}
+		var result azureListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+
+		if err != nil {
+			return fmt.Errorf("decoding blob listing: %s", err)
+		}
+
+		for _, blob := range result.Blobs {
+			if frag, ok, err := parseListedPath(name, prefix, blob.Name, postfix); !ok {
+				// Not a fragment of this journal.
+			} else if err != nil {
+				log.WithFields(log.Fields{"container": cfg.container, "name": blob.Name, "err": err}).Warning("parsing fragment")
+			} else if blob.ContentLength == 0 && frag.ContentLength() > 0 {
+				log.WithFields(log.Fields{"container": cfg.container, "name": blob.Name}).Warning("zero-length fragment")
+			} else if modTime, err := time.Parse(time.RFC1123, blob.LastModified); err != nil {
+				log.WithFields(log.Fields{"container": cfg.container, "name": blob.Name, "err": err}).Warning("parsing Last-Modified")
+			} else {
+				frag.ModTime = modTime.Unix()
+				frag.BackingStore = store
+				callback(frag)
+			}
+		}
+
+		if marker = result.NextMarker; marker == "" {
+			return nil
+		}
+	}
+}
+
+func (a *azureBackend) Remove(ctx context.Context, fragment pb.Fragment) error {
+	var cfg, err = a.azureClient(fragment.BackingStore.URL())
+	if err != nil {
+		return err
+	}
+	return a.doAndClose(ctx, "DELETE", a.blobURL(cfg, cfg.rewritePath(cfg.prefix, fragment.ContentPath())), nil, nil, 0)
+}
+
+func (a *azureBackend) azureClient(ep *url.URL) (cfg azureCfg, err error) {
+	if err = parseStoreArgs(ep, &cfg); err != nil {
+		return
+	}
+	// Omit leading slash from container prefix. Note that FragmentStore already
+	// enforces that URL Paths end in '/'.
+	cfg.container, cfg.prefix = ep.Host, ep.Path[1:]
+
+	if cfg.Endpoint != "" {
+		if _, err = url.Parse(cfg.Endpoint); err != nil {
+			err = fmt.Errorf("parsing Endpoint: %s", err)
+			return
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.client != nil {
+		return
+	}
+
+	var account, key = os.Getenv("AZURE_ACCOUNT_NAME"), os.Getenv("AZURE_ACCOUNT_KEY")
+	if account == "" || key == "" {
+		err = fmt.Errorf("use of Azure requires that AZURE_ACCOUNT_NAME and AZURE_ACCOUNT_KEY be set")
+		return
+	}
+	if a.key, err = base64.StdEncoding.DecodeString(key); err != nil {
+		err = fmt.Errorf("decoding AZURE_ACCOUNT_KEY: %s", err)
+		return
+	}
+	a.account = account
+
+	// Override the default http.Transport's behavior of inserting
+	// "Accept-Encoding: gzip" and transparently decompressing client-side.
+	a.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext:        keepalive.Dialer.DialContext,
+			DisableCompression: true,
+		},
+	}
+
+	log.WithFields(log.Fields{
+		"account":  account,
+		"endpoint": cfg.Endpoint,
+	}).Info("constructed new Azure client")
+
+	return
+}
+
+// blobURL returns the URL of |blob| within the configured container.
+func (a *azureBackend) blobURL(cfg azureCfg, blob string) *url.URL {
+	var base = cfg.Endpoint
+	if base == "" {
+		base = "https://" + a.account + ".blob.core.windows.net"
+	}
+	var u, _ = url.Parse(strings.TrimSuffix(base, "/")) // Validated by azureClient.
+	u.Path = u.Path + "/" + cfg.container
+	if blob != "" {
+		u.Path += "/" + blob
+	}
+	return u
+}
+
+// doAndClose is do, which also closes a successful response.
+func (a *azureBackend) doAndClose(ctx context.Context, method string, u *url.URL, hdr http.Header, body io.Reader, length int64) error {
+	var resp, err = a.do(ctx, method, u, hdr, body, length)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+// do issues an authorized request of the Blob service. A response status
+// other than 2xx is mapped into an *azureError.
+func (a *azureBackend) do(ctx context.Context, method string, u *url.URL, hdr http.Header, body io.Reader, length int64) (*http.Response, error) {
+	var req, err = http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.ContentLength = length
+
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("Authorization", "SharedKey "+a.account+":"+a.sign(a.stringToSign(req)))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	var msg, _ = ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+
+	return nil, &azureError{
+		status:  resp.StatusCode,
+		code:    resp.Header.Get("x-ms-error-code"),
+		message: string(msg),
+	}
+}
+
+// stringToSign returns the Shared Key string-to-sign of |req|. For details, see
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (a *azureBackend) stringToSign(req *http.Request) string {
+	var contentLength string
+	if req.ContentLength != 0 {
+		contentLength = fmt.Sprint(req.ContentLength)
+	}
+
+	var b strings.Builder
+	for _, s := range []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date (use of x-ms-date is required).
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	} {
+		b.WriteString(s)
+		b.WriteByte('\n')
+	}
+
+	// Canonicalized headers.
+	var names []string
+	for name := range req.Header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+
+	// Canonicalized resource.
+	b.WriteString("/" + a.account + req.URL.EscapedPath())
+
+	var query = req.URL.Query()
+	names = names[:0]
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var values = append([]string(nil), query[name]...)
+		sort.Strings(values)
+		fmt.Fprintf(&b, "\n%s:%s", strings.ToLower(name), strings.Join(values, ","))
+	}
+	return b.String()
+}
+
+// sign returns the base64 HMAC-SHA256 of |s| under the account key.
+func (a *azureBackend) sign(s string) string {
+	var mac = hmac.New(sha256.New, a.key)
+	_, _ = mac.Write([]byte(s))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+type azureError struct {
+	status        int
+	code, message string
+}
+
+func (e *azureError) Error() string {
+	return fmt.Sprintf("azure: %d %s: %s", e.status, e.code, e.message)
+}
+
+type azureListResult struct {
+	Blobs []struct {
+		Name          string `xml:"Name"`
+		LastModified  string `xml:"Properties>Last-Modified"`
+		ContentLength int64  `xml:"Properties>Content-Length"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+const (
+	azureAPIVersion    = "2019-02-02"
+	azureSASTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+var (
+	azureMaxPutBlobSize int64 = 256 << 20
+	azureBlockSize      int64 = 64 << 20
+)
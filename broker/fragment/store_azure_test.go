@@ -0,0 +1,300 @@
+package fragment
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+type AzureSuite struct{}
+
+func (s *AzureSuite) TestRoundTrip(c *gc.C) {
+	var fake = newFakeAzure(c)
+	defer fake.srv.Close()
+
+	defer func(a, b int64) { azureMaxPutBlobSize, azureBlockSize = a, b }(azureMaxPutBlobSize, azureBlockSize)
+	azureBlockSize = 10
+
+	var store = pb.FragmentStore("azure://a-container/a/prefix/?endpoint=" +
+		url.QueryEscape(fake.srv.URL+"/an-account"))
+	var ep = store.URL()
+	var b = newTestAzureBackend(c)
+	var ctx = context.Background()
+
+	var spools []Spool
+	for _, tc := range []struct {
+		content string
+		codec   pb.CompressionCodec
+	}{
+		{"some content", pb.CompressionCodec_NONE},
+		{"some content which is uploaded in blocks", pb.CompressionCodec_NONE},
+		{"more content", pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION},
+	} {
+		var spool = buildAzureSpool(c, tc.content, tc.codec, int64(len(spools))*1000)
+		spool.BackingStore = store
+
+		// Uploads exceeding the maximum blob size are made in blocks.
+		if len(tc.content) > 30 {
+			azureMaxPutBlobSize = 30
+		} else {
+			azureMaxPutBlobSize = 1 << 20
+		}
+
+		var exists, err = b.Exists(ctx, ep, spool.Fragment.Fragment)
+		c.Check(err, gc.IsNil)
+		c.Check(exists, gc.Equals, false)
+
+		c.Check(b.Persist(ctx, ep, spool), gc.IsNil)
+
+		exists, err = b.Exists(ctx, ep, spool.Fragment.Fragment)
+		c.Check(err, gc.IsNil)
+		c.Check(exists, gc.Equals, true)
+
+		spools = append(spools, spool)
+	}
+
+	// Blob content encoding reflects the compression codec.
+	c.Check(fake.blob("a/prefix/"+spools[0].ContentPath()).encoding, gc.Equals, "")
+	c.Check(fake.blob("a/prefix/"+spools[2].ContentPath()).encoding, gc.Equals, "gzip")
+	// Content uploaded in blocks was assembled.
+	c.Check(fake.blob("a/prefix/"+spools[1].ContentPath()).content, gc.Equals,
+		"some content which is uploaded in blocks")
+
+	// Content is read back.
+	var rc, err = b.Open(ctx, ep, spools[1].Fragment.Fragment)
+	c.Assert(err, gc.IsNil)
+	content, _ := ioutil.ReadAll(rc)
+	c.Check(string(content), gc.Equals, "some content which is uploaded in blocks")
+	c.Check(rc.Close(), gc.IsNil)
+
+	// A blob in a sub-directory of the journal is not listed.
+	fake.put("a/prefix/a/journal/sub/dir", "ignored")
+
+	var listed []pb.Fragment
+	c.Check(b.List(ctx, store, ep, "a/journal", nil, func(f pb.Fragment) {
+		listed = append(listed, f)
+	}), gc.IsNil)
+
+	c.Assert(listed, gc.HasLen, 3)
+	for i, f := range listed {
+		c.Check(f.ModTime, gc.Equals, fake.modTime.Unix())
+		f.ModTime = 0
+		c.Check(f, gc.DeepEquals, spools[i].Fragment.Fragment)
+	}
+	c.Check(fake.listPages > 1, gc.Equals, true) // Listing was paginated.
+
+	// Signed URLs are read-only SASs of the blob.
+	signed, err := b.SignGet(ep, spools[0].Fragment.Fragment, time.Minute)
+	c.Check(err, gc.IsNil)
+
+	signedURL, err := url.Parse(signed)
+	c.Assert(err, gc.IsNil)
+	c.Check(signedURL.Path, gc.Equals, "/an-account/a-container/a/prefix/"+spools[0].ContentPath())
+	c.Check(signedURL.Query().Get("sp"), gc.Equals, "r")
+	c.Check(signedURL.Query().Get("sr"), gc.Equals, "b")
+	c.Check(signedURL.Query().Get("sig"), gc.Not(gc.Equals), "")
+
+	// Fragments are removed.
+	for _, spool := range spools {
+		c.Check(b.Remove(ctx, spool.Fragment.Fragment), gc.IsNil)
+	}
+	c.Check(b.Remove(ctx, spools[0].Fragment.Fragment), gc.ErrorMatches, `azure: 404 BlobNotFound: not found\n`)
+}
+
+func (s *AzureSuite) TestStringToSign(c *gc.C) {
+	var b = newTestAzureBackend(c)
+
+	var req, _ = http.NewRequest("PUT", "https://host/a-container/a/blob?comp=block&blockid=MDA%3D", nil)
+	req.ContentLength = 1234
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("X-Ms-Version", azureAPIVersion)
+	req.Header.Set("X-Ms-Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+
+	c.Check(b.stringToSign(req), gc.Equals, "PUT\n\n\n1234\n\napplication/xml\n\n\n\n\n\n\n"+
+		"x-ms-date:Mon, 02 Jan 2006 15:04:05 GMT\n"+
+		"x-ms-version:"+azureAPIVersion+"\n"+
+		"/an-account/a-container/a/blob\n"+
+		"blockid:MDA=\n"+
+		"comp:block")
+
+	// Missing credentials are an error.
+	os.Unsetenv("AZURE_ACCOUNT_KEY")
+	var _, err = new(azureBackend).azureClient(pb.FragmentStore("azure://a-container/").URL())
+	c.Check(err, gc.ErrorMatches, `use of Azure requires that AZURE_ACCOUNT_NAME and AZURE_ACCOUNT_KEY be set`)
+}
+
+func newTestAzureBackend(c *gc.C) *azureBackend {
+	os.Setenv("AZURE_ACCOUNT_NAME", "an-account")
+	os.Setenv("AZURE_ACCOUNT_KEY", base64.StdEncoding.EncodeToString([]byte("a-secret-key")))
+
+	var b = new(azureBackend)
+	var _, err = b.azureClient(pb.FragmentStore("azure://a-container/").URL())
+	c.Assert(err, gc.IsNil)
+	return b
+}
+
+func buildAzureSpool(c *gc.C, content string, codec pb.CompressionCodec, begin int64) Spool {
+	var spool = NewSpool("a/journal", new(testSpoolObserver))
+	spool.MustApply(&pb.ReplicateRequest{Proposal: &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            begin,
+		End:              begin,
+		CompressionCodec: codec,
+	}})
+	var _, err = spool.Apply(&pb.ReplicateRequest{Content: []byte(content)}, true)
+	c.Assert(err, gc.IsNil)
+	spool.MustApply(&pb.ReplicateRequest{Proposal: &pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            begin,
+		End:              begin + int64(len(content)),
+		Sum:              pb.SHA1SumOf(content),
+		CompressionCodec: codec,
+	}})
+
+	if codec != pb.CompressionCodec_NONE {
+		spool.compressThrough(spool.End)
+		spool.finishCompression()
+	}
+	return spool
+}
+
+// fakeAzure is a minimal, in-memory Azure Blob service.
+type fakeAzure struct {
+	c         *gc.C
+	srv       *httptest.Server
+	modTime   time.Time
+	blobs     map[string]fakeBlob
+	blocks    map[string]string
+	listPages int
+	mu        sync.Mutex
+}
+
+type fakeBlob struct {
+	content, encoding string
+}
+
+func newFakeAzure(c *gc.C) *fakeAzure {
+	var f = &fakeAzure{
+		c:       c,
+		modTime: time.Unix(1500000000, 0),
+		blobs:   make(map[string]fakeBlob),
+		blocks:  make(map[string]string),
+	}
+	f.srv = httptest.NewServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+func (f *fakeAzure) blob(name string) fakeBlob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.blobs[name]
+}
+
+func (f *fakeAzure) put(name, content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blobs[name] = fakeBlob{content: content}
+}
+
+func (f *fakeAzure) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.c.Check(r.Header.Get("Authorization"), gc.Matches, `SharedKey an-account:.+`)
+	f.c.Check(r.Header.Get("x-ms-version"), gc.Equals, azureAPIVersion)
+
+	const root = "/an-account/a-container"
+	if !strings.HasPrefix(r.URL.Path, root) {
+		http.Error(w, "unexpected path", http.StatusBadRequest)
+		return
+	}
+	var name, q = strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, root), "/"), r.URL.Query()
+	var body, _ = ioutil.ReadAll(r.Body)
+
+	switch {
+	case r.Method == "GET" && q.Get("comp") == "list":
+		f.serveList(w, q)
+	case r.Method == "PUT" && q.Get("comp") == "block":
+		f.blocks[name+"@"+q.Get("blockid")] = string(body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == "PUT" && q.Get("comp") == "blocklist":
+		var list struct {
+			Latest []string `xml:"Latest"`
+		}
+		f.c.Check(xml.Unmarshal(body, &list), gc.IsNil)
+
+		var content string
+		for _, id := range list.Latest {
+			content += f.blocks[name+"@"+id]
+		}
+		f.blobs[name] = fakeBlob{content: content, encoding: r.Header.Get("x-ms-blob-content-encoding")}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == "PUT":
+		f.c.Check(r.Header.Get("x-ms-blob-type"), gc.Equals, "BlockBlob")
+		f.blobs[name] = fakeBlob{content: string(body), encoding: r.Header.Get("x-ms-blob-content-encoding")}
+		w.WriteHeader(http.StatusCreated)
+	default:
+		var blob, ok = f.blobs[name]
+		if !ok {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "HEAD":
+		case "GET":
+			_, _ = w.Write([]byte(blob.content))
+		case "DELETE":
+			delete(f.blobs, name)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}
+}
+
+func (f *fakeAzure) serveList(w http.ResponseWriter, q url.Values) {
+	f.c.Check(q.Get("restype"), gc.Equals, "container")
+	f.c.Check(q.Get("delimiter"), gc.Equals, "/")
+	f.listPages++
+
+	var names []string
+	for name := range f.blobs {
+		if strings.HasPrefix(name, q.Get("prefix")) && name >= q.Get("marker") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Return pages of two results.
+	var next string
+	if len(names) > 2 {
+		names, next = names[:2], names[2]
+	}
+
+	fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+	for _, name := range names {
+		var rest = strings.TrimPrefix(name, q.Get("prefix"))
+		if ind := strings.IndexByte(rest, '/'); ind != -1 {
+			fmt.Fprintf(w, "<BlobPrefix><Name>%s%s</Name></BlobPrefix>", q.Get("prefix"), rest[:ind+1])
+			continue
+		}
+		fmt.Fprintf(w, "<Blob><Name>%s</Name><Properties><Last-Modified>%s</Last-Modified>"+
+			"<Content-Length>%d</Content-Length></Properties></Blob>",
+			name, f.modTime.UTC().Format(time.RFC1123), len(f.blobs[name].content))
+	}
+	fmt.Fprintf(w, "</Blobs><NextMarker>%s</NextMarker></EnumerationResults>", next)
+}
+
+var _ = gc.Suite(&AzureSuite{})
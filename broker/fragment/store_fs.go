@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -106,13 +107,12 @@ func (s fsBackend) Persist(_ context.Context, ep *url.URL, spool Spool) error {
 	return err
 }
 
-func (s fsBackend) List(_ context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, callback func(pb.Fragment)) error {
+func (s fsBackend) List(_ context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, postfix *regexp.Regexp, callback func(pb.Fragment)) error {
 	var cfg, err = s.fsCfg(ep)
 	if err != nil {
 		return err
 	}
 
-	var root = filepath.Join(FileSystemStoreRoot, filepath.FromSlash(ep.Path))
 	var walkFrom = filepath.Join(FileSystemStoreRoot,
 		filepath.FromSlash(cfg.rewritePath(ep.Path, name.String()+"/")))
 
@@ -122,20 +122,22 @@ func (s fsBackend) List(_ context.Context, store pb.FragmentStore, ep *url.URL,
 	return filepath.Walk(walkFrom,
 		func(path string, info os.FileInfo, err error) error {
 
-			var name string
+			var rel string
 
 			if err != nil {
 				return err
 			} else if info.IsDir() {
 				return nil // Descend into directory.
-			} else if name, err = filepath.Rel(root, path); err != nil {
+			} else if rel, err = filepath.Rel(walkFrom, path); err != nil {
 				return err
-			} else if name == "." || name == ".." {
+			} else if rel == "." || rel == ".." {
 				// Never return "." or ".." as they are not real directories.
 				return nil
 			}
 
-			if frag, err := pb.ParseContentPath(filepath.ToSlash(name)); err != nil {
+			if frag, ok, err := parseListedPath(name, "", filepath.ToSlash(rel), postfix); !ok {
+				// Not a fragment of this journal.
+			} else if err != nil {
 				log.WithFields(log.Fields{"path": path, "err": err}).Warning("parsing fragment")
 			} else if info.Size() == 0 && frag.ContentLength() > 0 {
 				log.WithFields(log.Fields{"path": path}).Warning("zero-length fragment")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"regexp"
 	"sync"
 	"time"
 
@@ -16,11 +17,36 @@ import (
 	"google.golang.org/api/option"
 )
 
+// GCSBillingProject is the project billed for requests of GCS fragment
+// stores which don't specify a BillingProject URL argument. It's required
+// for stores of Requester Pays buckets. If empty, requests are billed to the
+// project owning the bucket.
+var GCSBillingProject string
+
+// GCSProjectID is the quota project of the GCS client. If empty, that of the
+// application default credentials is used.
+var GCSProjectID string
+
 type gcsCfg struct {
 	bucket string
 	prefix string
 
 	rewriterCfg
+
+	// BillingProject is the project billed for requests of the store, which
+	// is required for a Requester Pays bucket. If empty, GCSBillingProject is
+	// used.
+	BillingProject string
+}
+
+// bucketHandle returns the BucketHandle of the store, billed to its
+// BillingProject (if any).
+func (cfg gcsCfg) bucketHandle(client *storage.Client) *storage.BucketHandle {
+	var bucket = client.Bucket(cfg.bucket)
+	if cfg.BillingProject != "" {
+		bucket = bucket.UserProject(cfg.BillingProject)
+	}
+	return bucket
 }
 
 type gcsBackend struct {
@@ -41,7 +67,21 @@ func (s *gcsBackend) SignGet(ep *url.URL, fragment pb.Fragment, d time.Duration)
 	opts.Method = "GET"
 	opts.Expires = time.Now().Add(d)
 
-	return storage.SignedURL(cfg.bucket, cfg.rewritePath(cfg.prefix, fragment.ContentPath()), &opts)
+	signed, err := storage.SignedURL(cfg.bucket, cfg.rewritePath(cfg.prefix, fragment.ContentPath()), &opts)
+	if err != nil || cfg.BillingProject == "" {
+		return signed, err
+	}
+	// Reads of a Requester Pays bucket must name the billed project.
+	// The signature doesn't cover query arguments, which may be added.
+	u, err := url.Parse(signed)
+	if err != nil {
+		return "", err
+	}
+	var q = u.Query()
+	q.Set("userProject", cfg.BillingProject)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
 }
 
 func (s *gcsBackend) Exists(ctx context.Context, ep *url.URL, fragment pb.Fragment) (exists bool, err error) {
@@ -49,7 +89,7 @@ func (s *gcsBackend) Exists(ctx context.Context, ep *url.URL, fragment pb.Fragme
 	if err != nil {
 		return false, err
 	}
-	_, err = client.Bucket(cfg.bucket).Object(cfg.rewritePath(cfg.prefix, fragment.ContentPath())).Attrs(ctx)
+	_, err = cfg.bucketHandle(client).Object(cfg.rewritePath(cfg.prefix, fragment.ContentPath())).Attrs(ctx)
 	if err == nil {
 		exists = true
 	} else if err == storage.ErrObjectNotExist {
@@ -63,7 +103,7 @@ func (s *gcsBackend) Open(ctx context.Context, ep *url.URL, fragment pb.Fragment
 	if err != nil {
 		return nil, err
 	}
-	return client.Bucket(cfg.bucket).Object(cfg.rewritePath(cfg.prefix, fragment.ContentPath())).NewReader(ctx)
+	return cfg.bucketHandle(client).Object(cfg.rewritePath(cfg.prefix, fragment.ContentPath())).NewReader(ctx)
 }
 
 func (s *gcsBackend) Persist(ctx context.Context, ep *url.URL, spool Spool) error {
@@ -72,7 +112,7 @@ func (s *gcsBackend) Persist(ctx context.Context, ep *url.URL, spool Spool) erro
 		return err
 	}
 	ctx, cancel := context.WithCancel(ctx)
-	var wc = client.Bucket(cfg.bucket).Object(cfg.rewritePath(cfg.prefix, spool.ContentPath())).NewWriter(ctx)
+	var wc = cfg.bucketHandle(client).Object(cfg.rewritePath(cfg.prefix, spool.ContentPath())).NewWriter(ctx)
 
 	if spool.CompressionCodec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
 		wc.ContentEncoding = "gzip"
@@ -90,26 +130,28 @@ func (s *gcsBackend) Persist(ctx context.Context, ep *url.URL, spool Spool) erro
 	return err
 }
 
-func (s *gcsBackend) List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, callback func(pb.Fragment)) error {
+func (s *gcsBackend) List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, postfix *regexp.Regexp, callback func(pb.Fragment)) error {
 	cfg, client, _, err := s.gcsClient(ep)
 	if err != nil {
 		return err
 	}
+	var q = storage.Query{Prefix: cfg.rewritePath(cfg.prefix, name.String()) + "/"}
+
+	if postfix == nil {
+		// Fragment files of the flat layout are directly within the journal's
+		// directory. Providing a delimiter excludes files in subdirectories
+		// from the query results because they will be collapsed into a single
+		// synthetic "directory entry".
+		q.Delimiter = "/"
+	}
 	var (
-		q = storage.Query{
-			Prefix: cfg.rewritePath(cfg.prefix, name.String()) + "/",
-			// Gazette stores all of a journal's fragment files in a flat
-			// structure. Providing a delimiter excludes files in
-			// subdirectories from the query results because they will be
-			// collapsed into a single synthetic "directory entry".
-			Delimiter: "/",
-		}
-		it    = client.Bucket(cfg.bucket).Objects(ctx, &q)
-		strip = len(cfg.prefix)
-		obj   *storage.ObjectAttrs
+		it  = cfg.bucketHandle(client).Objects(ctx, &q)
+		obj *storage.ObjectAttrs
 	)
 	for obj, err = it.Next(); err == nil; obj, err = it.Next() {
-		if obj.Name[strip:] == q.Prefix || obj.Prefix != "" {
+		var frag, ok, err2 = parseListedPath(name, q.Prefix, obj.Name, postfix)
+
+		if !ok || obj.Prefix != "" {
 			// The parent directory is included in the results because it
 			// matches the prefix. Additionally, if there are subdirectories,
 			// they will be represented by synthetic "directory entries". Both
@@ -119,7 +161,7 @@ func (s *gcsBackend) List(ctx context.Context, store pb.FragmentStore, ep *url.U
 			// See:
 			// - https://cloud.google.com/storage/docs/json_api/v1/objects/list
 			// - https://godoc.org/cloud.google.com/go/storage#ObjectAttrs.Prefix
-		} else if frag, err2 := pb.ParseContentPath(obj.Name[strip:]); err2 != nil {
+		} else if err2 != nil {
 			log.WithFields(log.Fields{"bucket": cfg.bucket, "name": obj.Name, "err": err2}).Warning("parsing fragment")
 		} else if obj.Size == 0 && frag.ContentLength() > 0 {
 			log.WithFields(log.Fields{"bucket": cfg.bucket, "name": obj.Name}).Warning("zero-length fragment")
@@ -140,7 +182,7 @@ func (s *gcsBackend) Remove(ctx context.Context, fragment pb.Fragment) error {
 	if err != nil {
 		return err
 	}
-	return client.Bucket(cfg.bucket).Object(cfg.rewritePath(cfg.prefix, fragment.ContentPath())).Delete(ctx)
+	return cfg.bucketHandle(client).Object(cfg.rewritePath(cfg.prefix, fragment.ContentPath())).Delete(ctx)
 }
 
 func (s *gcsBackend) gcsClient(ep *url.URL) (cfg gcsCfg, client *storage.Client, opts storage.SignedURLOptions, err error) {
@@ -151,6 +193,10 @@ func (s *gcsBackend) gcsClient(ep *url.URL) (cfg gcsCfg, client *storage.Client,
 	// enforces that URL Paths end in '/'.
 	cfg.bucket, cfg.prefix = ep.Host, ep.Path[1:]
 
+	if cfg.BillingProject == "" {
+		cfg.BillingProject = GCSBillingProject
+	}
+
 	s.clientMu.Lock()
 	defer s.clientMu.Unlock()
 
@@ -172,7 +218,11 @@ func (s *gcsBackend) gcsClient(ep *url.URL) (cfg gcsCfg, client *storage.Client,
 	if err != nil {
 		return
 	}
-	client, err = storage.NewClient(ctx, option.WithTokenSource(conf.TokenSource(ctx)))
+	var clientOpts = []option.ClientOption{option.WithTokenSource(conf.TokenSource(ctx))}
+	if GCSProjectID != "" {
+		clientOpts = append(clientOpts, option.WithQuotaProject(GCSProjectID))
+	}
+	client, err = storage.NewClient(ctx, clientOpts...)
 	if err != nil {
 		return
 	}
@@ -184,6 +234,7 @@ func (s *gcsBackend) gcsClient(ep *url.URL) (cfg gcsCfg, client *storage.Client,
 
 	log.WithFields(log.Fields{
 		"ProjectID":      creds.ProjectID,
+		"QuotaProject":   GCSProjectID,
 		"GoogleAccessID": conf.Email,
 		"PrivateKeyID":   conf.PrivateKeyID,
 		"Subject":        conf.Subject,
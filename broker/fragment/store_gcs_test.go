@@ -0,0 +1,114 @@
+package fragment
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+	"google.golang.org/api/option"
+)
+
+type GCSStoreSuite struct{}
+
+func (s *GCSStoreSuite) TestBillingProjectOfRequests(c *gc.C) {
+	var frag = pb.Fragment{
+		Journal: "a/journal",
+		Begin:   0,
+		End:     3,
+		Sum:     pb.SHA1SumOf("foo"),
+
+		CompressionCodec: pb.CompressionCodec_NONE,
+	}
+	var projects []string
+
+	// Fake the JSON API, recording the billed project of each request.
+	var srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		projects = append(projects, r.URL.Query().Get("userProject"))
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/b/a-bucket/o"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"items": []map[string]interface{}{{
+					"name":    "a/prefix/" + frag.ContentPath(),
+					"size":    "3",
+					"updated": time.Unix(1234, 0).Format(time.RFC3339),
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var client, err = storage.NewClient(context.Background(),
+		option.WithEndpoint(srv.URL+"/storage/v1/"), option.WithoutAuthentication())
+	c.Assert(err, gc.IsNil)
+
+	var b = &gcsBackend{
+		client: client,
+		signedURLOptions: storage.SignedURLOptions{
+			GoogleAccessID: "tester@example.com",
+			PrivateKey:     newTestPrivateKey(c),
+		},
+	}
+	defer func(p string) { GCSBillingProject = p }(GCSBillingProject)
+
+	for _, tc := range []struct {
+		store, dflt, expect string
+	}{
+		{"gs://a-bucket/a/prefix/", "", ""},                               // Billed to the bucket's owner.
+		{"gs://a-bucket/a/prefix/", "default-project", "default-project"}, // Billed to the default project.
+		{"gs://a-bucket/a/prefix/?billingProject=a-project", "default-project", "a-project"},
+	} {
+		GCSBillingProject = tc.dflt
+		projects = projects[:0]
+
+		var store = pb.FragmentStore(tc.store)
+		var ep = store.URL()
+		frag.BackingStore = store
+
+		var listed []pb.Fragment
+		c.Check(b.List(context.Background(), store, ep, frag.Journal, nil, func(f pb.Fragment) {
+			listed = append(listed, f)
+		}), gc.IsNil)
+		c.Check(listed, gc.HasLen, 1)
+
+		exists, err := b.Exists(context.Background(), ep, frag)
+		c.Check(err, gc.IsNil)
+		c.Check(exists, gc.Equals, false)
+
+		c.Check(projects, gc.DeepEquals, []string{tc.expect, tc.expect})
+
+		// Signed URLs name the billed project, if any.
+		signed, err := b.SignGet(ep, frag, time.Minute)
+		c.Assert(err, gc.IsNil)
+		u, err := url.Parse(signed)
+		c.Assert(err, gc.IsNil)
+
+		c.Check(u.Query().Get("userProject"), gc.Equals, tc.expect)
+		c.Check(u.Query().Get("Signature"), gc.Not(gc.Equals), "")
+	}
+}
+
+func newTestPrivateKey(c *gc.C) []byte {
+	var key, err = rsa.GenerateKey(rand.Reader, 1024)
+	c.Assert(err, gc.IsNil)
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+var _ = gc.Suite(&GCSStoreSuite{})
@@ -0,0 +1,104 @@
+package fragment
+
+import (
+	"context"
+	"io"
+
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// StoreReader is an io.ReadCloser of journal content which reads Fragments
+// directly from the stores of a JournalSpec, without the involvement of a
+// broker. It's intended for offline and batch jobs which process historical
+// journal content. Only Fragments persisted to stores are read: content
+// which is not yet persisted (as is held by broker Spools) is not.
+//
+// Fragments are listed by OpenJournalFromStore, and are then opened and
+// read in turn. Where listed Fragments don't cover the next offset to be
+// read (eg, because Fragments were removed, or because the requested offset
+// is before the first persisted Fragment), Read skips forward to the next
+// listed Fragment and returns client.ErrOffsetJump. As with client.Reader,
+// the StoreReader remains valid and may continue to be read, and the
+// updated Offset reflects the skipped gap. Read returns io.EOF upon reaching
+// EndOffset.
+type StoreReader struct {
+	// Next journal offset to be read.
+	Offset int64
+	// Exclusive end offset of listed Fragments.
+	EndOffset int64
+
+	ctx context.Context
+	set CoverSet
+	fr  *client.FragmentReader // Current FragmentReader, or nil.
+}
+
+// OpenJournalFromStore lists Fragments of the stores of the JournalSpec,
+// and returns a StoreReader of their content beginning at |fromOffset|.
+// Fragments are opened using the JournalSpec's configured stores. Unlike
+// client.OpenFragmentURL, no signed URL is required, but the caller must
+// have credentials sufficient to list and read the stores.
+func OpenJournalFromStore(ctx context.Context, spec pb.JournalSpec, fromOffset int64) (*StoreReader, error) {
+	var set, err = WalkAllStores(ctx, spec.Name, spec.Fragment)
+	if err != nil {
+		return nil, err
+	}
+	return &StoreReader{
+		Offset:    fromOffset,
+		EndOffset: set.EndOffset(),
+		ctx:       ctx,
+		set:       set,
+	}, nil
+}
+
+// Read returns the next bytes of journal content. When Read returns, Offset
+// has been updated to reflect the next byte to be read.
+func (r *StoreReader) Read(p []byte) (n int, err error) {
+	for n == 0 && err == nil {
+		if r.fr == nil {
+			if err = r.open(); err != nil {
+				return
+			}
+		}
+		n, err = r.fr.Read(p)
+		r.Offset = r.fr.Offset
+
+		if err == io.EOF {
+			// Fragment is fully read. Continue with the next.
+			err, r.fr = r.fr.Close(), nil
+		}
+	}
+	return
+}
+
+// Close the StoreReader, and its current Fragment (if any).
+func (r *StoreReader) Close() error {
+	if r.fr == nil {
+		return nil
+	}
+	var err = r.fr.Close()
+	r.fr = nil
+	return err
+}
+
+// open the Fragment covering Offset, or skips Offset to the next Fragment.
+func (r *StoreReader) open() error {
+	if r.Offset >= r.EndOffset {
+		return io.EOF
+	}
+
+	var ind, found = r.set.LongestOverlappingFragment(r.Offset)
+	if !found {
+		// As Offset < EndOffset, a Fragment beginning after Offset must exist.
+		r.Offset = r.set[ind].Begin
+		return client.ErrOffsetJump
+	}
+	var frag = r.set[ind].Fragment
+
+	var rc, err = Open(r.ctx, frag)
+	if err != nil {
+		return err
+	}
+	r.fr, err = client.NewFragmentReader(rc, frag, r.Offset)
+	return err
+}
@@ -0,0 +1,97 @@
+package fragment
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+type StoreReaderSuite struct{}
+
+func (s *StoreReaderSuite) TestReadAcrossFragmentsAndGaps(c *gc.C) {
+	var tmpdir, err = ioutil.TempDir("", "StoreReaderSuite")
+	c.Assert(err, gc.IsNil)
+
+	defer func() { os.RemoveAll(tmpdir) }()
+	defer func(s string) { FileSystemStoreRoot = s }(FileSystemStoreRoot)
+	FileSystemStoreRoot = tmpdir
+
+	var fixtures = []struct {
+		path, content string
+	}{
+		{"root/a/journal/0000000000000002-0000000000000005-0000000000000000000000000000000000000111", "abc"},
+		{"root/a/journal/0000000000000005-0000000000000008-0000000000000000000000000000000000000222", "def"},
+		{"root/a/journal/0000000000000006-0000000000000008-0000000000000000000000000000000000000333", "ef"}, // Covered.
+		{"root/a/journal/000000000000000c-0000000000000010-0000000000000000000000000000000000000444", "ghij"},
+	}
+	for _, f := range fixtures {
+		var path = filepath.Join(tmpdir, filepath.FromSlash(f.path))
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0700), gc.IsNil)
+		c.Assert(ioutil.WriteFile(path, []byte(f.content), 0600), gc.IsNil)
+	}
+
+	var ctx = context.Background()
+	var spec = pb.JournalSpec{
+		Name:     "a/journal",
+		Fragment: pb.JournalSpec_Fragment{Stores: []pb.FragmentStore{"file:///root/"}},
+	}
+
+	// Read from offset zero, which precedes the first Fragment.
+	rr, err := OpenJournalFromStore(ctx, spec, 0)
+	c.Assert(err, gc.IsNil)
+	c.Check(rr.EndOffset, gc.Equals, int64(0x10))
+
+	var buf = make([]byte, 4)
+	var expect = []struct {
+		content string
+		offset  int64
+		err     error
+	}{
+		{"", 2, client.ErrOffsetJump}, // Skip gap [0, 2).
+		{"abc", 5, nil},
+		{"def", 8, nil},
+		{"", 12, client.ErrOffsetJump}, // Skip gap [8, 12).
+		{"ghij", 16, nil},
+		{"", 16, io.EOF},
+	}
+	for _, e := range expect {
+		var n, err = rr.Read(buf)
+		c.Check(string(buf[:n]), gc.Equals, e.content)
+		c.Check(rr.Offset, gc.Equals, e.offset)
+		c.Check(err, gc.Equals, e.err)
+	}
+	c.Check(rr.Close(), gc.IsNil)
+
+	// Read from an offset within a Fragment, through to EOF.
+	rr, err = OpenJournalFromStore(ctx, spec, 6)
+	c.Assert(err, gc.IsNil)
+
+	var content []byte
+	for err = nil; err != io.EOF; {
+		if err != nil {
+			c.Check(err, gc.Equals, client.ErrOffsetJump)
+		}
+		var n int
+		n, err = rr.Read(buf)
+		content = append(content, buf[:n]...)
+	}
+	c.Check(string(content), gc.Equals, "efghij")
+	c.Check(rr.Close(), gc.IsNil)
+
+	// Case: a journal without Fragments is immediately at EOF.
+	spec.Name = "other/journal"
+	rr, err = OpenJournalFromStore(ctx, spec, 0)
+	c.Assert(err, gc.IsNil)
+
+	n, err := rr.Read(buf)
+	c.Check(n, gc.Equals, 0)
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+var _ = gc.Suite(&StoreReaderSuite{})
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sync"
 	"time"
 
@@ -138,7 +139,7 @@ func (s *s3Backend) Persist(ctx context.Context, ep *url.URL, spool Spool) error
 	_, err = client.PutObjectWithContext(ctx, &putObj)
 	return err
 }
-func (s *s3Backend) List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, callback func(pb.Fragment)) error {
+func (s *s3Backend) List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, postfix *regexp.Regexp, callback func(pb.Fragment)) error {
 	cfg, client, err := s.s3Client(ep)
 	if err != nil {
 		return err
@@ -148,12 +149,12 @@ func (s *s3Backend) List(ctx context.Context, store pb.FragmentStore, ep *url.UR
 		Bucket: aws.String(cfg.bucket),
 		Prefix: aws.String(cfg.rewritePath(cfg.prefix, name.String()) + "/"),
 	}
-	var strip = len(cfg.prefix)
-
 	return client.ListObjectsV2PagesWithContext(ctx, &list, func(objs *s3.ListObjectsV2Output, _ bool) bool {
 		for _, obj := range objs.Contents {
 
-			if frag, err := pb.ParseContentPath((*obj.Key)[strip:]); err != nil {
+			if frag, ok, err := parseListedPath(name, *list.Prefix, *obj.Key, postfix); !ok {
+				// Not a fragment of this journal.
+			} else if err != nil {
 				log.WithFields(log.Fields{"bucket": cfg.bucket, "key": *obj.Key, "err": err}).Warning("parsing fragment")
 			} else if *obj.Size == 0 && frag.ContentLength() > 0 {
 				log.WithFields(log.Fields{"obj": obj}).Warning("zero-length fragment")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -19,18 +20,20 @@ type backend interface {
 	Exists(ctx context.Context, ep *url.URL, fragment pb.Fragment) (bool, error)
 	Open(ctx context.Context, ep *url.URL, fragment pb.Fragment) (io.ReadCloser, error)
 	Persist(ctx context.Context, ep *url.URL, spool Spool) error
-	List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, callback func(pb.Fragment)) error
+	List(ctx context.Context, store pb.FragmentStore, ep *url.URL, name pb.Journal, postfix *regexp.Regexp, callback func(pb.Fragment)) error
 	Remove(ctx context.Context, fragment pb.Fragment) error
 }
 
 var sharedStores = struct {
-	s3  *s3Backend
-	gcs *gcsBackend
-	fs  *fsBackend
+	s3    *s3Backend
+	gcs   *gcsBackend
+	azure *azureBackend
+	fs    *fsBackend
 }{
-	s3:  newS3Backend(),
-	gcs: &gcsBackend{},
-	fs:  &fsBackend{},
+	s3:    newS3Backend(),
+	gcs:   &gcsBackend{},
+	azure: &azureBackend{},
+	fs:    &fsBackend{},
 }
 
 func getBackend(scheme string) backend {
@@ -39,6 +42,8 @@ func getBackend(scheme string) backend {
 		return sharedStores.s3
 	case "gs":
 		return sharedStores.gcs
+	case "azure":
+		return sharedStores.azure
 	case "file":
 		return sharedStores.fs
 	default:
@@ -103,13 +108,16 @@ func Persist(ctx context.Context, spool Spool) error {
 	return err
 }
 
-// List Fragments of the FragmentStore for a given journal. |callback| is
-// invoked with each listed Fragment, and any returned error aborts the listing.
-func List(ctx context.Context, store pb.FragmentStore, name pb.Journal, callback func(pb.Fragment)) error {
+// List Fragments of the FragmentStore for a given journal, having either the
+// flat layout or PathPostfix directories matched by |postfix| (see
+// JournalSpec_Fragment.PathPostfixPattern), which is nil if only the flat
+// layout is listed. |callback| is invoked with each listed
+// Fragment, and any returned error aborts the listing.
+func List(ctx context.Context, store pb.FragmentStore, name pb.Journal, postfix *regexp.Regexp, callback func(pb.Fragment)) error {
 	var ep = store.URL()
 	var b = getBackend(ep.Scheme)
 
-	var err = b.List(ctx, store, ep, name, callback)
+	var err = b.List(ctx, store, ep, name, postfix, callback)
 	instrumentStoreOp(b.Provider(), "list", err)
 	return err
 }
@@ -134,6 +142,26 @@ func parseStoreArgs(ep *url.URL, args interface{}) error {
 	return nil
 }
 
+// parseListedPath parses the |listed| path of a Fragment of journal |name|,
+// having the journal's directory |prefix|. It returns false if the path is
+// of a directory, or is neither of the flat layout nor has PathPostfix
+// directories matched by |postfix|. Notably, files of a nested journal
+// (having the journal's directory as a prefix) aren't matched.
+func parseListedPath(name pb.Journal, prefix, listed string, postfix *regexp.Regexp) (pb.Fragment, bool, error) {
+	if !strings.HasPrefix(listed, prefix) || strings.HasSuffix(listed, "/") {
+		return pb.Fragment{}, false, nil
+	}
+	var rel = listed[len(prefix):]
+
+	if dir := rel[:strings.LastIndexByte(rel, '/')+1]; dir == "" {
+		// Fragment of the flat layout.
+	} else if postfix == nil || !postfix.MatchString(dir) {
+		return pb.Fragment{}, false, nil
+	}
+	var frag, err = pb.ParseContentPostfixPath(name, rel)
+	return frag, true, err
+}
+
 func instrumentStoreOp(provider, op string, err error) {
 	if err != nil {
 		metrics.StoreRequestTotal.WithLabelValues(provider, op, metrics.Fail).Inc()
@@ -0,0 +1,68 @@
+package broker
+
+import (
+	"context"
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.gazette.dev/core/broker/fragment"
+	pb "go.gazette.dev/core/broker/protocol"
+	"google.golang.org/grpc/peer"
+)
+
+// Head dispatches the JournalServer.Head API.
+func (svc *Service) Head(ctx context.Context, req *pb.HeadRequest) (resp *pb.HeadResponse, err error) {
+	var res *resolution
+	defer instrumentJournalServerOp("Head", &err, &res, time.Now())
+
+	defer func() {
+		if err != nil {
+			var addr net.Addr
+			if p, ok := peer.FromContext(ctx); ok {
+				addr = p.Addr
+			}
+			log.WithFields(log.Fields{"err": err, "req": req, "client": addr}).
+				Warn("served Head RPC failed")
+		}
+	}()
+
+	if err = req.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err = svc.resolver.resolve(resolveArgs{
+		ctx:            ctx,
+		journal:        req.Journal,
+		mayProxy:       !req.DoNotProxy,
+		requirePrimary: false,
+		proxyHeader:    req.Header,
+	})
+
+	if err != nil {
+		return nil, err
+	} else if res.status != pb.Status_OK {
+		return &pb.HeadResponse{Status: res.status, Header: res.Header}, nil
+	} else if !res.journalSpec.Flags.MayRead() {
+		return &pb.HeadResponse{Status: pb.Status_NOT_ALLOWED, Header: res.Header}, nil
+	} else if res.replica == nil {
+		req.Header = &res.Header // Attach resolved Header to |req|, which we'll forward.
+		ctx = pb.WithDispatchRoute(ctx, req.Header.Route, req.Header.ProcessId)
+		return svc.jc.Head(ctx, req)
+	} else if err = res.replica.index.WaitForFirstRemoteRefresh(ctx); err != nil {
+		return nil, err
+	}
+
+	resp = &pb.HeadResponse{
+		Status: pb.Status_OK,
+		Header: res.Header,
+	}
+	err = res.replica.index.Inspect(func(set fragment.CoverSet) error {
+		if len(set) != 0 {
+			var frag = set[len(set)-1].Fragment
+			resp.Fragment, resp.WriteHead = &frag, frag.End
+		}
+		return nil
+	})
+	return resp, err
+}
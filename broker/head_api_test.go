@@ -0,0 +1,99 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.gazette.dev/core/broker/fragment"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/etcdtest"
+)
+
+func TestHeadCases(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+
+	// Case: Request validation error.
+	var _, err = broker.client().Head(ctx, &pb.HeadRequest{Journal: "/invalid"})
+	assert.EqualError(t, err, `rpc error: code = Unknown desc = Journal: cannot begin with '/' (/invalid)`)
+
+	// Case: Resolution error.
+	resp, err := broker.client().Head(ctx, &pb.HeadRequest{Journal: "a/missing/journal"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.HeadResponse{
+		Status: pb.Status_JOURNAL_NOT_FOUND,
+		Header: *broker.header("a/missing/journal"),
+	}, resp)
+
+	// Case: Head of a write only journal.
+	setTestJournal(broker, pb.JournalSpec{Name: "write/only", Replication: 1, Flags: pb.JournalSpec_O_WRONLY}, broker.id)
+	resp, err = broker.client().Head(ctx, &pb.HeadRequest{Journal: "write/only"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.HeadResponse{
+		Status: pb.Status_NOT_ALLOWED,
+		Header: *broker.header("write/only"),
+	}, resp)
+
+	// Case: Journal has no fragments.
+	broker.replica("a/journal").index.ReplaceRemote(fragment.CoverSet{})
+
+	resp, err = broker.client().Head(ctx, &pb.HeadRequest{Journal: "a/journal"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.HeadResponse{
+		Status: pb.Status_OK,
+		Header: *broker.header("a/journal"),
+	}, resp)
+
+	// Case: The most recent fragment and write head are returned.
+	var fragments = buildFragmentsFixture()
+	broker.replica("a/journal").index.ReplaceRemote(buildFragmentSet(fragments))
+
+	resp, err = broker.client().Head(ctx, &pb.HeadRequest{Journal: "a/journal"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.HeadResponse{
+		Status:    pb.Status_OK,
+		Header:    *broker.header("a/journal"),
+		WriteHead: fragments[5].Spec.End,
+		Fragment:  &fragments[5].Spec,
+	}, resp)
+
+	// Case: Proxy request to peer.
+	var peer = newMockBroker(t, etcd, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "proxy/journal", Replication: 1}, peer.id)
+	var proxyHeader = broker.header("proxy/journal")
+
+	peer.HeadFunc = func(ctx context.Context, req *pb.HeadRequest) (*pb.HeadResponse, error) {
+		assert.Equal(t, &pb.HeadRequest{
+			Header:  proxyHeader,
+			Journal: "proxy/journal",
+		}, req)
+		return &pb.HeadResponse{
+			Status:    pb.Status_OK,
+			Header:    *proxyHeader,
+			WriteHead: 1234,
+		}, nil
+	}
+
+	resp, err = broker.client().Head(ctx, &pb.HeadRequest{Journal: "proxy/journal"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.HeadResponse{
+		Status:    pb.Status_OK,
+		Header:    *proxyHeader,
+		WriteHead: 1234,
+	}, resp)
+
+	// Case: Proxy is not allowed.
+	resp, err = broker.client().Head(ctx, &pb.HeadRequest{Journal: "proxy/journal", DoNotProxy: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.HeadResponse{
+		Status: pb.Status_NOT_JOURNAL_BROKER,
+		Header: *boxHeaderProcessID(*broker.header("proxy/journal"), broker.id),
+	}, resp)
+
+	broker.cleanup()
+	peer.Cleanup()
+}
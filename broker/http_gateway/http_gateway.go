@@ -115,8 +115,9 @@ func (h *Gateway) serveWrite(w http.ResponseWriter, r *http.Request) {
 
 func (h *Gateway) parseReadRequest(r *http.Request) (pb.ReadRequest, error) {
 	var schema struct {
-		Offset int64
-		Block  bool
+		Offset            int64
+		Block             bool
+		FragmentsFromHead int32
 	}
 	var q url.Values
 	var err error
@@ -125,10 +126,11 @@ func (h *Gateway) parseReadRequest(r *http.Request) (pb.ReadRequest, error) {
 		err = h.decoder.Decode(&schema, q)
 	}
 	var req = pb.ReadRequest{
-		Journal:      pb.Journal(r.URL.Path[1:]),
-		Offset:       schema.Offset,
-		Block:        schema.Block,
-		MetadataOnly: r.Method == "HEAD",
+		Journal:           pb.Journal(r.URL.Path[1:]),
+		Offset:            schema.Offset,
+		Block:             schema.Block,
+		FragmentsFromHead: schema.FragmentsFromHead,
+		MetadataOnly:      r.Method == "HEAD",
 	}
 	if err == nil {
 		err = req.Validate()
@@ -120,7 +120,7 @@ func (n *Node) Hoist() {
 			n.Spec = pb.IntersectJournalSpecs(n.Spec, c.Spec)
 		}
 	}
-	n.Spec.Name = name
+	n.Spec.Name, n.Spec.Aliases = name, nil
 
 	// Subtract portions of child specs covered by the parent spec.
 	for i, c := range n.Children {
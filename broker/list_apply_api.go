@@ -2,7 +2,9 @@ package broker
 
 import (
 	"context"
+	"encoding/base64"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,25 +40,45 @@ func (svc *Service) List(ctx context.Context, req *pb.ListRequest) (resp *pb.Lis
 		return resp, err
 	}
 
-	// TODO(johnny): Implement support for PageLimit & PageToken.
-
+	var after string
+	if req.PageToken != "" {
+		if after, err = decodeListPageToken(req.PageToken); err != nil {
+			return resp, err
+		}
+	}
 	var metaLabels, allLabels pb.LabelSet
 
 	defer s.KS.Mu.RUnlock()
 	s.KS.Mu.RLock()
 
+	// Pin the Header to the revision of the listing, which clients use to
+	// verify that multiple pages reflect a consistent snapshot.
+	resp.Header.Etcd = pb.FromEtcdResponseHeader(s.KS.Header)
+
+	// Items and Assignments are ordered on journal name. Begin iteration with
+	// the first of each which follows the page token.
+	var items, asns = s.Items, s.Assignments
+	if after != "" {
+		items = items[sort.Search(len(items), func(i int) bool {
+			return items[i].Decoded.(allocator.Item).ID > after
+		}):]
+		asns = asns[sort.Search(len(asns), func(i int) bool {
+			return asns[i].Decoded.(allocator.Assignment).ItemID > after
+		}):]
+	}
+
 	var it = allocator.LeftJoin{
-		LenL: len(s.Items),
-		LenR: len(s.Assignments),
+		LenL: len(items),
+		LenR: len(asns),
 		Compare: func(l, r int) int {
-			var lID = s.Items[l].Decoded.(allocator.Item).ID
-			var rID = s.Assignments[r].Decoded.(allocator.Assignment).ItemID
+			var lID = items[l].Decoded.(allocator.Item).ID
+			var rID = asns[r].Decoded.(allocator.Assignment).ItemID
 			return strings.Compare(lID, rID)
 		},
 	}
 	for cur, ok := it.Next(); ok; cur, ok = it.Next() {
 		var journal = pb.ListResponse_Journal{
-			Spec: *s.Items[cur.Left].Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)}
+			Spec: *items[cur.Left].Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)}
 
 		metaLabels = pb.ExtractJournalSpecMetaLabels(&journal.Spec, metaLabels)
 		allLabels = pb.UnionLabelSets(metaLabels, journal.Spec.LabelSet, allLabels)
@@ -64,8 +86,13 @@ func (svc *Service) List(ctx context.Context, req *pb.ListRequest) (resp *pb.Lis
 		if !req.Selector.Matches(allLabels) {
 			continue
 		}
-		journal.ModRevision = s.Items[cur.Left].Raw.ModRevision
-		journal.Route.Init(s.Assignments[cur.RightBegin:cur.RightEnd])
+		// If the page is full, this matched journal begins the next page.
+		if req.PageLimit != 0 && len(resp.Journals) == int(req.PageLimit) {
+			resp.NextPageToken = encodeListPageToken(resp.Journals[len(resp.Journals)-1].Spec.Name)
+			break
+		}
+		journal.ModRevision = items[cur.Left].Raw.ModRevision
+		journal.Route.Init(asns[cur.RightBegin:cur.RightEnd])
 		journal.Route.AttachEndpoints(s.KS)
 
 		resp.Journals = append(resp.Journals, journal)
@@ -73,6 +100,23 @@ func (svc *Service) List(ctx context.Context, req *pb.ListRequest) (resp *pb.Lis
 	return resp, nil
 }
 
+// encodeListPageToken returns an opaque ListRequest.PageToken which resumes
+// a listing with the first journal ordered after |last|.
+func encodeListPageToken(last pb.Journal) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(last))
+}
+
+// decodeListPageToken returns the journal name encoded by |token|.
+func decodeListPageToken(token string) (string, error) {
+	var b, err = base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", pb.NewValidationError("invalid PageToken (%s)", err)
+	} else if err = pb.Journal(b).Validate(); err != nil {
+		return "", pb.ExtendContext(err, "PageToken")
+	}
+	return string(b), nil
+}
+
 // Apply dispatches the JournalServer.Apply API.
 func (svc *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (resp *pb.ApplyResponse, err error) {
 	defer instrumentJournalServerOp("Apply", &err, nil, time.Now())
@@ -99,6 +143,18 @@ func (svc *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (resp *pb.A
 	}
 
 	var cmp []clientv3.Cmp
+
+	if resp.Status, cmp, err = verifyApplyPreservesSeals(ctx, s, req); err != nil || resp.Status != pb.Status_OK {
+		return resp, err
+	} else if err = verifyApplyPathTemplates(s, req); err != nil {
+		return resp, err
+	} else if err = verifyApplyAliases(s, req); err != nil {
+		return resp, err
+	} else if req.DryRun {
+		dryRunApply(s, req, resp)
+		return resp, nil
+	}
+
 	var ops []clientv3.Op
 
 	for _, change := range req.Changes {
@@ -127,3 +183,154 @@ func (svc *Service) Apply(ctx context.Context, req *pb.ApplyRequest) (resp *pb.A
 	}
 	return resp, err
 }
+
+// dryRunApply evaluates |req| against the current KeySpace without applying
+// it, populating |resp| with the Status the Apply would have returned, and
+// the journals it would create, update, or delete. The response Header is
+// pinned to the evaluated KeySpace revision.
+func dryRunApply(s *allocator.State, req *pb.ApplyRequest, resp *pb.ApplyResponse) {
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	resp.Header.Etcd = pb.FromEtcdResponseHeader(s.KS.Header)
+
+	var created, updated, deleted []pb.Journal
+	for _, change := range req.Changes {
+		var name = change.Delete
+		if change.Upsert != nil {
+			name = change.Upsert.Name
+		}
+		var modRevision int64
+		if ind, ok := s.Items.Search(allocator.ItemKey(s.KS, name.String())); ok {
+			modRevision = s.Items[ind].Raw.ModRevision
+		}
+
+		if modRevision != change.ExpectModRevision {
+			resp.Status = pb.Status_ETCD_TRANSACTION_FAILED
+			return
+		} else if change.Delete != "" {
+			deleted = append(deleted, name)
+		} else if modRevision == 0 {
+			created = append(created, name)
+		} else {
+			updated = append(updated, name)
+		}
+	}
+	resp.DryRunCreated, resp.DryRunUpdated, resp.DryRunDeleted = created, updated, deleted
+}
+
+// verifyApplyPreservesSeals returns JOURNAL_SEALED if an Upsert of |req| would
+// remove the Seal of a currently-sealed journal or decrease its Seal.Offset,
+// or if a Delete of |req| would remove a currently-sealed journal. The KeySpace
+// is first read through the largest ExpectModRevision of |req|. Each change is
+// checked against the journal's JournalSpec (if any) of that KeySpace, and a
+// returned Cmp of the Apply transaction verifies the journal is unchanged from
+// the checked JournalSpec. If it has since changed, the Apply fails.
+func verifyApplyPreservesSeals(ctx context.Context, s *allocator.State, req *pb.ApplyRequest) (pb.Status, []clientv3.Cmp, error) {
+	var maxRevision int64
+	for _, change := range req.Changes {
+		if change.ExpectModRevision > maxRevision {
+			maxRevision = change.ExpectModRevision
+		}
+	}
+
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	if err := s.KS.WaitForRevision(ctx, maxRevision); err != nil {
+		return pb.Status_OK, nil, err
+	}
+	var cmp []clientv3.Cmp
+
+	for _, change := range req.Changes {
+		var name = change.Delete
+		if change.Upsert != nil {
+			name = change.Upsert.Name
+		}
+		var key = allocator.ItemKey(s.KS, name.String())
+		var checkedRevision int64
+
+		if ind, ok := s.Items.Search(key); ok {
+			var cur = s.Items[ind].Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)
+			checkedRevision = s.Items[ind].Raw.ModRevision
+
+			if cur.Seal == nil {
+				// Pass.
+			} else if change.Upsert == nil {
+				return pb.Status_JOURNAL_SEALED, nil, nil
+			} else if next := change.Upsert.Seal; next == nil || next.Offset < cur.Seal.Offset {
+				return pb.Status_JOURNAL_SEALED, nil, nil
+			}
+		}
+		cmp = append(cmp, clientv3.Compare(clientv3.ModRevision(key), "=", checkedRevision))
+	}
+	return pb.Status_OK, cmp, nil
+}
+
+// verifyApplyPathTemplates returns an error if an Upsert of |req| would change
+// the PathTemplate of a current journal such that Fragments persisted under
+// its current template no longer match the template's PathPostfixPattern.
+// Listings of the journal would otherwise omit those Fragments, and their
+// content would be lost to readers. Fragments of the flat layout are always
+// listed, so a journal of the flat layout may adopt any PathTemplate. Note
+// the check is against the KeySpace as last read by verifyApplyPreservesSeals.
+func verifyApplyPathTemplates(s *allocator.State, req *pb.ApplyRequest) error {
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	for _, change := range req.Changes {
+		if change.Upsert == nil {
+			continue
+		}
+		var ind, ok = s.Items.Search(allocator.ItemKey(s.KS, change.Upsert.Name.String()))
+		if !ok {
+			continue
+		}
+		var cur = s.Items[ind].Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)
+
+		var curRe = cur.Fragment.PathPostfixPattern()
+		if curRe == nil {
+			continue // Flat layout.
+		} else if nextRe := change.Upsert.Fragment.PathPostfixPattern(); nextRe == nil || nextRe.String() != curRe.String() {
+			return pb.NewValidationError("PathTemplate of journal %s may not change from %q to %q (persisted Fragments would no longer be listed)",
+				cur.Name, cur.Fragment.PathTemplate, change.Upsert.Fragment.PathTemplate)
+		}
+	}
+	return nil
+}
+
+// verifyApplyAliases returns an error if, after applying |req| to the current
+// KeySpace, a JournalSpec alias would be the Name of a journal or an alias of
+// another journal. Note the check is against the KeySpace as last read by
+// verifyApplyPreservesSeals.
+func verifyApplyAliases(s *allocator.State, req *pb.ApplyRequest) error {
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	// Build the effective set of journals, and their aliases.
+	var journals = make(map[pb.Journal][]pb.Journal, len(s.Items))
+	for _, kv := range s.Items {
+		var spec = kv.Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)
+		journals[spec.Name] = spec.Aliases
+	}
+	for _, change := range req.Changes {
+		if change.Upsert != nil {
+			journals[change.Upsert.Name] = change.Upsert.Aliases
+		} else {
+			delete(journals, change.Delete)
+		}
+	}
+
+	var aliases = make(map[pb.Journal]pb.Journal)
+	for name, ja := range journals {
+		for _, alias := range ja {
+			if _, ok := journals[alias]; ok {
+				return pb.NewValidationError("alias %s of journal %s is the name of a journal", alias, name)
+			} else if other, ok := aliases[alias]; ok {
+				return pb.NewValidationError("alias %s of journal %s is also an alias of journal %s", alias, name, other)
+			}
+			aliases[alias] = name
+		}
+	}
+	return nil
+}
@@ -118,6 +118,31 @@ func TestListCases(t *testing.T) {
 	})
 	assert.Regexp(t, `.* Selector.Include.Labels\["prefix"\]: expected trailing '/' (.*)`, err)
 
+	// Case: Listings are paginated by PageLimit.
+	resp, err = broker.client().List(ctx, &pb.ListRequest{PageLimit: 2})
+	assert.NoError(t, err)
+	verify(resp, specA, specC)
+	assert.NotEmpty(t, resp.NextPageToken)
+	var token = resp.NextPageToken
+
+	resp, err = broker.client().List(ctx, &pb.ListRequest{PageLimit: 2, PageToken: token})
+	assert.NoError(t, err)
+	verify(resp, specB)
+	assert.Empty(t, resp.NextPageToken)
+
+	// Case: A page which exactly completes the listing has no NextPageToken.
+	resp, err = broker.client().List(ctx, &pb.ListRequest{
+		Selector:  pb.LabelSelector{Include: pb.MustLabelSet("prefix", "journal/1/")},
+		PageLimit: 2,
+	})
+	assert.NoError(t, err)
+	verify(resp, specA, specC)
+	assert.Empty(t, resp.NextPageToken)
+
+	// Case: Errors on an invalid PageToken.
+	_, err = broker.client().List(ctx, &pb.ListRequest{PageToken: "#invalid#"})
+	assert.Regexp(t, `.* invalid PageToken .*`, err)
+
 	broker.cleanup()
 }
 
@@ -202,11 +227,268 @@ func TestApplyCases(t *testing.T) {
 			},
 		})).Status)
 
-	// Case: Invalid requests fail with an error.
+	// Case: Seal spec B.
+	var unsealedB = specB
+	specB.Seal = &pb.JournalSpec_Seal{Offset: 1024}
+
+	assert.Equal(t, pb.Status_OK,
+		must(broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Upsert: &specB, ExpectModRevision: verifyAndFetchRev("journal/B", unsealedB)},
+			},
+		})).Status)
+
+	// Case: Updates which would remove the seal, or move it backwards, fail.
+	var backwardsB = specB
+	backwardsB.Seal = &pb.JournalSpec_Seal{Offset: 512}
+
+	for _, spec := range []pb.JournalSpec{unsealedB, backwardsB} {
+		assert.Equal(t, pb.Status_JOURNAL_SEALED,
+			must(broker.client().Apply(ctx, &pb.ApplyRequest{
+				Changes: []pb.ApplyRequest_Change{
+					{Upsert: &spec, ExpectModRevision: verifyAndFetchRev("journal/B", specB)},
+				},
+			})).Status)
+	}
+
+	// Case: Other updates of a sealed spec, including moving its seal
+	// forward, are permitted.
+	var sealedB = specB
+	specB.Seal = &pb.JournalSpec_Seal{Offset: 2048}
+	specB.Replication = 2
+
+	assert.Equal(t, pb.Status_OK,
+		must(broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Upsert: &specB, ExpectModRevision: verifyAndFetchRev("journal/B", sealedB)},
+			},
+		})).Status)
+
+	// Case: Deletion of a sealed spec fails.
+	assert.Equal(t, pb.Status_JOURNAL_SEALED,
+		must(broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Delete: "journal/B", ExpectModRevision: verifyAndFetchRev("journal/B", specB)},
+			},
+		})).Status)
+
+	// Case: Aliases may be added to a spec.
+	var aliasedB = specB
+	aliasedB.Aliases = []pb.Journal{"journal/prior/B"}
+
+	assert.Equal(t, pb.Status_OK,
+		must(broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Upsert: &aliasedB, ExpectModRevision: verifyAndFetchRev("journal/B", specB)},
+			},
+		})).Status)
+	specB = aliasedB
+
+	// Case: Aliases which name a journal, or which are an alias of another
+	// journal (including one of the same request), fail with an error.
+	var specC = pb.JournalSpec{
+		Name:        "journal/C",
+		Replication: 1,
+		Fragment:    fragSpec,
+	}
+	for _, tc := range []struct {
+		aliases []pb.Journal
+		expect  string
+	}{
+		{[]pb.Journal{"journal/B"}, `alias journal/B of journal journal/C is the name of a journal`},
+		{[]pb.Journal{"journal/prior/B"}, `alias journal/prior/B of journal journal/[BC] is also an alias of journal journal/[BC]`},
+	} {
+		specC.Aliases = tc.aliases
+
+		var _, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{{Upsert: &specC}},
+		})
+		assert.Regexp(t, tc.expect, err)
+	}
+	specC.Aliases = nil
+
 	var _, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{
+			{Upsert: &specC},
+			{Upsert: &pb.JournalSpec{Name: "journal/D", Replication: 1, Fragment: fragSpec,
+				Aliases: []pb.Journal{"journal/C"}}},
+		},
+	})
+	assert.Regexp(t, `alias journal/C of journal journal/D is the name of a journal`, err)
+
+	// Case: A journal of the flat layout may adopt a PathTemplate.
+	var templatedB = specB
+	templatedB.Fragment.PathTemplate = "{{.Journal}}/{{.Year}}/{{.Month}}/"
+
+	assert.Equal(t, pb.Status_OK,
+		must(broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Upsert: &templatedB, ExpectModRevision: verifyAndFetchRev("journal/B", specB)},
+			},
+		})).Status)
+	specB = templatedB
+
+	// Case: A PathTemplate may be re-written without changing its shape.
+	templatedB.Fragment.PathTemplate = "{{.Journal}}/{{ .Year }}/{{ .Month }}/"
+
+	assert.Equal(t, pb.Status_OK,
+		must(broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Upsert: &templatedB, ExpectModRevision: verifyAndFetchRev("journal/B", specB)},
+			},
+		})).Status)
+	specB = templatedB
+
+	// Case: Changes of a PathTemplate's shape, or its removal, fail.
+	for _, tmpl := range []string{"", "{{.Journal}}/{{.Year}}/", "{{.Journal}}/{{.Year}}-{{.Month}}/"} {
+		templatedB.Fragment.PathTemplate = tmpl
+
+		_, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{
+				{Upsert: &templatedB, ExpectModRevision: verifyAndFetchRev("journal/B", specB)},
+			},
+		})
+		assert.Regexp(t, `PathTemplate of journal journal/B may not change from .*`, err)
+	}
+
+	// Case: Invalid requests fail with an error.
+	_, err = broker.client().Apply(ctx, &pb.ApplyRequest{
 		Changes: []pb.ApplyRequest_Change{{Delete: "invalid journal name"}},
 	})
 	assert.Regexp(t, `.* Changes\[0\].Delete: not a valid token \(invalid journal name\)`, err)
 
 	broker.cleanup()
 }
+
+func TestApplySealChecksAreTransactional(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var spec = pb.JournalSpec{
+		Name:        "journal/A",
+		Replication: 1,
+		Fragment: pb.JournalSpec_Fragment{
+			Length:           1024,
+			RefreshInterval:  time.Second,
+			CompressionCodec: pb.CompressionCodec_SNAPPY,
+		},
+	}
+	var resp, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &spec}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, resp.Status)
+
+	// Check an update, and deletion, of the unsealed journal.
+	var updated = spec
+	updated.Replication = 2
+
+	status, cmp, err := verifyApplyPreservesSeals(ctx, broker.svc.resolver.state, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{
+			{Upsert: &updated, ExpectModRevision: resp.Header.Etcd.Revision},
+			{Delete: "journal/A", ExpectModRevision: resp.Header.Etcd.Revision},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, status)
+	assert.Len(t, cmp, 2)
+
+	// The journal is sealed before the Apply transaction is evaluated.
+	var sealed = spec
+	sealed.Seal = &pb.JournalSpec_Seal{Offset: 1024}
+	_, err = etcd.Put(ctx, allocator.ItemKey(broker.ks, "journal/A"), sealed.MarshalString())
+	assert.NoError(t, err)
+
+	// Expect the transaction fails, as the checked spec has since changed.
+	txnResp, err := etcd.Txn(ctx).If(cmp...).Commit()
+	assert.NoError(t, err)
+	assert.False(t, txnResp.Succeeded)
+
+	broker.cleanup()
+}
+
+func TestApplyDryRun(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+
+	var fragSpec = pb.JournalSpec_Fragment{
+		Length:           1024,
+		RefreshInterval:  time.Second,
+		CompressionCodec: pb.CompressionCodec_SNAPPY,
+	}
+	var specA = pb.JournalSpec{Name: "journal/A", Replication: 1, Fragment: fragSpec}
+	var specB = pb.JournalSpec{Name: "journal/B", Replication: 1, Fragment: fragSpec}
+	var specC = pb.JournalSpec{Name: "journal/C", Replication: 1, Fragment: fragSpec}
+
+	var resp, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &specA}, {Upsert: &specB}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, resp.Status)
+
+	var list = func() *pb.ListResponse {
+		var resp, err = broker.client().List(ctx, &pb.ListRequest{})
+		assert.NoError(t, err)
+		return resp
+	}
+	var before = list()
+	var revA, revB = before.Journals[0].ModRevision, before.Journals[1].ModRevision
+
+	// Case: a dry-run reports journals which would be created, updated, and
+	// deleted, at the evaluated KeySpace revision.
+	var updatedA = specA
+	updatedA.Replication = 2
+
+	var changes = []pb.ApplyRequest_Change{
+		{Upsert: &specC},
+		{Upsert: &updatedA, ExpectModRevision: revA},
+		{Delete: "journal/B", ExpectModRevision: revB},
+	}
+	resp, err = broker.client().Apply(ctx, &pb.ApplyRequest{Changes: changes, DryRun: true})
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Validate())
+	assert.Equal(t, pb.Status_OK, resp.Status)
+	assert.Equal(t, []pb.Journal{"journal/C"}, resp.DryRunCreated)
+	assert.Equal(t, []pb.Journal{"journal/A"}, resp.DryRunUpdated)
+	assert.Equal(t, []pb.Journal{"journal/B"}, resp.DryRunDeleted)
+	assert.Equal(t, before.Header.Etcd.Revision, resp.Header.Etcd.Revision)
+
+	// Expect no changes were applied.
+	var after = list()
+	assert.Equal(t, before.Journals, after.Journals)
+	assert.Equal(t, before.Header.Etcd.Revision, after.Header.Etcd.Revision)
+
+	// Case: a dry-run at a wrong revision fails as the Apply would.
+	resp, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &updatedA, ExpectModRevision: revA - 1}},
+		DryRun:  true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_ETCD_TRANSACTION_FAILED, resp.Status)
+	assert.Empty(t, resp.DryRunUpdated)
+
+	// Case: a dry-run which fails validation returns an error.
+	var aliasedC = specC
+	aliasedC.Aliases = []pb.Journal{"journal/A"}
+
+	_, err = broker.client().Apply(ctx, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: &aliasedC}},
+		DryRun:  true,
+	})
+	assert.Regexp(t, `alias journal/A of journal journal/C is the name of a journal`, err)
+
+	// Case: a subsequent Apply of the dry-run changes succeeds.
+	resp, err = broker.client().Apply(ctx, &pb.ApplyRequest{Changes: changes})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.Status_OK, resp.Status)
+
+	after = list()
+	assert.Len(t, after.Journals, 2)
+	assert.Equal(t, updatedA, after.Journals[0].Spec)
+	assert.Equal(t, specC, after.Journals[1].Spec)
+
+	broker.cleanup()
+}
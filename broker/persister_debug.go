@@ -0,0 +1,21 @@
+package broker
+
+import (
+	"net/http"
+
+	"go.gazette.dev/core/broker/fragment"
+)
+
+// PersisterDebugHandler returns an http.Handler which serves the status of
+// the Persister (see Persister.ServeHTTP). Anyone may GET the status, but a
+// POST which pauses or resumes persistence must present |token| as a Bearer
+// token in its Authorization header. If |token| is empty, all POSTs are refused.
+func PersisterDebugHandler(p *fragment.Persister, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && !authorizedDebugRequest(r, token) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		p.ServeHTTP(w, r)
+	})
+}
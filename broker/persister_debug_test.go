@@ -0,0 +1,59 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.gazette.dev/core/broker/fragment"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+func TestPersisterDebugHandler(t *testing.T) {
+	var persister = fragment.NewPersister(NewKeySpace("/root"))
+
+	var do = func(h http.Handler, method, token, form string) (code int, paused []pb.Journal) {
+		var req = httptest.NewRequest(method, "/debug/persister", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		var w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		var status struct{ Paused []pb.Journal }
+		if w.Code == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&status))
+		}
+		return w.Code, status.Paused
+	}
+	var h = PersisterDebugHandler(persister, "secret")
+
+	// Case: status may be read without a token.
+	var code, paused = do(h, "GET", "", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, paused)
+
+	// Case: POSTs without a matching token are refused.
+	code, _ = do(h, "POST", "", "action=pause")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = do(h, "POST", "wrong", "action=pause")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Case: an authorized POST pauses persistence.
+	code, paused = do(h, "POST", "secret", "action=pause&journal=a/journal")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []pb.Journal{"a/journal"}, paused)
+
+	// Case: if no token is configured, all POSTs are refused.
+	h = PersisterDebugHandler(persister, "")
+
+	code, _ = do(h, "POST", "", "action=resume&journal=a/journal")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, paused = do(h, "GET", "", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []pb.Journal{"a/journal"}, paused)
+}
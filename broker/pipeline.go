@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/metrics"
 )
 
 // pipeline is an in-flight write replication pipeline of a journal.
@@ -21,10 +23,19 @@ type pipeline struct {
 	readBarrierCh chan struct{}                // Coordinates hand-off of receive-side of the pipeline.
 	recvResp      []pb.ReplicateResponse       // Most recent response gathered from each peer.
 	recvErrs      []error                      // First error on receive from each peer.
+	recvAt        []time.Time                  // Time of each peer's receive, not yet observed by observeAcks.
+	// JournalSpec WriteReplication with which the pipeline was built.
+	writeReplication int
+	// History into which scattered proposals are recorded, or nil.
+	history *pipelineHistory
+	// Content bytes scattered since the last proposal.
+	scattered int64
 }
 
-// newPipeline returns a new pipeline.
-func newPipeline(ctx context.Context, hdr pb.Header, spool fragment.Spool, returnCh chan<- fragment.Spool, jc pb.JournalClient) *pipeline {
+// newPipeline returns a new pipeline. If |writeReplication| is non-zero and
+// less than the number of Route members, the pipeline replicates only to the
+// primary and the (writeReplication - 1) members which follow it in the Route.
+func newPipeline(ctx context.Context, hdr pb.Header, spool fragment.Spool, returnCh chan<- fragment.Spool, jc pb.JournalClient, writeReplication int) *pipeline {
 	if hdr.Route.Primary == -1 {
 		panic("dial requires Route with Primary != -1")
 	}
@@ -39,13 +50,18 @@ func newPipeline(ctx context.Context, hdr pb.Header, spool fragment.Spool, retur
 		readBarrierCh: make(chan struct{}),
 		recvResp:      make([]pb.ReplicateResponse, R),
 		recvErrs:      make([]error, R),
+		recvAt:        make([]time.Time, R),
+
+		writeReplication: writeReplication,
 	}
 	close(pln.readBarrierCh)
 
-	for i := range pln.Route.Members {
-		if i == int(pln.Route.Primary) {
-			continue
-		}
+	if writeReplication == 0 || writeReplication > R {
+		writeReplication = R
+	}
+	for j := 1; j < writeReplication; j++ {
+		var i = (int(pln.Route.Primary) + j) % R
+
 		pln.streams[i], pln.sendErrs[i] = jc.Replicate(
 			pb.WithDispatchRoute(ctx, pln.Route, pln.Route.Members[i]))
 	}
@@ -54,6 +70,9 @@ func newPipeline(ctx context.Context, hdr pb.Header, spool fragment.Spool, retur
 
 // scatter asynchronously applies the ReplicateRequest to all replicas.
 func (pln *pipeline) scatter(r *pb.ReplicateRequest) {
+	if pln.history != nil {
+		pln.recordHistory(r)
+	}
 	for i, s := range pln.streams {
 		if s != nil && pln.sendErrs[i] == nil {
 			if r.Header != nil {
@@ -62,7 +81,14 @@ func (pln *pipeline) scatter(r *pb.ReplicateRequest) {
 			}
 			// Send may return an io.EOF if the remote peer breaks the stream.
 			// We read the actual error in the gather() phase.
+			var began = time.Now()
 			pln.sendErrs[i] = s.Send(r)
+
+			var peer = peerLabel(pln.Route.Members[i])
+			metrics.PipelinePeerSendSeconds.WithLabelValues(peer).Observe(time.Since(began).Seconds())
+			if pln.sendErrs[i] != nil {
+				metrics.PipelinePeerErrorsTotal.WithLabelValues(peer, "send").Inc()
+			}
 		}
 	}
 	if i := pln.Route.Primary; pln.sendErrs[i] == nil {
@@ -123,6 +149,7 @@ func (pln *pipeline) gather() {
 	for i, s := range pln.streams {
 		if s != nil && pln.recvErrs[i] == nil {
 			pln.recvErrs[i] = s.RecvMsg(&pln.recvResp[i])
+			pln.recvAt[i] = time.Now()
 
 			// Map EOF to ErrUnexpectedEOF, as EOFs should only be
 			// read by gatherEOF().
@@ -146,6 +173,60 @@ func (pln *pipeline) gatherOK() {
 	}
 }
 
+// gatherQuorumOK receives a ReplicateResponse from all replicas, treating
+// any non-OK response status as an error. Unlike gatherOK, it returns as soon
+// as |quorum| replicas (including the primary) have responded OK, or any
+// replica fails. The returned error, if any, is that of the failed replica.
+// Responses of remaining replicas continue to be gathered, and the returned
+// channel is closed once all have been. Until then, the caller must not access
+// |recvResp| or |recvErrs|, nor may other clients read from the pipeline.
+func (pln *pipeline) gatherQuorumOK(quorum int) (<-chan struct{}, error) {
+	var readyCh, doneCh = make(chan int, len(pln.streams)), make(chan struct{})
+	var acks, pending = 1, 0 // The primary's Spool has already applied.
+	var err error
+
+	for i, s := range pln.streams {
+		if s == nil {
+			continue
+		} else if pln.recvErrs[i] != nil && err == nil {
+			err = errors.WithMessagef(pln.recvErrs[i], "recv from %s", &pln.Route.Members[i])
+			continue
+		}
+		pending++
+
+		go func(i int, s pb.Journal_ReplicateClient) {
+			var err = s.RecvMsg(&pln.recvResp[i])
+			pln.recvAt[i] = time.Now()
+
+			if err == io.EOF {
+				pln.recvErrs[i] = io.ErrUnexpectedEOF // As with gather().
+			} else if err != nil {
+				pln.recvErrs[i] = err
+			} else if pln.recvResp[i].Status != pb.Status_OK {
+				pln.recvErrs[i] = fmt.Errorf("unexpected !OK response: %s", &pln.recvResp[i])
+			}
+			readyCh <- i
+		}(i, s)
+	}
+
+	for ; err == nil && acks < quorum && pending != 0; pending-- {
+		if i := <-readyCh; pln.recvErrs[i] != nil {
+			err = errors.WithMessagef(pln.recvErrs[i], "recv from %s", &pln.Route.Members[i])
+		} else {
+			acks++
+		}
+	}
+
+	go func(pending int) {
+		for ; pending != 0; pending-- {
+			<-readyCh
+		}
+		close(doneCh)
+	}(pending)
+
+	return doneCh, err
+}
+
 // gatherSync calls gather, extracts and returns a peer-advertised future offset
 // or etcd revision to read through relative to |proposal|, and treats any other
 // non-OK response status as an error.
@@ -209,6 +290,25 @@ func (pln *pipeline) gatherEOF() {
 	}
 }
 
+// observeAcks records metrics of peer responses gathered since the last call
+// to observeAcks, of an acknowledged proposal which was sent at |sentAt|.
+// Like |recvResp|, it may be called only by the owner of the receive-side.
+func (pln *pipeline) observeAcks(sentAt time.Time) {
+	for i := range pln.recvAt {
+		if pln.recvAt[i].IsZero() {
+			continue
+		}
+		var peer = peerLabel(pln.Route.Members[i])
+
+		if pln.recvErrs[i] != nil {
+			metrics.PipelinePeerErrorsTotal.WithLabelValues(peer, "recv").Inc()
+		} else {
+			metrics.PipelinePeerAckSeconds.WithLabelValues(peer).Observe(pln.recvAt[i].Sub(sentAt).Seconds())
+		}
+		pln.recvAt[i] = time.Time{}
+	}
+}
+
 // recvErr returns the first encountered receive-side error.
 func (pln *pipeline) recvErr() error {
 	for i, err := range pln.recvErrs {
@@ -242,6 +342,11 @@ func (pln *pipeline) String() string {
 	return fmt.Sprintf("pipeline<header: %s, spool: %s>", &pln.Header, pln.spool.String())
 }
 
+// peerLabel maps a peer ProcessSpec_ID to its metric label value.
+func peerLabel(id pb.ProcessSpec_ID) string {
+	return id.Zone + ":" + id.Suffix
+}
+
 func boxHeaderProcessID(hdr pb.Header, id pb.ProcessSpec_ID) *pb.Header {
 	var out = new(pb.Header)
 	*out = hdr
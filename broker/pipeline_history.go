@@ -0,0 +1,137 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// pipelineHistorySize is the number of most-recent proposals retained by
+// the pipelineHistory of each replica.
+const pipelineHistorySize = 256
+
+// pipelineEvent is a proposal scattered by a replication pipeline to its peers.
+type pipelineEvent struct {
+	// Time at which the proposal was scattered.
+	Time time.Time
+	// Route and Etcd revision of the pipeline which scattered the proposal.
+	Route    pb.Route
+	Revision int64
+	// Sync is true iff the proposal began the synchronization of a new pipeline.
+	Sync bool
+	// Proposal scattered to peers, and whether acknowledgement was requested.
+	Proposal    pb.Fragment
+	Acknowledge bool
+	// Number of content bytes scattered between the prior proposal and this
+	// one. A proposal having content is a commit of that content.
+	ContentLength int64
+}
+
+// pipelineHistory is a bounded ring buffer of the pipelineEvents of a
+// replica, retained across the replica's successive pipelines to aid in
+// diagnosing divergence of replication peers. Content itself is not retained.
+type pipelineHistory struct {
+	mu     sync.Mutex
+	events []pipelineEvent // Ring buffer of capacity |size|.
+	next   int             // Index of |events| at which to record the next event.
+	size   int
+}
+
+func newPipelineHistory(size int) *pipelineHistory {
+	return &pipelineHistory{
+		events: make([]pipelineEvent, 0, size),
+		size:   size,
+	}
+}
+
+// record the pipelineEvent, evicting the oldest event if the history is full.
+func (h *pipelineHistory) record(ev pipelineEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.events) < h.size {
+		h.events = append(h.events, ev)
+	} else {
+		h.events[h.next] = ev
+	}
+	h.next = (h.next + 1) % h.size
+}
+
+// snapshot returns a copy of recorded pipelineEvents, ordered oldest first.
+func (h *pipelineHistory) snapshot() []pipelineEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var out = make([]pipelineEvent, 0, len(h.events))
+	if len(h.events) == h.size {
+		out = append(out, h.events[h.next:]...)
+		out = append(out, h.events[:h.next]...)
+	} else {
+		out = append(out, h.events...)
+	}
+	return out
+}
+
+// PipelineHistoryDebugHandler returns an http.Handler which lists the most
+// recent proposals and commits scattered by the replication pipelines of a
+// journal, as retained by its replica. It's intended to aid operators in
+// diagnosing why replication peers of a journal diverged. Requests are GETs
+// having form value "journal", and are not proxied: only the broker's own
+// replica history is returned, and only a primary broker scatters proposals.
+// The response is a JSON array of events ordered oldest first. Content bytes
+// are not retained, and only their length is returned.
+func (svc *Service) PipelineHistoryDebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "expected GET", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var journal = pb.Journal(r.FormValue("journal"))
+		if err := journal.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var res, err = svc.resolver.resolve(resolveArgs{
+			ctx:      r.Context(),
+			journal:  journal,
+			mayProxy: false,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if res.status != pb.Status_OK {
+			http.Error(w, res.status.String(), http.StatusNotFound)
+			return
+		}
+		var out = res.replica.history.snapshot()
+
+		w.Header().Set("Content-Type", "application/json")
+		var enc = json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	})
+}
+
+// recordHistory records a proposal of the ReplicateRequest into the pipeline
+// history, or accumulates the length of its content.
+func (pln *pipeline) recordHistory(r *pb.ReplicateRequest) {
+	if r.Proposal == nil {
+		pln.scattered += int64(len(r.Content))
+		return
+	}
+	pln.history.record(pipelineEvent{
+		Time:          time.Now(),
+		Route:         pln.Route,
+		Revision:      pln.Etcd.Revision,
+		Sync:          r.Header != nil,
+		Proposal:      *r.Proposal,
+		Acknowledge:   r.Acknowledge,
+		ContentLength: pln.scattered,
+	})
+	pln.scattered = 0
+}
@@ -0,0 +1,126 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/etcdtest"
+)
+
+func TestPipelineHistoryRingBuffer(t *testing.T) {
+	var h = newPipelineHistory(3)
+	var offsets = func() (out []int64) {
+		for _, ev := range h.snapshot() {
+			out = append(out, ev.Proposal.End)
+		}
+		return
+	}
+	assert.Len(t, h.snapshot(), 0)
+
+	for i := int64(1); i <= 2; i++ {
+		h.record(pipelineEvent{Proposal: pb.Fragment{End: i}})
+	}
+	assert.Equal(t, []int64{1, 2}, offsets())
+
+	// Once full, the oldest events are evicted.
+	for i := int64(3); i <= 7; i++ {
+		h.record(pipelineEvent{Proposal: pb.Fragment{End: i}})
+	}
+	assert.Equal(t, []int64{5, 6, 7}, offsets())
+}
+
+func TestPipelineHistoryDebugHandler(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var peer = newMockBroker(t, etcd, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Replication: 2,
+		Fragment: pb.JournalSpec_Fragment{
+			Length:           1 << 20,
+			CompressionCodec: pb.CompressionCodec_NONE,
+			FlushInterval:    time.Hour,
+		},
+	}, broker.id, peer.id)
+	broker.initialFragmentLoad()
+
+	var srv = httptest.NewServer(broker.svc.PipelineHistoryDebugHandler())
+	defer srv.Close()
+
+	// Perform several appends, each of which the peer acknowledges.
+	var appendCh = make(chan struct{})
+	go func() {
+		defer close(appendCh)
+
+		for _, content := range []string{"foo", "barbaz", "bing"} {
+			var stream, _ = broker.client().Append(ctx)
+			assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal"}))
+			assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte(content)}))
+			assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+			var _, err = stream.CloseAndRecv()
+			assert.NoError(t, err)
+		}
+	}()
+
+	var pln = <-peer.ReplReqCh // Pipeline synchronization proposal.
+	assert.NotNil(t, pln.Header)
+	peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+
+	for i := 0; i != 3; i++ {
+		if i == 1 {
+			// A journal's first write is always rolled. The second append
+			// first scatters an un-acknowledged proposal of the roll.
+			assert.False(t, (<-peer.ReplReqCh).Acknowledge)
+		}
+		assert.NotNil(t, (<-peer.ReplReqCh).Content)  // Content.
+		assert.NotNil(t, (<-peer.ReplReqCh).Proposal) // Commit.
+		peer.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+	}
+	<-appendCh
+
+	var resp, err = http.Get(srv.URL + "?journal=a/journal")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var events []pipelineEvent
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+	resp.Body.Close()
+
+	type summary struct {
+		Sync, Ack     bool
+		Begin, End    int64
+		ContentLength int64
+	}
+	var summaries []summary
+	for _, ev := range events {
+		assert.Equal(t, broker.id, ev.Route.Members[ev.Route.Primary])
+		summaries = append(summaries, summary{ev.Sync, ev.Acknowledge,
+			ev.Proposal.Begin, ev.Proposal.End, ev.ContentLength})
+	}
+	// Expect the pipeline synchronization, the roll, and each append's commit.
+	assert.Equal(t, []summary{
+		{Sync: true, Ack: true, Begin: 0, End: 0},
+		{Ack: true, Begin: 0, End: 3, ContentLength: 3},
+		{Begin: 3, End: 3},
+		{Ack: true, Begin: 3, End: 9, ContentLength: 6},
+		{Ack: true, Begin: 3, End: 13, ContentLength: 4},
+	}, summaries)
+
+	// Case: the journal must be valid.
+	resp, err = http.Get(srv.URL + "?journal=/invalid")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+
+	peer.ErrCh <- nil // Peer closes.
+	broker.cleanup()
+	peer.Cleanup()
+}
@@ -5,11 +5,15 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"go.gazette.dev/core/broker/fragment"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/broker/teststub"
+	"go.gazette.dev/core/metrics"
 )
 
 func TestPipelineBasicLifeCycle(t *testing.T) {
@@ -139,6 +143,64 @@ func TestPipelinePeerErrorCases(t *testing.T) {
 	assert.EqualError(t, pln.recvErrs[2], `unexpected response: status:WRONG_ROUTE `)
 }
 
+func TestPipelinePeerMetrics(t *testing.T) {
+	var ctx, rm = context.Background(), newReplicationMock(t)
+	defer rm.cleanup()
+
+	var sampleCount = func(h prometheus.Histogram) uint64 {
+		var m dto.Metric
+		assert.NoError(t, h.Write(&m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	var counter = func(c prometheus.Counter) float64 {
+		var m dto.Metric
+		assert.NoError(t, c.Write(&m))
+		return m.GetCounter().GetValue()
+	}
+	var (
+		sendA   = metrics.PipelinePeerSendSeconds.WithLabelValues("A:1")
+		sendC   = metrics.PipelinePeerSendSeconds.WithLabelValues("C:3")
+		ackA    = metrics.PipelinePeerAckSeconds.WithLabelValues("A:1")
+		ackC    = metrics.PipelinePeerAckSeconds.WithLabelValues("C:3")
+		recvErr = metrics.PipelinePeerErrorsTotal.WithLabelValues("C:3", "recv")
+
+		sendA0, sendC0, ackA0, ackC0, recvErr0 = sampleCount(sendA), sampleCount(sendC),
+			sampleCount(ackA), sampleCount(ackC), counter(recvErr)
+	)
+
+	var pln = rm.newPipeline(ctx, rm.header(1, 100))
+
+	var sentAt = time.Now()
+	pln.scatter(&pb.ReplicateRequest{Content: []byte("foo")})
+	_, _ = <-rm.brokerA.ReplReqCh, <-rm.brokerC.ReplReqCh
+
+	// Expect each peer send was observed.
+	assert.Equal(t, sendA0+1, sampleCount(sendA))
+	assert.Equal(t, sendC0+1, sampleCount(sendC))
+
+	// Peer A acknowledges, while peer C fails.
+	rm.brokerA.ReplRespCh <- &pb.ReplicateResponse{Status: pb.Status_OK}
+	rm.brokerC.ErrCh <- errors.New("error!")
+
+	pln.gatherOK()
+	pln.observeAcks(sentAt)
+
+	assert.Equal(t, ackA0+1, sampleCount(ackA))
+	assert.Equal(t, ackC0, sampleCount(ackC))
+	assert.Equal(t, recvErr0+1, counter(recvErr))
+
+	// Receives are observed only once.
+	pln.observeAcks(sentAt)
+
+	assert.Equal(t, ackA0+1, sampleCount(ackA))
+	assert.Equal(t, recvErr0+1, counter(recvErr))
+
+	pln.closeSend()
+	assert.Nil(t, <-rm.brokerA.ReplReqCh) // Read EOF.
+	rm.brokerA.ErrCh <- nil               // Send EOF.
+	pln.gatherEOF()
+}
+
 func TestPipelineGatherSyncCases(t *testing.T) {
 	var ctx, rm = context.Background(), newReplicationMock(t)
 	defer rm.cleanup()
@@ -304,7 +366,7 @@ func (m *replicationMock) header(id int, rev int64) *pb.Header {
 }
 
 func (m *replicationMock) newPipeline(ctx context.Context, hdr *pb.Header) *pipeline {
-	return newPipeline(ctx, *hdr, <-m.spoolCh, m.spoolCh, m.brokerA.Client())
+	return newPipeline(ctx, *hdr, <-m.spoolCh, m.spoolCh, m.brokerA.Client(), 0)
 }
 
 func (m *replicationMock) cleanup() {
@@ -5,19 +5,27 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"mime"
 	"path"
 	"strconv"
 	"strings"
 )
 
 // ContentName returns the content-addressed base file name of this Fragment.
+// If the Fragment has a ContentType, it's hex-encoded as a fourth field of the
+// name (preceding the extension), so that it survives Fragment persistence.
 func (m *Fragment) ContentName() string {
-	return fmt.Sprintf("%016x-%016x-%x%s", m.Begin, m.End,
-		m.Sum.ToDigest(), m.CompressionCodec.ToExtension())
+	var name = fmt.Sprintf("%016x-%016x-%x", m.Begin, m.End, m.Sum.ToDigest())
+	if m.ContentType != "" {
+		name += "-" + hex.EncodeToString([]byte(m.ContentType))
+	}
+	return name + m.CompressionCodec.ToExtension()
 }
 
 // ContentPath returns the content-addressed path of this Fragment.
-func (m *Fragment) ContentPath() string { return m.Journal.String() + "/" + m.ContentName() }
+func (m *Fragment) ContentPath() string {
+	return m.Journal.String() + "/" + m.PathPostfix + m.ContentName()
+}
 
 // ContentLength returns the number of content bytes contained in this Fragment.
 // If compression is used, this will differ from the file size of the Fragment.
@@ -31,6 +39,10 @@ func (m *Fragment) Validate() error {
 		return NewValidationError("expected Begin <= End (have %d, %d)", m.Begin, m.End)
 	} else if err = m.CompressionCodec.Validate(); err != nil {
 		return ExtendContext(err, "CompressionCodec")
+	} else if err = validateContentType(m.ContentType); err != nil {
+		return ExtendContext(err, "ContentType")
+	} else if err = validatePathPostfix(m.PathPostfix); err != nil {
+		return ExtendContext(err, "PathPostfix")
 	}
 	return nil
 }
@@ -40,6 +52,39 @@ func ParseContentPath(p string) (Fragment, error) {
 	return ParseContentName(Journal(path.Dir(p)), path.Base(p))
 }
 
+// ParseContentPostfixPath parses the path of a Fragment of |journal|, relative
+// to the journal's directory, into a Fragment having a PathPostfix of the
+// path's directories (if any) or returns an error.
+func ParseContentPostfixPath(journal Journal, p string) (Fragment, error) {
+	var ind = strings.LastIndexByte(p, '/')
+
+	var f, err = ParseContentName(journal, p[ind+1:])
+	if err != nil {
+		return Fragment{}, err
+	}
+	f.PathPostfix = p[:ind+1]
+	return f, f.Validate()
+}
+
+// validatePathPostfix returns an error if the PathPostfix |p| is not empty or
+// a clean, relative directory path having a trailing '/'. In addition to
+// token characters, '=' is permitted to allow for Hive-style "key=value"
+// partitioning of directories.
+func validatePathPostfix(p string) error {
+	if p == "" {
+		return nil
+	} else if l := len(p); l < 2 || l > maxJournalNameLen {
+		return NewValidationError("invalid length (%d; expected 2 <= length <= %d)", l, maxJournalNameLen)
+	} else if len(strings.Trim(p, tokenAlphabet+"=")) != 0 {
+		return NewValidationError("not a valid token (%s)", p)
+	} else if !strings.HasSuffix(p, "/") {
+		return NewValidationError("expected trailing '/' (%s)", p)
+	} else if d := p[:len(p)-1]; path.Clean(d) != d || d[0] == '/' || d[0] == '.' {
+		return NewValidationError("must be a clean relative path (%s)", p)
+	}
+	return nil
+}
+
 // ParseContentName parses a Journal and ContentName into a Fragment, or returns an error.
 func ParseContentName(journal Journal, name string) (Fragment, error) {
 	var f Fragment
@@ -47,7 +92,15 @@ func ParseContentName(journal Journal, name string) (Fragment, error) {
 	var ext = path.Ext(name)
 	name = name[:len(name)-len(ext)]
 
-	if fields := strings.Split(name, "-"); len(fields) != 3 {
+	var fields = strings.Split(name, "-")
+	var contentType []byte
+	var ctErr error
+
+	if len(fields) == 4 {
+		contentType, ctErr = hex.DecodeString(fields[3])
+	}
+
+	if len(fields) != 3 && len(fields) != 4 {
 		return Fragment{}, NewValidationError("wrong Fragment format: %v", name)
 	} else if begin, err := strconv.ParseInt(fields[0], 16, 64); err != nil {
 		return Fragment{}, ExtendContext(&ValidationError{Err: err}, "Begin")
@@ -57,6 +110,8 @@ func ParseContentName(journal Journal, name string) (Fragment, error) {
 		return Fragment{}, ExtendContext(&ValidationError{Err: err}, "Sum")
 	} else if len(sum) != sha1.Size {
 		return Fragment{}, NewValidationError("invalid SHA1Sum length: %x", sum)
+	} else if ctErr != nil {
+		return Fragment{}, ExtendContext(&ValidationError{Err: ctErr}, "ContentType")
 	} else if cc, err := CompressionCodecFromExtension(ext); err != nil {
 		return Fragment{}, err
 	} else {
@@ -66,6 +121,7 @@ func ParseContentName(journal Journal, name string) (Fragment, error) {
 			End:              end,
 			Sum:              SHA1SumFromDigest(sum),
 			CompressionCodec: cc,
+			ContentType:      string(contentType),
 		}
 	}
 	return f, f.Validate()
@@ -116,6 +172,8 @@ func CompressionCodecFromExtension(ext string) (CompressionCodec, error) {
 		return CompressionCodec_SNAPPY, nil
 	case "", ".gzod":
 		return CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION, nil
+	case ".lz4":
+		return CompressionCodec_LZ4, nil
 	default:
 		return CompressionCodec_NONE, NewValidationError("unrecognized compression extension: %s", ext)
 	}
@@ -142,6 +200,8 @@ func (m CompressionCodec) ToExtension() string {
 		return ".sz"
 	case CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION:
 		return "" // TODO(johnny): Switch to ".gzod" when v2 broker fully released.
+	case CompressionCodec_LZ4:
+		return ".lz4"
 	default:
 		panic("invalid CompressionCodec")
 	}
@@ -168,3 +228,14 @@ func (m *CompressionCodec) UnmarshalYAML(unmarshal func(interface{}) error) erro
 		return nil
 	}
 }
+
+// validateContentType returns an error if non-empty |ct| doesn't parse as an
+// RFC 1521 MIME / media-type.
+func validateContentType(ct string) error {
+	if ct == "" {
+		return nil
+	} else if _, _, err := mime.ParseMediaType(ct); err != nil {
+		return &ValidationError{Err: err}
+	}
+	return nil
+}
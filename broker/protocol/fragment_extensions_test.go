@@ -27,6 +27,19 @@ func (s *FragmentSuite) TestContentName(c *gc.C) {
 	f.CompressionCodec = CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION
 	c.Check(f.ContentName(), gc.Equals,
 		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314")
+
+	f.CompressionCodec = CompressionCodec_LZ4
+	c.Check(f.ContentName(), gc.Equals,
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.lz4")
+
+	f.ContentType = "application/x-ndjson"
+	c.Check(f.ContentName(), gc.Equals,
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314-"+
+			"6170706c69636174696f6e2f782d6e646a736f6e.lz4")
+
+	var codec, err = CompressionCodecFromExtension(".lz4")
+	c.Check(err, gc.IsNil)
+	c.Check(codec, gc.Equals, CompressionCodec_LZ4)
 }
 
 func (s *FragmentSuite) TestContentPath(c *gc.C) {
@@ -39,6 +52,11 @@ func (s *FragmentSuite) TestContentPath(c *gc.C) {
 	}
 	c.Assert(f.ContentPath(), gc.Equals, "a/journal/name/"+
 		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
+
+	// A PathPostfix is placed between the Journal and ContentName.
+	f.PathPostfix = "date=2019-03-04/hour=05/"
+	c.Assert(f.ContentPath(), gc.Equals, "a/journal/name/date=2019-03-04/hour=05/"+
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
 }
 
 func (s *FragmentSuite) TestValidationCases(c *gc.C) {
@@ -61,6 +79,22 @@ func (s *FragmentSuite) TestValidationCases(c *gc.C) {
 	f.Journal = "foo/bar/baz"
 	f.CompressionCodec = 1 << 20
 	c.Check(f.Validate(), gc.ErrorMatches, "CompressionCodec: invalid value .*")
+
+	f.CompressionCodec = CompressionCodec_GZIP
+	f.ContentType = "not a / type"
+	c.Check(f.Validate(), gc.ErrorMatches, "ContentType: mime: .*")
+
+	f.ContentType = ""
+	f.PathPostfix = "2019/03"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathPostfix: expected trailing '/' \(2019/03\)`)
+	f.PathPostfix = "/2019/03/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathPostfix: must be a clean relative path \(/2019/03/\)`)
+	f.PathPostfix = "2019/./03/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathPostfix: must be a clean relative path \(2019/./03/\)`)
+	f.PathPostfix = "2019 03/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathPostfix: not a valid token \(2019 03/\)`)
+	f.PathPostfix = "2019/03/"
+	c.Check(f.Validate(), gc.IsNil)
 }
 
 func (s *FragmentSuite) TestParsingSuccessCases(c *gc.C) {
@@ -89,6 +123,16 @@ func (s *FragmentSuite) TestParsingSuccessCases(c *gc.C) {
 		CompressionCodec: CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION,
 	})
 
+	// Again, with a Fragment ContentType.
+	f, err = ParseContentPath("a/journal/" +
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314-" +
+		"6170706c69636174696f6e2f782d6e646a736f6e.gz")
+
+	c.Check(err, gc.IsNil)
+	c.Check(f.ContentType, gc.Equals, "application/x-ndjson")
+	c.Check(f.ContentName(), gc.Equals, "00000000499602d2-7fffffffffffffff-"+
+		"0102030405060708090a0b0c0d0e0f1011121314-6170706c69636174696f6e2f782d6e646a736f6e.gz")
+
 	// Empty spool (begin == end, and zero checksum).
 	f, err = ParseContentPath("a/journal/" +
 		"00000000499602d2-00000000499602d2-0000000000000000000000000000000000000000.raw")
@@ -102,11 +146,48 @@ func (s *FragmentSuite) TestParsingSuccessCases(c *gc.C) {
 	})
 }
 
+func (s *FragmentSuite) TestPostfixPathParsing(c *gc.C) {
+	var f, err = ParseContentPostfixPath("a/journal", "date=2019-03-04/hour=05/"+
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
+
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.DeepEquals, Fragment{
+		Journal:          "a/journal",
+		Begin:            1234567890,
+		End:              math.MaxInt64,
+		Sum:              SHA1Sum{Part1: 0x0102030405060708, Part2: 0x090a0b0c0d0e0f10, Part3: 0x11121314},
+		CompressionCodec: CompressionCodec_GZIP,
+		PathPostfix:      "date=2019-03-04/hour=05/",
+	})
+	c.Check(f.ContentPath(), gc.Equals, "a/journal/date=2019-03-04/hour=05/"+
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
+
+	// A path without directories has an empty PathPostfix.
+	f, err = ParseContentPostfixPath("a/journal",
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
+	c.Check(err, gc.IsNil)
+	c.Check(f.PathPostfix, gc.Equals, "")
+
+	_, err = ParseContentPostfixPath("a/journal", "bad//"+
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
+	c.Check(err, gc.ErrorMatches, `PathPostfix: must be a clean relative path \(bad//\)`)
+	_, err = ParseContentPostfixPath("a/journal", "2019/not-a-fragment")
+	c.Check(err, gc.ErrorMatches, "Begin: strconv.ParseInt: .*")
+}
+
 func (s *FragmentSuite) TestParsingErrorCases(c *gc.C) {
 	var _, err = ParseContentPath("a/journal/" +
-		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314-extra.gz")
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314-0a-extra.gz")
 	c.Check(err, gc.ErrorMatches, "wrong Fragment format: .*")
 
+	_, err = ParseContentPath("a/journal/" +
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314-extra.gz")
+	c.Check(err, gc.ErrorMatches, "ContentType: encoding/hex: .*")
+
+	_, err = ParseContentPath("a/journal/" +
+		"00000000499602d2-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314-2f2f.gz")
+	c.Check(err, gc.ErrorMatches, "ContentType: mime: .*")
+
 	_, err = ParseContentPath("a/journal/" +
 		"00000000499602XX-7fffffffffffffff-0102030405060708090a0b0c0d0e0f1011121314.gz")
 	c.Check(err, gc.ErrorMatches, "Begin: strconv.ParseInt: .*")
@@ -15,10 +15,12 @@ import (
 // store implementation to see properties available for configuration.
 //
 // Currently supported schemes are `gs` for Google Cloud Storage, `s3` for
-// Amazon S3, and `file` for a local file-system / NFS mount. Eg:
+// Amazon S3, `azure` for Azure Blob Storage (where the host is the container
+// name), and `file` for a local file-system / NFS mount. Eg:
 //
 //  * s3://bucket-name/a/sub-path/?profile=a-shared-credentials-profile
-//  * gs://bucket-name/a/sub-path/?
+//  * gs://bucket-name/a/sub-path/?billingProject=a-requester-pays-project
+//  * azure://container-name/a/sub-path/
 //  * file:///a/local/volume/mount
 //
 type FragmentStore string
@@ -47,7 +49,7 @@ func (fs FragmentStore) parse() (*url.URL, error) {
 	}
 
 	switch url.Scheme {
-	case "s3", "gs":
+	case "s3", "gs", "azure":
 		if url.Host == "" {
 			return nil, NewValidationError("missing bucket (%s)", fs)
 		}
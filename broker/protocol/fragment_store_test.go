@@ -17,6 +17,7 @@ func (s *FragmentStoreSuite) TestValidation(c *gc.C) {
 		{"s3://my-bucket/subpath/?query", ""}, // Success (non-empty prefix).
 		{"file:///mnt/path/", ``},             // Success.
 		{"file:///mnt/path/?query", ``},       // Success.
+		{"azure://container/path/", ``},       // Success.
 
 		{"s3://my-bucket", `path component doesn't end in '/' \(\)`},
 		{"s3://my-bucket/subpath?query", `path component doesn't end in '/' \(/subpath\)`},
@@ -24,6 +25,7 @@ func (s *FragmentStoreSuite) TestValidation(c *gc.C) {
 		{"foobar://baz/", `invalid scheme \(foobar\)`},
 		{"/baz/bing/", `not absolute \(/baz/bing/\)`},
 		{"gs:///baz/bing/", `missing bucket \(gs:///baz/bing/\)`},
+		{"azure:///baz/bing/", `missing bucket \(azure:///baz/bing/\)`},
 		{"file://host/mnt/path/", `file scheme cannot have host \(file://host/mnt/path/\)`},
 		{"file:///mnt/path", `path component doesn't end in '/' \(/mnt/path\)`},
 	}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"mime"
 	"path"
+	"regexp"
 	"strings"
+	"text/template"
+	"text/template/parse"
 	"time"
 
 	"go.gazette.dev/core/allocator"
@@ -55,6 +58,37 @@ func (m *JournalSpec) Validate() error {
 		return ExtendContext(err, "Fragment")
 	} else if err = m.Flags.Validate(); err != nil {
 		return ExtendContext(err, "Flags")
+	} else if m.Seal != nil && m.Seal.Offset < 0 {
+		return NewValidationError("invalid Seal.Offset (%d; expected >= 0)", m.Seal.Offset)
+	} else if m.AppendChunkTimeout < 0 || m.AppendChunkTimeout > maxAppendChunkTimeout {
+		return NewValidationError("invalid AppendChunkTimeout (%s; expected 0 <= timeout <= %s)",
+			m.AppendChunkTimeout, maxAppendChunkTimeout)
+	} else if m.AckQuorum < 0 || m.AckQuorum > m.Replication {
+		return NewValidationError("invalid AckQuorum (%d; expected 0 <= AckQuorum <= Replication %d)",
+			m.AckQuorum, m.Replication)
+	} else if m.WriteReplication < 0 || m.WriteReplication > m.Replication {
+		return NewValidationError("invalid WriteReplication (%d; expected 0 <= WriteReplication <= Replication %d)",
+			m.WriteReplication, m.Replication)
+	} else if m.WriteReplication != 0 && m.AckQuorum > m.WriteReplication {
+		return NewValidationError("invalid AckQuorum (%d; expected AckQuorum <= WriteReplication %d)",
+			m.AckQuorum, m.WriteReplication)
+	} else if m.MaxAppendRate < 0 {
+		return NewValidationError("invalid MaxAppendRate (%d; expected >= 0)", m.MaxAppendRate)
+	}
+	// Brokers further verify upon Apply that an alias is neither the Name nor
+	// an alias of another Journal. An alias thus never names a Journal, and
+	// cannot form a cycle of aliases.
+	for i, alias := range m.Aliases {
+		if err := alias.Validate(); err != nil {
+			return ExtendContext(err, "Aliases[%d]", i)
+		} else if alias == m.Name {
+			return NewValidationError("Aliases[%d] is the Journal Name (%s)", i, alias)
+		}
+		for j := 0; j != i; j++ {
+			if m.Aliases[j] == alias {
+				return NewValidationError("duplicated Aliases[%d] (%s)", i, alias)
+			}
+		}
 	}
 	return nil
 }
@@ -85,12 +119,117 @@ func (m *JournalSpec_Fragment) Validate() error {
 		return NewValidationError("invalid FlushInterval (%s; expected >= %s)",
 			m.FlushInterval, minFlushInterval)
 	}
+	if m.PathTemplate != "" {
+		if _, err := m.pathPostfix(time.Unix(0, 0)); err != nil {
+			return ExtendContext(err, "PathTemplate")
+		} else if _, err = m.pathPostfixPattern(); err != nil {
+			return ExtendContext(err, "PathTemplate")
+		}
+	}
 
 	// Retention requires no explicit validation (all values permitted).
 
 	return nil
 }
 
+// PathPostfix returns the PathPostfix of a Fragment begun at time |t|, as
+// produced by the PathTemplate. It's empty if the PathTemplate is empty (or
+// is the flat layout). The JournalSpec_Fragment must Validate, or PathPostfix
+// panics.
+func (m *JournalSpec_Fragment) PathPostfix(t time.Time) string {
+	var postfix, err = m.pathPostfix(t)
+	if err != nil {
+		panic(err.Error())
+	}
+	return postfix
+}
+
+// PathPostfixPattern returns a Regexp which matches each PathPostfix that
+// the PathTemplate may produce, where each of its time fields matches only
+// digits of the field's width. It's nil if the PathTemplate is the flat
+// layout. The JournalSpec_Fragment must Validate, or PathPostfixPattern panics.
+func (m *JournalSpec_Fragment) PathPostfixPattern() *regexp.Regexp {
+	var re, err = m.pathPostfixPattern()
+	if err != nil {
+		panic(err.Error())
+	}
+	return re
+}
+
+func (m *JournalSpec_Fragment) pathPostfix(t time.Time) (string, error) {
+	var tmpl, err = m.parsePathTemplate()
+	if err != nil || tmpl == nil {
+		return "", err
+	}
+	t = t.UTC()
+
+	var b strings.Builder
+	if err = tmpl.Execute(&b, struct{ Year, Month, Day, Hour string }{
+		Year:  fmt.Sprintf("%04d", t.Year()),
+		Month: fmt.Sprintf("%02d", t.Month()),
+		Day:   fmt.Sprintf("%02d", t.Day()),
+		Hour:  fmt.Sprintf("%02d", t.Hour()),
+	}); err != nil {
+		return "", &ValidationError{Err: err}
+	} else if err = validatePathPostfix(b.String()); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (m *JournalSpec_Fragment) pathPostfixPattern() (*regexp.Regexp, error) {
+	var tmpl, err = m.parsePathTemplate()
+	if err != nil || tmpl == nil || len(tmpl.Tree.Root.Nodes) == 0 {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for _, node := range tmpl.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			b.WriteString(regexp.QuoteMeta(string(n.Text)))
+		case *parse.ActionNode:
+			if pattern, ok := pathTemplateFields[n.String()]; ok {
+				b.WriteString(pattern)
+				continue
+			}
+			return nil, NewValidationError("unsupported action %s (expected one of {{.Year}}, {{.Month}}, {{.Day}}, or {{.Hour}})", n)
+		default:
+			return nil, NewValidationError("unsupported template node (%s)", n)
+		}
+	}
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String()), nil
+}
+
+// parsePathTemplate parses the PathTemplate, less its pathTemplatePrefix.
+// It returns nil if the PathTemplate is empty.
+func (m *JournalSpec_Fragment) parsePathTemplate() (*template.Template, error) {
+	if m.PathTemplate == "" {
+		return nil, nil
+	} else if !strings.HasPrefix(m.PathTemplate, pathTemplatePrefix) {
+		return nil, NewValidationError("expected prefix %s (%s)", pathTemplatePrefix, m.PathTemplate)
+	} else if !strings.HasSuffix(m.PathTemplate, "/") {
+		return nil, NewValidationError("expected trailing '/' (%s)", m.PathTemplate)
+	}
+	var tmpl, err = template.New("path").Option("missingkey=error").
+		Parse(m.PathTemplate[len(pathTemplatePrefix):])
+	if err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+	return tmpl, nil
+}
+
+// pathTemplateFields maps each PathTemplate action to a pattern of its values.
+var pathTemplateFields = map[string]string{
+	"{{.Year}}":  "[0-9]{4}",
+	"{{.Month}}": "[0-9]{2}",
+	"{{.Day}}":   "[0-9]{2}",
+	"{{.Hour}}":  "[0-9]{2}",
+}
+
 // Validate returns an error if the JournalSpec_Flag is malformed.
 func (x JournalSpec_Flag) Validate() error {
 	switch x {
@@ -188,7 +327,9 @@ func JournalRouteMatchesAssignments(rt Route, assignments keyspace.KeyValues) bo
 
 // UnionJournalSpecs returns a JournalSpec combining all non-zero-valued fields
 // across |a| and |b|. Where both |a| and |b| provide a non-zero value for
-// a field, the value of |a| is retained.
+// a field, the value of |a| is retained. As with Name, Aliases identify a
+// specific Journal and are not combined: the Name and Aliases of |a| are
+// retained by UnionJournalSpecs, IntersectJournalSpecs, and SubtractJournalSpecs.
 func UnionJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Replication == 0 {
 		a.Replication = b.Replication
@@ -213,9 +354,27 @@ func UnionJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Fragment.FlushInterval == 0 {
 		a.Fragment.FlushInterval = b.Fragment.FlushInterval
 	}
+	if a.Fragment.PathTemplate == "" {
+		a.Fragment.PathTemplate = b.Fragment.PathTemplate
+	}
 	if a.Flags == JournalSpec_NOT_SPECIFIED {
 		a.Flags = b.Flags
 	}
+	if a.Seal == nil {
+		a.Seal = b.Seal
+	}
+	if a.AppendChunkTimeout == 0 {
+		a.AppendChunkTimeout = b.AppendChunkTimeout
+	}
+	if a.AckQuorum == 0 {
+		a.AckQuorum = b.AckQuorum
+	}
+	if a.WriteReplication == 0 {
+		a.WriteReplication = b.WriteReplication
+	}
+	if a.MaxAppendRate == 0 {
+		a.MaxAppendRate = b.MaxAppendRate
+	}
 	return a
 }
 
@@ -245,9 +404,27 @@ func IntersectJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Fragment.FlushInterval != b.Fragment.FlushInterval {
 		a.Fragment.FlushInterval = 0
 	}
+	if a.Fragment.PathTemplate != b.Fragment.PathTemplate {
+		a.Fragment.PathTemplate = ""
+	}
 	if a.Flags != b.Flags {
 		a.Flags = JournalSpec_NOT_SPECIFIED
 	}
+	if !sealsEq(a.Seal, b.Seal) {
+		a.Seal = nil
+	}
+	if a.AppendChunkTimeout != b.AppendChunkTimeout {
+		a.AppendChunkTimeout = 0
+	}
+	if a.AckQuorum != b.AckQuorum {
+		a.AckQuorum = 0
+	}
+	if a.WriteReplication != b.WriteReplication {
+		a.WriteReplication = 0
+	}
+	if a.MaxAppendRate != b.MaxAppendRate {
+		a.MaxAppendRate = 0
+	}
 	return a
 }
 
@@ -277,9 +454,27 @@ func SubtractJournalSpecs(a, b JournalSpec) JournalSpec {
 	if a.Fragment.FlushInterval == b.Fragment.FlushInterval {
 		a.Fragment.FlushInterval = 0
 	}
+	if a.Fragment.PathTemplate == b.Fragment.PathTemplate {
+		a.Fragment.PathTemplate = ""
+	}
 	if a.Flags == b.Flags {
 		a.Flags = JournalSpec_NOT_SPECIFIED
 	}
+	if sealsEq(a.Seal, b.Seal) {
+		a.Seal = nil
+	}
+	if a.AppendChunkTimeout == b.AppendChunkTimeout {
+		a.AppendChunkTimeout = 0
+	}
+	if a.AckQuorum == b.AckQuorum {
+		a.AckQuorum = 0
+	}
+	if a.WriteReplication == b.WriteReplication {
+		a.WriteReplication = 0
+	}
+	if a.MaxAppendRate == b.MaxAppendRate {
+		a.MaxAppendRate = 0
+	}
 	return a
 }
 
@@ -300,7 +495,9 @@ func ExtractJournalSpecMetaLabels(spec *JournalSpec, out LabelSet) LabelSet {
 // validateJournalLabelConstraints asserts expected invariants of MessageType,
 // MessageSubType, and ContentType labels:
 //  * ContentType must parse as a RFC 1521 MIME / media-type.
-//  * If MessageType is present, ContentType must be present and match a known framing.
+//  * If MessageType is present, ContentType must be present and match a known
+//    framing, optionally having compression or encryption suffixes
+//    (eg "+snappy" or "+gzip+encrypted").
 //  * If MessageSubType is present, so is MessageType.
 func validateJournalLabelConstraints(ls LabelSet) error {
 	if err := ValidateSingleValueLabels(ls); err != nil {
@@ -315,7 +512,7 @@ func validateJournalLabelConstraints(ls LabelSet) error {
 	if mt := ls.ValuesOf(labels.MessageType); mt != nil {
 		if ct == nil {
 			return NewValidationError("expected %s label alongside %s", labels.ContentType, labels.MessageType)
-		} else if _, ok := labels.FramedContentTypes[ct[0]]; !ok {
+		} else if _, ok := labels.FramedContentTypes[trimFramingSuffixes(ct[0])]; !ok {
 			return NewValidationError("%s label is not a known message framing (%s; expected one of %v)",
 				labels.ContentType, ct[0], labels.FramedContentTypes)
 		}
@@ -325,10 +522,44 @@ func validateJournalLabelConstraints(ls LabelSet) error {
 	return nil
 }
 
+// trimFramingSuffixes returns the ContentType |ct| with suffixes of wrapping
+// message framings removed: a "+codec" suffix which names a CompressionCodec
+// other than GZIP_OFFLOAD_DECOMPRESSION, which message.CompressedFraming
+// doesn't support (see message.CompressedFraming), an "+encrypted" suffix (see
+// message.EncryptedFraming), or a "+crc32c" suffix (see
+// message.ChecksummedFraming).
+func trimFramingSuffixes(ct string) string {
+	for {
+		var ind = strings.LastIndexByte(ct, '+')
+		if ind == -1 {
+			return ct
+		}
+		var suffix = ct[ind+1:]
+
+		if c, ok := CompressionCodec_value[strings.ToUpper(suffix)]; ok && c != 0 &&
+			c != int32(CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION) {
+			ct = ct[:ind]
+		} else if suffix == "encrypted" || suffix == "crc32c" {
+			ct = ct[:ind]
+		} else {
+			return ct
+		}
+	}
+}
+
+func sealsEq(a, b *JournalSpec_Seal) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 const (
 	minJournalNameLen, maxJournalNameLen   = 4, 512
 	maxJournalReplication                  = 5
 	minRefreshInterval, maxRefreshInterval = time.Second, time.Hour * 24
 	minFlushInterval                       = time.Minute
+	maxAppendChunkTimeout                  = time.Minute * 5
 	minFragmentLen, maxFragmentLen         = 1 << 10, 1 << 34 // 1024 => 17,179,869,184
+	pathTemplatePrefix                     = "{{.Journal}}/"
 )
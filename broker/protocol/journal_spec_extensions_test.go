@@ -89,6 +89,16 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 	c.Check(spec.Validate(), gc.IsNil)
 	spec.LabelSet = MustLabelSet(labels.MessageSubType, "subtype", labels.MessageType, "type", labels.ContentType, labels.ContentType_JSONLines)
 	c.Check(spec.Validate(), gc.IsNil)
+	spec.LabelSet = MustLabelSet(labels.MessageType, "type", labels.ContentType, labels.ContentType_JSONLines+"+other")
+	c.Check(spec.Validate(), gc.ErrorMatches, `Labels: `+labels.ContentType+` label is not a known message framing .*`)
+	spec.LabelSet = MustLabelSet(labels.MessageType, "type", labels.ContentType, labels.ContentType_JSONLines+"+gzip+encrypted")
+	c.Check(spec.Validate(), gc.IsNil)
+	spec.LabelSet = MustLabelSet(labels.MessageType, "type", labels.ContentType, labels.ContentType_JSONLines+"+snappy")
+	c.Check(spec.Validate(), gc.IsNil)
+	spec.LabelSet = MustLabelSet(labels.MessageType, "type", labels.ContentType, labels.ContentType_JSONLines+"+gzip_offload_decompression")
+	c.Check(spec.Validate(), gc.ErrorMatches, `Labels: `+labels.ContentType+` label is not a known message framing .*`)
+	spec.LabelSet = MustLabelSet(labels.MessageType, "type", labels.ContentType, labels.ContentType_ProtoFixed+"+snappy+crc32c")
+	c.Check(spec.Validate(), gc.IsNil)
 
 	spec.Fragment.Length = 0
 	c.Check(spec.Validate(), gc.ErrorMatches, `Fragment: invalid Length \(0; expected 1024 <= length <= \d+\)`)
@@ -114,6 +124,46 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 		c.Check(spec.Validate(), gc.IsNil)
 	}
 
+	spec.Seal = &JournalSpec_Seal{Offset: -1}
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid Seal.Offset \(-1; expected >= 0\)`)
+	spec.Seal.Offset = 1234
+	c.Check(spec.Validate(), gc.IsNil)
+
+	spec.AppendChunkTimeout = -time.Second
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid AppendChunkTimeout \(-1s; expected 0 <= timeout <= 5m0s\)`)
+	spec.AppendChunkTimeout = time.Hour
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid AppendChunkTimeout \(1h0m0s; expected 0 <= timeout <= 5m0s\)`)
+	spec.AppendChunkTimeout = time.Minute
+	c.Check(spec.Validate(), gc.IsNil)
+
+	spec.AckQuorum = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid AckQuorum \(-1; expected 0 <= AckQuorum <= Replication \d+\)`)
+	spec.AckQuorum = spec.Replication + 1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid AckQuorum \(\d+; expected 0 <= AckQuorum <= Replication \d+\)`)
+	spec.AckQuorum = spec.Replication
+	spec.WriteReplication = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid WriteReplication \(-1; expected 0 <= WriteReplication <= Replication \d+\)`)
+	spec.WriteReplication = spec.Replication + 1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid WriteReplication \(\d+; expected 0 <= WriteReplication <= Replication \d+\)`)
+	spec.WriteReplication = spec.Replication - 1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid AckQuorum \(\d+; expected AckQuorum <= WriteReplication \d+\)`)
+	spec.WriteReplication = spec.Replication
+	c.Check(spec.Validate(), gc.IsNil)
+
+	spec.MaxAppendRate = -1
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid MaxAppendRate \(-1; expected >= 0\)`)
+	spec.MaxAppendRate = 1 << 20
+	c.Check(spec.Validate(), gc.IsNil)
+
+	spec.Aliases = []Journal{"a/prior/name", "a bad alias"}
+	c.Check(spec.Validate(), gc.ErrorMatches, `Aliases\[1\]: not a valid token \(a bad alias\)`)
+	spec.Aliases[1] = spec.Name
+	c.Check(spec.Validate(), gc.ErrorMatches, `Aliases\[1\] is the Journal Name \(.*\)`)
+	spec.Aliases[1] = "a/prior/name"
+	c.Check(spec.Validate(), gc.ErrorMatches, `duplicated Aliases\[1\] \(a/prior/name\)`)
+	spec.Aliases[1] = "another/prior/name"
+	c.Check(spec.Validate(), gc.IsNil)
+
 	// Additional tests of JournalSpec_Fragment cases.
 	var f = &spec.Fragment
 
@@ -128,6 +178,8 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 	f.Stores[0] = "file:///a/root/"
 	c.Check(f.Validate(), gc.ErrorMatches,
 		`GZIP_OFFLOAD_DECOMPRESSION is incompatible with file:// stores \(file:///a/root/\)`)
+	f.CompressionCodec = CompressionCodec_LZ4
+	c.Check(f.Validate(), gc.IsNil)
 	f.CompressionCodec = CompressionCodec_SNAPPY
 
 	f.RefreshInterval = time.Millisecond
@@ -140,10 +192,55 @@ func (s *JournalSuite) TestSpecValidationCases(c *gc.C) {
 	c.Check(f.Validate(), gc.ErrorMatches, `invalid FlushInterval \(1s; expected >= 1m0s\)`)
 	f.FlushInterval = time.Hour * 2
 
+	f.PathTemplate = "{{.Year}}/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: expected prefix {{.Journal}}/ \({{.Year}}/\)`)
+	f.PathTemplate = "{{.Journal}}/{{.Year}}"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: expected trailing '/' \(.*\)`)
+	f.PathTemplate = "{{.Journal}}/{{.Year/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: template: path:1: .*`)
+	f.PathTemplate = "{{.Journal}}/{{.Minute}}/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: template: path:1:2: executing .*`)
+	f.PathTemplate = "{{.Journal}}/{{.Year}}//{{.Month}}/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: must be a clean relative path \(1970//01/\)`)
+	f.PathTemplate = "{{.Journal}}/../{{.Month}}/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: must be a clean relative path \(../01/\)`)
+	f.PathTemplate = "{{.Journal}}/{{printf \"y%s\" .Year}}/"
+	c.Check(f.Validate(), gc.ErrorMatches, `PathTemplate: unsupported action {{printf "y%s" .Year}} \(expected .*\)`)
+	f.PathTemplate = "{{.Journal}}/year={{.Year}}/month={{.Month}}/"
+	c.Check(f.Validate(), gc.IsNil)
+
 	f.Stores = append(f.Stores, "invalid")
 	c.Check(f.Validate(), gc.ErrorMatches, `Stores\[2\]: not absolute \(invalid\)`)
 }
 
+func (s *JournalSuite) TestPathPostfix(c *gc.C) {
+	var f = JournalSpec_Fragment{}
+	var ts = time.Date(2019, time.March, 4, 5, 6, 7, 0, time.FixedZone("X", -7*3600))
+
+	// An empty PathTemplate is the flat layout.
+	c.Check(f.PathPostfix(ts), gc.Equals, "")
+	c.Check(f.PathPostfixPattern(), gc.IsNil)
+
+	f.PathTemplate = "{{.Journal}}/"
+	c.Check(f.PathPostfix(ts), gc.Equals, "")
+	c.Check(f.PathPostfixPattern(), gc.IsNil)
+
+	// Times are UTC.
+	f.PathTemplate = "{{.Journal}}/date={{.Year}}-{{.Month}}-{{ .Day }}/hour={{.Hour}}/"
+	c.Check(f.PathPostfix(ts), gc.Equals, "date=2019-03-04/hour=12/")
+
+	// The pattern matches only postfixes of the template's shape.
+	var re = f.PathPostfixPattern()
+	c.Check(re.String(), gc.Equals, `^date=[0-9]{4}-[0-9]{2}-[0-9]{2}/hour=[0-9]{2}/$`)
+	c.Check(re.MatchString("date=2019-03-04/hour=12/"), gc.Equals, true)
+	c.Check(re.MatchString("date=2019-03-04/"), gc.Equals, false)
+	c.Check(re.MatchString("date=2019-3-04/hour=12/"), gc.Equals, false)
+	c.Check(re.MatchString("nested/journal/"), gc.Equals, false)
+
+	f.PathTemplate = "{{.Journal}}/{{.Minute}}/"
+	c.Check(func() { f.PathPostfix(ts) }, gc.PanicMatches, `template: path:1:2: executing .*`)
+}
+
 func (s *JournalSuite) TestMetaLabelExtraction(c *gc.C) {
 	c.Check(ExtractJournalSpecMetaLabels(&JournalSpec{Name: "path/to/my/journal"}, MustLabelSet("label", "buffer")),
 		gc.DeepEquals, MustLabelSet(
@@ -233,8 +330,14 @@ func (s *JournalSuite) TestSetOperations(c *gc.C) {
 			RefreshInterval:  time.Minute,
 			Retention:        time.Hour,
 			FlushInterval:    time.Hour,
+			PathTemplate:     "{{.Journal}}/{{.Year}}/",
 		},
-		Flags: JournalSpec_O_RDWR,
+		Flags:              JournalSpec_O_RDWR,
+		Seal:               &JournalSpec_Seal{Offset: 1234},
+		AppendChunkTimeout: time.Second,
+		AckQuorum:          2,
+		WriteReplication:   2,
+		MaxAppendRate:      1024,
 	}
 	var other = JournalSpec{
 		Replication: 1,
@@ -252,8 +355,14 @@ func (s *JournalSuite) TestSetOperations(c *gc.C) {
 			RefreshInterval:  10 * time.Hour,
 			Retention:        10 * time.Hour,
 			FlushInterval:    10 * time.Hour,
+			PathTemplate:     "{{.Journal}}/{{.Day}}/",
 		},
-		Flags: JournalSpec_O_RDONLY,
+		Flags:              JournalSpec_O_RDONLY,
+		Seal:               &JournalSpec_Seal{Offset: 5678},
+		AppendChunkTimeout: time.Minute,
+		AckQuorum:          1,
+		WriteReplication:   1,
+		MaxAppendRate:      2048,
 	}
 
 	c.Check(UnionJournalSpecs(JournalSpec{}, model), gc.DeepEquals, model)
@@ -69,6 +69,10 @@ const (
 	// that journal replication consistency has been lost in the past, due to
 	// too many broker or Etcd failures.
 	Status_INDEX_HAS_GREATER_OFFSET Status = 12
+	// The Append is refused because the journal has been sealed, or the Apply
+	// is refused because it would remove the seal of a sealed journal, or
+	// delete it.
+	Status_JOURNAL_SEALED Status = 13
 )
 
 var Status_name = map[int32]string{
@@ -85,6 +89,7 @@ var Status_name = map[int32]string{
 	10: "NOT_ALLOWED",
 	11: "WRONG_APPEND_OFFSET",
 	12: "INDEX_HAS_GREATER_OFFSET",
+	13: "JOURNAL_SEALED",
 }
 
 var Status_value = map[string]int32{
@@ -101,6 +106,7 @@ var Status_value = map[string]int32{
 	"NOT_ALLOWED":                  10,
 	"WRONG_APPEND_OFFSET":          11,
 	"INDEX_HAS_GREATER_OFFSET":     12,
+	"JOURNAL_SEALED":               13,
 }
 
 func (x Status) String() string {
@@ -137,6 +143,10 @@ const (
 	// it is an advanced configuration and the "Content-Encoding" header handling
 	// can be subtle and sometimes confusing. It uses the default suffix ".gzod".
 	CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION CompressionCodec = 5
+	// LZ4 encodes Fragments using the LZ4 frame format, with default suffix ".lz4".
+	// It's cheaper to compress than GZIP, and compresses better than NONE,
+	// making it suited to latency-sensitive journals.
+	CompressionCodec_LZ4 CompressionCodec = 6
 )
 
 var CompressionCodec_name = map[int32]string{
@@ -146,6 +156,7 @@ var CompressionCodec_name = map[int32]string{
 	3: "ZSTANDARD",
 	4: "SNAPPY",
 	5: "GZIP_OFFLOAD_DECOMPRESSION",
+	6: "LZ4",
 }
 
 var CompressionCodec_value = map[string]int32{
@@ -155,6 +166,7 @@ var CompressionCodec_value = map[string]int32{
 	"ZSTANDARD":                  3,
 	"SNAPPY":                     4,
 	"GZIP_OFFLOAD_DECOMPRESSION": 5,
+	"LZ4":                        6,
 }
 
 func (x CompressionCodec) String() string {
@@ -344,6 +356,52 @@ type JournalSpec struct {
 	// Flags of the Journal, as a combination of Flag enum values. The Flag enum
 	// not used directly, as protobuf enums do not allow for or'ed bitfields.
 	Flags JournalSpec_Flag `protobuf:"varint,6,opt,name=flags,proto3,casttype=JournalSpec_Flag" json:"flags,omitempty" yaml:",omitempty"`
+	// Seal of the Journal. If nil, the Journal is not sealed.
+	Seal *JournalSpec_Seal `protobuf:"bytes,7,opt,name=seal,proto3" json:"seal,omitempty" yaml:",omitempty"`
+	// Maximum duration the broker will wait for each chunk of an Append stream
+	// of the Journal, before aborting the stream. Within this window, the broker
+	// opens the flow-control window to the client and the client must begin to
+	// fill it. Journals receiving slow bulk uploads may warrant a longer window,
+	// while interactive Journals are better served by a tighter one, as a slow
+	// client holds the exclusively-owned replication pipeline of the Journal.
+	// If zero, the broker default of one second is used.
+	AppendChunkTimeout time.Duration `protobuf:"bytes,8,opt,name=append_chunk_timeout,json=appendChunkTimeout,proto3,stdduration" json:"append_chunk_timeout" yaml:"append_chunk_timeout,omitempty"`
+	// Number of replicas, including the primary, which must acknowledge an
+	// Append before it's committed and its response is returned to the client.
+	// The remaining replicas of the Journal's route receive content and
+	// acknowledge asynchronously, and later Appends are ordered behind their
+	// acknowledgements. If any replica fails to acknowledge then the pipeline
+	// is torn down and re-built, as usual. An Append committed under a quorum
+	// is durable to the failure of up to (ack_quorum - 1) replicas until its
+	// Fragment is persisted to the backing store, rather than (replication - 1).
+	// If zero, all replicas must acknowledge.
+	AckQuorum int32 `protobuf:"varint,9,opt,name=ack_quorum,json=ackQuorum,proto3" json:"ack_quorum,omitempty" yaml:"ack_quorum,omitempty"`
+	// Number of replicas, including the primary, to which Appends are
+	// replicated. It suits cold Journals for which keeping every replica hot is
+	// wasteful, and which instead rely on the Fragment store for durability.
+	// Remaining replicas of the Journal's route receive no Append content, and
+	// serve reads only of Fragments which have been persisted and indexed from
+	// the store (which may lag, as determined by the Fragment flush and refresh
+	// intervals). An Append is durable to the failure of up to
+	// (write_replication - 1) replicas until its Fragment is persisted.
+	// If zero, Appends are replicated to all replicas.
+	WriteReplication int32 `protobuf:"varint,10,opt,name=write_replication,json=writeReplication,proto3" json:"write_replication,omitempty" yaml:"write_replication,omitempty"`
+	// Aliases are additional names of the Journal. Brokers resolve an alias to
+	// this (canonical) Journal, such that reads and appends of an alias are
+	// served by the canonical Journal. Aliases allow a Journal to be renamed
+	// without a migration of its content: the JournalSpec is re-created under
+	// the new name with the prior name as an alias. An alias may not be the
+	// name of another Journal, nor an alias of another Journal.
+	Aliases []Journal `protobuf:"bytes,11,rep,name=aliases,proto3,casttype=Journal" json:"aliases,omitempty" yaml:",omitempty"`
+	// Maximum rate, in bytes per second, of Append content to the Journal.
+	// The primary broker admits Append content against a token bucket having
+	// a burst of one second at this rate. Content in excess of the bucket is
+	// not refused, but places the bucket in debt, and later Appends are slowed
+	// until the debt is repaid. Appends wait without holding the Journal's
+	// replication pipeline, so that a throttled client doesn't block the
+	// Journal's other Appends any longer than its own content requires.
+	// If zero, the rate of Appends is not limited.
+	MaxAppendRate int64 `protobuf:"varint,12,opt,name=max_append_rate,json=maxAppendRate,proto3" json:"max_append_rate,omitempty" yaml:"max_append_rate,omitempty"`
 }
 
 func (m *JournalSpec) Reset()         { *m = JournalSpec{} }
@@ -420,6 +478,24 @@ type JournalSpec_Fragment struct {
 	// Flush interval defines a UTC time segment, since epoch time,
 	// after which a spool must be flushed to the FragmentStore.
 	FlushInterval time.Duration `protobuf:"bytes,6,opt,name=flush_interval,json=flushInterval,proto3,stdduration" json:"flush_interval" yaml:"flush_interval,omitempty"`
+	// Path template of the directory of each persisted Fragment, relative to
+	// its fragment_store. It's a Go text/template which must begin with
+	// "{{.Journal}}/" and end with "/". The remainder is executed as each
+	// Fragment is begun, with fields Year, Month, Day, and Hour (as zero-padded
+	// strings of the current UTC time), and yields the Fragment's path_postfix.
+	// Eg, "{{.Journal}}/{{.Year}}/{{.Month}}/{{.Day}}/" spreads a Journal's
+	// Fragments over per-day prefixes, which improves listing parallelism of
+	// object stores and allows lifecycle rules to target time ranges.
+	//
+	// Fields of the template may only be {{.Year}}, {{.Month}}, {{.Day}}, and
+	// {{.Hour}}. Fragment listings include only directories matching the shape
+	// of the template (with fields matching digits of the field's width), which
+	// excludes the Fragments of nested Journals (eg, "my/journal/nested").
+	// Listings also include Fragments of the flat layout, so the template of a
+	// Journal may be introduced at any time, but may not thereafter be removed
+	// or changed in its shape.
+	// If empty, the flat layout "{{.Journal}}/" is used.
+	PathTemplate string `protobuf:"bytes,7,opt,name=path_template,json=pathTemplate,proto3" json:"path_template,omitempty" yaml:"path_template,omitempty"`
 }
 
 func (m *JournalSpec_Fragment) Reset()         { *m = JournalSpec_Fragment{} }
@@ -455,6 +531,48 @@ func (m *JournalSpec_Fragment) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_JournalSpec_Fragment proto.InternalMessageInfo
 
+// Seal marks a Journal as permanently finalized. Unlike O_RDONLY, a seal
+// cannot be removed once applied: brokers refuse Apply requests which would
+// un-seal or delete the Journal, and refuse all further non-empty Appends with
+// status JOURNAL_SEALED. Reads of a sealed Journal continue to be served.
+type JournalSpec_Seal struct {
+	// Final write head of the Journal, at which it was sealed.
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty" yaml:",omitempty"`
+}
+
+func (m *JournalSpec_Seal) Reset()         { *m = JournalSpec_Seal{} }
+func (m *JournalSpec_Seal) String() string { return proto.CompactTextString(m) }
+func (*JournalSpec_Seal) ProtoMessage()    {}
+func (*JournalSpec_Seal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c0999e5af553218, []int{3, 1}
+}
+func (m *JournalSpec_Seal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JournalSpec_Seal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JournalSpec_Seal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JournalSpec_Seal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JournalSpec_Seal.Merge(m, src)
+}
+func (m *JournalSpec_Seal) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *JournalSpec_Seal) XXX_DiscardUnknown() {
+	xxx_messageInfo_JournalSpec_Seal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JournalSpec_Seal proto.InternalMessageInfo
+
 // ProcessSpec describes a uniquely identified process and its addressable endpoint.
 type ProcessSpec struct {
 	Id ProcessSpec_ID `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
@@ -617,6 +735,13 @@ type Fragment struct {
 	// Modification timestamp of the Fragment within the backing store, represented as seconds
 	// since the epoch.
 	ModTime int64 `protobuf:"varint,7,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
+	// ContentType of the Fragment, as provided by the Appends which wrote it.
+	// If empty, content has the ContentType of the journal's "content-type" label.
+	ContentType string `protobuf:"bytes,8,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Postfix of the Fragment's directory, between its Journal and content name,
+	// as produced by the JournalSpec fragment path_template. If non-empty, it
+	// ends in "/". Empty if the Fragment has the flat layout.
+	PathPostfix string `protobuf:"bytes,9,opt,name=path_postfix,json=pathPostfix,proto3" json:"path_postfix,omitempty"`
 }
 
 func (m *Fragment) Reset()         { *m = Fragment{} }
@@ -713,6 +838,30 @@ type ReadRequest struct {
 	// If metadata_only is true, the broker will respond with Journal and
 	// Fragment metadata but not content.
 	MetadataOnly bool `protobuf:"varint,6,opt,name=metadata_only,json=metadataOnly,proto3" json:"metadata_only,omitempty"`
+	// SignatureTTL is an optional requested lifetime of a returned Fragment URL.
+	// Brokers bound the effective TTL by their configured maximum. If not set,
+	// the broker's default TTL is used.
+	SignatureTTL *time.Duration `protobuf:"bytes,7,opt,name=signatureTTL,proto3,stdduration" json:"signatureTTL,omitempty"`
+	// If non-zero, offset must be -1 and the read begins at the first offset of
+	// the Fragment which is fragments_from_head Fragments back from the write
+	// head: 1 is the last Fragment of the Journal (which ends at the write head),
+	// 2 is the Fragment preceding it, and so on. If the Journal has fewer
+	// Fragments, the read begins at its first Fragment. If it has no Fragments,
+	// the read begins at the write head. The resolved offset is returned by the
+	// first ReadResponse.
+	FragmentsFromHead int32 `protobuf:"varint,8,opt,name=fragments_from_head,json=fragmentsFromHead,proto3" json:"fragments_from_head,omitempty"`
+	// If resolve_freshest_index is true, and the read may be proxied, the broker
+	// resolves the read to the replica having the most recently refreshed index
+	// of remote Fragments, rather than to itself or an arbitrary replica. This
+	// suits reads desiring the most up-to-date persisted view of the Journal
+	// (eg, after a failover). Metadata responses of the read also carry the
+	// index_refresh_time of the serving replica.
+	ResolveFreshestIndex bool `protobuf:"varint,9,opt,name=resolve_freshest_index,json=resolveFreshestIndex,proto3" json:"resolve_freshest_index,omitempty"`
+	// Optional Filter of journal content. If set, the broker streams only
+	// messages of the journal which match the Filter. Filter may not be used
+	// with do_not_proxy or metadata_only. Responses of a filtered read don't
+	// include a fragment_url, as the broker must itself filter persisted content.
+	Filter *ReadFilter `protobuf:"bytes,10,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
 func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
@@ -748,6 +897,62 @@ func (m *ReadRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
+// ReadFilter is a predicate of journal messages, applied by brokers to the
+// content of a Read such that only matching messages are streamed to the
+// client. Brokers are otherwise agnostic to the framing of journal content,
+// and ReadFilter is scoped to framings where each message is terminated by
+// a delimiter byte (eg, newline-delimited JSON). A message matches if it
+// begins with prefix.
+//
+// Messages are never split by the filter: a matching message is streamed only
+// once it's been read through its delimiter, and each content ReadResponse
+// holds one or more complete and contiguous matching messages, having the
+// journal offset of its first message. A message which spans Fragments or
+// the write head is held until its remainder is read, and is discarded if the
+// Read ends first. Offsets of content responses skip over filtered content,
+// and metadata responses are sent only where the read is positioned at a
+// message boundary. The Read must begin at a message boundary.
+type ReadFilter struct {
+	// Prefix which a message must begin with to match. Required.
+	Prefix []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Delimiter byte which terminates each message. If empty, a newline
+	// ("\n") is used. Otherwise, it must be a single byte.
+	Delimiter []byte `protobuf:"bytes,2,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+}
+
+func (m *ReadFilter) Reset()         { *m = ReadFilter{} }
+func (m *ReadFilter) String() string { return proto.CompactTextString(m) }
+func (*ReadFilter) ProtoMessage()    {}
+func (*ReadFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c0999e5af553218, []int{9}
+}
+func (m *ReadFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadFilter.Merge(m, src)
+}
+func (m *ReadFilter) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *ReadFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadFilter proto.InternalMessageInfo
+
 type ReadResponse struct {
 	// Status of the Read RPC.
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=protocol.Status" json:"status,omitempty"`
@@ -767,13 +972,18 @@ type ReadResponse struct {
 	FragmentUrl string `protobuf:"bytes,6,opt,name=fragment_url,json=fragmentUrl,proto3" json:"fragment_url,omitempty"`
 	// Content chunks of the read.
 	Content []byte `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	// Time at which the serving replica last refreshed its index of remote
+	// Fragments, as Unix nanoseconds, or zero if it has yet to do so. Populated
+	// only for reads which set resolve_freshest_index. This is a metadata field
+	// and will not be returned with a content response.
+	IndexRefreshTime int64 `protobuf:"varint,8,opt,name=index_refresh_time,json=indexRefreshTime,proto3" json:"index_refresh_time,omitempty"`
 }
 
 func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{9}
+	return fileDescriptor_0c0999e5af553218, []int{10}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -820,13 +1030,35 @@ type AppendRequest struct {
 	// indicate the Append should be committed. Absence of this empty chunk
 	// prior to EOF is interpreted by the broker as a rollback of the Append.
 	Content []byte `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// Optional idempotency key of the Append. Brokers track keys of recently
+	// committed Appends to each journal. If an Append is received having the
+	// key of an already-committed Append, its content is read and discarded,
+	// and the AppendResponse of the prior Append is returned. This allows a
+	// client to safely retry an Append which may or may not have committed.
+	// Keys are tracked by the journal's primary broker and within a bounded
+	// window of time and count, and deduplication is best-effort beyond them.
+	IdempotencyKey string `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Optional ContentType of the Append, which overrides the "content-type"
+	// label of the journal. Each Fragment has a single ContentType, and brokers
+	// roll to a new Fragment when an Append's ContentType differs from that of
+	// the current Fragment. Readers may then select a framing of each Fragment
+	// from its ContentType. If empty, the journal label applies.
+	ContentType string `protobuf:"bytes,7,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// SignatureTTL indicates that a temporary signed GET URL of the committed
+	// Fragment should be returned with the AppendResponse, valid for
+	// |signatureTTL| (bounded by the broker's configured maximum). To produce a
+	// Fragment holding exactly the Append content, the broker rolls to a new
+	// Fragment both before and after the Append. The URL may be read once the
+	// Fragment is persisted to the journal's store. As each such Append is its
+	// own Fragment, SignatureTTL should be reserved for infrequent Appends.
+	SignatureTTL *time.Duration `protobuf:"bytes,8,opt,name=signatureTTL,proto3,stdduration" json:"signatureTTL,omitempty"`
 }
 
 func (m *AppendRequest) Reset()         { *m = AppendRequest{} }
 func (m *AppendRequest) String() string { return proto.CompactTextString(m) }
 func (*AppendRequest) ProtoMessage()    {}
 func (*AppendRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{10}
+	return fileDescriptor_0c0999e5af553218, []int{11}
 }
 func (m *AppendRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -863,13 +1095,17 @@ type AppendResponse struct {
 	// If status is OK, then |commit| is the Fragment which places the
 	// committed Append content within the Journal.
 	Commit *Fragment `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+	// FragmentUrl is a temporary signed GET URL of the |commit| Fragment, if the
+	// AppendRequest specified a SignatureTTL and the journal has a fragment store.
+	// It's empty if the URL couldn't be signed, which doesn't fail the Append.
+	FragmentUrl string `protobuf:"bytes,4,opt,name=fragment_url,json=fragmentUrl,proto3" json:"fragment_url,omitempty"`
 }
 
 func (m *AppendResponse) Reset()         { *m = AppendResponse{} }
 func (m *AppendResponse) String() string { return proto.CompactTextString(m) }
 func (*AppendResponse) ProtoMessage()    {}
 func (*AppendResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{11}
+	return fileDescriptor_0c0999e5af553218, []int{12}
 }
 func (m *AppendResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -920,7 +1156,7 @@ func (m *ReplicateRequest) Reset()         { *m = ReplicateRequest{} }
 func (m *ReplicateRequest) String() string { return proto.CompactTextString(m) }
 func (*ReplicateRequest) ProtoMessage()    {}
 func (*ReplicateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{12}
+	return fileDescriptor_0c0999e5af553218, []int{13}
 }
 func (m *ReplicateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -964,7 +1200,7 @@ func (m *ReplicateResponse) Reset()         { *m = ReplicateResponse{} }
 func (m *ReplicateResponse) String() string { return proto.CompactTextString(m) }
 func (*ReplicateResponse) ProtoMessage()    {}
 func (*ReplicateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{13}
+	return fileDescriptor_0c0999e5af553218, []int{14}
 }
 func (m *ReplicateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1014,7 +1250,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{14}
+	return fileDescriptor_0c0999e5af553218, []int{15}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1058,7 +1294,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{15}
+	return fileDescriptor_0c0999e5af553218, []int{16}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1100,7 +1336,7 @@ func (m *ListResponse_Journal) Reset()         { *m = ListResponse_Journal{} }
 func (m *ListResponse_Journal) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Journal) ProtoMessage()    {}
 func (*ListResponse_Journal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{15, 0}
+	return fileDescriptor_0c0999e5af553218, []int{16, 0}
 }
 func (m *ListResponse_Journal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1131,13 +1367,21 @@ var xxx_messageInfo_ListResponse_Journal proto.InternalMessageInfo
 
 type ApplyRequest struct {
 	Changes []ApplyRequest_Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes"`
+	// DryRun validates the request against the current KeySpace, and returns
+	// the journals which would be created, updated, or deleted, without
+	// applying any changes. The response Status is that which the Apply would
+	// have returned, and the response Header's Etcd revision is that of the
+	// KeySpace against which the request was evaluated. An ApplyRequest of the
+	// same Changes will succeed if no implicated JournalSpec is modified after
+	// that revision.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (m *ApplyRequest) Reset()         { *m = ApplyRequest{} }
 func (m *ApplyRequest) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest) ProtoMessage()    {}
 func (*ApplyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{16}
+	return fileDescriptor_0c0999e5af553218, []int{17}
 }
 func (m *ApplyRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1183,7 +1427,7 @@ func (m *ApplyRequest_Change) Reset()         { *m = ApplyRequest_Change{} }
 func (m *ApplyRequest_Change) String() string { return proto.CompactTextString(m) }
 func (*ApplyRequest_Change) ProtoMessage()    {}
 func (*ApplyRequest_Change) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{16, 0}
+	return fileDescriptor_0c0999e5af553218, []int{17, 0}
 }
 func (m *ApplyRequest_Change) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1217,13 +1461,18 @@ type ApplyResponse struct {
 	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=protocol.Status" json:"status,omitempty"`
 	// Header of the response.
 	Header Header `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
+	// Journals which would be created, updated, or deleted by a DryRun
+	// ApplyRequest having an OK Status. Not populated if not a DryRun.
+	DryRunCreated []Journal `protobuf:"bytes,3,rep,name=dry_run_created,json=dryRunCreated,proto3,casttype=Journal" json:"dry_run_created,omitempty"`
+	DryRunUpdated []Journal `protobuf:"bytes,4,rep,name=dry_run_updated,json=dryRunUpdated,proto3,casttype=Journal" json:"dry_run_updated,omitempty"`
+	DryRunDeleted []Journal `protobuf:"bytes,5,rep,name=dry_run_deleted,json=dryRunDeleted,proto3,casttype=Journal" json:"dry_run_deleted,omitempty"`
 }
 
 func (m *ApplyResponse) Reset()         { *m = ApplyResponse{} }
 func (m *ApplyResponse) String() string { return proto.CompactTextString(m) }
 func (*ApplyResponse) ProtoMessage()    {}
 func (*ApplyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{17}
+	return fileDescriptor_0c0999e5af553218, []int{18}
 }
 func (m *ApplyResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1281,7 +1530,7 @@ func (m *FragmentsRequest) Reset()         { *m = FragmentsRequest{} }
 func (m *FragmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*FragmentsRequest) ProtoMessage()    {}
 func (*FragmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{18}
+	return fileDescriptor_0c0999e5af553218, []int{19}
 }
 func (m *FragmentsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1325,7 +1574,7 @@ func (m *FragmentsResponse) Reset()         { *m = FragmentsResponse{} }
 func (m *FragmentsResponse) String() string { return proto.CompactTextString(m) }
 func (*FragmentsResponse) ProtoMessage()    {}
 func (*FragmentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{19}
+	return fileDescriptor_0c0999e5af553218, []int{20}
 }
 func (m *FragmentsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1367,7 +1616,7 @@ func (m *FragmentsResponse__Fragment) Reset()         { *m = FragmentsResponse__
 func (m *FragmentsResponse__Fragment) String() string { return proto.CompactTextString(m) }
 func (*FragmentsResponse__Fragment) ProtoMessage()    {}
 func (*FragmentsResponse__Fragment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{19, 0}
+	return fileDescriptor_0c0999e5af553218, []int{20, 0}
 }
 func (m *FragmentsResponse__Fragment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1396,6 +1645,98 @@ func (m *FragmentsResponse__Fragment) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FragmentsResponse__Fragment proto.InternalMessageInfo
 
+// HeadRequest is the unary request of the Head RPC.
+type HeadRequest struct {
+	// Header is attached by a proxying broker peer.
+	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// Journal to be inspected.
+	Journal Journal `protobuf:"bytes,2,opt,name=journal,proto3,casttype=Journal" json:"journal,omitempty"`
+	// If do_not_proxy is true, the broker will not proxy the request to another
+	// broker on the client's behalf, and will instead return NOT_JOURNAL_BROKER
+	// if it is not a member of the Journal's Route.
+	DoNotProxy bool `protobuf:"varint,3,opt,name=do_not_proxy,json=doNotProxy,proto3" json:"do_not_proxy,omitempty"`
+}
+
+func (m *HeadRequest) Reset()         { *m = HeadRequest{} }
+func (m *HeadRequest) String() string { return proto.CompactTextString(m) }
+func (*HeadRequest) ProtoMessage()    {}
+func (*HeadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c0999e5af553218, []int{21}
+}
+func (m *HeadRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeadRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeadRequest.Merge(m, src)
+}
+func (m *HeadRequest) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *HeadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeadRequest proto.InternalMessageInfo
+
+// HeadResponse is the unary response of the Head RPC.
+type HeadResponse struct {
+	// Status of the Head RPC.
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=protocol.Status" json:"status,omitempty"`
+	// Header of the response, including the current Route of the Journal.
+	Header Header `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
+	// Current write head of the Journal, being the offset at which the next
+	// appended byte will be written.
+	WriteHead int64 `protobuf:"varint,3,opt,name=write_head,json=writeHead,proto3" json:"write_head,omitempty"`
+	// Most recent Fragment of the Journal, ending at |write_head|. Nil if the
+	// Journal has no Fragments.
+	Fragment *Fragment `protobuf:"bytes,4,opt,name=fragment,proto3" json:"fragment,omitempty"`
+}
+
+func (m *HeadResponse) Reset()         { *m = HeadResponse{} }
+func (m *HeadResponse) String() string { return proto.CompactTextString(m) }
+func (*HeadResponse) ProtoMessage()    {}
+func (*HeadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c0999e5af553218, []int{22}
+}
+func (m *HeadResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeadResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeadResponse.Merge(m, src)
+}
+func (m *HeadResponse) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *HeadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeadResponse proto.InternalMessageInfo
+
 // Route captures the current topology of an item and the processes serving it.
 type Route struct {
 	// Members of the Route, ordered on ascending ProcessSpec.ID (zone, suffix).
@@ -1412,7 +1753,7 @@ func (m *Route) Reset()         { *m = Route{} }
 func (m *Route) String() string { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()    {}
 func (*Route) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{20}
+	return fileDescriptor_0c0999e5af553218, []int{23}
 }
 func (m *Route) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1458,7 +1799,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{21}
+	return fileDescriptor_0c0999e5af553218, []int{24}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1509,7 +1850,7 @@ func (m *Header_Etcd) Reset()         { *m = Header_Etcd{} }
 func (m *Header_Etcd) String() string { return proto.CompactTextString(m) }
 func (*Header_Etcd) ProtoMessage()    {}
 func (*Header_Etcd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c0999e5af553218, []int{21, 0}
+	return fileDescriptor_0c0999e5af553218, []int{24, 0}
 }
 func (m *Header_Etcd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1547,12 +1888,14 @@ func init() {
 	proto.RegisterType((*LabelSelector)(nil), "protocol.LabelSelector")
 	proto.RegisterType((*JournalSpec)(nil), "protocol.JournalSpec")
 	proto.RegisterType((*JournalSpec_Fragment)(nil), "protocol.JournalSpec.Fragment")
+	proto.RegisterType((*JournalSpec_Seal)(nil), "protocol.JournalSpec.Seal")
 	proto.RegisterType((*ProcessSpec)(nil), "protocol.ProcessSpec")
 	proto.RegisterType((*ProcessSpec_ID)(nil), "protocol.ProcessSpec.ID")
 	proto.RegisterType((*BrokerSpec)(nil), "protocol.BrokerSpec")
 	proto.RegisterType((*Fragment)(nil), "protocol.Fragment")
 	proto.RegisterType((*SHA1Sum)(nil), "protocol.SHA1Sum")
 	proto.RegisterType((*ReadRequest)(nil), "protocol.ReadRequest")
+	proto.RegisterType((*ReadFilter)(nil), "protocol.ReadFilter")
 	proto.RegisterType((*ReadResponse)(nil), "protocol.ReadResponse")
 	proto.RegisterType((*AppendRequest)(nil), "protocol.AppendRequest")
 	proto.RegisterType((*AppendResponse)(nil), "protocol.AppendResponse")
@@ -1567,6 +1910,8 @@ func init() {
 	proto.RegisterType((*FragmentsRequest)(nil), "protocol.FragmentsRequest")
 	proto.RegisterType((*FragmentsResponse)(nil), "protocol.FragmentsResponse")
 	proto.RegisterType((*FragmentsResponse__Fragment)(nil), "protocol.FragmentsResponse._Fragment")
+	proto.RegisterType((*HeadRequest)(nil), "protocol.HeadRequest")
+	proto.RegisterType((*HeadResponse)(nil), "protocol.HeadResponse")
 	proto.RegisterType((*Route)(nil), "protocol.Route")
 	proto.RegisterType((*Header)(nil), "protocol.Header")
 	proto.RegisterType((*Header_Etcd)(nil), "protocol.Header.Etcd")
@@ -1575,149 +1920,183 @@ func init() {
 func init() { proto.RegisterFile("broker/protocol/protocol.proto", fileDescriptor_0c0999e5af553218) }
 
 var fileDescriptor_0c0999e5af553218 = []byte{
-	// 2265 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x18, 0x4b, 0x73, 0xdb, 0xc6,
-	0x59, 0xe0, 0x9b, 0x1f, 0x49, 0x19, 0xda, 0xc4, 0xb6, 0xcc, 0xc4, 0xa4, 0x82, 0x3c, 0x46, 0x71,
-	0x6c, 0xca, 0x96, 0xdb, 0x26, 0xf5, 0x8c, 0xdb, 0x82, 0x22, 0x25, 0x33, 0xa6, 0x48, 0xce, 0x92,
-	0xb2, 0x63, 0x5f, 0x30, 0x10, 0xb0, 0xa2, 0x51, 0x83, 0x00, 0x0a, 0x80, 0x8e, 0x95, 0x4e, 0xaf,
-	0x6e, 0xa7, 0xd3, 0x43, 0x4f, 0x6d, 0x6e, 0xf5, 0xf4, 0xd0, 0x5f, 0xd0, 0x53, 0x7f, 0x81, 0x67,
-	0x7a, 0xf1, 0xb4, 0x97, 0x1e, 0x5a, 0x65, 0x1a, 0xff, 0x03, 0x4f, 0x4f, 0x39, 0x75, 0xf6, 0x01,
-	0x12, 0xa4, 0x28, 0xab, 0x3d, 0xe8, 0x86, 0xfd, 0x5e, 0xfb, 0x3d, 0xf6, 0x7b, 0x01, 0x2a, 0xfb,
-	0xbe, 0xfb, 0x98, 0xf8, 0x1b, 0x9e, 0xef, 0x86, 0xae, 0xe1, 0xda, 0x93, 0x8f, 0x1a, 0xfb, 0x40,
-	0xb9, 0xe8, 0x5c, 0x7e, 0x7b, 0xe8, 0x0e, 0x5d, 0x76, 0xda, 0xa0, 0x5f, 0x1c, 0x5f, 0xae, 0x78,
-	0xe1, 0xa1, 0x47, 0x82, 0x0d, 0x73, 0xec, 0xeb, 0xa1, 0xe5, 0x3a, 0x93, 0x0f, 0x8e, 0x57, 0x6e,
-	0x40, 0xba, 0xad, 0xef, 0x13, 0x1b, 0x21, 0x48, 0x39, 0xfa, 0x88, 0xac, 0x4a, 0x6b, 0xd2, 0x7a,
-	0x1e, 0xb3, 0x6f, 0xf4, 0x36, 0xa4, 0x9f, 0xe8, 0xf6, 0x98, 0xac, 0x26, 0x18, 0x90, 0x1f, 0x94,
-	0x0e, 0xe4, 0x18, 0x4b, 0x9f, 0x84, 0xa8, 0x0e, 0x19, 0x9b, 0x7e, 0x07, 0xab, 0xd2, 0x5a, 0x72,
-	0xbd, 0xb0, 0x79, 0xae, 0x36, 0xd1, 0x8f, 0xd1, 0xd4, 0x2f, 0xbd, 0x38, 0xaa, 0x2e, 0xbd, 0x3e,
-	0xaa, 0xae, 0x1c, 0xea, 0x23, 0xfb, 0x96, 0x72, 0xd5, 0x1d, 0x59, 0x21, 0x19, 0x79, 0xe1, 0xa1,
-	0x82, 0x05, 0xa7, 0xf2, 0x0b, 0x28, 0x09, 0x79, 0x36, 0x31, 0x42, 0xd7, 0x47, 0x9b, 0x90, 0xb5,
-	0x1c, 0xc3, 0x1e, 0x9b, 0x5c, 0x9b, 0xc2, 0x26, 0x9a, 0x93, 0xda, 0x27, 0x61, 0x3d, 0x45, 0x05,
-	0xe3, 0x88, 0x90, 0xf2, 0x90, 0xa7, 0x9c, 0x27, 0x71, 0x1a, 0x8f, 0x20, 0xbc, 0x95, 0xfa, 0xfa,
-	0x79, 0x75, 0x49, 0xf9, 0x5b, 0x16, 0x0a, 0x9f, 0xbb, 0x63, 0xdf, 0xd1, 0xed, 0xbe, 0x47, 0x0c,
-	0xf4, 0xbd, 0xb8, 0x23, 0xea, 0x6b, 0x0b, 0x75, 0xff, 0xee, 0xa8, 0x9a, 0x15, 0x3c, 0xc2, 0x55,
-	0x9f, 0x42, 0xc1, 0x27, 0x9e, 0x6d, 0x19, 0xcc, 0xb9, 0x4c, 0x87, 0x74, 0xfd, 0xfc, 0x62, 0xc3,
-	0xe3, 0x94, 0xa8, 0x37, 0xf1, 0x60, 0xf2, 0x44, 0xbd, 0x3f, 0xa0, 0x7a, 0xbf, 0x3c, 0xaa, 0x4a,
-	0xaf, 0x8f, 0xaa, 0xab, 0xf3, 0xf2, 0xae, 0x5a, 0x8e, 0x6d, 0x39, 0x64, 0xe2, 0x4f, 0xb4, 0x07,
-	0xb9, 0x03, 0x5f, 0x1f, 0x8e, 0x88, 0x13, 0xae, 0xa6, 0x98, 0xcc, 0xca, 0x54, 0x66, 0xcc, 0xd2,
-	0xda, 0xb6, 0xa0, 0x7a, 0x53, 0x90, 0x26, 0xa2, 0xd0, 0x8f, 0x21, 0x7d, 0x60, 0xeb, 0xc3, 0x60,
-	0x35, 0xb3, 0x26, 0xad, 0x97, 0xea, 0x1f, 0x9f, 0xe4, 0x18, 0x39, 0x76, 0x85, 0xb6, 0x6d, 0xeb,
-	0x43, 0xcc, 0xf9, 0xca, 0x7f, 0x4a, 0x41, 0x2e, 0xba, 0x12, 0x5d, 0x83, 0x8c, 0x4d, 0x9c, 0x61,
-	0xf8, 0x88, 0xf9, 0x39, 0x79, 0x92, 0xab, 0x04, 0x11, 0x72, 0x61, 0xc5, 0x70, 0x47, 0x9e, 0x4f,
-	0x82, 0xc0, 0x72, 0x1d, 0xcd, 0x70, 0x4d, 0x62, 0x30, 0x27, 0x2f, 0x6f, 0x96, 0xa7, 0xc6, 0x6d,
-	0x4d, 0x49, 0xb6, 0x28, 0x45, 0xfd, 0xa3, 0xd7, 0x47, 0x55, 0x85, 0x4b, 0x3d, 0xc6, 0x1e, 0xbf,
-	0x46, 0x36, 0xe6, 0x38, 0xd1, 0x8f, 0x20, 0x13, 0x84, 0xae, 0x4f, 0x68, 0x58, 0x92, 0xeb, 0x79,
-	0x26, 0x69, 0xa1, 0xb9, 0xa5, 0xc8, 0xa4, 0x3e, 0x25, 0xc7, 0x82, 0x0b, 0x05, 0x20, 0xfb, 0xe4,
-	0xc0, 0x27, 0xc1, 0x23, 0xcd, 0x72, 0x42, 0xe2, 0x3f, 0xd1, 0x6d, 0x11, 0x8c, 0x4b, 0xb5, 0xa1,
-	0xeb, 0x0e, 0x6d, 0xc2, 0xd5, 0xde, 0x1f, 0x1f, 0xd4, 0x1a, 0x22, 0x25, 0xeb, 0xd7, 0x44, 0x1c,
-	0xde, 0xe3, 0x17, 0xcd, 0x0b, 0x88, 0x5d, 0xfc, 0xf5, 0x37, 0x55, 0x09, 0x9f, 0x13, 0x04, 0x2d,
-	0x81, 0x47, 0xf7, 0x20, 0xef, 0x93, 0x90, 0x38, 0xec, 0x09, 0xa6, 0x4f, 0xbb, 0xed, 0xf2, 0x89,
-	0x51, 0x67, 0xd2, 0xa7, 0xa2, 0xd0, 0x08, 0x96, 0x0f, 0xec, 0x71, 0xdc, 0x94, 0xcc, 0x69, 0xc2,
-	0x3f, 0x11, 0xc2, 0xab, 0x5c, 0xf8, 0x2c, 0xfb, 0xfc, 0x55, 0x25, 0x86, 0x8e, 0xcc, 0x50, 0x54,
-	0x48, 0xd1, 0x77, 0x83, 0x56, 0xa0, 0xd4, 0xe9, 0x0e, 0xb4, 0x7e, 0xaf, 0xb9, 0xd5, 0xda, 0x6e,
-	0x35, 0x1b, 0xf2, 0x12, 0x2a, 0x42, 0xae, 0xab, 0xe1, 0x46, 0xb7, 0xd3, 0x7e, 0x20, 0x4b, 0xfc,
-	0x74, 0x1f, 0xb3, 0x53, 0x02, 0x01, 0x64, 0x28, 0xee, 0x3e, 0x96, 0x53, 0xca, 0x1f, 0x24, 0x28,
-	0xf4, 0x7c, 0xd7, 0x20, 0x41, 0xc0, 0x92, 0xba, 0x06, 0x09, 0xcb, 0x14, 0xd5, 0x64, 0x75, 0xfa,
-	0x60, 0x62, 0x24, 0xb5, 0x56, 0x43, 0xd4, 0x87, 0x84, 0x65, 0xa2, 0x75, 0xc8, 0x11, 0xc7, 0xf4,
-	0x5c, 0xcb, 0x09, 0x79, 0xf1, 0xab, 0x17, 0xbf, 0x3b, 0xaa, 0xe6, 0x9a, 0x02, 0x86, 0x27, 0xd8,
-	0xf2, 0x75, 0x48, 0xb4, 0x1a, 0xb4, 0x7a, 0x7e, 0xe5, 0x3a, 0x93, 0xea, 0x49, 0xbf, 0xd1, 0x05,
-	0xc8, 0x04, 0xe3, 0x83, 0x03, 0xeb, 0xa9, 0x28, 0x9f, 0xe2, 0x74, 0x2b, 0xf5, 0xab, 0xe7, 0x55,
-	0x49, 0xf9, 0xa5, 0x04, 0x50, 0x67, 0xb5, 0x9d, 0x29, 0x38, 0x80, 0xa2, 0xc7, 0x95, 0xd1, 0x02,
-	0x8f, 0x18, 0x42, 0xd5, 0xf3, 0x0b, 0x55, 0xad, 0x97, 0x63, 0xf5, 0x60, 0x59, 0x44, 0x2f, 0xaa,
-	0x02, 0x05, 0x2f, 0x66, 0xf6, 0xfb, 0x50, 0xfa, 0x29, 0xcf, 0x46, 0xcd, 0xb6, 0x46, 0x16, 0xb7,
-	0xa5, 0x84, 0x8b, 0x02, 0xd8, 0xa6, 0x30, 0xe5, 0x79, 0x22, 0x96, 0x97, 0x1f, 0x42, 0x56, 0x20,
-	0x45, 0x01, 0x2c, 0xc4, 0x6b, 0x5d, 0x84, 0xa3, 0x9d, 0x61, 0x9f, 0x0c, 0x2d, 0x5e, 0xe8, 0x92,
-	0x98, 0x1f, 0x90, 0x0c, 0x49, 0xe2, 0x98, 0xac, 0x90, 0x25, 0x31, 0xfd, 0x44, 0x1f, 0x43, 0x32,
-	0x18, 0x8f, 0xc4, 0xcb, 0x5f, 0x99, 0x5a, 0xd3, 0xbf, 0xa3, 0xde, 0xe8, 0x8f, 0x47, 0xc2, 0xe3,
-	0x94, 0x06, 0xed, 0x2c, 0x4a, 0xf1, 0xf4, 0x69, 0x29, 0xbe, 0x20, 0x75, 0x7f, 0x00, 0xa5, 0x7d,
-	0xdd, 0x78, 0x6c, 0x39, 0x43, 0x8d, 0x25, 0x23, 0x7b, 0xac, 0xf9, 0xfa, 0xca, 0xf1, 0x64, 0x2d,
-	0x0a, 0x3a, 0x76, 0x42, 0x97, 0x20, 0x37, 0x72, 0x4d, 0x2d, 0xb4, 0x46, 0x64, 0x35, 0xcb, 0x4c,
-	0xc8, 0x8e, 0x5c, 0x73, 0x60, 0x8d, 0x88, 0x72, 0x17, 0xb2, 0x42, 0x63, 0x6a, 0xb9, 0xa7, 0xfb,
-	0xe1, 0x0d, 0xe6, 0x9e, 0x0c, 0xe6, 0x87, 0x08, 0xba, 0xc9, 0xfc, 0x21, 0xa0, 0x9b, 0x11, 0xf4,
-	0x26, 0xf3, 0x48, 0x96, 0x43, 0x6f, 0x2a, 0x7f, 0x97, 0xa0, 0x80, 0x89, 0x6e, 0x62, 0xf2, 0xb3,
-	0x31, 0x09, 0x42, 0xb4, 0x0e, 0x99, 0x47, 0x44, 0x37, 0x89, 0x2f, 0x82, 0x2e, 0x4f, 0xad, 0xbd,
-	0xc3, 0xe0, 0x58, 0xe0, 0xe3, 0xc1, 0x49, 0xbc, 0x21, 0x38, 0x17, 0x20, 0xe3, 0x1e, 0x1c, 0x04,
-	0x24, 0x14, 0x91, 0x10, 0x27, 0x16, 0x34, 0xdb, 0x35, 0x1e, 0xb3, 0x70, 0xe4, 0x30, 0x3f, 0xa0,
-	0x35, 0x28, 0x9a, 0xae, 0xe6, 0xb8, 0xa1, 0xe6, 0xf9, 0xee, 0xd3, 0x43, 0xe6, 0xf2, 0x1c, 0x06,
-	0xd3, 0xed, 0xb8, 0x61, 0x8f, 0x42, 0xe8, 0x2b, 0x1a, 0x91, 0x50, 0x37, 0xf5, 0x50, 0xd7, 0x5c,
-	0xc7, 0x3e, 0x64, 0x0e, 0xcd, 0xe1, 0x62, 0x04, 0xec, 0x3a, 0xf6, 0xa1, 0xf2, 0x2c, 0x01, 0x45,
-	0x6e, 0x55, 0xe0, 0xb9, 0x4e, 0x40, 0xa8, 0x59, 0x41, 0xa8, 0x87, 0xe3, 0x80, 0x99, 0xb5, 0x1c,
-	0x37, 0xab, 0xcf, 0xe0, 0x58, 0xe0, 0x63, 0x0e, 0x48, 0x9c, 0xe2, 0x80, 0x93, 0x2c, 0xbb, 0x0c,
-	0xf0, 0xa5, 0x6f, 0x85, 0x44, 0xa3, 0x74, 0xcc, 0xbc, 0x24, 0xce, 0x33, 0x08, 0x15, 0x80, 0x6a,
-	0xb1, 0x8e, 0x98, 0x9e, 0xef, 0xb2, 0xd1, 0x93, 0x88, 0xb5, 0xba, 0xf7, 0xa0, 0x18, 0x7d, 0x6b,
-	0x63, 0x9f, 0x57, 0xbb, 0x3c, 0x2e, 0x44, 0xb0, 0x3d, 0xdf, 0x46, 0xab, 0x90, 0x35, 0x5c, 0x87,
-	0x16, 0x48, 0xf6, 0x56, 0x8a, 0x38, 0x3a, 0x2a, 0x7f, 0x96, 0xa0, 0xa4, 0x7a, 0x1e, 0x71, 0xce,
-	0x2e, 0xc0, 0xf3, 0x21, 0x4b, 0x1e, 0x0b, 0xd9, 0xd4, 0x51, 0xe9, 0x19, 0x47, 0xc5, 0xd4, 0x4e,
-	0xcd, 0xaa, 0xfd, 0x3b, 0x09, 0x96, 0x23, 0xb5, 0xff, 0xef, 0x08, 0xd6, 0x4e, 0x8b, 0xa0, 0x48,
-	0xf4, 0xc8, 0xce, 0x2b, 0x90, 0x31, 0xdc, 0x11, 0x2d, 0x48, 0xc9, 0x13, 0xc3, 0x21, 0x28, 0x94,
-	0xff, 0x48, 0x20, 0x63, 0x31, 0x30, 0x91, 0x33, 0x73, 0x69, 0x0d, 0xe8, 0x24, 0xed, 0xb9, 0x81,
-	0x6e, 0xbf, 0x41, 0xa7, 0x09, 0xcd, 0xc9, 0x8e, 0xa4, 0xd9, 0x22, 0x3e, 0x35, 0x93, 0xd8, 0xa1,
-	0x2e, 0x22, 0x50, 0x14, 0xc0, 0x06, 0x85, 0xa1, 0x35, 0x28, 0xe8, 0xc6, 0x63, 0xc7, 0xfd, 0xd2,
-	0x26, 0xe6, 0x90, 0x88, 0x84, 0x8a, 0x83, 0x94, 0xdf, 0x4b, 0xb0, 0x12, 0x33, 0xfb, 0x0c, 0x93,
-	0x2a, 0x9e, 0x1d, 0xc9, 0xd3, 0xb3, 0x43, 0x79, 0x26, 0x41, 0xa1, 0x6d, 0x05, 0x61, 0x14, 0x8b,
-	0x1f, 0x42, 0x2e, 0x10, 0xa3, 0xbb, 0x88, 0xc6, 0xc5, 0x63, 0x33, 0x2c, 0x47, 0x8b, 0x57, 0x30,
-	0x21, 0xa7, 0x79, 0xeb, 0xe9, 0x43, 0x32, 0xd3, 0x9c, 0xf2, 0x14, 0xc2, 0x3a, 0xd3, 0x04, 0x1d,
-	0xba, 0x8f, 0x89, 0xc3, 0x74, 0xcb, 0x73, 0xf4, 0x80, 0x02, 0x94, 0x6f, 0x12, 0x50, 0xe4, 0x8a,
-	0x9c, 0xf9, 0x83, 0xfd, 0x09, 0xe4, 0xc4, 0x4b, 0xe1, 0x03, 0xe1, 0xcc, 0x4c, 0x1d, 0xd7, 0x21,
-	0x1a, 0xb0, 0x23, 0x53, 0x23, 0x2e, 0xf4, 0x11, 0x9c, 0x73, 0xc8, 0xd3, 0x50, 0x8b, 0x19, 0x94,
-	0x62, 0x06, 0x95, 0x28, 0xb8, 0x17, 0x19, 0x55, 0xfe, 0xb5, 0x04, 0xd1, 0xeb, 0x44, 0x1b, 0x90,
-	0x5a, 0x3c, 0x0c, 0xc4, 0x46, 0x6c, 0x71, 0x11, 0x23, 0xa4, 0x85, 0x8b, 0xb6, 0x30, 0x9f, 0x3c,
-	0xb1, 0x82, 0x68, 0x0d, 0x49, 0xe2, 0xc2, 0xc8, 0x35, 0xb1, 0x00, 0xa1, 0x4f, 0x20, 0xed, 0xbb,
-	0xe3, 0x90, 0x88, 0x50, 0xc7, 0x16, 0x36, 0x4c, 0xc1, 0x42, 0x1c, 0xa7, 0x51, 0xfe, 0x29, 0x41,
-	0x51, 0xf5, 0x3c, 0xfb, 0x30, 0x8a, 0xf5, 0x6d, 0xc8, 0x1a, 0x8f, 0x74, 0x67, 0x48, 0xa2, 0x85,
-	0xef, 0xf2, 0x94, 0x3f, 0x4e, 0x58, 0xdb, 0x62, 0x54, 0xd1, 0xc6, 0x25, 0x78, 0xca, 0xbf, 0x91,
-	0x20, 0xc3, 0x31, 0xa8, 0x06, 0x6f, 0x91, 0xa7, 0x1e, 0x31, 0x42, 0x6d, 0x46, 0x63, 0xb6, 0x0d,
-	0xe0, 0x15, 0x8e, 0xda, 0x8d, 0xe9, 0x7d, 0x0d, 0x32, 0x63, 0x2f, 0x20, 0x7e, 0x28, 0x22, 0xb6,
-	0xd8, 0x1b, 0x58, 0x10, 0xa1, 0xf7, 0x21, 0x63, 0x12, 0x9b, 0x08, 0x3b, 0xe7, 0xb2, 0x5e, 0xa0,
-	0x14, 0x8b, 0x55, 0x6a, 0xaa, 0xf4, 0x59, 0x3f, 0x20, 0xe5, 0x5f, 0x09, 0x90, 0xa3, 0x5c, 0x0a,
-	0xce, 0xac, 0x8a, 0x7d, 0x00, 0xcb, 0x6c, 0x12, 0xd3, 0x26, 0x83, 0x0c, 0xef, 0x93, 0x45, 0x06,
-	0xdd, 0xe5, 0xd3, 0x0c, 0x6d, 0x1f, 0xc4, 0x31, 0xa7, 0x34, 0xbc, 0x5f, 0x02, 0x71, 0xcc, 0x88,
-	0x62, 0xc1, 0x63, 0xe5, 0x55, 0x6c, 0xf6, 0xb1, 0xce, 0xe5, 0x2f, 0xad, 0x62, 0xe9, 0x78, 0xfe,
-	0xee, 0x40, 0x31, 0xb0, 0x86, 0x8e, 0x1e, 0x8e, 0x7d, 0x32, 0x18, 0xb4, 0x59, 0xa7, 0x7c, 0xe3,
-	0xd6, 0x90, 0x7b, 0x71, 0x54, 0x95, 0xd8, 0x4a, 0x30, 0xc3, 0x78, 0xac, 0xe1, 0xe5, 0xe6, 0x1b,
-	0x9e, 0xf2, 0x97, 0x04, 0xac, 0xc4, 0xfc, 0x7b, 0xe6, 0x05, 0xa1, 0x05, 0xf9, 0xa8, 0x20, 0x46,
-	0x15, 0xe1, 0xc3, 0xe3, 0x55, 0x73, 0xa2, 0x49, 0x4d, 0x9b, 0x2c, 0xdb, 0x5c, 0xce, 0x94, 0xfb,
-	0xa4, 0xca, 0x30, 0xef, 0xec, 0xf2, 0x17, 0x90, 0x9f, 0x48, 0x41, 0x57, 0x67, 0x4a, 0xc3, 0x82,
-	0x82, 0x3d, 0x53, 0x17, 0x2e, 0x03, 0x50, 0x7f, 0x12, 0x93, 0x8d, 0x33, 0x7c, 0x1d, 0xc9, 0x73,
-	0xc8, 0x9e, 0x6f, 0xd3, 0x5d, 0x24, 0xcd, 0xb2, 0x1f, 0x7d, 0x06, 0xd9, 0x11, 0x19, 0xed, 0x13,
-	0x3f, 0xca, 0xef, 0xd3, 0x96, 0xa5, 0x88, 0x9c, 0x36, 0x44, 0xcf, 0xb7, 0x46, 0xba, 0x7f, 0xc8,
-	0x7f, 0x7e, 0xe0, 0xe8, 0x88, 0xae, 0x40, 0x3e, 0xda, 0x96, 0xa2, 0x6d, 0x7a, 0x76, 0x99, 0x9a,
-	0xa2, 0x95, 0x3f, 0x26, 0x20, 0xc3, 0xfd, 0x8d, 0x6e, 0x03, 0x44, 0x1b, 0xd1, 0xff, 0xbc, 0xba,
-	0xe5, 0x05, 0x47, 0xcb, 0x9c, 0xd6, 0xb9, 0xc4, 0xe9, 0x75, 0x8e, 0x16, 0x5a, 0x12, 0x1a, 0xa6,
-	0xa8, 0x89, 0xe7, 0xe7, 0x63, 0x5f, 0x6b, 0x86, 0x86, 0x19, 0x39, 0x94, 0x12, 0x96, 0x7f, 0x0e,
-	0x29, 0x0a, 0xa3, 0x8e, 0x35, 0xec, 0x71, 0x10, 0x12, 0x3f, 0x52, 0x32, 0x85, 0xf3, 0x02, 0xd2,
-	0x32, 0xd1, 0x3b, 0x90, 0xe7, 0xfe, 0xa1, 0xd8, 0x04, 0xc3, 0xe6, 0x38, 0xa0, 0x65, 0xa2, 0x32,
-	0xe4, 0x26, 0x65, 0x8f, 0xa7, 0xe9, 0xe4, 0x4c, 0x19, 0x7d, 0xfd, 0x20, 0xd4, 0x42, 0xe2, 0xf3,
-	0xed, 0x29, 0x85, 0x73, 0x14, 0x30, 0x20, 0xfe, 0xe8, 0xca, 0x5f, 0x13, 0x90, 0xe1, 0xcf, 0x17,
-	0x65, 0x20, 0xd1, 0xbd, 0x2b, 0x2f, 0xa1, 0xf3, 0xb0, 0xf2, 0x79, 0x77, 0x0f, 0x77, 0xd4, 0xb6,
-	0x46, 0x57, 0xe6, 0xed, 0xee, 0x5e, 0xa7, 0x21, 0x4b, 0xe8, 0x32, 0x5c, 0xea, 0x74, 0xb5, 0x08,
-	0xd3, 0xc3, 0xad, 0x5d, 0x15, 0x3f, 0xd0, 0xea, 0xb8, 0x7b, 0xb7, 0x89, 0xe5, 0x04, 0xaa, 0x40,
-	0x99, 0x52, 0x9f, 0x80, 0x4f, 0xa2, 0x0b, 0x80, 0xe2, 0x78, 0x01, 0x4f, 0xa3, 0x35, 0x78, 0xb7,
-	0xd5, 0xe9, 0xef, 0x6d, 0x6f, 0xb7, 0xb6, 0x5a, 0xcd, 0xce, 0x3c, 0x41, 0x5f, 0x4e, 0xa1, 0x77,
-	0x61, 0xb5, 0xbb, 0xbd, 0xdd, 0x6f, 0x0e, 0x98, 0x3a, 0x0f, 0x9a, 0x03, 0x4d, 0xbd, 0xa7, 0xb6,
-	0xda, 0x6a, 0xbd, 0xdd, 0x94, 0x33, 0xe8, 0x1c, 0x14, 0xe8, 0xd6, 0xbe, 0xa3, 0xe1, 0xee, 0xde,
-	0xa0, 0x29, 0x67, 0xa9, 0xfa, 0xdb, 0x58, 0xdd, 0xd9, 0xa5, 0xc2, 0x76, 0x5b, 0xfd, 0x5d, 0x75,
-	0xb0, 0x75, 0x47, 0xce, 0xa1, 0x77, 0xe0, 0x62, 0x73, 0xb0, 0xd5, 0xd0, 0x06, 0x58, 0xed, 0xf4,
-	0xd5, 0xad, 0x41, 0xab, 0xdb, 0xd1, 0xb6, 0xd5, 0x56, 0xbb, 0xd9, 0x90, 0xf3, 0x54, 0x08, 0x95,
-	0xad, 0xb6, 0xdb, 0xdd, 0xfb, 0xcd, 0x86, 0x0c, 0xe8, 0x22, 0xbc, 0xc5, 0xa5, 0xaa, 0xbd, 0x5e,
-	0xb3, 0xd3, 0xd0, 0xb8, 0x02, 0x72, 0x81, 0x2a, 0xd3, 0xea, 0x34, 0x9a, 0x5f, 0x68, 0x77, 0xd4,
-	0xbe, 0xb6, 0x83, 0x9b, 0xea, 0xa0, 0x89, 0x23, 0x6c, 0xf1, 0x8a, 0x03, 0xf2, 0xfc, 0x52, 0x89,
-	0x0a, 0x90, 0x6d, 0x75, 0xee, 0xa9, 0xed, 0x56, 0x43, 0x5e, 0x42, 0x39, 0x48, 0x75, 0xba, 0x9d,
-	0xa6, 0x2c, 0xd1, 0xaf, 0x9d, 0x87, 0xad, 0x9e, 0x9c, 0x40, 0x25, 0xc8, 0x3f, 0xec, 0x0f, 0xd4,
-	0x4e, 0x43, 0xc5, 0x0d, 0x39, 0x89, 0x00, 0x32, 0xfd, 0x8e, 0xda, 0xeb, 0x3d, 0x90, 0x53, 0xd4,
-	0xa9, 0x94, 0x88, 0x5e, 0xd0, 0xee, 0xaa, 0x0d, 0xad, 0xd1, 0xdc, 0xea, 0xee, 0xf6, 0x70, 0xb3,
-	0xdf, 0x6f, 0x75, 0x3b, 0x72, 0x7a, 0xf3, 0x59, 0x72, 0xda, 0xe0, 0xbf, 0x0f, 0x29, 0x3a, 0x3c,
-	0xa0, 0xf3, 0xf3, 0xc3, 0x04, 0xeb, 0x0f, 0xe5, 0x0b, 0x8b, 0x67, 0x0c, 0xf4, 0x19, 0xa4, 0x59,
-	0xdf, 0x42, 0x17, 0x16, 0x77, 0xdf, 0xf2, 0xc5, 0x63, 0x70, 0xc1, 0xf9, 0x29, 0xa4, 0xe8, 0x92,
-	0x16, 0xbf, 0x30, 0xb6, 0x8a, 0xc6, 0x2f, 0x8c, 0xef, 0x72, 0xd7, 0x25, 0x74, 0x1b, 0x32, 0x7c,
-	0x3b, 0x40, 0xb3, 0xb2, 0xa7, 0x6b, 0x4e, 0x79, 0xf5, 0x38, 0x82, 0xb3, 0xaf, 0x4b, 0xe8, 0x0e,
-	0xe4, 0x27, 0xc3, 0x2c, 0x2a, 0xc7, 0x6f, 0x99, 0x1d, 0xec, 0xcb, 0xef, 0x2c, 0xc4, 0x45, 0x72,
-	0xae, 0x53, 0x49, 0x25, 0xea, 0x8b, 0x49, 0x85, 0x8d, 0x4b, 0x9b, 0x6f, 0xb0, 0x71, 0x69, 0xc7,
-	0x4a, 0x72, 0x5d, 0x7d, 0xf1, 0xef, 0xca, 0xd2, 0x8b, 0x6f, 0x2b, 0xd2, 0xcb, 0x6f, 0x2b, 0xd2,
-	0x6f, 0x5f, 0x55, 0x96, 0x9e, 0xbf, 0xaa, 0x48, 0x2f, 0x5f, 0x55, 0x96, 0xfe, 0xf1, 0xaa, 0xb2,
-	0xf4, 0xf0, 0xfd, 0xa1, 0x5b, 0x1b, 0xea, 0x5f, 0x91, 0x30, 0x24, 0x35, 0x93, 0x3c, 0xd9, 0x30,
-	0x5c, 0x9f, 0x6c, 0xcc, 0xfd, 0x8c, 0xdf, 0xcf, 0xb0, 0xaf, 0x9b, 0xff, 0x0d, 0x00, 0x00, 0xff,
-	0xff, 0xe5, 0x34, 0xdc, 0xe9, 0xa6, 0x17, 0x00, 0x00,
+	// 2816 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x39, 0x4b, 0x6f, 0x1b, 0xd7,
+	0xd5, 0x1a, 0xbe, 0x79, 0x48, 0x4a, 0xa3, 0x1b, 0x5b, 0xa6, 0xe9, 0x58, 0x54, 0xc6, 0x49, 0x3e,
+	0xc5, 0x71, 0xe8, 0x58, 0x4e, 0xbe, 0xa4, 0x06, 0x92, 0x96, 0x14, 0x29, 0x89, 0x31, 0x45, 0xaa,
+	0x97, 0x54, 0x12, 0x7b, 0x33, 0x18, 0xcd, 0x5c, 0xd1, 0xac, 0x86, 0x33, 0x93, 0x99, 0xa1, 0x23,
+	0xa6, 0x68, 0xd1, 0x55, 0x13, 0x14, 0x5d, 0x74, 0xd7, 0xec, 0x1a, 0xf4, 0x17, 0x74, 0x51, 0xa0,
+	0x0f, 0xa0, 0x7b, 0x77, 0x97, 0x65, 0x37, 0x55, 0xd0, 0x18, 0xe8, 0xb6, 0x80, 0xd1, 0x6e, 0xb2,
+	0x2a, 0xee, 0x63, 0xc8, 0x21, 0x45, 0x99, 0x4e, 0x50, 0xed, 0xe6, 0x9e, 0x17, 0xcf, 0x3d, 0xef,
+	0x7b, 0x08, 0xab, 0x07, 0xae, 0x7d, 0x44, 0xdc, 0x9b, 0x8e, 0x6b, 0xfb, 0xb6, 0x6e, 0x9b, 0xa3,
+	0x8f, 0x12, 0xfb, 0x40, 0xa9, 0xe0, 0x5c, 0xb8, 0xd0, 0xb5, 0xbb, 0x36, 0x3b, 0xdd, 0xa4, 0x5f,
+	0x1c, 0x5f, 0x58, 0x75, 0xfc, 0xa1, 0x43, 0xbc, 0x9b, 0xc6, 0xc0, 0xd5, 0xfc, 0x9e, 0x6d, 0x8d,
+	0x3e, 0x38, 0x5e, 0xb9, 0x05, 0xf1, 0x86, 0x76, 0x40, 0x4c, 0x84, 0x20, 0x66, 0x69, 0x7d, 0x92,
+	0x97, 0xd6, 0xa4, 0xf5, 0x34, 0x66, 0xdf, 0xe8, 0x02, 0xc4, 0x1f, 0x6a, 0xe6, 0x80, 0xe4, 0x23,
+	0x0c, 0xc8, 0x0f, 0x4a, 0x13, 0x52, 0x8c, 0xa5, 0x4d, 0x7c, 0x54, 0x81, 0x84, 0x49, 0xbf, 0xbd,
+	0xbc, 0xb4, 0x16, 0x5d, 0xcf, 0x6c, 0x2c, 0x95, 0x46, 0xfa, 0x31, 0x9a, 0xca, 0xe5, 0x47, 0x27,
+	0xc5, 0x85, 0x27, 0x27, 0xc5, 0xe5, 0xa1, 0xd6, 0x37, 0xef, 0x28, 0x37, 0xec, 0x7e, 0xcf, 0x27,
+	0x7d, 0xc7, 0x1f, 0x2a, 0x58, 0x70, 0x2a, 0x3f, 0x81, 0x9c, 0x90, 0x67, 0x12, 0xdd, 0xb7, 0x5d,
+	0xb4, 0x01, 0xc9, 0x9e, 0xa5, 0x9b, 0x03, 0x83, 0x6b, 0x93, 0xd9, 0x40, 0x53, 0x52, 0xdb, 0xc4,
+	0xaf, 0xc4, 0xa8, 0x60, 0x1c, 0x10, 0x52, 0x1e, 0x72, 0xcc, 0x79, 0x22, 0xf3, 0x78, 0x04, 0xe1,
+	0x9d, 0xd8, 0xe7, 0x5f, 0x14, 0x17, 0x94, 0x3f, 0x66, 0x21, 0xf3, 0x9e, 0x3d, 0x70, 0x2d, 0xcd,
+	0x6c, 0x3b, 0x44, 0x47, 0x6f, 0x84, 0x0d, 0x51, 0x59, 0x9b, 0xa9, 0xfb, 0x37, 0x27, 0xc5, 0xa4,
+	0xe0, 0x11, 0xa6, 0x7a, 0x0b, 0x32, 0x2e, 0x71, 0xcc, 0x9e, 0xce, 0x8c, 0xcb, 0x74, 0x88, 0x57,
+	0x2e, 0xce, 0xbe, 0x78, 0x98, 0x12, 0xed, 0x8d, 0x2c, 0x18, 0x3d, 0x53, 0xef, 0x17, 0xa9, 0xde,
+	0x5f, 0x9e, 0x14, 0xa5, 0x27, 0x27, 0xc5, 0xfc, 0xb4, 0xbc, 0x1b, 0x3d, 0xcb, 0xec, 0x59, 0x64,
+	0x64, 0x4f, 0xb4, 0x0f, 0xa9, 0x43, 0x57, 0xeb, 0xf6, 0x89, 0xe5, 0xe7, 0x63, 0x4c, 0xe6, 0xea,
+	0x58, 0x66, 0xe8, 0xa6, 0xa5, 0x2d, 0x41, 0xf5, 0x34, 0x27, 0x8d, 0x44, 0xa1, 0xef, 0x43, 0xfc,
+	0xd0, 0xd4, 0xba, 0x5e, 0x3e, 0xb1, 0x26, 0xad, 0xe7, 0x2a, 0xaf, 0x9c, 0x65, 0x18, 0x39, 0xf4,
+	0x13, 0xea, 0x96, 0xa9, 0x75, 0x31, 0xe7, 0x43, 0x35, 0x88, 0x79, 0x44, 0x33, 0xf3, 0x49, 0xa6,
+	0x53, 0x61, 0xb6, 0x4e, 0x6d, 0xa2, 0x99, 0x67, 0xd9, 0x8d, 0xb1, 0xa3, 0x9f, 0xc2, 0x05, 0xcd,
+	0x71, 0x88, 0x65, 0xa8, 0xfa, 0x83, 0x81, 0x75, 0xa4, 0xfa, 0xbd, 0x3e, 0xb1, 0x07, 0x7e, 0x3e,
+	0xc5, 0xc4, 0x5e, 0x2e, 0x75, 0x6d, 0xbb, 0x6b, 0x12, 0x2e, 0xfd, 0x60, 0x70, 0x58, 0xaa, 0x8a,
+	0x80, 0xaf, 0xdc, 0x12, 0xb7, 0x7c, 0x89, 0x4b, 0x9e, 0x25, 0x24, 0xf4, 0x6b, 0x9f, 0x7f, 0x55,
+	0x94, 0x30, 0xe2, 0x44, 0x9b, 0x94, 0xa6, 0xc3, 0x49, 0xd0, 0xbb, 0x00, 0x9a, 0x7e, 0xa4, 0x7e,
+	0x34, 0xb0, 0xdd, 0x41, 0x3f, 0x9f, 0x66, 0x8e, 0x2e, 0x3e, 0x39, 0x29, 0x5e, 0x11, 0x62, 0x47,
+	0xb8, 0xb0, 0xea, 0x69, 0x4d, 0x3f, 0xfa, 0x21, 0x83, 0xa2, 0x36, 0x2c, 0x7f, 0xec, 0xf6, 0x7c,
+	0xa2, 0x86, 0xe3, 0x05, 0x98, 0x98, 0x97, 0x9f, 0x9c, 0x14, 0x15, 0x2e, 0xe6, 0x14, 0x49, 0x58,
+	0x9a, 0xcc, 0xb0, 0x78, 0x8c, 0x44, 0x77, 0x20, 0xa9, 0x99, 0x3d, 0xcd, 0x23, 0x5e, 0x3e, 0xb3,
+	0x16, 0x7d, 0xa6, 0xb8, 0x0d, 0x18, 0x50, 0x03, 0x96, 0xfa, 0xda, 0xb1, 0x2a, 0xec, 0xe1, 0x6a,
+	0x3e, 0xc9, 0x67, 0xd7, 0xa4, 0xf5, 0x68, 0xe5, 0xc5, 0x27, 0x27, 0xc5, 0x35, 0x2e, 0x63, 0x8a,
+	0x20, 0xac, 0x4c, 0xae, 0xaf, 0x1d, 0x97, 0x19, 0x0a, 0x6b, 0x3e, 0x29, 0xfc, 0x2b, 0x06, 0xa9,
+	0x20, 0xb0, 0xd0, 0x6b, 0x90, 0x30, 0x89, 0xd5, 0xf5, 0x1f, 0xb0, 0x6c, 0x8a, 0x9e, 0xe5, 0x58,
+	0x41, 0x84, 0x6c, 0x58, 0xd6, 0xed, 0xbe, 0xe3, 0x12, 0xcf, 0xeb, 0xd9, 0x96, 0xaa, 0xdb, 0x06,
+	0xd1, 0x59, 0x2a, 0x2d, 0x86, 0xc3, 0x65, 0x73, 0x4c, 0xb2, 0x49, 0x29, 0xc2, 0x66, 0x3b, 0xc5,
+	0x3e, 0x61, 0x36, 0x7d, 0x8a, 0x13, 0xbd, 0x0b, 0x09, 0xcf, 0xb7, 0x5d, 0x42, 0x93, 0x8f, 0x5a,
+	0xed, 0xe5, 0xb3, 0xac, 0x96, 0x0b, 0xae, 0xd4, 0xa6, 0xe4, 0x58, 0x70, 0x21, 0x0f, 0x64, 0x97,
+	0x1c, 0xba, 0xc4, 0x7b, 0xa0, 0xf6, 0x2c, 0x9f, 0xb8, 0x0f, 0x35, 0x33, 0x1f, 0x9b, 0x17, 0x87,
+	0xaf, 0x89, 0x38, 0x7c, 0x81, 0xff, 0xd0, 0xb4, 0x80, 0xe9, 0x18, 0x5c, 0x12, 0x04, 0x75, 0x81,
+	0x47, 0xef, 0x43, 0xda, 0x25, 0x3e, 0xb1, 0x58, 0xe0, 0xc4, 0xe7, 0xfd, 0xda, 0xd5, 0x33, 0x73,
+	0x9b, 0x49, 0x1f, 0x8b, 0x42, 0x7d, 0x58, 0x3c, 0x34, 0x07, 0xe1, 0xab, 0x24, 0xe6, 0x09, 0x7f,
+	0x55, 0x08, 0x2f, 0x72, 0xe1, 0x93, 0xec, 0xd3, 0x3f, 0x95, 0x63, 0xe8, 0xd1, 0x35, 0xb6, 0x21,
+	0xe7, 0x68, 0xfe, 0x03, 0x95, 0x92, 0x98, 0x34, 0xe8, 0x92, 0xac, 0xe0, 0x2a, 0x4f, 0x4e, 0x8a,
+	0xab, 0x5c, 0xdc, 0x04, 0x3a, 0xec, 0xc8, 0x2c, 0xc5, 0x74, 0x04, 0xa2, 0xf0, 0x26, 0xc4, 0x68,
+	0xd5, 0xa0, 0xc1, 0x66, 0x1f, 0x1e, 0x7a, 0xc4, 0x9f, 0x13, 0x6c, 0x9c, 0x48, 0x29, 0x43, 0x8c,
+	0x56, 0x27, 0xb4, 0x0c, 0xb9, 0x66, 0xab, 0xa3, 0xb6, 0xf7, 0x6a, 0x9b, 0xf5, 0xad, 0x7a, 0xad,
+	0x2a, 0x2f, 0xa0, 0x2c, 0xa4, 0x5a, 0x2a, 0xae, 0xb6, 0x9a, 0x8d, 0x7b, 0xb2, 0xc4, 0x4f, 0x1f,
+	0x60, 0x76, 0x8a, 0x20, 0x80, 0x04, 0xc5, 0x7d, 0x80, 0xe5, 0x98, 0xf2, 0x1b, 0x09, 0x32, 0x7b,
+	0xae, 0xad, 0x13, 0xcf, 0x63, 0xad, 0xa3, 0x04, 0x91, 0x9e, 0x21, 0x7a, 0x56, 0x7e, 0x1c, 0xb0,
+	0x21, 0x92, 0x52, 0xbd, 0x2a, 0xba, 0x50, 0xa4, 0x67, 0xa0, 0x75, 0x48, 0x11, 0xcb, 0x70, 0xec,
+	0x9e, 0xe5, 0xf3, 0x16, 0x5b, 0xc9, 0x7e, 0x73, 0x52, 0x4c, 0xd5, 0x04, 0x0c, 0x8f, 0xb0, 0x85,
+	0xd7, 0x21, 0x52, 0xaf, 0xd2, 0x1e, 0xfd, 0x89, 0x6d, 0x8d, 0x7a, 0x34, 0xfd, 0x46, 0x2b, 0x90,
+	0xf0, 0x06, 0x87, 0x87, 0xbd, 0x63, 0xd1, 0xa4, 0xc5, 0xe9, 0x4e, 0xec, 0xb3, 0x2f, 0x8a, 0x92,
+	0xf2, 0xa9, 0x04, 0x50, 0x61, 0x13, 0x04, 0x53, 0xb0, 0x03, 0x59, 0x87, 0x2b, 0xa3, 0x7a, 0x0e,
+	0xd1, 0x85, 0xaa, 0x17, 0x67, 0xaa, 0x5a, 0x29, 0x84, 0xba, 0xce, 0xa2, 0xb0, 0x63, 0xd0, 0x6b,
+	0x32, 0x4e, 0xe8, 0xda, 0xd7, 0x20, 0xf7, 0x23, 0x5e, 0x54, 0x54, 0xb3, 0xd7, 0xef, 0xf1, 0xbb,
+	0xe4, 0x70, 0x56, 0x00, 0x1b, 0x14, 0xa6, 0xfc, 0x33, 0x12, 0xaa, 0x0b, 0x2f, 0x41, 0x52, 0x20,
+	0x45, 0x9b, 0xcd, 0x4c, 0x54, 0x26, 0x81, 0xa3, 0xf3, 0xc7, 0x01, 0xe9, 0xf6, 0x78, 0x3b, 0x8d,
+	0x62, 0x7e, 0x40, 0x32, 0x44, 0x89, 0x65, 0xb0, 0x76, 0x19, 0xc5, 0xf4, 0x13, 0xbd, 0x02, 0x51,
+	0x6f, 0xd0, 0x17, 0x99, 0xb7, 0x3c, 0xbe, 0x4d, 0x7b, 0xa7, 0x7c, 0xab, 0x3d, 0xe8, 0x0b, 0x8b,
+	0x53, 0x1a, 0xb4, 0x3d, 0xab, 0xc4, 0xc4, 0xe7, 0x95, 0x98, 0x19, 0xa5, 0xe3, 0xff, 0x21, 0x77,
+	0xa0, 0xe9, 0x47, 0x3d, 0xab, 0xab, 0xb2, 0x62, 0xc0, 0x92, 0x25, 0x5d, 0x59, 0x3e, 0x5d, 0x2c,
+	0xb2, 0x82, 0x8e, 0x9d, 0xd0, 0x65, 0x48, 0xf5, 0x6d, 0x83, 0x35, 0x1c, 0x16, 0xf1, 0x51, 0x9c,
+	0xec, 0xdb, 0x06, 0x6d, 0x2e, 0xe8, 0x05, 0xc8, 0xea, 0xb6, 0x45, 0xd3, 0x51, 0xa5, 0x43, 0x1b,
+	0xeb, 0x68, 0x69, 0x9c, 0x11, 0xb0, 0xce, 0xd0, 0x61, 0x24, 0x2c, 0x2b, 0x1c, 0xdb, 0xf3, 0xa9,
+	0xcf, 0xd3, 0x9c, 0x84, 0xc2, 0xf6, 0x38, 0x48, 0xb9, 0x0b, 0x49, 0x71, 0x6f, 0x6a, 0x3f, 0x47,
+	0x73, 0xfd, 0x5b, 0xcc, 0xc8, 0x09, 0xcc, 0x0f, 0x01, 0x74, 0x23, 0x1f, 0x19, 0x43, 0x37, 0x02,
+	0xe8, 0x6d, 0x66, 0xd7, 0x24, 0x87, 0xde, 0x56, 0x7e, 0x17, 0x85, 0x0c, 0x26, 0x9a, 0x81, 0xc9,
+	0x47, 0x03, 0xe2, 0xf9, 0x68, 0x1d, 0x12, 0x0f, 0x88, 0x66, 0x10, 0x57, 0x84, 0x8e, 0x3c, 0xb6,
+	0xd9, 0x0e, 0x83, 0x63, 0x81, 0x0f, 0xbb, 0x38, 0xf2, 0x14, 0x17, 0xaf, 0x8c, 0x92, 0x96, 0xfb,
+	0x53, 0x9c, 0x98, 0xeb, 0x4d, 0x5b, 0x3f, 0x62, 0x4e, 0x4d, 0x61, 0x7e, 0x40, 0x6b, 0x90, 0x35,
+	0x6c, 0xd5, 0xb2, 0x7d, 0xd5, 0x71, 0xed, 0xe3, 0x21, 0x73, 0x5c, 0x0a, 0x83, 0x61, 0x37, 0x6d,
+	0x7f, 0x8f, 0x42, 0x68, 0x2c, 0xf6, 0x89, 0xaf, 0x19, 0x9a, 0xaf, 0xa9, 0xb6, 0x65, 0x0e, 0x99,
+	0x5b, 0x52, 0x38, 0x1b, 0x00, 0x5b, 0x96, 0x39, 0x44, 0xdb, 0x90, 0xf5, 0x7a, 0x5d, 0x4b, 0xf3,
+	0x07, 0x2e, 0xe9, 0x74, 0x1a, 0xf9, 0xe4, 0xbc, 0x3a, 0x97, 0x7a, 0x74, 0x52, 0x94, 0x58, 0x11,
+	0x9b, 0x60, 0x44, 0x25, 0x78, 0x2e, 0x98, 0x8f, 0x3c, 0xf5, 0xd0, 0xb5, 0xfb, 0x2a, 0xbd, 0x3d,
+	0x73, 0x5c, 0x1c, 0x2f, 0x8f, 0x50, 0x5b, 0xae, 0xdd, 0xa7, 0xe6, 0x41, 0x6f, 0xc0, 0x8a, 0x4b,
+	0x3c, 0xdb, 0x7c, 0x48, 0x54, 0x56, 0xd3, 0x89, 0xe7, 0xab, 0x3d, 0xcb, 0x20, 0xdc, 0x91, 0x29,
+	0x7c, 0x41, 0x60, 0xb7, 0x04, 0xb2, 0x4e, 0x71, 0xe8, 0x06, 0x24, 0x0e, 0x7b, 0xa6, 0x4f, 0x5c,
+	0x36, 0x26, 0x64, 0x36, 0x2e, 0x8c, 0x8d, 0x4e, 0x7d, 0xb3, 0xc5, 0x70, 0x58, 0xd0, 0x28, 0x15,
+	0x80, 0x31, 0x94, 0xda, 0xd7, 0x71, 0x09, 0x0d, 0x15, 0xea, 0xb0, 0x2c, 0x16, 0x27, 0xf4, 0x3c,
+	0xa4, 0x0d, 0xc2, 0xb2, 0x95, 0xb8, 0xcc, 0x41, 0x59, 0x3c, 0x06, 0x28, 0xbf, 0x8f, 0x40, 0x96,
+	0xbb, 0xdd, 0x73, 0x6c, 0xcb, 0x23, 0xd4, 0xef, 0x9e, 0xaf, 0xf9, 0x03, 0x8f, 0x89, 0x59, 0x0c,
+	0xfb, 0xbd, 0xcd, 0xe0, 0x58, 0xe0, 0x43, 0x11, 0x12, 0x99, 0x13, 0x21, 0x67, 0xb9, 0xfe, 0x2a,
+	0x00, 0x9f, 0x7e, 0x98, 0x2d, 0x63, 0x0c, 0x97, 0x66, 0x10, 0x66, 0xc3, 0x52, 0x68, 0xbc, 0x8d,
+	0x4f, 0x8f, 0xcc, 0x41, 0xe6, 0x85, 0xe6, 0xd6, 0x17, 0x20, 0x1b, 0x7c, 0xab, 0x03, 0x97, 0x37,
+	0xb5, 0x34, 0xce, 0x04, 0xb0, 0x7d, 0xd7, 0x44, 0x79, 0x48, 0x8a, 0x24, 0x63, 0xa1, 0x90, 0xc5,
+	0xc1, 0x11, 0xdd, 0x00, 0xc4, 0xfc, 0xa3, 0x06, 0x5d, 0x9a, 0xe5, 0x6d, 0x8a, 0xe9, 0x24, 0x33,
+	0x0c, 0xe6, 0x08, 0x9a, 0xc0, 0xca, 0x5f, 0x23, 0x90, 0x13, 0xa3, 0xd0, 0x79, 0xe5, 0xcb, 0x74,
+	0x06, 0x44, 0x4f, 0x65, 0xc0, 0xd8, 0xac, 0xf1, 0x09, 0xb3, 0x86, 0x2e, 0x19, 0x9b, 0xbc, 0xe4,
+	0xff, 0xc1, 0x52, 0xcf, 0x20, 0x7d, 0xc7, 0xf6, 0x89, 0xa5, 0x0f, 0xd5, 0x23, 0x32, 0x14, 0x46,
+	0x5a, 0x0c, 0x81, 0xef, 0x92, 0xe1, 0xa9, 0x02, 0x95, 0x3c, 0x5d, 0xa0, 0xa6, 0x53, 0x2b, 0xf5,
+	0x1d, 0x53, 0x4b, 0xf9, 0x93, 0x04, 0x8b, 0x81, 0x2d, 0xbf, 0x75, 0x10, 0x96, 0xe6, 0x05, 0xa1,
+	0x68, 0x09, 0x81, 0xf1, 0xaf, 0x43, 0x42, 0xb7, 0xfb, 0xb4, 0x75, 0x45, 0xcf, 0x8c, 0x28, 0x41,
+	0x71, 0x2a, 0x9e, 0x62, 0xa7, 0xe2, 0x49, 0xf9, 0xb7, 0x04, 0x72, 0x30, 0x9d, 0x93, 0x73, 0x0b,
+	0x85, 0x12, 0xd0, 0xc7, 0xbf, 0x63, 0x7b, 0x9a, 0xf9, 0x14, 0xb5, 0x47, 0x34, 0x4f, 0x09, 0x80,
+	0x6b, 0x90, 0x0b, 0xfc, 0x6a, 0x10, 0xd3, 0xd7, 0x44, 0xe4, 0x04, 0xce, 0xae, 0x52, 0x18, 0x5a,
+	0x83, 0x8c, 0xa6, 0x1f, 0x59, 0xf6, 0xc7, 0x26, 0x31, 0xba, 0x44, 0xd4, 0xd5, 0x30, 0x48, 0xf9,
+	0xb5, 0x04, 0xcb, 0xa1, 0x6b, 0x9f, 0x63, 0xe9, 0x08, 0xd7, 0x80, 0xe8, 0xfc, 0x1a, 0xa0, 0xfc,
+	0x5c, 0x82, 0x4c, 0xa3, 0xe7, 0xf9, 0x81, 0x2f, 0xbe, 0x07, 0x29, 0x4f, 0x6c, 0x1b, 0x84, 0x37,
+	0x2e, 0x9d, 0x7a, 0x76, 0x73, 0xb4, 0x08, 0x94, 0x11, 0x39, 0xad, 0x4e, 0x8e, 0xd6, 0x25, 0x13,
+	0x93, 0x4e, 0x9a, 0x42, 0xd8, 0x98, 0x33, 0x42, 0xfb, 0xf6, 0x11, 0xb1, 0x98, 0x6e, 0x69, 0x8e,
+	0xee, 0x50, 0x80, 0xf2, 0x55, 0x04, 0xb2, 0x5c, 0x91, 0x73, 0x8f, 0xe9, 0x1f, 0x40, 0x4a, 0x44,
+	0x0a, 0x7f, 0xdd, 0x4c, 0xac, 0x01, 0xc2, 0x3a, 0x04, 0xef, 0xef, 0xe0, 0xaa, 0x01, 0x17, 0x7a,
+	0x19, 0x96, 0x2c, 0x72, 0xec, 0xab, 0xa1, 0x0b, 0xf1, 0x60, 0xcf, 0x51, 0xf0, 0x5e, 0x70, 0xa9,
+	0xc2, 0x2f, 0x24, 0x08, 0xa2, 0x13, 0xdd, 0x84, 0xd8, 0xec, 0xc9, 0x32, 0xf4, 0xc8, 0x17, 0x3f,
+	0xc4, 0x08, 0x69, 0x3a, 0xd1, 0x79, 0xc8, 0x25, 0x0f, 0x7b, 0x5e, 0xb0, 0x39, 0x89, 0xe2, 0x4c,
+	0xdf, 0x36, 0xb0, 0x00, 0xa1, 0x57, 0x21, 0xee, 0xda, 0x03, 0x9f, 0x08, 0x57, 0x87, 0x76, 0x4c,
+	0x98, 0x82, 0x85, 0x38, 0x4e, 0xa3, 0xfc, 0x47, 0x82, 0x6c, 0xd9, 0x71, 0xcc, 0x61, 0xe0, 0xeb,
+	0x77, 0x20, 0xa9, 0x3f, 0xd0, 0xac, 0x2e, 0x09, 0x76, 0x54, 0x57, 0xc7, 0xfc, 0x61, 0xc2, 0xd2,
+	0x26, 0xa3, 0x0a, 0x96, 0x44, 0x82, 0x07, 0x5d, 0x82, 0xa4, 0xe1, 0x0e, 0x55, 0x77, 0xc0, 0x55,
+	0x4b, 0xe1, 0x84, 0xe1, 0x0e, 0xf1, 0xc0, 0x2a, 0xfc, 0x52, 0x82, 0x04, 0x67, 0xa1, 0x63, 0x00,
+	0x39, 0x76, 0x88, 0xee, 0xab, 0x13, 0x57, 0x61, 0xcf, 0x10, 0xbc, 0xcc, 0x51, 0xbb, 0xa1, 0x0b,
+	0xbd, 0x06, 0x89, 0x81, 0xe3, 0x11, 0xd7, 0xcf, 0x47, 0x9e, 0x62, 0x26, 0x2c, 0x88, 0xd0, 0x35,
+	0x48, 0x18, 0xc4, 0x24, 0xc2, 0x00, 0x53, 0xe5, 0x40, 0xa0, 0x94, 0xcf, 0x78, 0xef, 0x31, 0x87,
+	0x81, 0x5b, 0xcf, 0x31, 0xb4, 0x6e, 0xc3, 0x92, 0xb0, 0x89, 0xaa, 0xbb, 0x44, 0xf3, 0x89, 0x21,
+	0xde, 0xcf, 0x13, 0x9a, 0xe5, 0xb8, 0xa1, 0x36, 0x39, 0x45, 0x98, 0x69, 0xe0, 0x18, 0x8c, 0x29,
+	0x76, 0x26, 0xd3, 0xbe, 0x63, 0x4c, 0x33, 0xf1, 0x7b, 0x1a, 0xf9, 0xf8, 0x99, 0x4c, 0x55, 0x4e,
+	0xa1, 0xfc, 0x3d, 0x02, 0x72, 0x50, 0x04, 0xbc, 0x73, 0x2b, 0xbf, 0x2f, 0xc2, 0x22, 0x7b, 0x8f,
+	0xa8, 0xa3, 0x71, 0x9e, 0x8f, 0x31, 0x59, 0x06, 0xdd, 0x15, 0x33, 0xfd, 0x1a, 0x64, 0xe9, 0xd2,
+	0x64, 0x44, 0xc3, 0xc7, 0x19, 0x20, 0x96, 0x11, 0x50, 0xcc, 0xc8, 0x32, 0x5e, 0x7e, 0x27, 0xb3,
+	0x6c, 0xaa, 0xf0, 0x24, 0xd8, 0x88, 0x19, 0x2a, 0x3c, 0xff, 0xb3, 0x99, 0x76, 0x7a, 0xc2, 0x48,
+	0x4d, 0x4f, 0x18, 0xca, 0x9f, 0x23, 0xb0, 0x1c, 0xb2, 0xef, 0xb9, 0x87, 0x5b, 0x1d, 0xd2, 0xa3,
+	0x51, 0x5a, 0x94, 0xb2, 0x97, 0x4e, 0x97, 0xfb, 0x91, 0x26, 0x25, 0x35, 0x00, 0x09, 0x39, 0x63,
+	0xee, 0xb3, 0x4a, 0xda, 0xb4, 0xb1, 0x0b, 0x1f, 0x42, 0x7a, 0x24, 0x05, 0xdd, 0x98, 0xa8, 0x69,
+	0x33, 0x3a, 0xcd, 0x44, 0x41, 0xbb, 0x0a, 0x40, 0xed, 0x49, 0x0c, 0x36, 0x1d, 0xf0, 0x47, 0x79,
+	0x9a, 0x43, 0xe8, 0x6c, 0xf0, 0x33, 0x09, 0x32, 0x3b, 0xe7, 0xf9, 0xa2, 0x9a, 0x3b, 0x21, 0x2a,
+	0x7f, 0x90, 0x20, 0xbb, 0xf3, 0xdd, 0xa6, 0xfb, 0x6f, 0xeb, 0xba, 0xc9, 0x59, 0x3e, 0xfa, 0xb4,
+	0x59, 0x3e, 0xf6, 0x0c, 0x7d, 0xfc, 0x53, 0x09, 0xe2, 0xac, 0xe6, 0xa3, 0xb7, 0x21, 0xd9, 0x27,
+	0xfd, 0x03, 0xe2, 0x06, 0x55, 0x7d, 0xde, 0xbe, 0x25, 0x20, 0xa7, 0x63, 0x90, 0xe3, 0xf6, 0xfa,
+	0x9a, 0x3b, 0xe4, 0x5b, 0x7a, 0x1c, 0x1c, 0xd1, 0x75, 0x48, 0x07, 0x0b, 0x97, 0x60, 0x21, 0x38,
+	0xb9, 0x8f, 0x19, 0xa3, 0x95, 0xdf, 0x46, 0x20, 0xc1, 0x6f, 0x8c, 0xde, 0x01, 0x08, 0x96, 0x2a,
+	0xcf, 0xbc, 0xfd, 0x49, 0x0b, 0x8e, 0xba, 0x31, 0xee, 0x6e, 0x91, 0xf9, 0xdd, 0x8d, 0xb6, 0x57,
+	0xe2, 0xeb, 0x46, 0x3e, 0x3a, 0xdd, 0x37, 0xb8, 0x2e, 0xa5, 0x9a, 0xaf, 0x1b, 0x41, 0x34, 0x52,
+	0xc2, 0xc2, 0x8f, 0x21, 0x46, 0x61, 0xd4, 0x11, 0xba, 0x39, 0xf0, 0x7c, 0xe2, 0x06, 0x4a, 0xc6,
+	0x70, 0x5a, 0x40, 0xea, 0x06, 0xba, 0x02, 0x69, 0x6e, 0x1f, 0x8a, 0x8d, 0x30, 0x6c, 0x8a, 0x03,
+	0xea, 0x06, 0x2a, 0x40, 0x6a, 0xd4, 0xd3, 0xb8, 0x0b, 0x47, 0x67, 0xca, 0xe8, 0x6a, 0x87, 0xbe,
+	0xea, 0x13, 0x97, 0x2f, 0x60, 0x62, 0x38, 0x45, 0x01, 0x1d, 0xe2, 0xf6, 0xaf, 0x7f, 0x15, 0x81,
+	0x04, 0x0f, 0x20, 0x94, 0x80, 0x48, 0xeb, 0xae, 0xbc, 0x80, 0x2e, 0xc2, 0xf2, 0x7b, 0xad, 0x7d,
+	0xdc, 0x2c, 0x37, 0x54, 0xba, 0x75, 0xdb, 0x6a, 0xed, 0x37, 0xab, 0xb2, 0x84, 0xae, 0xc2, 0xe5,
+	0x66, 0x4b, 0x0d, 0x30, 0x7b, 0xb8, 0xbe, 0x5b, 0xc6, 0xf7, 0xd4, 0x0a, 0x6e, 0xdd, 0xad, 0x61,
+	0x39, 0x82, 0x56, 0xa1, 0x40, 0xa9, 0xcf, 0xc0, 0x47, 0xd1, 0x0a, 0xa0, 0x30, 0x5e, 0xc0, 0xe3,
+	0x68, 0x0d, 0x9e, 0xaf, 0x37, 0xdb, 0xfb, 0x5b, 0x5b, 0xf5, 0xcd, 0x7a, 0xad, 0x39, 0x4d, 0xd0,
+	0x96, 0x63, 0xe8, 0x79, 0xc8, 0xb7, 0xb6, 0xb6, 0xda, 0xb5, 0x0e, 0x53, 0xe7, 0x5e, 0xad, 0xa3,
+	0x96, 0xdf, 0x2f, 0xd7, 0x1b, 0xe5, 0x4a, 0xa3, 0x26, 0x27, 0xd0, 0x12, 0x64, 0xe8, 0xe2, 0x6f,
+	0x5b, 0xc5, 0xad, 0xfd, 0x4e, 0x4d, 0x4e, 0x52, 0xf5, 0xb7, 0x70, 0x79, 0x7b, 0x97, 0x0a, 0xdb,
+	0xad, 0xb7, 0x77, 0xcb, 0x9d, 0xcd, 0x1d, 0x39, 0x85, 0xae, 0xc0, 0xa5, 0x5a, 0x67, 0xb3, 0xaa,
+	0x76, 0x70, 0xb9, 0xd9, 0x2e, 0x6f, 0x76, 0xea, 0xad, 0xa6, 0xba, 0x55, 0xae, 0x37, 0x6a, 0x55,
+	0x39, 0x4d, 0x85, 0x50, 0xd9, 0xe5, 0x46, 0xa3, 0xf5, 0x41, 0xad, 0x2a, 0x03, 0xba, 0x04, 0xcf,
+	0x71, 0xa9, 0xe5, 0xbd, 0xbd, 0x5a, 0xb3, 0xaa, 0x72, 0x05, 0xe4, 0x0c, 0x55, 0xa6, 0xde, 0xac,
+	0xd6, 0x3e, 0x54, 0x77, 0xca, 0x6d, 0x75, 0x1b, 0xd7, 0xca, 0x9d, 0x1a, 0x0e, 0xb0, 0x59, 0x84,
+	0x60, 0x31, 0xd0, 0xbf, 0x5d, 0x2b, 0x53, 0xd9, 0xb9, 0xeb, 0x1f, 0x83, 0x3c, 0xbd, 0xab, 0x42,
+	0x19, 0x48, 0xd6, 0x9b, 0xef, 0x97, 0x1b, 0x75, 0xba, 0xca, 0x4c, 0x41, 0xac, 0xd9, 0x6a, 0xd6,
+	0x64, 0x89, 0x7e, 0x6d, 0xdf, 0xaf, 0xef, 0xc9, 0x11, 0x94, 0x83, 0xf4, 0xfd, 0x76, 0xa7, 0xdc,
+	0xac, 0x96, 0x71, 0x55, 0x8e, 0xd2, 0x8d, 0x66, 0xbb, 0x59, 0xde, 0xdb, 0xbb, 0x27, 0xc7, 0xa8,
+	0xa1, 0x29, 0x11, 0xfd, 0xd1, 0x46, 0xab, 0x5c, 0x55, 0xab, 0xb5, 0xcd, 0xd6, 0xee, 0x1e, 0xae,
+	0xb5, 0xdb, 0xf5, 0x56, 0x53, 0x8e, 0xa3, 0x24, 0x44, 0x1b, 0xf7, 0xdf, 0x90, 0x13, 0x1b, 0x7f,
+	0x89, 0x8e, 0x67, 0xbe, 0x37, 0x21, 0x46, 0xe7, 0x49, 0x74, 0x71, 0x7a, 0xbe, 0x64, 0x15, 0xae,
+	0xb0, 0x32, 0x7b, 0xec, 0x44, 0x6f, 0x43, 0x9c, 0x0d, 0x2c, 0x68, 0x65, 0xf6, 0x40, 0x56, 0xb8,
+	0x74, 0x0a, 0x2e, 0x38, 0xdf, 0x82, 0x18, 0xdd, 0x4e, 0x84, 0x7f, 0x30, 0xb4, 0xa4, 0x2a, 0xac,
+	0x4c, 0x83, 0x39, 0xdb, 0xeb, 0x12, 0x7a, 0x07, 0x12, 0xfc, 0x4d, 0x89, 0x26, 0x65, 0x8f, 0x5f,
+	0xec, 0x85, 0xfc, 0x69, 0x04, 0x67, 0x5f, 0x97, 0xd0, 0x0e, 0xa4, 0x47, 0xef, 0x1b, 0x54, 0x08,
+	0xff, 0xca, 0xe4, 0x5b, 0xaf, 0x70, 0x65, 0x26, 0x2e, 0x90, 0xf3, 0x3a, 0x95, 0x94, 0xa3, 0xb6,
+	0x18, 0xf5, 0xae, 0xb0, 0xb4, 0xe9, 0xd1, 0xa5, 0x70, 0x65, 0x26, 0x4e, 0xd8, 0xe2, 0x4d, 0x88,
+	0xed, 0x4c, 0xd9, 0x62, 0x67, 0xb6, 0x2d, 0xc2, 0x25, 0xbf, 0x52, 0x7e, 0xf4, 0x8f, 0xd5, 0x85,
+	0x47, 0x5f, 0xaf, 0x4a, 0x5f, 0x7e, 0xbd, 0x2a, 0xfd, 0xea, 0xf1, 0xea, 0xc2, 0x17, 0x8f, 0x57,
+	0xa5, 0x2f, 0x1f, 0xaf, 0x2e, 0xfc, 0xed, 0xf1, 0xea, 0xc2, 0xfd, 0x6b, 0x5d, 0xbb, 0xd4, 0xd5,
+	0x3e, 0x21, 0xbe, 0x4f, 0x4a, 0x06, 0x79, 0x78, 0x53, 0xb7, 0x5d, 0x72, 0x73, 0xea, 0x9f, 0xe8,
+	0x83, 0x04, 0xfb, 0xba, 0xfd, 0xdf, 0x01, 0x00, 0x2c, 0xa8, 0x90, 0xcc, 0xa3, 0x1e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1746,6 +2125,10 @@ type JournalClient interface {
 	Replicate(ctx context.Context, opts ...grpc.CallOption) (Journal_ReplicateClient, error)
 	// List Fragments of a Journal.
 	ListFragments(ctx context.Context, in *FragmentsRequest, opts ...grpc.CallOption) (*FragmentsResponse, error)
+	// Head returns the current write head and most recent Fragment of a
+	// Journal. It's equivalent to a metadata-only Read at the write head, but
+	// is a lightweight unary call which doesn't open a stream.
+	Head(ctx context.Context, in *HeadRequest, opts ...grpc.CallOption) (*HeadResponse, error)
 }
 
 type journalClient struct {
@@ -1880,6 +2263,15 @@ func (c *journalClient) ListFragments(ctx context.Context, in *FragmentsRequest,
 	return out, nil
 }
 
+func (c *journalClient) Head(ctx context.Context, in *HeadRequest, opts ...grpc.CallOption) (*HeadResponse, error) {
+	out := new(HeadResponse)
+	err := c.cc.Invoke(ctx, "/protocol.Journal/Head", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JournalServer is the server API for Journal service.
 type JournalServer interface {
 	// List Journals, their JournalSpecs and current Routes.
@@ -1896,6 +2288,10 @@ type JournalServer interface {
 	Replicate(Journal_ReplicateServer) error
 	// List Fragments of a Journal.
 	ListFragments(context.Context, *FragmentsRequest) (*FragmentsResponse, error)
+	// Head returns the current write head and most recent Fragment of a
+	// Journal. It's equivalent to a metadata-only Read at the write head, but
+	// is a lightweight unary call which doesn't open a stream.
+	Head(context.Context, *HeadRequest) (*HeadResponse, error)
 }
 
 func RegisterJournalServer(s *grpc.Server, srv JournalServer) {
@@ -2029,6 +2425,24 @@ func _Journal_ListFragments_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Journal_Head_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JournalServer).Head(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protocol.Journal/Head",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JournalServer).Head(ctx, req.(*HeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Journal_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protocol.Journal",
 	HandlerType: (*JournalServer)(nil),
@@ -2045,6 +2459,10 @@ var _Journal_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListFragments",
 			Handler:    _Journal_ListFragments_Handler,
 		},
+		{
+			MethodName: "Head",
+			Handler:    _Journal_Head_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2208,6 +2626,54 @@ func (m *JournalSpec) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Flags))
 	}
+	if m.Seal != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Seal.ProtoSize()))
+		n5, err := m.Seal.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.AppendChunkTimeout)))
+	n6, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.AppendChunkTimeout, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	if m.AckQuorum != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.AckQuorum))
+	}
+	if m.WriteReplication != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.WriteReplication))
+	}
+	if len(m.Aliases) > 0 {
+		for _, s := range m.Aliases {
+			dAtA[i] = 0x5a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MaxAppendRate != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MaxAppendRate))
+	}
 	return i, nil
 }
 
@@ -2254,27 +2720,56 @@ func (m *JournalSpec_Fragment) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.RefreshInterval)))
-	n5, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RefreshInterval, dAtA[i:])
+	n7, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RefreshInterval, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n7
 	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.Retention)))
-	n6, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Retention, dAtA[i:])
+	n8, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Retention, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n8
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)))
-	n7, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.FlushInterval, dAtA[i:])
+	n9, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.FlushInterval, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n9
+	if len(m.PathTemplate) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.PathTemplate)))
+		i += copy(dAtA[i:], m.PathTemplate)
+	}
+	return i, nil
+}
+
+func (m *JournalSpec_Seal) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JournalSpec_Seal) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Offset))
+	}
 	return i, nil
 }
 
@@ -2296,11 +2791,11 @@ func (m *ProcessSpec) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Id.ProtoSize()))
-	n8, err := m.Id.MarshalTo(dAtA[i:])
+	n10, err := m.Id.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n10
 	if len(m.Endpoint) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -2358,11 +2853,11 @@ func (m *BrokerSpec) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.ProcessSpec.ProtoSize()))
-	n9, err := m.ProcessSpec.MarshalTo(dAtA[i:])
+	n11, err := m.ProcessSpec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n11
 	if m.JournalLimit != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -2405,11 +2900,11 @@ func (m *Fragment) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Sum.ProtoSize()))
-	n10, err := m.Sum.MarshalTo(dAtA[i:])
+	n12, err := m.Sum.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
+	i += n12
 	if m.CompressionCodec != 0 {
 		dAtA[i] = 0x28
 		i++
@@ -2426,6 +2921,18 @@ func (m *Fragment) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.ModTime))
 	}
+	if len(m.ContentType) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.ContentType)))
+		i += copy(dAtA[i:], m.ContentType)
+	}
+	if len(m.PathPostfix) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.PathPostfix)))
+		i += copy(dAtA[i:], m.PathPostfix)
+	}
 	return i, nil
 }
 
@@ -2484,11 +2991,11 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n11, err := m.Header.MarshalTo(dAtA[i:])
+		n13, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n13
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -2531,6 +3038,71 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.SignatureTTL != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SignatureTTL)))
+		n14, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.SignatureTTL, dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.FragmentsFromHead != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.FragmentsFromHead))
+	}
+	if m.ResolveFreshestIndex {
+		dAtA[i] = 0x48
+		i++
+		if m.ResolveFreshestIndex {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Filter != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Filter.ProtoSize()))
+		n15, err := m.Filter.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	return i, nil
+}
+
+func (m *ReadFilter) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadFilter) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if len(m.Delimiter) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Delimiter)))
+		i += copy(dAtA[i:], m.Delimiter)
+	}
 	return i, nil
 }
 
@@ -2558,11 +3130,11 @@ func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n12, err := m.Header.MarshalTo(dAtA[i:])
+		n16, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n16
 	}
 	if m.Offset != 0 {
 		dAtA[i] = 0x18
@@ -2578,11 +3150,11 @@ func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Fragment.ProtoSize()))
-		n13, err := m.Fragment.MarshalTo(dAtA[i:])
+		n17, err := m.Fragment.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n17
 	}
 	if len(m.FragmentUrl) > 0 {
 		dAtA[i] = 0x32
@@ -2596,6 +3168,11 @@ func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Content)))
 		i += copy(dAtA[i:], m.Content)
 	}
+	if m.IndexRefreshTime != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.IndexRefreshTime))
+	}
 	return i, nil
 }
 
@@ -2618,11 +3195,11 @@ func (m *AppendRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n14, err := m.Header.MarshalTo(dAtA[i:])
+		n18, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n14
+		i += n18
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -2651,6 +3228,28 @@ func (m *AppendRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Offset))
 	}
+	if len(m.IdempotencyKey) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.IdempotencyKey)))
+		i += copy(dAtA[i:], m.IdempotencyKey)
+	}
+	if len(m.ContentType) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.ContentType)))
+		i += copy(dAtA[i:], m.ContentType)
+	}
+	if m.SignatureTTL != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SignatureTTL)))
+		n19, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.SignatureTTL, dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
 	return i, nil
 }
 
@@ -2677,20 +3276,26 @@ func (m *AppendResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n15, err := m.Header.MarshalTo(dAtA[i:])
+	n20, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n15
+	i += n20
 	if m.Commit != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Commit.ProtoSize()))
-		n16, err := m.Commit.MarshalTo(dAtA[i:])
+		n21, err := m.Commit.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n21
+	}
+	if len(m.FragmentUrl) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.FragmentUrl)))
+		i += copy(dAtA[i:], m.FragmentUrl)
 	}
 	return i, nil
 }
@@ -2714,11 +3319,11 @@ func (m *ReplicateRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n17, err := m.Header.MarshalTo(dAtA[i:])
+		n22, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n22
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -2730,11 +3335,11 @@ func (m *ReplicateRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Proposal.ProtoSize()))
-		n18, err := m.Proposal.MarshalTo(dAtA[i:])
+		n23, err := m.Proposal.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n23
 	}
 	if len(m.Content) > 0 {
 		dAtA[i] = 0x22
@@ -2784,21 +3389,21 @@ func (m *ReplicateResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n19, err := m.Header.MarshalTo(dAtA[i:])
+		n24, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n24
 	}
 	if m.Fragment != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Fragment.ProtoSize()))
-		n20, err := m.Fragment.MarshalTo(dAtA[i:])
+		n25, err := m.Fragment.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n25
 	}
 	return i, nil
 }
@@ -2821,11 +3426,11 @@ func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Selector.ProtoSize()))
-	n21, err := m.Selector.MarshalTo(dAtA[i:])
+	n26, err := m.Selector.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
+	i += n26
 	if m.PageLimit != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -2863,11 +3468,11 @@ func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n22, err := m.Header.MarshalTo(dAtA[i:])
+	n27, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
+	i += n27
 	if len(m.Journals) > 0 {
 		for _, msg := range m.Journals {
 			dAtA[i] = 0x1a
@@ -2907,11 +3512,11 @@ func (m *ListResponse_Journal) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Spec.ProtoSize()))
-	n23, err := m.Spec.MarshalTo(dAtA[i:])
+	n28, err := m.Spec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
+	i += n28
 	if m.ModRevision != 0 {
 		dAtA[i] = 0x10
 		i++
@@ -2920,11 +3525,11 @@ func (m *ListResponse_Journal) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Route.ProtoSize()))
-	n24, err := m.Route.MarshalTo(dAtA[i:])
+	n29, err := m.Route.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
+	i += n29
 	return i, nil
 }
 
@@ -2955,6 +3560,16 @@ func (m *ApplyRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.DryRun {
+		dAtA[i] = 0x10
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -2982,11 +3597,11 @@ func (m *ApplyRequest_Change) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Upsert.ProtoSize()))
-		n25, err := m.Upsert.MarshalTo(dAtA[i:])
+		n30, err := m.Upsert.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n30
 	}
 	if len(m.Delete) > 0 {
 		dAtA[i] = 0x1a
@@ -3020,11 +3635,56 @@ func (m *ApplyResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n26, err := m.Header.MarshalTo(dAtA[i:])
+	n31, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n26
+	i += n31
+	if len(m.DryRunCreated) > 0 {
+		for _, s := range m.DryRunCreated {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.DryRunUpdated) > 0 {
+		for _, s := range m.DryRunUpdated {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.DryRunDeleted) > 0 {
+		for _, s := range m.DryRunDeleted {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
 	return i, nil
 }
 
@@ -3047,11 +3707,11 @@ func (m *FragmentsRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-		n27, err := m.Header.MarshalTo(dAtA[i:])
+		n32, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n27
+		i += n32
 	}
 	if len(m.Journal) > 0 {
 		dAtA[i] = 0x12
@@ -3083,11 +3743,11 @@ func (m *FragmentsRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x3a
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SignatureTTL)))
-		n28, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.SignatureTTL, dAtA[i:])
+		n33, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.SignatureTTL, dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n28
+		i += n33
 	}
 	if m.DoNotProxy {
 		dAtA[i] = 0x40
@@ -3125,11 +3785,11 @@ func (m *FragmentsResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n29, err := m.Header.MarshalTo(dAtA[i:])
+	n34, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n29
+	i += n34
 	if len(m.Fragments) > 0 {
 		for _, msg := range m.Fragments {
 			dAtA[i] = 0x1a
@@ -3168,11 +3828,11 @@ func (m *FragmentsResponse__Fragment) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Spec.ProtoSize()))
-	n30, err := m.Spec.MarshalTo(dAtA[i:])
+	n35, err := m.Spec.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n30
+	i += n35
 	if len(m.SignedUrl) > 0 {
 		dAtA[i] = 0x12
 		i++
@@ -3182,6 +3842,96 @@ func (m *FragmentsResponse__Fragment) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *HeadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeadRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
+		n36, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n36
+	}
+	if len(m.Journal) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Journal)))
+		i += copy(dAtA[i:], m.Journal)
+	}
+	if m.DoNotProxy {
+		dAtA[i] = 0x18
+		i++
+		if m.DoNotProxy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *HeadResponse) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeadResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Status != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
+	n37, err := m.Header.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n37
+	if m.WriteHead != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.WriteHead))
+	}
+	if m.Fragment != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Fragment.ProtoSize()))
+		n38, err := m.Fragment.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n38
+	}
+	return i, nil
+}
+
 func (m *Route) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -3250,27 +4000,27 @@ func (m *Header) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.ProcessId.ProtoSize()))
-	n31, err := m.ProcessId.MarshalTo(dAtA[i:])
+	n39, err := m.ProcessId.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n31
+	i += n39
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Route.ProtoSize()))
-	n32, err := m.Route.MarshalTo(dAtA[i:])
+	n40, err := m.Route.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n32
+	i += n40
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Etcd.ProtoSize()))
-	n33, err := m.Etcd.MarshalTo(dAtA[i:])
+	n41, err := m.Etcd.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n33
+	i += n41
 	return i, nil
 }
 
@@ -3386,6 +4136,27 @@ func (m *JournalSpec) ProtoSize() (n int) {
 	if m.Flags != 0 {
 		n += 1 + sovProtocol(uint64(m.Flags))
 	}
+	if m.Seal != nil {
+		l = m.Seal.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.AppendChunkTimeout)
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.AckQuorum != 0 {
+		n += 1 + sovProtocol(uint64(m.AckQuorum))
+	}
+	if m.WriteReplication != 0 {
+		n += 1 + sovProtocol(uint64(m.WriteReplication))
+	}
+	if len(m.Aliases) > 0 {
+		for _, s := range m.Aliases {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if m.MaxAppendRate != 0 {
+		n += 1 + sovProtocol(uint64(m.MaxAppendRate))
+	}
 	return n
 }
 
@@ -3413,6 +4184,22 @@ func (m *JournalSpec_Fragment) ProtoSize() (n int) {
 	n += 1 + l + sovProtocol(uint64(l))
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.FlushInterval)
 	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.PathTemplate)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *JournalSpec_Seal) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		n += 1 + sovProtocol(uint64(m.Offset))
+	}
 	return n
 }
 
@@ -3490,6 +4277,14 @@ func (m *Fragment) ProtoSize() (n int) {
 	if m.ModTime != 0 {
 		n += 1 + sovProtocol(uint64(m.ModTime))
 	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.PathPostfix)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -3537,6 +4332,37 @@ func (m *ReadRequest) ProtoSize() (n int) {
 	if m.MetadataOnly {
 		n += 2
 	}
+	if m.SignatureTTL != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SignatureTTL)
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.FragmentsFromHead != 0 {
+		n += 1 + sovProtocol(uint64(m.FragmentsFromHead))
+	}
+	if m.ResolveFreshestIndex {
+		n += 2
+	}
+	if m.Filter != nil {
+		l = m.Filter.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *ReadFilter) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.Delimiter)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -3571,6 +4397,9 @@ func (m *ReadResponse) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	if m.IndexRefreshTime != 0 {
+		n += 1 + sovProtocol(uint64(m.IndexRefreshTime))
+	}
 	return n
 }
 
@@ -3598,6 +4427,18 @@ func (m *AppendRequest) ProtoSize() (n int) {
 	if m.Offset != 0 {
 		n += 1 + sovProtocol(uint64(m.Offset))
 	}
+	l = len(m.IdempotencyKey)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.SignatureTTL != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.SignatureTTL)
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -3616,6 +4457,10 @@ func (m *AppendResponse) ProtoSize() (n int) {
 		l = m.Commit.ProtoSize()
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	l = len(m.FragmentUrl)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -3740,6 +4585,9 @@ func (m *ApplyRequest) ProtoSize() (n int) {
 			n += 1 + l + sovProtocol(uint64(l))
 		}
 	}
+	if m.DryRun {
+		n += 2
+	}
 	return n
 }
 
@@ -3774,6 +4622,24 @@ func (m *ApplyResponse) ProtoSize() (n int) {
 	}
 	l = m.Header.ProtoSize()
 	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.DryRunCreated) > 0 {
+		for _, s := range m.DryRunCreated {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if len(m.DryRunUpdated) > 0 {
+		for _, s := range m.DryRunUpdated {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if len(m.DryRunDeleted) > 0 {
+		for _, s := range m.DryRunDeleted {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -3851,20 +4717,61 @@ func (m *FragmentsResponse__Fragment) ProtoSize() (n int) {
 	return n
 }
 
-func (m *Route) ProtoSize() (n int) {
+func (m *HeadRequest) ProtoSize() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.ProtoSize()
-			n += 1 + l + sovProtocol(uint64(l))
-		}
-	}
-	if m.Primary != 0 {
-		n += 1 + sovProtocol(uint64(m.Primary))
+	if m.Header != nil {
+		l = m.Header.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.Journal)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.DoNotProxy {
+		n += 2
+	}
+	return n
+}
+
+func (m *HeadResponse) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	l = m.Header.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.WriteHead != 0 {
+		n += 1 + sovProtocol(uint64(m.WriteHead))
+	}
+	if m.Fragment != nil {
+		l = m.Fragment.ProtoSize()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *Route) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.ProtoSize()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if m.Primary != 0 {
+		n += 1 + sovProtocol(uint64(m.Primary))
 	}
 	if len(m.Endpoints) > 0 {
 		for _, s := range m.Endpoints {
@@ -4412,6 +5319,164 @@ func (m *JournalSpec) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Seal == nil {
+				m.Seal = &JournalSpec_Seal{}
+			}
+			if err := m.Seal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppendChunkTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.AppendChunkTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckQuorum", wireType)
+			}
+			m.AckQuorum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AckQuorum |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteReplication", wireType)
+			}
+			m.WriteReplication = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteReplication |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aliases = append(m.Aliases, Journal(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAppendRate", wireType)
+			}
+			m.MaxAppendRate = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAppendRate |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4634,6 +5699,38 @@ func (m *JournalSpec_Fragment) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PathTemplate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PathTemplate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4658,7 +5755,7 @@ func (m *JournalSpec_Fragment) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProcessSpec) Unmarshal(dAtA []byte) error {
+func (m *JournalSpec_Seal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4681,17 +5778,17 @@ func (m *ProcessSpec) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessSpec: wiretype end group for non-group")
+			return fmt.Errorf("proto: Seal: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessSpec: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Seal: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			var msglen int
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4701,23 +5798,95 @@ func (m *ProcessSpec) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Offset |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessSpec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessSpec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessSpec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 2:
@@ -5200,6 +6369,70 @@ func (m *Fragment) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PathPostfix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PathPostfix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5483,81 +6716,9 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.MetadataOnly = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ReadResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ReadResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			m.Status = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Status |= Status(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureTTL", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5584,18 +6745,18 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &Header{}
+			if m.SignatureTTL == nil {
+				m.SignatureTTL = new(time.Duration)
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.SignatureTTL, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FragmentsFromHead", wireType)
 			}
-			m.Offset = 0
+			m.FragmentsFromHead = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5605,16 +6766,16 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= int64(b&0x7F) << shift
+				m.FragmentsFromHead |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WriteHead", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolveFreshestIndex", wireType)
 			}
-			m.WriteHead = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5624,14 +6785,15 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.WriteHead |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			m.ResolveFreshestIndex = bool(v != 0)
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fragment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5658,60 +6820,363 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Fragment == nil {
-				m.Fragment = &Fragment{}
+			if m.Filter == nil {
+				m.Filter = &ReadFilter{}
 			}
-			if err := m.Fragment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FragmentUrl", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FragmentUrl = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delimiter", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Delimiter = append(m.Delimiter[:0], dAtA[iNdEx:postIndex]...)
+			if m.Delimiter == nil {
+				m.Delimiter = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= Status(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteHead", wireType)
+			}
+			m.WriteHead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteHead |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fragment", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Fragment == nil {
+				m.Fragment = &Fragment{}
+			}
+			if err := m.Fragment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FragmentUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FragmentUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5731,6 +7196,25 @@ func (m *ReadResponse) Unmarshal(dAtA []byte) error {
 				m.Content = []byte{}
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexRefreshTime", wireType)
+			}
+			m.IndexRefreshTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IndexRefreshTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5925,6 +7409,106 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdempotencyKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdempotencyKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureTTL", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignatureTTL == nil {
+				m.SignatureTTL = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.SignatureTTL, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6066,6 +7650,38 @@ func (m *AppendResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FragmentUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FragmentUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6973,6 +8589,26 @@ func (m *ApplyRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7218,6 +8854,102 @@ func (m *ApplyResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRunCreated", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DryRunCreated = append(m.DryRunCreated, Journal(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRunUpdated", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DryRunUpdated = append(m.DryRunUpdated, Journal(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRunDeleted", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DryRunDeleted = append(m.DryRunDeleted, Journal(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7379,9 +9111,156 @@ func (m *FragmentsRequest) Unmarshal(dAtA []byte) error {
 			}
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+			}
+			m.NextPageToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextPageToken |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageLimit", wireType)
+			}
+			m.PageLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PageLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureTTL", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SignatureTTL == nil {
+				m.SignatureTTL = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.SignatureTTL, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoNotProxy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DoNotProxy = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FragmentsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FragmentsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.NextPageToken = 0
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7391,16 +9270,16 @@ func (m *FragmentsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NextPageToken |= int64(b&0x7F) << shift
+				m.Status |= Status(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PageLimit", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			m.PageLimit = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7410,14 +9289,28 @@ func (m *FragmentsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PageLimit |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignatureTTL", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fragments", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7444,18 +9337,16 @@ func (m *FragmentsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SignatureTTL == nil {
-				m.SignatureTTL = new(time.Duration)
-			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.SignatureTTL, dAtA[iNdEx:postIndex]); err != nil {
+			m.Fragments = append(m.Fragments, FragmentsResponse__Fragment{})
+			if err := m.Fragments[len(m.Fragments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DoNotProxy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
 			}
-			var v int
+			m.NextPageToken = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7465,12 +9356,11 @@ func (m *FragmentsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.NextPageToken |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.DoNotProxy = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7495,7 +9385,7 @@ func (m *FragmentsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
+func (m *FragmentsResponse__Fragment) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7518,17 +9408,17 @@ func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FragmentsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: _Fragment: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FragmentsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: _Fragment: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
 			}
-			m.Status = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7538,12 +9428,111 @@ func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= Status(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignedUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
@@ -7572,15 +9561,18 @@ func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
+			if m.Header == nil {
+				m.Header = &Header{}
+			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fragments", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Journal", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7590,31 +9582,29 @@ func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fragments = append(m.Fragments, FragmentsResponse__Fragment{})
-			if err := m.Fragments[len(m.Fragments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Journal = Journal(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DoNotProxy", wireType)
 			}
-			m.NextPageToken = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7624,11 +9614,12 @@ func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NextPageToken |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.DoNotProxy = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7653,7 +9644,7 @@ func (m *FragmentsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FragmentsResponse__Fragment) Unmarshal(dAtA []byte) error {
+func (m *HeadResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7676,15 +9667,34 @@ func (m *FragmentsResponse__Fragment) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: _Fragment: wiretype end group for non-group")
+			return fmt.Errorf("proto: HeadResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: _Fragment: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HeadResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= Status(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Spec", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7711,15 +9721,34 @@ func (m *FragmentsResponse__Fragment) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Spec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteHead", wireType)
+			}
+			m.WriteHead = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteHead |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedUrl", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fragment", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7729,23 +9758,27 @@ func (m *FragmentsResponse__Fragment) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SignedUrl = string(dAtA[iNdEx:postIndex])
+			if m.Fragment == nil {
+				m.Fragment = &Fragment{}
+			}
+			if err := m.Fragment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
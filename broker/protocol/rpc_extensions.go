@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"bytes"
 	"net/url"
 	"strings"
 )
@@ -33,6 +34,22 @@ func (m *ReadRequest) Validate() error {
 		return ExtendContext(err, "Journal")
 	} else if m.Offset < -1 {
 		return NewValidationError("invalid Offset (%d; expected -1 <= Offset <= MaxInt64)", m.Offset)
+	} else if m.SignatureTTL != nil && *m.SignatureTTL <= 0 {
+		return NewValidationError("invalid SignatureTTL (%v; must be > 0s)", *m.SignatureTTL)
+	} else if m.FragmentsFromHead < 0 {
+		return NewValidationError("invalid FragmentsFromHead (%d; expected >= 0)", m.FragmentsFromHead)
+	} else if m.FragmentsFromHead != 0 && m.Offset != -1 {
+		return NewValidationError("unexpected Offset with FragmentsFromHead (%d; expected -1)", m.Offset)
+	}
+
+	if m.Filter != nil {
+		if err := m.Filter.Validate(); err != nil {
+			return ExtendContext(err, "Filter")
+		} else if m.DoNotProxy {
+			return NewValidationError("unexpected DoNotProxy with Filter")
+		} else if m.MetadataOnly {
+			return NewValidationError("unexpected MetadataOnly with Filter")
+		}
 	}
 
 	// Block, DoNotProxy, and MetadataOnly (each type bool) require no extra validation.
@@ -40,6 +57,27 @@ func (m *ReadRequest) Validate() error {
 	return nil
 }
 
+// Validate returns an error if the ReadFilter is not well-formed.
+func (m *ReadFilter) Validate() error {
+	if len(m.Prefix) == 0 {
+		return NewValidationError("expected Prefix")
+	} else if len(m.Delimiter) > 1 {
+		return NewValidationError("invalid Delimiter (%q; expected a single byte)", m.Delimiter)
+	} else if bytes.IndexByte(m.Prefix, m.DelimiterByte()) != -1 {
+		return NewValidationError("invalid Prefix (%q; contains Delimiter)", m.Prefix)
+	}
+	return nil
+}
+
+// DelimiterByte returns the Delimiter of the ReadFilter, or newline if the
+// Delimiter is empty.
+func (m *ReadFilter) DelimiterByte() byte {
+	if len(m.Delimiter) == 0 {
+		return '\n'
+	}
+	return m.Delimiter[0]
+}
+
 // Validate returns an error if the ReadResponse is not well-formed.
 func (m *ReadResponse) Validate() error {
 	if err := m.Status.Validate(); err != nil {
@@ -58,6 +96,8 @@ func (m *ReadResponse) Validate() error {
 			return NewValidationError("unexpected Fragment with Content (%s)", m.Fragment)
 		} else if m.FragmentUrl != "" {
 			return NewValidationError("unexpected FragmentUrl with Content (%s)", m.FragmentUrl)
+		} else if m.IndexRefreshTime != 0 {
+			return NewValidationError("unexpected IndexRefreshTime with Content (%d)", m.IndexRefreshTime)
 		}
 		return nil
 	}
@@ -69,6 +109,8 @@ func (m *ReadResponse) Validate() error {
 	}
 	if m.WriteHead < 0 {
 		return NewValidationError("invalid WriteHead (%d; expected >= 0)", m.WriteHead)
+	} else if m.IndexRefreshTime < 0 {
+		return NewValidationError("invalid IndexRefreshTime (%d; expected >= 0)", m.IndexRefreshTime)
 	}
 
 	if m.Fragment != nil {
@@ -110,6 +152,13 @@ func (m *AppendRequest) Validate() error {
 			return NewValidationError("invalid Offset (%d; expected >= 0)", m.Offset)
 		} else if len(m.Content) != 0 {
 			return NewValidationError("unexpected Content")
+		} else if l := len(m.IdempotencyKey); l > maxIdempotencyKeyLen {
+			return NewValidationError("invalid IdempotencyKey length (%d; expected <= %d)",
+				l, maxIdempotencyKeyLen)
+		} else if err := validateContentType(m.ContentType); err != nil {
+			return ExtendContext(err, "ContentType")
+		} else if m.SignatureTTL != nil && *m.SignatureTTL <= 0 {
+			return NewValidationError("invalid SignatureTTL (%v; must be > 0s)", *m.SignatureTTL)
 		}
 	} else if m.Header != nil {
 		return NewValidationError("unexpected Header")
@@ -117,6 +166,12 @@ func (m *AppendRequest) Validate() error {
 		return NewValidationError("unexpected DoNotProxy")
 	} else if m.Offset != 0 {
 		return NewValidationError("unexpected Offset")
+	} else if m.IdempotencyKey != "" {
+		return NewValidationError("unexpected IdempotencyKey")
+	} else if m.ContentType != "" {
+		return NewValidationError("unexpected ContentType")
+	} else if m.SignatureTTL != nil {
+		return NewValidationError("unexpected SignatureTTL")
 	}
 	return nil
 }
@@ -307,6 +362,21 @@ func (m *ApplyResponse) Validate() error {
 	} else if err = m.Header.Validate(); err != nil {
 		return ExtendContext(err, "Header")
 	}
+	for i, j := range m.DryRunCreated {
+		if err := j.Validate(); err != nil {
+			return ExtendContext(err, "DryRunCreated[%d]", i)
+		}
+	}
+	for i, j := range m.DryRunUpdated {
+		if err := j.Validate(); err != nil {
+			return ExtendContext(err, "DryRunUpdated[%d]", i)
+		}
+	}
+	for i, j := range m.DryRunDeleted {
+		if err := j.Validate(); err != nil {
+			return ExtendContext(err, "DryRunDeleted[%d]", i)
+		}
+	}
 	return nil
 }
 
@@ -356,9 +426,45 @@ func (m *FragmentsResponse__Fragment) Validate() error {
 	return nil
 }
 
+// Validate returns an error if the HeadRequest is not well-formed.
+func (m *HeadRequest) Validate() error {
+	if m.Header != nil {
+		if err := m.Header.Validate(); err != nil {
+			return ExtendContext(err, "Header")
+		}
+	}
+	if err := m.Journal.Validate(); err != nil {
+		return ExtendContext(err, "Journal")
+	}
+	return nil
+}
+
+// Validate returns an error if the HeadResponse is not well-formed.
+func (m *HeadResponse) Validate() error {
+	if err := m.Status.Validate(); err != nil {
+		return ExtendContext(err, "Status")
+	} else if err = m.Header.Validate(); err != nil {
+		return ExtendContext(err, "Header")
+	} else if m.WriteHead < 0 {
+		return NewValidationError("invalid WriteHead (%d; expected >= 0)", m.WriteHead)
+	}
+
+	if m.Fragment != nil {
+		if err := m.Fragment.Validate(); err != nil {
+			return ExtendContext(err, "Fragment")
+		} else if m.Fragment.End != m.WriteHead {
+			return NewValidationError("invalid WriteHead (%d; expected Fragment.End %d)",
+				m.WriteHead, m.Fragment.End)
+		}
+	}
+	return nil
+}
+
 func (x Status) Validate() error {
 	if _, ok := Status_name[int32(x)]; !ok {
 		return NewValidationError("invalid status (%s)", x)
 	}
 	return nil
 }
+
+const maxIdempotencyKeyLen = 128
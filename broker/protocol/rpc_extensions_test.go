@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"strings"
 	"time"
 
 	gc "github.com/go-check/check"
@@ -23,6 +24,29 @@ func (s *RPCSuite) TestReadRequestValidation(c *gc.C) {
 	req.Journal = "good"
 	c.Check(req.Validate(), gc.ErrorMatches, `invalid Offset \(-2; expected -1 <= Offset <= MaxInt64\)`)
 	req.Offset = -1
+	var ttl time.Duration
+	req.SignatureTTL = &ttl
+	c.Check(req.Validate(), gc.ErrorMatches, `invalid SignatureTTL \(0s; must be > 0s\)`)
+	ttl = time.Hour
+	req.FragmentsFromHead = -1
+	c.Check(req.Validate(), gc.ErrorMatches, `invalid FragmentsFromHead \(-1; expected >= 0\)`)
+	req.FragmentsFromHead, req.Offset = 2, 1234
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Offset with FragmentsFromHead \(1234; expected -1\)`)
+	req.Offset = -1
+
+	req.Filter = &ReadFilter{}
+	c.Check(req.Validate(), gc.ErrorMatches, `Filter: expected Prefix`)
+	req.Filter.Prefix = []byte("pre\n")
+	c.Check(req.Validate(), gc.ErrorMatches, `Filter: invalid Prefix \("pre\\n"; contains Delimiter\)`)
+	req.Filter.Delimiter = []byte("\x00\x01")
+	c.Check(req.Validate(), gc.ErrorMatches, `Filter: invalid Delimiter \("\\x00\\x01"; expected a single byte\)`)
+	req.Filter.Delimiter = []byte{0}
+	c.Check(req.Filter.DelimiterByte(), gc.Equals, byte(0))
+	req.DoNotProxy = true
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected DoNotProxy with Filter`)
+	req.DoNotProxy, req.MetadataOnly = false, true
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected MetadataOnly with Filter`)
+	req.MetadataOnly = false
 
 	c.Check(req.Validate(), gc.IsNil)
 
@@ -66,7 +90,10 @@ func (s *RPCSuite) TestReadResponseValidationCases(c *gc.C) {
 	resp.WriteHead = -1
 
 	c.Check(resp.Validate(), gc.ErrorMatches, `invalid WriteHead \(-1; expected >= 0\)`)
-	resp.WriteHead = 1234
+	resp.WriteHead, resp.IndexRefreshTime = 1234, -1
+
+	c.Check(resp.Validate(), gc.ErrorMatches, `invalid IndexRefreshTime \(-1; expected >= 0\)`)
+	resp.IndexRefreshTime = 1234
 
 	c.Check(resp.Validate(), gc.ErrorMatches, `unexpected Offset without Fragment or Content \(\d+\)`)
 	resp.Offset = 0
@@ -98,6 +125,9 @@ func (s *RPCSuite) TestReadResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.ErrorMatches, `unexpected FragmentUrl with Content \(http://foo\)`)
 	resp.FragmentUrl = ""
 
+	c.Check(resp.Validate(), gc.ErrorMatches, `unexpected IndexRefreshTime with Content \(1234\)`)
+	resp.IndexRefreshTime = 0
+
 	c.Check(resp.Validate(), gc.IsNil)
 }
 
@@ -118,6 +148,16 @@ func (s *RPCSuite) TestAppendRequestValidationCases(c *gc.C) {
 	req.Offset = 100
 	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Content`)
 	req.Content = nil
+	req.IdempotencyKey = strings.Repeat("k", maxIdempotencyKeyLen+1)
+	c.Check(req.Validate(), gc.ErrorMatches, `invalid IdempotencyKey length \(129; expected <= 128\)`)
+	req.IdempotencyKey = "key"
+	req.ContentType = "not a / type"
+	c.Check(req.Validate(), gc.ErrorMatches, `ContentType: mime: .*`)
+	req.ContentType = "application/x-msgpack"
+	var ttl time.Duration
+	req.SignatureTTL = &ttl
+	c.Check(req.Validate(), gc.ErrorMatches, `invalid SignatureTTL \(0s; must be > 0s\)`)
+	ttl = time.Minute
 
 	c.Check(req.Validate(), gc.IsNil)
 
@@ -130,6 +170,12 @@ func (s *RPCSuite) TestAppendRequestValidationCases(c *gc.C) {
 	req.DoNotProxy = false
 	c.Check(req.Validate(), gc.ErrorMatches, `unexpected Offset`)
 	req.Offset = 0
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected IdempotencyKey`)
+	req.IdempotencyKey = ""
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected ContentType`)
+	req.ContentType = ""
+	c.Check(req.Validate(), gc.ErrorMatches, `unexpected SignatureTTL`)
+	req.SignatureTTL = nil
 
 	c.Check(req.Validate(), gc.IsNil)
 
@@ -369,6 +415,11 @@ func (s *RPCSuite) TestApplyResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
 	resp.Header.Etcd.ClusterId = 1234
 
+	resp.DryRunCreated = []Journal{"a/journal"}
+	resp.DryRunDeleted = []Journal{"other/journal", "bad journal"}
+	c.Check(resp.Validate(), gc.ErrorMatches, `DryRunDeleted\[1\]: not a valid token \(bad journal\)`)
+	resp.DryRunDeleted[1] = "another/journal"
+
 	c.Check(resp.Validate(), gc.IsNil)
 }
 
@@ -426,6 +477,49 @@ func (s *RPCSuite) TestFragmentsResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.IsNil)
 }
 
+func (s *RPCSuite) TestHeadRequestValidationCases(c *gc.C) {
+	var req = HeadRequest{
+		Header:  badHeaderFixture(),
+		Journal: "/bad",
+	}
+
+	c.Check(req.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
+	req.Header.Etcd.ClusterId = 12
+	c.Check(req.Validate(), gc.ErrorMatches, `Journal: cannot begin with '/' \(/bad\)`)
+	req.Journal = "good"
+
+	c.Check(req.Validate(), gc.IsNil)
+}
+
+func (s *RPCSuite) TestHeadResponseValidationCases(c *gc.C) {
+	var resp = HeadResponse{
+		Status:    9101,
+		Header:    *badHeaderFixture(),
+		WriteHead: -1,
+		Fragment: &Fragment{
+			Journal:          "in valid",
+			Begin:            100,
+			End:              200,
+			CompressionCodec: CompressionCodec_NONE,
+		},
+	}
+
+	c.Check(resp.Validate(), gc.ErrorMatches, `Status: invalid status \(9101\)`)
+	resp.Status = Status_OK
+	c.Check(resp.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
+	resp.Header.Etcd.ClusterId = 1234
+	c.Check(resp.Validate(), gc.ErrorMatches, `invalid WriteHead \(-1; expected >= 0\)`)
+	resp.WriteHead = 100
+	c.Check(resp.Validate(), gc.ErrorMatches, `Fragment.Journal: not a valid token \(in valid\)`)
+	resp.Fragment.Journal = "valid/name"
+	c.Check(resp.Validate(), gc.ErrorMatches, `invalid WriteHead \(100; expected Fragment.End 200\)`)
+	resp.WriteHead = 200
+	c.Check(resp.Validate(), gc.IsNil)
+
+	resp.Fragment = nil
+	c.Check(resp.Validate(), gc.IsNil)
+}
+
 func badHeaderFixture() *Header {
 	return &Header{
 		ProcessId: ProcessSpec_ID{Zone: "zone", Suffix: "name"},
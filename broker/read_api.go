@@ -5,6 +5,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -49,7 +50,15 @@ func (svc *Service) Read(req *pb.ReadRequest, stream pb.Journal_ReadServer) (err
 		return stream.Send(&pb.ReadResponse{Status: resolved.status, Header: &resolved.Header})
 	} else if !resolved.journalSpec.Flags.MayRead() {
 		return stream.Send(&pb.ReadResponse{Status: pb.Status_NOT_ALLOWED, Header: &resolved.Header})
-	} else if resolved.ProcessId != resolved.localID {
+	}
+
+	// If requested, and we may proxy, resolve to the replica having the freshest
+	// index. A proxied request (having a Header) has already been resolved.
+	if req.ResolveFreshestIndex && !req.DoNotProxy && req.Header == nil {
+		resolved.ProcessId = resolveFreshestIndex(stream.Context(), req.Journal, resolved, svc.jc)
+	}
+
+	if resolved.ProcessId != resolved.localID {
 		req.Header = &resolved.Header // Attach resolved Header to |req|, which we'll forward.
 		return proxyRead(stream, req, svc.jc, svc.stopProxyReadsCh)
 	}
@@ -65,6 +74,81 @@ func (svc *Service) Read(req *pb.ReadRequest, stream pb.Journal_ReadServer) (err
 	return err
 }
 
+// resolveFreshestIndex returns the ProcessSpec_ID of the Route member having
+// the most recently refreshed index of remote Fragments. Peers are queried for
+// their refresh times with a metadata-only Read, which they serve locally.
+// If no member has refreshed its index, the resolved ProcessId is returned.
+func resolveFreshestIndex(ctx context.Context, journal pb.Journal, res *resolution, jc pb.JournalClient) pb.ProcessSpec_ID {
+	var members = res.Route.Members
+	var times = make([]int64, len(members))
+	var wg sync.WaitGroup
+
+	for i := range members {
+		if members[i] == res.localID && res.replica != nil {
+			if t := res.replica.index.LastRemoteRefresh(); !t.IsZero() {
+				times[i] = t.UnixNano()
+			}
+			continue
+		}
+
+		var hdr = res.Header
+		hdr.ProcessId = members[i]
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			times[i] = queryIndexRefreshTime(ctx, journal, &hdr, jc)
+		}(i)
+	}
+	wg.Wait()
+
+	var best = -1
+	for i := range members {
+		if times[i] == 0 {
+			continue // Never refreshed, or the query failed.
+		} else if best == -1 || times[i] > times[best] ||
+			times[i] == times[best] && members[i] == res.localID {
+			best = i
+		}
+	}
+	addTrace(ctx, "resolveFreshestIndex(%s) => %v (times %v)", journal, best, times)
+
+	if best == -1 {
+		return res.ProcessId
+	}
+	return members[best]
+}
+
+// queryIndexRefreshTime of the journal replica of the peer identified by
+// |hdr|.ProcessId. Zero is returned if the query fails.
+func queryIndexRefreshTime(ctx context.Context, journal pb.Journal, hdr *pb.Header, jc pb.JournalClient) int64 {
+	var rpcCtx, cancel = context.WithCancel(pb.WithDispatchRoute(ctx, hdr.Route, hdr.ProcessId))
+	defer cancel()
+
+	var resp pb.ReadResponse
+	var stream, err = jc.Read(rpcCtx, &pb.ReadRequest{
+		Header:               hdr,
+		Journal:              journal,
+		Offset:               -1,
+		DoNotProxy:           true,
+		MetadataOnly:         true,
+		ResolveFreshestIndex: true,
+	})
+	if err == nil {
+		err = stream.RecvMsg(&resp)
+	}
+	if err == nil {
+		err = resp.Validate()
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "journal": journal, "peer": hdr.ProcessId}).
+			Warn("failed to query peer index refresh time")
+		return 0
+	}
+	return resp.IndexRefreshTime
+}
+
 // proxyRead forwards a ReadRequest to a resolved peer broker.
 func proxyRead(stream grpc.ServerStream, req *pb.ReadRequest, jc pb.JournalClient, stopCh <-chan struct{}) error {
 	var ctx = pb.WithDispatchRoute(stream.Context(), req.Header.Route, req.Header.ProcessId)
@@ -122,6 +206,14 @@ type proxyChunk struct {
 func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, index *fragment.Index) error {
 	var buffer = make([]byte, chunkSize)
 	var reader io.ReadCloser
+	var filter *readFilter
+
+	if req.Filter != nil {
+		filter = newReadFilter(*req.Filter)
+	}
+	var emit = func(offset int64, content []byte) error {
+		return stream.SendMsg(&pb.ReadResponse{Offset: offset, Content: content})
+	}
 
 	for i := 0; true; i++ {
 		var resp, file, err = index.Query(stream.Context(), req)
@@ -129,12 +221,25 @@ func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, in
 			return err
 		}
 
+		if filter != nil && (i == 0 || resp.Offset != req.Offset) {
+			filter.reset(resp.Offset) // Read began, or offset jumped.
+		}
+
 		// Send the Header with the first response message (only).
 		if i == 0 {
 			resp.Header = hdr
 		}
-		if err = stream.SendMsg(resp); err != nil {
-			return err
+		// A filtered read positioned within a message doesn't send metadata
+		// of an OK response, as its offset doesn't reflect a message boundary.
+		// A non-OK response ends the read, and is sent with the offset of the
+		// partial message (if any) from which the client should resume.
+		if filter != nil && resp.Status != pb.Status_OK {
+			resp.Offset = filter.begin
+		}
+		if filter == nil || filter.atBoundary() || resp.Status != pb.Status_OK {
+			if err = stream.SendMsg(resp); err != nil {
+				return err
+			}
 		}
 
 		// Return after sending Metadata if the Fragment query failed,
@@ -167,10 +272,12 @@ func serveRead(stream grpc.ServerStream, req *pb.ReadRequest, hdr *pb.Header, in
 				continue
 			}
 
-			if err = stream.SendMsg(&pb.ReadResponse{
-				Offset:  req.Offset,
-				Content: buffer[:n],
-			}); err != nil {
+			if filter != nil {
+				err = filter.filter(req.Offset, buffer[:n], emit)
+			} else {
+				err = emit(req.Offset, buffer[:n])
+			}
+			if err != nil {
 				return err
 			}
 			req.Offset += int64(n)
@@ -1,6 +1,7 @@
 package broker
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -101,6 +102,162 @@ func TestReadStreaming(t *testing.T) {
 	broker.cleanup()
 }
 
+func TestReadFiltering(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	// Make |chunkSize| small so that messages span chunks.
+	defer func(cs int) { chunkSize = cs }(chunkSize)
+	chunkSize = 5
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+
+	var spool = <-broker.replica("a/journal").spoolCh
+	var filter = &pb.ReadFilter{Prefix: []byte("a:")}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var stream, err = broker.client().Read(ctx, &pb.ReadRequest{
+		Journal: "a/journal",
+		Offset:  0,
+		Block:   true,
+		Filter:  filter,
+	})
+	assert.NoError(t, err)
+
+	// Commit content which ends within a filtered message.
+	spool.MustApply(&pb.ReplicateRequest{Content: []byte("a:1\nb:22\na:333\nb")})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: boxFragment(spool.Next())})
+
+	var frag = func(content string) *pb.Fragment {
+		return &pb.Fragment{
+			Journal:          "a/journal",
+			Begin:            0,
+			End:              int64(len(content)),
+			Sum:              pb.SHA1SumOf(content),
+			CompressionCodec: pb.CompressionCodec_NONE,
+		}
+	}
+	var content = "a:1\nb:22\na:333\nb"
+
+	expectReadResponse(t, stream, pb.ReadResponse{
+		Status:    pb.Status_OK,
+		Header:    broker.header("a/journal"),
+		Offset:    0,
+		WriteHead: 16,
+		Fragment:  frag(content),
+	})
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 0, Content: []byte("a:1\n")})
+	// A run of matched content is emitted in pieces of at most |chunkSize|.
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 9, Content: []byte("a:333")})
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 14, Content: []byte("\n")})
+
+	// Commit content which completes the filtered message, and ends within a
+	// matching one. As the read isn't at a message boundary, no metadata is sent.
+	spool.MustApply(&pb.ReplicateRequest{Content: []byte("x\na:4\na:5")})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: boxFragment(spool.Next())})
+	content += "x\na:4\na:5"
+
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 18, Content: []byte("a:4\n")})
+
+	// A non-blocking read ends with the offset of its partial message.
+	nbStream, err := broker.client().Read(ctx, &pb.ReadRequest{
+		Journal: "a/journal",
+		Offset:  18,
+		Filter:  filter,
+	})
+	assert.NoError(t, err)
+
+	expectReadResponse(t, nbStream, pb.ReadResponse{
+		Status:    pb.Status_OK,
+		Header:    broker.header("a/journal"),
+		Offset:    18,
+		WriteHead: 25,
+		Fragment:  frag(content),
+	})
+	expectReadResponse(t, nbStream, pb.ReadResponse{Status: pb.Status_OK, Offset: 18, Content: []byte("a:4\n")})
+	expectReadResponse(t, nbStream, pb.ReadResponse{
+		Status:    pb.Status_OFFSET_NOT_YET_AVAILABLE,
+		Offset:    22,
+		WriteHead: 25,
+	})
+	_, err = nbStream.Recv() // Broker closes.
+	assert.Equal(t, io.EOF, err)
+
+	// Complete the partial message, and commit further messages. The blocking
+	// read is again at a message boundary after emitting "a:55", and sends
+	// metadata of the next commit.
+	spool.MustApply(&pb.ReplicateRequest{Content: []byte("5\n")})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: boxFragment(spool.Next())})
+	content += "5\n"
+
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 22, Content: []byte("a:55\n")})
+
+	spool.MustApply(&pb.ReplicateRequest{Content: []byte("a:6\na:7\nc\n")})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: boxFragment(spool.Next())})
+	content += "a:6\na:7\nc\n"
+
+	expectReadResponse(t, stream, pb.ReadResponse{
+		Status:    pb.Status_OK,
+		Offset:    27,
+		WriteHead: 37,
+		Fragment:  frag(content),
+	})
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 27, Content: []byte("a:6\n")})
+	expectReadResponse(t, stream, pb.ReadResponse{Status: pb.Status_OK, Offset: 31, Content: []byte("a:7\n")})
+
+	cancel()
+	_, err = stream.Recv()
+	assert.EqualError(t, err, `rpc error: code = Canceled desc = context canceled`)
+
+	broker.replica("a/journal").spoolCh <- spool
+	broker.cleanup()
+}
+
+func TestReadFilteringOfLargeMessage(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 1}, broker.id)
+
+	var spool = <-broker.replica("a/journal").spoolCh
+
+	// Commit a matching message which is larger than the default (4MB)
+	// maximum message size of a gRPC client.
+	var large = append([]byte("a:"), bytes.Repeat([]byte("x"), 5<<20)...)
+	large = append(large, '\n')
+
+	spool.MustApply(&pb.ReplicateRequest{Content: append([]byte("b:1\n"), large...)})
+	spool.MustApply(&pb.ReplicateRequest{Proposal: boxFragment(spool.Next())})
+
+	var stream, err = broker.client().Read(ctx, &pb.ReadRequest{
+		Journal: "a/journal",
+		Filter:  &pb.ReadFilter{Prefix: []byte("a:")},
+	})
+	assert.NoError(t, err)
+
+	var resp, _ = stream.Recv() // Metadata.
+	assert.Equal(t, pb.Status_OK, resp.Status)
+
+	// Expect the message is received in contiguous pieces.
+	var content []byte
+	for len(content) != len(large) {
+		if resp, err = stream.Recv(); !assert.NoError(t, err) {
+			break
+		}
+		assert.Equal(t, int64(4+len(content)), resp.Offset)
+		assert.True(t, len(resp.Content) <= chunkSize)
+
+		content = append(content, resp.Content...)
+	}
+	assert.Equal(t, large, content)
+
+	broker.replica("a/journal").spoolCh <- spool
+	broker.cleanup()
+}
+
 func TestReadMetadataAndNonBlocking(t *testing.T) {
 	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -258,6 +415,55 @@ func TestReadProxyCases(t *testing.T) {
 	peer.Cleanup()
 }
 
+func TestReadResolvesFreshestIndex(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var peer = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+
+	setTestJournal(broker, pb.JournalSpec{Name: "a/journal", Replication: 2}, broker.id, peer.id)
+	peer.catchUpKeySpace()
+
+	var read = func() *pb.ReadResponse {
+		var stream, err = broker.client().Read(ctx, &pb.ReadRequest{
+			Journal:              "a/journal",
+			Offset:               -1,
+			MetadataOnly:         true,
+			ResolveFreshestIndex: true,
+		})
+		assert.NoError(t, err)
+		resp, err := stream.Recv()
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+		return resp
+	}
+
+	// Case: neither replica has refreshed its index. The read is served locally.
+	var resp = read()
+	assert.Equal(t, broker.id, resp.Header.ProcessId)
+	assert.Equal(t, int64(0), resp.IndexRefreshTime)
+
+	// Case: the peer's index is fresher. The read is proxied to it.
+	broker.initialFragmentLoad()
+	peer.initialFragmentLoad()
+
+	resp = read()
+	assert.Equal(t, peer.id, resp.Header.ProcessId)
+	assert.Equal(t, peer.replica("a/journal").index.LastRemoteRefresh().UnixNano(), resp.IndexRefreshTime)
+
+	// Case: the local index is again fresher.
+	broker.initialFragmentLoad()
+
+	resp = read()
+	assert.Equal(t, broker.id, resp.Header.ProcessId)
+	assert.Equal(t, broker.replica("a/journal").index.LastRemoteRefresh().UnixNano(), resp.IndexRefreshTime)
+
+	broker.cleanup()
+	peer.cleanup()
+}
+
 func TestReadRemoteFragmentCases(t *testing.T) {
 	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -330,6 +536,37 @@ func TestReadRemoteFragmentCases(t *testing.T) {
 	_, err = stream.Recv() // Broker closes.
 	assert.Equal(t, io.EOF, err)
 
+	// Case: filtered read. The broker filters remote fragment content itself,
+	// and doesn't provide a FragmentUrl which would bypass the filter.
+	stream, err = broker.client().Read(pb.WithDispatchDefault(ctx),
+		&pb.ReadRequest{
+			Journal: "a/journal",
+			Offset:  100,
+			Block:   false,
+			Filter:  &pb.ReadFilter{Prefix: []byte("fr"), Delimiter: []byte(" ")},
+		})
+	assert.NoError(t, err)
+
+	expectReadResponse(t, stream, pb.ReadResponse{
+		Status:    pb.Status_OK,
+		Header:    broker.header("a/journal"),
+		Offset:    100,
+		WriteHead: 120,
+		Fragment:  &frag,
+	})
+	expectReadResponse(t, stream, pb.ReadResponse{
+		Status:  pb.Status_OK,
+		Offset:  107,
+		Content: []byte("fragment "),
+	})
+	expectReadResponse(t, stream, pb.ReadResponse{
+		Status:    pb.Status_OFFSET_NOT_YET_AVAILABLE,
+		Offset:    116,
+		WriteHead: 120,
+	})
+	_, err = stream.Recv() // Broker closes.
+	assert.Equal(t, io.EOF, err)
+
 	broker.cleanup()
 }
 
@@ -0,0 +1,105 @@
+package broker
+
+import (
+	"bytes"
+	"fmt"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// readFilter applies a ReadFilter to the content of a served Read. Content is
+// fed to the readFilter as it's read, and complete matching messages are
+// emitted in contiguous runs of up to |chunkSize|. Only as much of a message as is needed to
+// determine whether it matches is buffered before skipping it, but a matching
+// message is buffered in its entirety.
+type readFilter struct {
+	prefix []byte
+	delim  byte
+
+	begin int64  // Journal offset of the current message.
+	buf   []byte // Buffered content of the current message, if not |skip|.
+	skip  bool   // The current message doesn't match, and is being skipped.
+
+	run       []byte // Contiguous complete matching messages to emit.
+	runOffset int64  // Journal offset of |run|.
+}
+
+func newReadFilter(f pb.ReadFilter) *readFilter {
+	return &readFilter{prefix: f.Prefix, delim: f.DelimiterByte()}
+}
+
+// reset the readFilter to a message boundary at |offset|, discarding a partial
+// message (if any). It's called as a read begins, and upon an offset jump.
+func (f *readFilter) reset(offset int64) {
+	f.begin, f.buf, f.skip = offset, f.buf[:0], false
+}
+
+// atBoundary returns whether the read is positioned at a message boundary.
+func (f *readFilter) atBoundary() bool { return !f.skip && len(f.buf) == 0 }
+
+// filter |p| of journal |offset|, which must be the offset through which the
+// readFilter has been fed. |emit| is called with each contiguous run of
+// complete matching messages within |p| (and prior buffered content).
+func (f *readFilter) filter(offset int64, p []byte, emit func(offset int64, content []byte) error) error {
+	f.run = f.run[:0]
+
+	for i := 0; i != len(p); {
+		var seg, done = p[i:], false
+		if j := bytes.IndexByte(seg, f.delim); j != -1 {
+			seg, done = seg[:j+1], true
+		}
+		i += len(seg)
+
+		if !f.skip {
+			f.buf = append(f.buf, seg...)
+
+			if len(f.buf) >= len(f.prefix) || done {
+				f.skip = !bytes.HasPrefix(f.buf, f.prefix)
+			}
+			if f.skip {
+				f.buf = f.buf[:0]
+			} else if !done && len(f.buf) > maxFilteredMessageSize {
+				return fmt.Errorf("filtered message at offset %d exceeds maximum size (%d)",
+					f.begin, maxFilteredMessageSize)
+			}
+		}
+		if !done {
+			continue
+		}
+
+		if !f.skip {
+			if len(f.run) != 0 && f.runOffset+int64(len(f.run)) != f.begin {
+				if err := f.emitRun(emit); err != nil {
+					return err
+				}
+			}
+			if len(f.run) == 0 {
+				f.runOffset = f.begin
+			}
+			f.run = append(f.run, f.buf...)
+		}
+		f.begin, f.buf, f.skip = offset+int64(i), f.buf[:0], false
+	}
+
+	return f.emitRun(emit)
+}
+
+// emitRun emits |run| in pieces of at most |chunkSize|, such that a large
+// matching message doesn't exceed the message size limits of gRPC.
+func (f *readFilter) emitRun(emit func(offset int64, content []byte) error) error {
+	for len(f.run) != 0 {
+		var n = len(f.run)
+		if n > chunkSize {
+			n = chunkSize
+		}
+		if err := emit(f.runOffset, f.run[:n]); err != nil {
+			return err
+		}
+		f.run, f.runOffset = f.run[n:], f.runOffset+int64(n)
+	}
+	return nil
+}
+
+// maxFilteredMessageSize bounds the size of a matching message of a filtered
+// read, which is buffered by the broker until its delimiter is read.
+var maxFilteredMessageSize = 1 << 25 // 32MB.
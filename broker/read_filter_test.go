@@ -0,0 +1,52 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+func TestReadFilterRunsAndBoundaries(t *testing.T) {
+	var f = newReadFilter(pb.ReadFilter{Prefix: []byte("ab"), Delimiter: []byte{';'}})
+	f.reset(100)
+
+	type run struct {
+		offset  int64
+		content string
+	}
+	var runs []run
+	var emit = func(offset int64, content []byte) error {
+		runs = append(runs, run{offset, string(content)})
+		return nil
+	}
+
+	// Adjacent matches are emitted as one run. A message shorter than
+	// the prefix doesn't match.
+	assert.NoError(t, f.filter(100, []byte("ab1;ab2;a;ab3;x;ab4"), emit))
+	assert.Equal(t, []run{{100, "ab1;ab2;"}, {110, "ab3;"}}, runs)
+	assert.False(t, f.atBoundary())
+
+	// The partial message is completed by later content.
+	runs = nil
+	assert.NoError(t, f.filter(119, []byte("4;xy"), emit))
+	assert.Equal(t, []run{{116, "ab44;"}}, runs)
+	assert.False(t, f.atBoundary()) // Within skipped "xy".
+
+	// A reset discards the partial message.
+	f.reset(200)
+	assert.True(t, f.atBoundary())
+
+	runs = nil
+	assert.NoError(t, f.filter(200, []byte("a"), emit))
+	assert.NoError(t, f.filter(201, []byte("b;"), emit))
+	assert.Equal(t, []run{{200, "ab;"}}, runs)
+	assert.True(t, f.atBoundary())
+
+	// Matching messages are bounded in size.
+	defer func(s int) { maxFilteredMessageSize = s }(maxFilteredMessageSize)
+	maxFilteredMessageSize = 4
+
+	assert.EqualError(t, f.filter(203, []byte("abcde"), emit),
+		"filtered message at offset 203 exceeds maximum size (4)")
+}
@@ -3,6 +3,7 @@ package broker
 import (
 	"context"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -27,6 +28,15 @@ type replica struct {
 	spoolCh chan fragment.Spool
 	// pipelineCh synchronizes access to the single pipeline of the replica.
 	pipelineCh chan *pipeline
+	// appendKeys tracks IdempotencyKeys of Appends committed by this replica.
+	appendKeys *appendKeys
+	// Runtime override of Fragment roll thresholds (see FragmentRollDebugHandler).
+	roll   rollOverride
+	rollMu sync.Mutex
+	// Recent proposals of the replica's pipelines (see PipelineHistoryDebugHandler).
+	history *pipelineHistory
+	// Limiter of the rate of Append content (see JournalSpec.MaxAppendRate).
+	appendLimiter appendLimiter
 }
 
 func newReplica(journal pb.Journal) *replica {
@@ -39,6 +49,8 @@ func newReplica(journal pb.Journal) *replica {
 		index:      fragment.NewIndex(ctx),
 		spoolCh:    make(chan fragment.Spool, 1),
 		pipelineCh: make(chan *pipeline, 1),
+		appendKeys: newAppendKeys(),
+		history:    newPipelineHistory(pipelineHistorySize),
 	}
 
 	r.spoolCh <- fragment.NewSpool(journal, struct {
@@ -52,7 +64,9 @@ func newReplica(journal pb.Journal) *replica {
 }
 
 // fragmentRefreshDaemon periodically refreshes the local index of replica
-// fragments from configured remote stores, at configured intervals.
+// fragments from configured remote stores, at configured intervals. The first
+// refresh happens immediately, and subsequent refreshes are jittered
+// (see fragment.NextRefreshDelay).
 func fragmentRefreshDaemon(ks *keyspace.KeySpace, r *replica) {
 	var timer = time.NewTimer(0) // Fires immediately.
 	defer timer.Stop()
@@ -78,7 +92,7 @@ func fragmentRefreshDaemon(ks *keyspace.KeySpace, r *replica) {
 			return
 		}
 
-		if set, err := fragment.WalkAllStores(r.ctx, spec.Name, spec.Fragment.Stores); err == nil {
+		if set, err := fragment.WalkAllStores(r.ctx, spec.Name, spec.Fragment); err == nil {
 			r.index.ReplaceRemote(set)
 		} else {
 			log.WithFields(log.Fields{
@@ -87,7 +101,7 @@ func fragmentRefreshDaemon(ks *keyspace.KeySpace, r *replica) {
 				"interval": spec.Fragment.RefreshInterval,
 			}).Warn("failed to refresh remote fragments (will retry)")
 		}
-		timer.Reset(spec.Fragment.RefreshInterval)
+		timer.Reset(fragment.NextRefreshDelay(spec.Fragment.RefreshInterval))
 	}
 }
 
@@ -127,6 +141,7 @@ func pulseDaemon(svc *Service, r *replica) {
 			fsm.onReadAcknowledgements()
 		}
 		fsm.returnPipeline()
+		fsm.observeStateTimes()
 
 		if fsm.state == stateFinished {
 			// We're done.
@@ -253,6 +268,7 @@ func nextProposal(cur fragment.Spool, rollToOffset int64, spec pb.JournalSpec_Fr
 		next.Begin = next.End
 		next.Sum = pb.SHA1Sum{}
 		next.CompressionCodec = spec.CompressionCodec
+		next.PathPostfix = spec.PathPostfix(timeNow())
 
 		return next
 	}
@@ -19,6 +19,8 @@ type resolver struct {
 	// Set of local replicas known to this resolver. Nil iff
 	// stopServingLocalReplicas() has been called.
 	replicas map[pb.Journal]*resolverReplica
+	// Index of JournalSpec Aliases, to their canonical Journal.
+	aliases map[pb.Journal]pb.Journal
 	// newReplica builds a new local replica instance.
 	newReplica func(pb.Journal) *replica
 	// wg synchronizes over all running local replicas.
@@ -129,9 +131,16 @@ func (r *resolver) resolve(args resolveArgs) (res *resolution, err error) {
 	}
 	res.Etcd = pb.FromEtcdResponseHeader(ks.Header)
 
-	// Extract JournalSpec.
+	// Extract JournalSpec. If |journal| is an alias, resolve its canonical Journal.
 	if item, ok := allocator.LookupItem(ks, args.journal.String()); ok {
 		res.journalSpec = item.ItemValue.(*pb.JournalSpec)
+	} else if canonical, ok := r.aliases[args.journal]; ok {
+		addTrace(args.ctx, " ... %s is an alias of %s", args.journal, canonical)
+		args.journal = canonical
+
+		if item, ok := allocator.LookupItem(ks, args.journal.String()); ok {
+			res.journalSpec = item.ItemValue.(*pb.JournalSpec)
+		}
 	}
 	// Extract Assignments and build Route.
 	res.assignments = ks.KeyValues.Prefixed(
@@ -200,6 +209,8 @@ func (r *resolver) resolve(args resolveArgs) (res *resolution, err error) {
 // updateResolutions, by virtue of being a KeySpace.Observer, expects that the
 // KeySpace.Mu Lock is held.
 func (r *resolver) updateResolutions() {
+	r.updateAliases()
+
 	if r.replicas == nil {
 		return // We've stopped serving local replicas.
 	}
@@ -237,6 +248,24 @@ func (r *resolver) updateResolutions() {
 	r.cancelReplicas(prev)
 }
 
+// updateAliases rebuilds the index of JournalSpec Aliases. It expects that the
+// KeySpace.Mu Lock is held.
+func (r *resolver) updateAliases() {
+	var next map[pb.Journal]pb.Journal
+
+	for _, kv := range r.state.Items {
+		var spec = kv.Decoded.(allocator.Item).ItemValue.(*pb.JournalSpec)
+
+		for _, alias := range spec.Aliases {
+			if next == nil {
+				next = make(map[pb.Journal]pb.Journal)
+			}
+			next[alias] = spec.Name
+		}
+	}
+	r.aliases = next
+}
+
 // stopServingLocalReplicas begins immediate shutdown of any & all local
 // replicas, and causes future attempts to resolve to local replicas to
 // return an error.
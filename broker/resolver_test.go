@@ -151,6 +151,46 @@ func TestResolveCases(t *testing.T) {
 	broker.cleanup()
 }
 
+func TestResolveAliases(t *testing.T) {
+	var ctx, etcd = context.Background(), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Aliases:     []pb.Journal{"an/alias", "another/alias"},
+		Replication: 1,
+	}, broker.id)
+
+	var resolver = broker.svc.resolver
+	var canonical, _ = resolver.resolve(resolveArgs{ctx: ctx, journal: "a/journal"})
+	assert.Equal(t, pb.Status_OK, canonical.status)
+
+	// Expect each alias resolves to the canonical journal, and its replica.
+	for _, alias := range []pb.Journal{"an/alias", "another/alias"} {
+		var r, _ = resolver.resolve(resolveArgs{ctx: ctx, journal: alias})
+		assert.Equal(t, pb.Status_OK, r.status)
+		assert.Equal(t, pb.Journal("a/journal"), r.journalSpec.Name)
+		assert.Equal(t, canonical.Header, r.Header)
+		assert.Equal(t, canonical.replica, r.replica)
+	}
+
+	// Removing an alias from the spec removes its resolution.
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Aliases:     []pb.Journal{"another/alias"},
+		Replication: 1,
+	}, broker.id)
+
+	var r, _ = resolver.resolve(resolveArgs{ctx: ctx, journal: "an/alias"})
+	assert.Equal(t, pb.Status_JOURNAL_NOT_FOUND, r.status)
+	r, _ = resolver.resolve(resolveArgs{ctx: ctx, journal: "another/alias"})
+	assert.Equal(t, pb.Status_OK, r.status)
+
+	broker.cleanup()
+}
+
 func TestResolverLocalReplicaStopping(t *testing.T) {
 	var ctx, etcd = context.Background(), etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -0,0 +1,146 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// FragmentRollDebugHandler returns an http.Handler which overrides the
+// Fragment Length and FlushInterval of a journal's JournalSpec, as used by
+// its primary broker in deciding when to roll the current Fragment. It allows
+// operators to react to traffic spikes without applying a new JournalSpec
+// (and without disturbing the journal's replication pipeline). Requests must
+// present |token| as a Bearer token in their Authorization header, and have
+// form value "journal". The broker must be primary for the journal (requests
+// are not proxied). A POST having form values "length" and "flush_interval"
+// sets (or, if empty, clears) each override, and a GET returns the current
+// overrides. The response is the JSON of the overrides, and of the effective
+// Fragment roll thresholds. If |token| is empty, all requests are refused.
+//
+// Overrides are held only in memory by the journal's primary replica, and
+// revert upon the broker's restart or the journal's re-assignment. Overrides
+// which would produce an invalid JournalSpec_Fragment (for example, a Length
+// or FlushInterval outside of permitted bounds) are refused, and ignored if a
+// later change of the JournalSpec renders them invalid.
+func (svc *Service) FragmentRollDebugHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			http.Error(w, "expected GET or POST", http.StatusMethodNotAllowed)
+			return
+		} else if !authorizedDebugRequest(r, token) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		var journal = pb.Journal(r.FormValue("journal"))
+		if err := journal.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var res, err = svc.resolver.resolve(resolveArgs{
+			ctx:            r.Context(),
+			journal:        journal,
+			mayProxy:       false,
+			requirePrimary: true,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if res.status != pb.Status_OK {
+			http.Error(w, res.status.String(), http.StatusNotFound)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			var next rollOverride
+
+			if s := r.FormValue("length"); s != "" {
+				if next.Length, err = strconv.ParseInt(s, 10, 64); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			if s := r.FormValue("flush_interval"); s != "" {
+				if next.FlushInterval, err = time.ParseDuration(s); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			var frag = next.apply(res.journalSpec.Fragment)
+			if err = frag.Validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			res.replica.setRollOverride(next)
+
+			log.WithFields(log.Fields{
+				"journal":       journal,
+				"length":        next.Length,
+				"flushInterval": next.FlushInterval,
+			}).Info("updated fragment roll override")
+		}
+
+		var out struct {
+			Override      rollOverride
+			Length        int64
+			FlushInterval time.Duration
+		}
+		out.Override = res.replica.getRollOverride()
+
+		var frag = res.replica.fragmentSpec(res.journalSpec.Fragment)
+		out.Length, out.FlushInterval = frag.Length, frag.FlushInterval
+
+		w.Header().Set("Content-Type", "application/json")
+		var enc = json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	})
+}
+
+// rollOverride overrides Fragment roll thresholds of a JournalSpec_Fragment.
+// Zero-valued fields are not overridden.
+type rollOverride struct {
+	Length        int64         `json:",omitempty"`
+	FlushInterval time.Duration `json:",omitempty"`
+}
+
+// apply the rollOverride to the JournalSpec_Fragment.
+func (o rollOverride) apply(spec pb.JournalSpec_Fragment) pb.JournalSpec_Fragment {
+	if o.Length != 0 {
+		spec.Length = o.Length
+	}
+	if o.FlushInterval != 0 {
+		spec.FlushInterval = o.FlushInterval
+	}
+	return spec
+}
+
+func (r *replica) setRollOverride(o rollOverride) {
+	r.rollMu.Lock()
+	r.roll = o
+	r.rollMu.Unlock()
+}
+
+func (r *replica) getRollOverride() rollOverride {
+	r.rollMu.Lock()
+	defer r.rollMu.Unlock()
+	return r.roll
+}
+
+// fragmentSpec returns the JournalSpec_Fragment |spec| with the replica's
+// rollOverride applied, or |spec| itself if the override would be invalid.
+func (r *replica) fragmentSpec(spec pb.JournalSpec_Fragment) pb.JournalSpec_Fragment {
+	var o = r.getRollOverride()
+	if o == (rollOverride{}) {
+		return spec
+	} else if next := o.apply(spec); next.Validate() == nil {
+		return next
+	}
+	return spec
+}
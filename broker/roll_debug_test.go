@@ -0,0 +1,117 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/etcdtest"
+)
+
+func TestFragmentRollDebugHandler(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Replication: 1,
+		Fragment: pb.JournalSpec_Fragment{
+			Length:           1 << 20,
+			CompressionCodec: pb.CompressionCodec_NONE,
+			FlushInterval:    time.Hour,
+		},
+	}, broker.id)
+	broker.initialFragmentLoad()
+
+	var srv = httptest.NewServer(broker.svc.FragmentRollDebugHandler("secret"))
+	defer srv.Close()
+
+	type status struct {
+		Override      rollOverride
+		Length        int64
+		FlushInterval time.Duration
+	}
+	var do = func(method string, form url.Values) (int, status) {
+		form.Set("journal", "a/journal")
+
+		var req, _ = http.NewRequest(method, srv.URL+"?"+form.Encode(), nil)
+		if method == http.MethodPost {
+			req, _ = http.NewRequest(method, srv.URL, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		req.Header.Set("Authorization", "Bearer secret")
+
+		var resp, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var out status
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		}
+		return resp.StatusCode, out
+	}
+	var appendAndSpool = func(content string) (begin, end int64) {
+		var stream, _ = broker.client().Append(ctx)
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal"}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte(content)}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+		var _, err = stream.CloseAndRecv()
+		assert.NoError(t, err)
+
+		var res, _ = broker.svc.resolver.resolve(resolveArgs{ctx: ctx, journal: "a/journal"})
+		var frag, _, _ = res.replica.spoolSnapshot(httptest.NewRequest("GET", "/", nil))
+		return frag.Begin, frag.End
+	}
+
+	// Case: initially, there is no override.
+	var code, out = do(http.MethodGet, url.Values{})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, status{Length: 1 << 20, FlushInterval: time.Hour}, out)
+
+	// Case: overrides outside of JournalSpec_Fragment bounds are refused.
+	code, _ = do(http.MethodPost, url.Values{"length": {"12"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = do(http.MethodPost, url.Values{"flush_interval": {"1s"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = do(http.MethodPost, url.Values{"length": {"not-a-number"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// The journal's first write is always rolled. Subsequent small writes
+	// accumulate in the current Fragment.
+	appendAndSpool("first")
+	var begin, end = appendAndSpool(strings.Repeat("x", 2048))
+	assert.Equal(t, []int64{5, 2053}, []int64{begin, end})
+
+	// Case: override the Length. The next append rolls the Fragment, without
+	// tearing down the pipeline.
+	code, out = do(http.MethodPost, url.Values{"length": {"1024"}})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, status{
+		Override:      rollOverride{Length: 1024},
+		Length:        1024,
+		FlushInterval: time.Hour,
+	}, out)
+
+	begin, end = appendAndSpool("y")
+	assert.Equal(t, []int64{2053, 2054}, []int64{begin, end})
+
+	// Case: clear the override. Small writes again accumulate.
+	code, out = do(http.MethodPost, url.Values{})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, status{Length: 1 << 20, FlushInterval: time.Hour}, out)
+
+	appendAndSpool(strings.Repeat("z", 256))
+	begin, end = appendAndSpool("z")
+	assert.Equal(t, []int64{2053, 2311}, []int64{begin, end})
+
+	broker.cleanup()
+}
@@ -2,6 +2,7 @@ package broker
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -22,6 +23,10 @@ type Service struct {
 	etcd     *clientv3.Client
 	resolver *resolver
 
+	// inFlightAppends indexes appendFSMs being evaluated, and their current
+	// state (as *appendFSM => inFlightAppend).
+	inFlightAppends sync.Map
+
 	// stopProxyReadsCh is closed when the Service is beginning shutdown.
 	// All other RPCs are allowed to gracefully complete as per usual, but
 	// because proxy reads can be very long lived, we must inject an EOF
@@ -0,0 +1,120 @@
+package broker
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"go.gazette.dev/core/broker/fragment"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// SpoolDebugHandler returns an http.Handler which streams the raw,
+// unpersisted content of a journal's current Spool, as of its last commit.
+// It's intended for low-level debugging by operators. Requests are GETs
+// having form value "journal", and must present |token| as a Bearer token in
+// their Authorization header. The broker must be primary for the journal
+// (requests are not proxied). The response body is the uncompressed
+// content of the Spool, and its Fragment is described by header
+// X-Fragment-Name. If |token| is empty, all requests are refused.
+func (svc *Service) SpoolDebugHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "expected GET", http.StatusMethodNotAllowed)
+			return
+		} else if !authorizedDebugRequest(r, token) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		var journal = pb.Journal(r.FormValue("journal"))
+		if err := journal.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var res, err = svc.resolver.resolve(resolveArgs{
+			ctx:            r.Context(),
+			journal:        journal,
+			mayProxy:       false,
+			requirePrimary: true,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if res.status != pb.Status_OK {
+			http.Error(w, res.status.String(), http.StatusNotFound)
+			return
+		}
+
+		frag, content, err := res.replica.spoolSnapshot(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("X-Fragment-Name", frag.ContentName())
+
+		if _, err = w.Write(content); err != nil {
+			log.WithFields(log.Fields{"err": err, "journal": journal}).
+				Warn("failed to serve spool debug content")
+		}
+	})
+}
+
+// spoolSnapshot returns the Fragment of the replica's current Spool, as of
+// its last commit, and its content. Content is read while the Spool is held,
+// as its File may be appended to, rolled, or closed once it's released.
+func (r *replica) spoolSnapshot(req *http.Request) (fragment.Fragment, []byte, error) {
+	var pln *pipeline
+	select {
+	case pln = <-r.pipelineCh:
+	case <-req.Context().Done():
+		return fragment.Fragment{}, nil, req.Context().Err()
+	case <-r.ctx.Done():
+		return fragment.Fragment{}, nil, r.ctx.Err()
+	}
+	defer func() { r.pipelineCh <- pln }()
+
+	if pln != nil {
+		return readSpoolSnapshot(pln.spool.Fragment)
+	}
+
+	select {
+	case spool := <-r.spoolCh:
+		defer func() { r.spoolCh <- spool }()
+		return readSpoolSnapshot(spool.Fragment)
+	case <-req.Context().Done():
+		return fragment.Fragment{}, nil, req.Context().Err()
+	case <-r.ctx.Done():
+		return fragment.Fragment{}, nil, r.ctx.Err()
+	}
+}
+
+// readSpoolSnapshot reads the committed content of Spool Fragment |frag|.
+func readSpoolSnapshot(frag fragment.Fragment) (fragment.Fragment, []byte, error) {
+	if frag.File == nil || frag.ContentLength() == 0 {
+		return frag, nil, nil
+	}
+	var content = make([]byte, frag.ContentLength())
+
+	if _, err := frag.File.ReadAt(content, 0); err != nil {
+		return frag, nil, err
+	}
+	return frag, content, nil
+}
+
+// authorizedDebugRequest returns true iff the request presents a Bearer
+// token matching the non-empty |token|.
+func authorizedDebugRequest(r *http.Request, token string) bool {
+	var auth = r.Header.Get("Authorization")
+	if token == "" || !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+	var presented = strings.TrimPrefix(auth, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
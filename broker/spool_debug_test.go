@@ -0,0 +1,94 @@
+package broker
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/etcdtest"
+)
+
+func TestSpoolDebugHandler(t *testing.T) {
+	var ctx, etcd = pb.WithDispatchDefault(context.Background()), etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = newTestBroker(t, etcd, pb.ProcessSpec_ID{Zone: "local", Suffix: "broker"})
+	var peer = newMockBroker(t, etcd, pb.ProcessSpec_ID{Zone: "peer", Suffix: "broker"})
+	setTestJournal(broker, pb.JournalSpec{
+		Name:        "a/journal",
+		Replication: 1,
+		Fragment: pb.JournalSpec_Fragment{
+			Length:           1 << 20,
+			CompressionCodec: pb.CompressionCodec_NONE,
+		},
+	}, broker.id)
+	setTestJournal(broker, pb.JournalSpec{Name: "peer/journal", Replication: 1}, peer.id)
+	broker.initialFragmentLoad()
+
+	var srv = httptest.NewServer(broker.svc.SpoolDebugHandler("secret"))
+	defer srv.Close()
+
+	var get = func(token, journal string) (int, string, http.Header) {
+		var req, _ = http.NewRequest("GET", srv.URL+"?journal="+journal, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		var resp, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		var b, _ = ioutil.ReadAll(resp.Body)
+		assert.NoError(t, resp.Body.Close())
+		return resp.StatusCode, string(b), resp.Header
+	}
+
+	// Case: requests without a matching token are refused.
+	var code, _, _ = get("", "a/journal")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _, _ = get("wrong", "a/journal")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Case: the Spool is empty.
+	code, body, _ := get("secret", "a/journal")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "", body)
+
+	// Append content, and expect that it's returned. Note the Spool is rolled
+	// after the journal's very first write.
+	for _, content := range []string{"foo", "bar", "baz"} {
+		var stream, _ = broker.client().Append(ctx)
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Journal: "a/journal"}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{Content: []byte(content)}))
+		assert.NoError(t, stream.Send(&pb.AppendRequest{}))
+
+		var resp, err = stream.CloseAndRecv()
+		assert.NoError(t, err)
+		assert.Equal(t, pb.Status_OK, resp.Status)
+	}
+
+	code, body, hdr := get("secret", "a/journal")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "barbaz", body)
+	assert.Equal(t, "6", hdr.Get("Content-Length"))
+	assert.Equal(t, (&pb.Fragment{
+		Journal:          "a/journal",
+		Begin:            3,
+		End:              9,
+		Sum:              pb.SHA1SumOf("barbaz"),
+		CompressionCodec: pb.CompressionCodec_NONE,
+	}).ContentName(), hdr.Get("X-Fragment-Name"))
+
+	// Case: this broker isn't primary for the journal.
+	code, body, _ = get("secret", "peer/journal")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "NOT_JOURNAL_PRIMARY_BROKER\n", body)
+
+	// Case: the journal is invalid.
+	code, _, _ = get("secret", "/invalid")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	broker.cleanup()
+	peer.Cleanup()
+}
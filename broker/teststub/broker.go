@@ -30,6 +30,7 @@ type Broker struct {
 	ListFunc          func(context.Context, *pb.ListRequest) (*pb.ListResponse, error)
 	ApplyFunc         func(context.Context, *pb.ApplyRequest) (*pb.ApplyResponse, error)
 	ListFragmentsFunc func(context.Context, *pb.FragmentsRequest) (*pb.FragmentsResponse, error)
+	HeadFunc          func(context.Context, *pb.HeadRequest) (*pb.HeadResponse, error)
 
 	ErrCh chan error
 }
@@ -198,4 +199,9 @@ func (b *Broker) ListFragments(ctx context.Context, req *pb.FragmentsRequest) (*
 	return b.ListFragmentsFunc(ctx, req)
 }
 
+// Head implements the JournalServer interface by proxying through HeadFunc.
+func (b *Broker) Head(ctx context.Context, req *pb.HeadRequest) (*pb.HeadResponse, error) {
+	return b.HeadFunc(ctx, req)
+}
+
 func init() { pb.RegisterGRPCDispatcher("local") }
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -101,6 +102,57 @@ func TestReplicatedReadAndWrite(t *testing.T) {
 	assert.NoError(t, bkA.Tasks.Wait())
 }
 
+func TestSealedJournal(t *testing.T) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var ctx = pb.WithDispatchDefault(context.Background())
+	var bk = NewBroker(t, etcd, "local", "broker")
+
+	CreateJournals(t, bk, Journal(pb.JournalSpec{Name: "foo/bar"}))
+
+	var conn, rjc = newDialedClient(t, bk)
+	defer conn.Close()
+
+	var _, err = client.Append(ctx, rjc, pb.AppendRequest{Journal: "foo/bar"},
+		strings.NewReader("hello, gazette\n"))
+	assert.NoError(t, err)
+
+	// Seal the journal. Expect its seal records the final write head.
+	spec, err := client.SealJournal(ctx, rjc, "foo/bar")
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.JournalSpec_Seal{Offset: 15}, spec.Seal)
+
+	// Sealing again is a no-op which verifies the seal.
+	spec, err = client.SealJournal(ctx, rjc, "foo/bar")
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.JournalSpec_Seal{Offset: 15}, spec.Seal)
+
+	// Expect further writes are rejected.
+	_, err = client.Append(ctx, rjc, pb.AppendRequest{Journal: "foo/bar"},
+		strings.NewReader("goodbye, gazette\n"))
+	assert.Equal(t, client.ErrJournalSealed, err)
+
+	// But reads continue to succeed.
+	var br = bufio.NewReader(client.NewReader(ctx, rjc, pb.ReadRequest{Journal: "foo/bar"}))
+	str, err := br.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "hello, gazette\n", str)
+
+	_, err = br.ReadString('\n')
+	assert.Equal(t, client.ErrOffsetNotYetAvailable, err)
+
+	// The seal cannot be removed.
+	spec.Seal = nil
+	_, err = client.ApplyJournals(ctx, rjc, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{Upsert: spec}},
+	})
+	assert.EqualError(t, err, pb.Status_JOURNAL_SEALED.String())
+
+	bk.Tasks.Cancel()
+	assert.NoError(t, bk.Tasks.Wait())
+}
+
 func TestReassignment(t *testing.T) {
 	var etcd = etcdtest.TestClient()
 	defer etcdtest.Cleanup()
@@ -14,6 +14,7 @@ import (
 
 type cmdJournalsApply struct {
 	ApplyConfig
+	ServerDryRun bool `long:"server-dry-run" description:"Perform a dry-run of the apply by brokers, reporting journals which would be created, updated, or deleted"`
 }
 
 func init() {
@@ -34,6 +35,11 @@ JournalSpecs; this check ensures concurrent modifications are caught.
 
 JournalSpecs may be created by setting "revision" to zero or omitting altogether.
 
+With --dry-run, the flattened ApplyRequest is printed without contacting
+brokers. With --server-dry-run, brokers instead validate the apply against
+current JournalSpecs and report the journals which would be created, updated,
+or deleted, without applying any changes.
+
 JournalSpecs may be deleted by setting field "delete" to true on individual
 journals or parents thereof in the hierarchy. Note that deleted parent prefixes
 will cascade only to JournalSpecs *explicitly listed* as children of the prefix
@@ -57,11 +63,22 @@ func (cmd *cmdJournalsApply) Execute([]string) error {
 		_ = proto.MarshalText(os.Stdout, req)
 		return nil
 	}
+	req.DryRun = cmd.ServerDryRun
 
 	var ctx = context.Background()
 	var resp, err = client.ApplyJournalsInBatches(ctx, journalsCfg.Broker.MustJournalClient(ctx), req, cmd.MaxTxnSize)
 	mbp.Must(err, "failed to apply journals")
-	log.WithField("revision", resp.Header.Etcd.Revision).Info("successfully applied")
+
+	if cmd.ServerDryRun {
+		log.WithFields(log.Fields{
+			"revision": resp.Header.Etcd.Revision,
+			"created":  resp.DryRunCreated,
+			"updated":  resp.DryRunUpdated,
+			"deleted":  resp.DryRunDeleted,
+		}).Info("dry-run of apply succeeded")
+	} else {
+		log.WithField("revision", resp.Header.Etcd.Revision).Info("successfully applied")
+	}
 
 	return nil
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.gazette.dev/core/broker/client"
+	"go.gazette.dev/core/broker/fragment"
+	pb "go.gazette.dev/core/broker/protocol"
+	mbp "go.gazette.dev/core/mainboilerplate"
+)
+
+type cmdJournalsGC struct {
+	pruneConfig
+	Retention time.Duration `long:"retention" default:"24h" description:"Minimum age of an unreferenced fragment before it's deleted"`
+}
+
+func init() {
+	_ = mustAddCmd(cmdJournals, "gc", "Deletes unreferenced fragments of journal stores", `
+Deletes fragments of the configured fragment stores of matching journals which are no longer referenced by the journal's fragment index, and which are older than the --retention window.
+
+Such fragments accumulate after a journal's history is truncated. Unlike "journals prune", which deletes aged fragments of the journal index, "journals gc" lists each fragment store directly and deletes only fragments which aren't in the index. To be safe against brokers which are concurrently writing the journal, a fragment is deleted only if it ends at or before the first persisted offset of the journal index.
+
+Use --selector to supply a LabelSelector to select journals to collect.
+See "journals list --help" for details and examples.
+`, &cmdJournalsGC{})
+}
+
+func (cmd *cmdJournalsGC) Execute([]string) error {
+	startup()
+
+	var resp = listJournals(cmd.Selector)
+	if len(resp.Journals) == 0 {
+		log.WithField("selector", cmd.Selector).Panic("no journals match selector")
+	}
+
+	var ctx = context.Background()
+	var jc = journalsCfg.Broker.MustRoutedJournalClient(ctx)
+	var horizon = time.Now().Add(-cmd.Retention)
+
+	for _, j := range resp.Journals {
+		var fr, err = client.ListAllFragments(ctx, jc, pb.FragmentsRequest{Journal: j.Spec.Name})
+		mbp.Must(err, "failed to fetch fragments", "journal", j.Spec.Name)
+
+		var index = make([]pb.Fragment, len(fr.Fragments))
+		for i, f := range fr.Fragments {
+			index[i] = f.Spec
+		}
+
+		garbage, err := fragment.CollectGarbage(ctx, j.Spec, index, horizon, cmd.DryRun)
+		for _, f := range garbage {
+			log.WithFields(log.Fields{
+				"journal": f.Journal,
+				"name":    f.ContentName(),
+				"store":   f.BackingStore,
+				"mod":     f.ModTime,
+				"dryRun":  cmd.DryRun,
+			}).Info("collected fragment")
+		}
+		mbp.Must(err, "failed to collect fragments", "journal", j.Spec.Name)
+
+		log.WithFields(log.Fields{
+			"journal":   j.Spec.Name,
+			"collected": len(garbage),
+			"indexed":   len(index),
+		}).Info("collected journal")
+	}
+	return nil
+}
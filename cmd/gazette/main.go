@@ -13,6 +13,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"go.gazette.dev/core/allocator"
 	"go.gazette.dev/core/broker"
+	"go.gazette.dev/core/broker/codecs"
 	"go.gazette.dev/core/broker/fragment"
 	"go.gazette.dev/core/broker/http_gateway"
 	"go.gazette.dev/core/broker/protocol"
@@ -31,7 +32,25 @@ const iniFilename = "gazette.ini"
 var Config = new(struct {
 	Broker struct {
 		mbp.ServiceConfig
-		Limit uint32 `long:"limit" env:"LIMIT" default:"1024" description:"Maximum number of Journals the broker will allocate"`
+		Limit           uint32        `long:"limit" env:"LIMIT" default:"1024" description:"Maximum number of Journals the broker will allocate"`
+		MaxSignatureTTL time.Duration `long:"max-signature-ttl" env:"MAX_SIGNATURE_TTL" default:"24h" description:"Maximum lifetime of signed fragment URLs returned to readers"`
+
+		GzipLevel      int `long:"gzip-level" env:"GZIP_LEVEL" default:"0" description:"Compression level of GZIP fragments, from 1 (fastest) to 9 (smallest). Zero uses the codec default"`
+		ZstandardLevel int `long:"zstd-level" env:"ZSTD_LEVEL" default:"0" description:"Compression level of ZSTANDARD fragments, from 1 (fastest) to 20 (smallest). Zero uses the codec default"`
+
+		VerifySpoolCommits bool `long:"verify-spool-commits" env:"VERIFY_SPOOL_COMMITS" description:"Re-read and verify the checksum of spooled content before each commit"`
+
+		MaxPausedBacklog int64 `long:"max-paused-backlog" env:"MAX_PAUSED_BACKLOG" default:"8589934592" description:"Maximum bytes of completed fragments which may queue while persistence is paused (via /debug/persister). Zero is unlimited"`
+
+		MinFragmentRefreshInterval time.Duration `long:"min-fragment-refresh-interval" env:"MIN_FRAGMENT_REFRESH_INTERVAL" default:"0" description:"Minimum interval between listings of a journal's fragment stores. JournalSpecs having a smaller refresh interval use this one instead"`
+		FragmentRefreshJitter      float64       `long:"fragment-refresh-jitter" env:"FRAGMENT_REFRESH_JITTER" default:"0.1" description:"Fraction, in [0, 1), by which intervals between listings of fragment stores are randomly jittered"`
+
+		GCSBillingProject string `long:"gcs-billing-project" env:"GCS_BILLING_PROJECT" description:"Project billed for requests of GCS fragment stores (required of Requester Pays buckets). Stores may override with a billingProject URL argument"`
+		GCSProjectID      string `long:"gcs-project-id" env:"GCS_PROJECT_ID" description:"Quota project of GCS requests. If empty, the project of application default credentials is used"`
+
+		SpoolDebugToken   string `long:"spool-debug-token" env:"SPOOL_DEBUG_TOKEN" description:"Bearer token required to read raw spool content via /debug/spool. If empty, /debug/spool is disabled"`
+		FragmentRollToken string `long:"fragment-roll-token" env:"FRAGMENT_ROLL_TOKEN" description:"Bearer token required to override fragment roll thresholds via /debug/fragment-roll. If empty, /debug/fragment-roll is disabled"`
+		PersisterToken    string `long:"persister-debug-token" env:"PERSISTER_DEBUG_TOKEN" description:"Bearer token required to pause or resume fragment persistence via /debug/persister. If empty, pauses and resumes are disabled"`
 	} `group:"Broker" namespace:"broker" env-namespace:"BROKER"`
 
 	Etcd struct {
@@ -57,6 +76,23 @@ func (serveBroker) Execute(args []string) error {
 	prometheus.MustRegister(metrics.GazetteBrokerCollectors()...)
 	protocol.RegisterGRPCDispatcher(Config.Broker.Zone)
 
+	fragment.SpoolCodecOptions = codecs.CodecOptions{
+		GzipLevel:      Config.Broker.GzipLevel,
+		ZstandardLevel: Config.Broker.ZstandardLevel,
+	}
+	mbp.Must(fragment.SpoolCodecOptions.Validate(), "invalid compression options")
+	fragment.MaxSignatureTTL = Config.Broker.MaxSignatureTTL
+	fragment.MaxPausedBacklog = Config.Broker.MaxPausedBacklog
+	fragment.VerifySpoolCommits = Config.Broker.VerifySpoolCommits
+
+	if j := Config.Broker.FragmentRefreshJitter; j < 0 || j >= 1 {
+		log.WithField("jitter", j).Fatal("invalid fragment refresh jitter (expected 0 <= jitter < 1)")
+	}
+	fragment.MinRefreshInterval = Config.Broker.MinFragmentRefreshInterval
+	fragment.RefreshJitter = Config.Broker.FragmentRefreshJitter
+	fragment.GCSBillingProject = Config.Broker.GCSBillingProject
+	fragment.GCSProjectID = Config.Broker.GCSProjectID
+
 	var ks = broker.NewKeySpace(Config.Etcd.Prefix)
 	var allocState = allocator.NewObservedState(ks, Config.Broker.MemberKey(ks))
 
@@ -88,6 +124,16 @@ func (serveBroker) Execute(args []string) error {
 
 	var persister = fragment.NewPersister(ks)
 	broker.SetSharedPersister(persister)
+	// Serve persister status, and pause / resume controls for maintenance windows to authorized operators.
+	srv.HTTPMux.Handle("/debug/persister", broker.PersisterDebugHandler(persister, Config.Broker.PersisterToken))
+	// Serve raw, unpersisted spool content of journals to authorized operators.
+	srv.HTTPMux.Handle("/debug/spool", service.SpoolDebugHandler(Config.Broker.SpoolDebugToken))
+	// Serve the current appendFSM states of in-flight appends, to diagnose stuck appends.
+	srv.HTTPMux.Handle("/debug/appends", service.AppendsDebugHandler())
+	// Serve runtime overrides of journal fragment roll thresholds to authorized operators.
+	srv.HTTPMux.Handle("/debug/fragment-roll", service.FragmentRollDebugHandler(Config.Broker.FragmentRollToken))
+	// Serve recent replication proposals and commits of journal pipelines, to diagnose diverged peers.
+	srv.HTTPMux.Handle("/debug/pipeline-history", service.PipelineHistoryDebugHandler())
 
 	tasks.Queue("persister.Serve", func() error {
 		persister.Serve()
@@ -21,6 +21,7 @@ type shardServerStub struct {
 	ListFunc     func(context.Context, *pc.ListRequest) (*pc.ListResponse, error)
 	ApplyFunc    func(context.Context, *pc.ApplyRequest) (*pc.ApplyResponse, error)
 	GetHintsFunc func(context.Context, *pc.GetHintsRequest) (*pc.GetHintsResponse, error)
+	LocateFunc   func(context.Context, *pc.LocateRequest) (*pc.LocateResponse, error)
 }
 
 // newShardServerStub returns a shardServerStub instance served by a local GRPC server.
@@ -69,3 +70,8 @@ func (s *shardServerStub) Apply(ctx context.Context, req *pc.ApplyRequest) (*pc.
 func (s *shardServerStub) GetHints(ctx context.Context, req *pc.GetHintsRequest) (*pc.GetHintsResponse, error) {
 	return s.GetHintsFunc(ctx, req)
 }
+
+// Locate implements the shardServerStub interface by proxying through LocateFunc.
+func (s *shardServerStub) Locate(ctx context.Context, req *pc.LocateRequest) (*pc.LocateResponse, error) {
+	return s.LocateFunc(ctx, req)
+}
@@ -0,0 +1,104 @@
+package consumer
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// freshness tracks the time through which the committed state of a primary
+// Replica reflects all available content of its source journals. A transaction
+// is caught up if it read through the write heads of its source journals (as
+// observed when each of its messages was read). While a caught up primary is
+// idle, its state is fresh. Otherwise it's fresh as of the time it was last
+// caught up. A recovered primary is stale until its first caught up transaction.
+//
+// freshness also tracks the replay of the recovery log by a standby Replica,
+// which is caught up while it has played through the log write head. A standby
+// reflects the committed state of its primary, and its freshness is that of
+// its replay. A standby which is promoted is fresh as of the time its replay
+// was last caught up, until its first caught up transaction as primary.
+type freshness struct {
+	caughtUpAt time.Time // Time at which the replica was last caught up.
+	caughtUp   bool      // Whether the last committed transaction (or replay) was caught up.
+	active     bool      // Whether a transaction is underway.
+	standby    bool      // Whether the replica is a standby replaying its log.
+	mu         sync.Mutex
+}
+
+// onReplay is called as a standby plays its recovery log through |offset|,
+// with a log |writeHead|, at time |at|.
+func (f *freshness) onReplay(offset, writeHead int64, at time.Time) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+
+	if !f.standby {
+		return // Replay of a primary (eg, during its recovery) isn't tracked.
+	} else if f.caughtUp {
+		f.caughtUpAt = at // Log content has arrived since we were caught up.
+	}
+	if f.caughtUp = offset >= writeHead; f.caughtUp {
+		f.caughtUpAt = at
+	}
+}
+
+// onPromote is called as a standby is promoted to primary, at time |at|.
+func (f *freshness) onPromote(at time.Time) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+
+	if f.caughtUp {
+		f.caughtUpAt = at
+	}
+	f.caughtUp, f.standby = false, false
+}
+
+// onBegin is called as a transaction |beganAt|.
+func (f *freshness) onBegin(beganAt time.Time) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+
+	if f.caughtUp {
+		f.caughtUpAt = beganAt
+	}
+	f.active = true
+}
+
+// onCommit is called upon the commit of |txn|.
+func (f *freshness) onCommit(txn *transaction) {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+
+	f.active, f.caughtUp = false, true
+	for journal, offset := range txn.offsets {
+		if offset < txn.writeHeads[journal] {
+			f.caughtUp = false
+		}
+	}
+	if f.caughtUp {
+		f.caughtUpAt = txn.stalledAt // Time at which all read messages were consumed.
+	}
+}
+
+// staleness returns the duration for which the replica state has not
+// reflected all available content of its source journals (or, for a standby,
+// of its recovery log), as of |now|.
+func (f *freshness) staleness(now time.Time) time.Duration {
+	defer f.mu.Unlock()
+	f.mu.Lock()
+
+	if f.caughtUp && !f.active {
+		return 0
+	} else if f.caughtUpAt.IsZero() {
+		return math.MaxInt64 // Never caught up.
+	}
+	return now.Sub(f.caughtUpAt)
+}
+
+// freshnessOf returns the freshness of a Shard, or nil if it isn't tracked.
+func freshnessOf(shard Shard) *freshness {
+	if r, ok := shard.(*Replica); ok {
+		return &r.freshness
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package consumer
+
+// Interceptor hooks the shard resolutions and consumer transactions of a
+// Service, and is provided to NewService. It's intended for integration with
+// tracing systems (such as OpenTelemetry), which may begin and end spans
+// around each resolution and transaction.
+//
+// Existing trace events of resolutions (as otherwise logged to a
+// golang.org/x/net/trace.Trace of the ResolveArgs.Context) may be bridged
+// into such a system: an Interceptor may attach its own implementation of
+// trace.Trace to the Context passed to |next| (via trace.NewContext), and
+// the events are then delivered to it.
+type Interceptor interface {
+	// InterceptResolve wraps a Resolver.Resolve of |args|, which is performed
+	// by calling |next|. The Interceptor may derive a new ResolveArgs.Context
+	// (eg, to propagate a trace span) before calling |next|, and must return
+	// its result.
+	InterceptResolve(args ResolveArgs, next func(ResolveArgs) (Resolution, error)) (Resolution, error)
+	// InterceptTransaction is called as a consumer transaction of the Shard
+	// begins, and returns a function which is called with the transaction's
+	// outcome upon its completion (after the transaction's FinishTxn, if the
+	// Application is a BeginFinisher).
+	InterceptTransaction(shard Shard) (done func(error))
+}
+
+// interceptedResolve invokes |resolve| through each of the Interceptors,
+// with the first Interceptor being outer-most.
+func interceptedResolve(interceptors []Interceptor, args ResolveArgs,
+	resolve func(ResolveArgs) (Resolution, error)) (Resolution, error) {
+
+	if len(interceptors) == 0 {
+		return resolve(args)
+	}
+	return interceptors[0].InterceptResolve(args, func(args ResolveArgs) (Resolution, error) {
+		return interceptedResolve(interceptors[1:], args, resolve)
+	})
+}
+
+// interceptTxn is called as a transaction of the Shard begins. It returns a
+// function to be called with the transaction outcome, or nil if the Shard
+// has no Interceptors.
+func interceptTxn(shard Shard) func(error) {
+	var replica, ok = shard.(*Replica)
+	if !ok || len(replica.interceptors) == 0 {
+		return nil
+	}
+	var dones = make([]func(error), len(replica.interceptors))
+	for i, ic := range replica.interceptors {
+		dones[i] = ic.InterceptTransaction(shard)
+	}
+	return func(err error) {
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i](err)
+		}
+	}
+}
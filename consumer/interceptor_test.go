@@ -0,0 +1,88 @@
+package consumer
+
+import (
+	"fmt"
+
+	gc "github.com/go-check/check"
+	pc "go.gazette.dev/core/consumer/protocol"
+	"golang.org/x/net/trace"
+)
+
+type InterceptorSuite struct{}
+
+func (s *InterceptorSuite) TestResolveAndTransactionInterception(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var outer, inner = newTestInterceptor("outer"), newTestInterceptor("inner")
+	var interceptors = []Interceptor{outer, inner}
+
+	tf.resolver.interceptors = interceptors
+	var newReplica = tf.resolver.newReplica
+	tf.resolver.newReplica = func() *Replica {
+		var r = newReplica()
+		r.interceptors = interceptors
+		return r
+	}
+
+	tf.allocateShard(c, makeShard(shardA), localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	var res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	defer res.Done()
+
+	// Expect Interceptors were invoked in order, and that a trace.Trace
+	// attached by an Interceptor received trace events of the resolution.
+	c.Check(<-outer.events, gc.Equals, "outer: resolve shard-A")
+	c.Check(<-inner.events, gc.Equals, "inner: resolve shard-A")
+	c.Check(len(inner.tr.events) != 0, gc.Equals, true)
+	c.Check(inner.tr.events[0], gc.Matches, `resolve\(shard-A\) => OK, .*`)
+
+	var aa = res.Shard.JournalClient().StartAppend(sourceA)
+	_, _ = aa.Writer().WriteString(`{"key":"foo","value":"bar"}` + "\n")
+	c.Check(aa.Release(), gc.IsNil)
+
+	// Transactions begin in order, and complete in reverse order.
+	c.Check(<-outer.events, gc.Equals, "outer: begin shard-A")
+	c.Check(<-inner.events, gc.Equals, "inner: begin shard-A")
+	c.Check(<-inner.events, gc.Equals, "inner: done shard-A <nil>")
+	c.Check(<-outer.events, gc.Equals, "outer: done shard-A <nil>")
+
+	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
+}
+
+type testInterceptor struct {
+	name   string
+	events chan string
+	tr     *testTrace
+}
+
+func newTestInterceptor(name string) *testInterceptor {
+	return &testInterceptor{name: name, events: make(chan string, 16), tr: new(testTrace)}
+}
+
+func (i *testInterceptor) InterceptResolve(args ResolveArgs, next func(ResolveArgs) (Resolution, error)) (Resolution, error) {
+	i.events <- fmt.Sprintf("%s: resolve %s", i.name, args.ShardID)
+	args.Context = trace.NewContext(args.Context, i.tr)
+	return next(args)
+}
+
+func (i *testInterceptor) InterceptTransaction(shard Shard) func(error) {
+	var id = shard.Spec().Id
+	i.events <- fmt.Sprintf("%s: begin %s", i.name, id)
+
+	return func(err error) { i.events <- fmt.Sprintf("%s: done %s %v", i.name, id, err) }
+}
+
+// testTrace is a trace.Trace which records LazyPrintf events.
+type testTrace struct {
+	trace.Trace
+	events []string
+}
+
+func (t *testTrace) LazyPrintf(format string, args ...interface{}) {
+	t.events = append(t.events, fmt.Sprintf(format, args...))
+}
+
+var _ = gc.Suite(&InterceptorSuite{})
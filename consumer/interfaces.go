@@ -34,6 +34,9 @@ type Shard interface {
 // initialization, and usage of an appropriate Store backend for their use case.
 type Store interface {
 	// Recorder which this Store wraps, into which all Store state is recorded.
+	// Recorder is nil if the Shard has no recovery log, in which case Flush
+	// must durably persist |offsets| before it returns (see
+	// ExternalCheckpointStore).
 	Recorder() *recoverylog.Recorder
 	// FetchJournalOffsets returns the collection of Journals and corresponding
 	// offsets represented within the Store.
@@ -46,6 +49,25 @@ type Store interface {
 	Destroy()
 }
 
+// Snapshotter is an optional interface of a Store which is able to capture
+// point-in-time, consistent snapshots of its contents. See SnapshotShard.
+type Snapshotter interface {
+	// Snapshot captures the current contents of the Store. It's called between
+	// consumer transactions, and should return quickly: iteration of the
+	// returned StoreIterator proceeds concurrently with further transactions,
+	// and must not reflect their updates.
+	Snapshot() (StoreIterator, error)
+}
+
+// StoreIterator iterates over the key/value contents of a Store snapshot.
+type StoreIterator interface {
+	// Next returns the next key and value of the snapshot, or io.EOF if none
+	// remain. Returned slices are valid only until the next call to Next.
+	Next() (key, value []byte, err error)
+	// Close releases resources of the StoreIterator.
+	Close()
+}
+
 // Application is the interface provided by domain applications
 // running as Gazette consumers. Only unrecoverable errors should be
 // returned by Application. A returned error will abort processing of an
@@ -85,7 +107,8 @@ type Store interface {
 // commit.
 type Application interface {
 	// NewStore constructs a Store for |shard| around the recovered local directory
-	// |dir| and initialized Recorder |rec|.
+	// |dir| and initialized Recorder |rec|. If the Shard has no recovery log,
+	// |dir| is empty and |rec| is nil.
 	NewStore(shard Shard, dir string, rec *recoverylog.Recorder) (Store, error)
 	// NewMessage returns a zero-valued Message of an appropriate representation
 	// for the JournalSpec.
@@ -125,3 +148,41 @@ type BeginFinisher interface {
 	// return an error just because argument error is non-nil.
 	FinishTxn(Shard, Store, error) error
 }
+
+// BeforeCommitter is an optional interface of Application which may veto the
+// commit of a transaction, for example upon failing an invariant check of
+// the transaction's effects.
+type BeforeCommitter interface {
+	// BeforeCommit is called after FinalizeTxn, and before the transaction's
+	// write barrier and Store Flush of its |checkpoint| source journal offsets.
+	// A returned error aborts the transaction and fails the Shard, as do errors
+	// of ConsumeMessage and FinalizeTxn. |checkpoint| may not be retained.
+	BeforeCommit(shard Shard, store Store, checkpoint map[pb.Journal]int64) error
+}
+
+// ExternalOffsetStore is an optional interface of Application which mirrors
+// the source journal offsets of its Shards into an external system (such as
+// a SQL database), for coordination with other systems which read them.
+//
+// The Store remains the source of truth for the offsets of a Shard, and the
+// ExternalOffsetStore trails it: offsets of a committed transaction are stored
+// only after the transaction is durable within the Store (eg, its recovery log
+// write barrier has resolved), and a Shard failure may prevent the offsets
+// of its final transactions from being stored at all. The ExternalOffsetStore
+// thus never leads the Store, but may lag it.
+//
+// As a Shard is recovered, the Store's recovered offsets take precedence.
+// Loaded external offsets are used only for source journals which aren't
+// represented in the Store, allowing a Shard to begin consumption from
+// offsets established by another system.
+type ExternalOffsetStore interface {
+	// LoadOffsets returns the stored offsets of the Shard, or nil if none
+	// have been stored.
+	LoadOffsets(Shard) (map[pb.Journal]int64, error)
+	// StoreOffsets stores |offsets| of a committed transaction of the Shard.
+	// |offsets| include only source journals read by the transaction, and
+	// must be merged with prior stored offsets. StoreOffsets is called from
+	// the Shard's consumer loop, and blocks further transaction progress until
+	// it returns. A returned error fails the Shard.
+	StoreOffsets(shard Shard, offsets map[pb.Journal]int64) error
+}
@@ -80,17 +80,93 @@ func completePlayback(shard Shard, app Application, pl *recoverylog.Player,
 		return nil, nil, extendErr(err, "initializing store")
 	} else if offsets, err = store.FetchJournalOffsets(); err != nil {
 		return nil, nil, extendErr(err, "fetching journal offsets from store")
+	} else if offsets, err = loadExternalOffsets(shard, app, offsets); err != nil {
+		return nil, nil, extendErr(err, "loading external offsets")
 	} else if err = storeRecoveredHints(shard, recoveredHints, etcd); err != nil {
 		return nil, nil, extendErr(err, "storingRecoveredHints")
 	}
 
-	// Lower-bound each source to its ShardSpec.Source.MinOffset.
-	for _, src := range shard.Spec().Sources {
+	return store, lowerBoundOffsets(shard.Spec(), offsets), nil
+}
+
+// loadCheckpoint initializes the Application Store of a Shard having no
+// recovery log, and returns offsets of its external checkpoint at which
+// journal consumption should continue.
+func loadCheckpoint(shard Shard, app Application) (Store, map[pb.Journal]int64, error) {
+	var store, err = app.NewStore(shard, "", nil)
+	if err != nil {
+		return nil, nil, extendErr(err, "initializing store")
+	} else if store.Recorder() != nil {
+		return nil, nil, errors.Errorf("store of a shard without a recovery log must not have a Recorder")
+	}
+	offsets, err := store.FetchJournalOffsets()
+	if err != nil {
+		return nil, nil, extendErr(err, "fetching journal offsets from store")
+	} else if offsets, err = loadExternalOffsets(shard, app, offsets); err != nil {
+		return nil, nil, extendErr(err, "loading external offsets")
+	}
+	return store, lowerBoundOffsets(shard.Spec(), offsets), nil
+}
+
+// loadExternalOffsets extends |offsets| recovered from the Store with those
+// of an ExternalOffsetStore Application, for journals not in |offsets|.
+func loadExternalOffsets(shard Shard, app Application, offsets map[pb.Journal]int64) (map[pb.Journal]int64, error) {
+	var eos, ok = app.(ExternalOffsetStore)
+	if !ok {
+		return offsets, nil
+	}
+	var external, err = eos.LoadOffsets(shard)
+	if err != nil {
+		return nil, err
+	} else if offsets == nil {
+		offsets = make(map[pb.Journal]int64)
+	}
+	for journal, offset := range external {
+		if _, ok := offsets[journal]; !ok {
+			offsets[journal] = offset
+		}
+	}
+	return offsets, nil
+}
+
+// storeExternalOffsets stores offsets of the durably committed transaction
+// |txn| to an ExternalOffsetStore Application.
+func storeExternalOffsets(shard Shard, app Application, txn *transaction) error {
+	var eos, ok = app.(ExternalOffsetStore)
+	if !ok || len(txn.offsets) == 0 {
+		return nil
+	} else if txn.barrier != nil && txn.barrier.Err() != nil {
+		return nil // Not committed. The Shard will fail via its Recorder.
+	}
+	return eos.StoreOffsets(shard, txn.offsets)
+}
+
+// lowerBoundOffsets lower-bounds each source to its ShardSpec.Source.MinOffset.
+func lowerBoundOffsets(spec *pc.ShardSpec, offsets map[pb.Journal]int64) map[pb.Journal]int64 {
+	if offsets == nil {
+		offsets = make(map[pb.Journal]int64)
+	}
+	for _, src := range spec.Sources {
 		if offsets[src.Journal] < src.MinOffset {
 			offsets[src.Journal] = src.MinOffset
 		}
 	}
-	return store, offsets, nil
+	return offsets
+}
+
+// verifySourceLabels returns an error if the ContentType or MessageType
+// declared by the ShardSpec_Source don't match labels of its JournalSpec.
+func verifySourceLabels(src pc.ShardSpec_Source, spec *pb.JournalSpec) error {
+	for _, tc := range []struct{ name, expect string }{
+		{labels.ContentType, src.ContentType},
+		{labels.MessageType, src.MessageType},
+	} {
+		if actual := spec.LabelSet.ValueOf(tc.name); tc.expect != "" && tc.expect != actual {
+			return errors.Errorf("source journal %s label %s (%q) doesn't match the shard's declared source %s (%q)",
+				spec.Name, tc.name, actual, tc.name, tc.expect)
+		}
+	}
+	return nil
 }
 
 // pumpMessages reads and decodes messages from a Journal & offset into the provided channel.
@@ -99,24 +175,38 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 	if err != nil {
 		return extendErr(err, "fetching JournalSpec")
 	}
-	framing, err := message.FramingByContentType(spec.LabelSet.ValueOf(labels.ContentType))
-	if err != nil {
-		return extendErr(err, "determining framing (%s)", journal)
+	for _, src := range shard.Spec().Sources {
+		if src.Journal == journal {
+			if err = verifySourceLabels(src, spec); err != nil {
+				return err
+			}
+		}
 	}
-
 	var rr = client.NewRetryReader(shard.Context(), shard.JournalClient(), pb.ReadRequest{
 		Journal:    journal,
 		Offset:     offset,
 		Block:      true,
 		DoNotProxy: !shard.JournalClient().IsNoopRouter(),
 	})
-	var br = bufio.NewReader(rr)
+	var hr = &client.WriteHeadReader{RetryReader: rr}
+	var br = bufio.NewReader(hr)
+
+	// Framing is resolved for each frame, as Fragments of the journal may
+	// have ContentTypes which override that of the journal.
+	ff, err := message.NewFragmentFraming(spec, hr, br)
+	if err != nil {
+		return extendErr(err, "determining framing (%s)", journal)
+	}
 
 	for next := offset; ; offset = next {
+		var framing message.Framing
 		var frame []byte
 		var msg message.Message
 
-		if frame, err = framing.Unpack(br); err != nil {
+		if framing, err = ff.Next(); err == nil {
+			frame, err = framing.Unpack(br)
+		}
+		if err != nil {
 			// Swallow ErrNoProgress from our bufio.Reader. client.Reader returns
 			// an empty read to allow for inspection of the ReadResponse message,
 			// and client.RetryReader also surface these empty reads. A journal
@@ -136,6 +226,16 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 				next = rr.Offset()
 				continue
 			}
+			// ErrFrameTooLarge indicates an oversized (likely corrupt) frame was
+			// discarded. As with a message which fails to unmarshal, log an error
+			// but continue processing with the next frame.
+			if errors.Cause(err) == message.ErrFrameTooLarge {
+				log.WithFields(log.Fields{"journal": journal, "offset": offset, "err": err}).
+					Error("failed to unpack message")
+
+				next = rr.AdjustedOffset(br)
+				continue
+			}
 
 			return extendErr(err, "unpacking frame (%s:%d)", spec.Name, offset)
 		}
@@ -154,6 +254,7 @@ func pumpMessages(shard Shard, app Application, journal pb.Journal, offset int64
 			JournalSpec: spec,
 			Fragment:    rr.Reader.Response.Fragment,
 			NextOffset:  next,
+			WriteHead:   hr.WriteHead,
 			Message:     msg,
 		}: // Pass.
 		case <-shard.Context().Done():
@@ -180,6 +281,10 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 	}
 	var txn, prior transaction
 
+	// Journals having a recorded lag gauge, which are dropped upon return.
+	var lagged = make(map[pb.Journal]struct{})
+	defer dropLag(shard, lagged)
+
 	for {
 		select {
 		case <-hintsCh:
@@ -199,24 +304,34 @@ func consumeMessages(shard Shard, store Store, app Application, etcd *clientv3.C
 		txn.minDur, txn.maxDur = spec.MinTxnDuration, spec.MaxTxnDuration
 		txn.msgCh = msgCh
 		txn.offsets = make(map[pb.Journal]int64)
+		txn.writeHeads = make(map[pb.Journal]int64)
 
 		// Run the transaction until completion or error.
 		for done := false; !done && err == nil; done, err = txnStep(&txn, &prior, shard, store, app, timer) {
 		}
 		if err != nil {
 			err = extendErr(err, "txnStep")
+		} else if f := freshnessOf(shard); f != nil {
+			f.onCommit(&txn)
 		}
 		if ba, ok := app.(BeginFinisher); ok && txn.msgCount != 0 {
 			if finishErr := ba.FinishTxn(shard, store, err); err == nil && finishErr != nil {
 				err = extendErr(finishErr, "FinishTxn")
 			}
 		}
+		if txn.msgCount != 0 {
+			releaseTxn(shard)
+		}
+		if txn.interceptDone != nil {
+			txn.interceptDone(err)
+		}
 		if err != nil {
 			return
 		}
 
 		recordMetrics(&prior)
-		prior, txn = txn, transaction{doneCh: txn.barrier.Done()}
+		recordLag(shard, &txn, lagged)
+		prior, txn = txn, transaction{doneCh: txn.barrierDone()}
 	}
 }
 
@@ -384,7 +499,9 @@ type transaction struct {
 	msgCh          <-chan message.Envelope // Message source. Nil'd upon reaching |maxDur|.
 	msgCount       int                     // Number of messages batched into this transaction.
 	offsets        map[pb.Journal]int64    // End (exclusive) journal offsets of the transaction.
+	writeHeads     map[pb.Journal]int64    // Journal write heads, as of the last message of each journal.
 	doneCh         <-chan struct{}         // DoneCh of prior transaction barrier.
+	interceptDone  func(error)             // Completes Interceptors of the transaction.
 
 	beganAt     time.Time // Time at which transaction began.
 	stalledAt   time.Time // Time at which processing stalled while waiting on IO.
@@ -393,6 +510,22 @@ type transaction struct {
 	syncedAt    time.Time // Time at which txn |barrier| resolved.
 }
 
+// barrierDone returns the DoneCh of the transaction |barrier|. A transaction
+// of a Store without a Recorder has no barrier, as its checkpoint is already
+// durable upon commit, and a closed channel is returned.
+func (txn *transaction) barrierDone() <-chan struct{} {
+	if txn.barrier != nil {
+		return txn.barrier.Done()
+	}
+	return closedCh
+}
+
+var closedCh = func() chan struct{} {
+	var ch = make(chan struct{})
+	close(ch)
+	return ch
+}()
+
 // txnTimer is a time.Timer which can be mocked within unit tests.
 type txnTimer struct {
 	C     <-chan time.Time
@@ -412,18 +545,29 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 		select {
 		case msg := <-txn.msgCh:
 			if txn.msgCount == 0 {
+				if err = acquireTxn(shard); err != nil {
+					return
+				}
+				txn.interceptDone = interceptTxn(shard)
+
 				if ba, ok := app.(BeginFinisher); ok {
 					// BeginTxn may block arbitrarily.
 					if err = ba.BeginTxn(shard, store); err != nil {
 						err = extendErr(err, "app.BeginTxn")
+						releaseTxn(shard)
 						return
 					}
 				}
 				txn.beganAt = timeNow()
 				timer.Reset(txn.minDur)
+
+				if f := freshnessOf(shard); f != nil {
+					f.onBegin(txn.beganAt)
+				}
 			}
 			txn.msgCount++
 			txn.offsets[msg.JournalSpec.Name] = msg.NextOffset
+			txn.writeHeads[msg.JournalSpec.Name] = msg.WriteHead
 
 			if err = app.ConsumeMessage(shard, store, msg); err != nil {
 				err = extendErr(err, "app.ConsumeMessage")
@@ -448,6 +592,10 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 		case _ = <-txn.doneCh:
 			prior.syncedAt = timeNow()
 			txn.doneCh = nil
+
+			if err = storeExternalOffsets(shard, app, prior); err != nil {
+				err = extendErr(err, "storing external offsets")
+			}
 			return
 
 		case _ = <-shard.Context().Done():
@@ -463,6 +611,7 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	case msg := <-txn.msgCh:
 		txn.msgCount++
 		txn.offsets[msg.JournalSpec.Name] = msg.NextOffset
+		txn.writeHeads[msg.JournalSpec.Name] = msg.WriteHead
 
 		if err = app.ConsumeMessage(shard, store, msg); err != nil {
 			err = extendErr(err, "app.ConsumeMessage")
@@ -493,20 +642,38 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 		err = extendErr(err, "app.FinalizeTxn")
 		return
 	}
+	if bc, ok := app.(BeforeCommitter); ok {
+		if err = bc.BeforeCommit(shard, store, txn.offsets); err != nil {
+			err = extendErr(err, "app.BeforeCommit")
+			return
+		}
+	}
 
 	// Inject a strong write barrier which resolves only after pending writes
 	// to all journals have completed. We do this before store.Flush to ensure
 	// that writes driven by transaction messages have completed before we
-	// persist updated offsets which step past those messages.
-	store.Recorder().StrongBarrier()
+	// persist updated offsets which step past those messages. A Store without
+	// a Recorder persists directly, so we must instead block for those writes.
+	if rec := store.Recorder(); rec != nil {
+		rec.StrongBarrier()
+	} else {
+		client.WaitForPendingAppends(shard.JournalClient().PendingExcept(""))
+	}
 
 	if err = store.Flush(txn.offsets); err != nil {
 		err = extendErr(err, "store.Flush")
 		return
 	}
-	txn.barrier = store.Recorder().WeakBarrier()
+	if rec := store.Recorder(); rec != nil {
+		txn.barrier = rec.WeakBarrier()
+	}
 	txn.committedAt = timeNow()
 
+	if err = publishAck(shard, txn); err != nil {
+		err = extendErr(err, "publishAck")
+		return
+	}
+
 	// If the timer is still running, stop and drain it.
 	if txn.maxDur != -1 && !timer.Stop() {
 		<-timer.C
@@ -516,6 +683,37 @@ func txnStep(txn, prior *transaction, shard Shard, store Store, app Application,
 	return
 }
 
+// Acknowledgement is published to the AckJournal of a ShardSpec upon each
+// transaction commit, and reports the source journal offsets through which the
+// shard has consumed and durably checkpointed. Acknowledgements are JSON-encoded.
+type Acknowledgement struct {
+	Shard pc.ShardID `json:"shard"`
+	// Offsets of source journals read by the transaction. Journals which were
+	// not read by the transaction are omitted.
+	Offsets map[pb.Journal]int64 `json:"offsets"`
+}
+
+// publishAck of a committed transaction to the ShardSpec AckJournal, if any.
+// The append is ordered after the transaction's recovery log |barrier|, and
+// commits only after its checkpoint is durable.
+func publishAck(shard Shard, txn *transaction) error {
+	var spec = shard.Spec()
+	if spec.AckJournal == "" || len(txn.offsets) == 0 {
+		return nil
+	}
+	var aa *client.AsyncAppend
+	if txn.barrier != nil {
+		aa = shard.JournalClient().StartAppend(spec.AckJournal, txn.barrier)
+	} else {
+		aa = shard.JournalClient().StartAppend(spec.AckJournal) // Checkpoint is already durable.
+	}
+	aa.Require(json.NewEncoder(aa.Writer()).Encode(Acknowledgement{
+		Shard:   spec.Id,
+		Offsets: txn.offsets,
+	}))
+	return aa.Release()
+}
+
 // recordMetrics of a fully completed transaction.
 func recordMetrics(txn *transaction) {
 	metrics.GazetteConsumerTxCountTotal.Inc()
@@ -528,6 +726,30 @@ func recordMetrics(txn *transaction) {
 	metrics.GazetteConsumerTxSyncSecondsTotal.Add(txn.syncedAt.Sub(txn.committedAt).Seconds())
 }
 
+// recordLag of each source journal consumed by a completed transaction.
+// Recorded journals are added to |lagged|.
+func recordLag(shard Shard, txn *transaction, lagged map[pb.Journal]struct{}) {
+	for journal, offset := range txn.offsets {
+		var lag = txn.writeHeads[journal] - offset
+		if lag < 0 {
+			lag = 0 // Write head of the read pre-dates |offset|.
+		}
+		metrics.GazetteConsumerShardLagBytes.
+			WithLabelValues(shard.Spec().Id.String(), journal.String()).Set(float64(lag))
+		lagged[journal] = struct{}{}
+	}
+}
+
+// dropLag deletes the lag gauges of |lagged| journals, which are no longer
+// updated once the shard stops consuming.
+func dropLag(shard Shard, lagged map[pb.Journal]struct{}) {
+	for journal := range lagged {
+		metrics.GazetteConsumerShardLagBytes.
+			DeleteLabelValues(shard.Spec().Id.String(), journal.String())
+		delete(lagged, journal)
+	}
+}
+
 func extendErr(err error, mFmt string, args ...interface{}) error {
 	if err == nil {
 		panic("expected error")
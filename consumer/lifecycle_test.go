@@ -1,6 +1,7 @@
 package consumer
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,7 +9,9 @@ import (
 	"time"
 
 	gc "github.com/go-check/check"
+	dto "github.com/prometheus/client_model/go"
 	"go.etcd.io/etcd/clientv3"
+	"go.gazette.dev/core/broker/client"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/brokertest"
 	pc "go.gazette.dev/core/consumer/protocol"
@@ -16,6 +19,7 @@ import (
 	"go.gazette.dev/core/keyspace"
 	"go.gazette.dev/core/labels"
 	"go.gazette.dev/core/message"
+	"go.gazette.dev/core/metrics"
 )
 
 type LifecycleSuite struct{}
@@ -188,6 +192,9 @@ func (s *LifecycleSuite) TestMessagePump(c *gc.C) {
 
 	var off = r.spec.Sources[0].MinOffset
 
+	// The write head reported by the broker is passed through with each message.
+	expect.WriteHead = off + 116
+
 	// Expect pumpMessages continues to extract messages despite unmarshal errors.
 	expect.NextOffset, expect.Message = off+28, &testMessage{Key: "foo", Value: "bar"}
 	c.Check(<-msgCh, gc.DeepEquals, expect)
@@ -197,6 +204,38 @@ func (s *LifecycleSuite) TestMessagePump(c *gc.C) {
 	c.Check(<-msgCh, gc.DeepEquals, expect)
 }
 
+func (s *LifecycleSuite) TestMessagePumpWithMixedContentTypes(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var msgCh = make(chan message.Envelope, 128)
+
+	go func() {
+		var src = r.spec.Sources[0]
+		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, msgCh), gc.Equals, context.Canceled)
+	}()
+
+	// Append messages, with the middle one having a ContentType which
+	// overrides that of the journal.
+	var appendMsg = func(contentType string, framing message.Framing, msg *testMessage) {
+		var a = client.NewAppender(r.ctx, r.JournalClient(),
+			pb.AppendRequest{Journal: sourceA, ContentType: contentType})
+		var bw = bufio.NewWriter(a)
+		c.Check(framing.Marshal(msg, bw), gc.IsNil)
+		c.Check(bw.Flush(), gc.IsNil)
+		c.Check(a.Close(), gc.IsNil)
+	}
+	appendMsg("", message.JSONFraming, &testMessage{Key: "one"})
+	appendMsg(labels.ContentType_MessagePack, message.MsgPackFraming, &testMessage{Key: "two"})
+	appendMsg("", message.JSONFraming, &testMessage{Key: "three"})
+
+	// Expect each message is unmarshaled using the Framing of its Fragment.
+	for _, expect := range []string{"one", "two", "three"} {
+		var env = <-msgCh
+		c.Check(env.Message, gc.DeepEquals, &testMessage{Key: expect})
+	}
+}
+
 func (s *LifecycleSuite) TestMessagePumpConsumesOffsetJumpError(c *gc.C) {
 	var r, cleanup = newLifecycleTestFixture(c)
 	defer cleanup()
@@ -264,11 +303,12 @@ func (s *LifecycleSuite) TestTxnPriorSyncsThenMinDurElapses(c *gc.C) {
 
 	var priorDoneCh = make(chan struct{})
 	var prior, txn = transaction{}, transaction{
-		minDur:  3 * time.Second,
-		maxDur:  5 * time.Second,
-		msgCh:   msgCh,
-		offsets: make(map[pb.Journal]int64),
-		doneCh:  priorDoneCh,
+		minDur:     3 * time.Second,
+		maxDur:     5 * time.Second,
+		msgCh:      msgCh,
+		offsets:    make(map[pb.Journal]int64),
+		writeHeads: make(map[pb.Journal]int64),
+		doneCh:     priorDoneCh,
 	}
 
 	// Resolve prior commit before txn begins.
@@ -330,11 +370,12 @@ func (s *LifecycleSuite) TestTxnMinDurElapsesThenPriorSyncs(c *gc.C) {
 
 	var priorDoneCh = make(chan struct{})
 	var prior, txn = transaction{}, transaction{
-		minDur:  3 * time.Second,
-		maxDur:  5 * time.Second,
-		msgCh:   msgCh,
-		offsets: make(map[pb.Journal]int64),
-		doneCh:  priorDoneCh,
+		minDur:     3 * time.Second,
+		maxDur:     5 * time.Second,
+		msgCh:      msgCh,
+		offsets:    make(map[pb.Journal]int64),
+		writeHeads: make(map[pb.Journal]int64),
+		doneCh:     priorDoneCh,
 	}
 
 	// Initial message opens the txn.
@@ -395,11 +436,12 @@ func (s *LifecycleSuite) TestTxnMaxDurElapsesThenPriorSyncs(c *gc.C) {
 
 	var priorDoneCh = make(chan struct{})
 	var prior, txn = transaction{}, transaction{
-		minDur:  3 * time.Second,
-		maxDur:  5 * time.Second,
-		msgCh:   msgCh,
-		offsets: make(map[pb.Journal]int64),
-		doneCh:  priorDoneCh,
+		minDur:     3 * time.Second,
+		maxDur:     5 * time.Second,
+		msgCh:      msgCh,
+		offsets:    make(map[pb.Journal]int64),
+		writeHeads: make(map[pb.Journal]int64),
+		doneCh:     priorDoneCh,
 	}
 
 	// Initial message opens the txn.
@@ -462,11 +504,12 @@ func (s *LifecycleSuite) TestTxnMaxDurElapsesBusyPipeline(c *gc.C) {
 
 	var priorDoneCh = make(chan struct{})
 	var prior, txn = transaction{}, transaction{
-		minDur:  3 * time.Second,
-		maxDur:  5 * time.Second,
-		msgCh:   msgCh,
-		offsets: make(map[pb.Journal]int64),
-		doneCh:  priorDoneCh,
+		minDur:     3 * time.Second,
+		maxDur:     5 * time.Second,
+		msgCh:      msgCh,
+		offsets:    make(map[pb.Journal]int64),
+		writeHeads: make(map[pb.Journal]int64),
+		doneCh:     priorDoneCh,
 	}
 
 	// Initial message opens the txn.
@@ -551,11 +594,12 @@ func (s *LifecycleSuite) TestTxnCancelledAfterStart(c *gc.C) {
 
 	var priorDoneCh = make(chan struct{})
 	var prior, txn = transaction{}, transaction{
-		minDur:  3 * time.Second,
-		maxDur:  5 * time.Second,
-		msgCh:   msgCh,
-		offsets: make(map[pb.Journal]int64),
-		doneCh:  priorDoneCh,
+		minDur:     3 * time.Second,
+		maxDur:     5 * time.Second,
+		msgCh:      msgCh,
+		offsets:    make(map[pb.Journal]int64),
+		writeHeads: make(map[pb.Journal]int64),
+		doneCh:     priorDoneCh,
 	}
 
 	// Initial message opens the txn.
@@ -624,6 +668,21 @@ func (s *LifecycleSuite) TestConsumeErrorCases(c *gc.C) {
 		gc.ErrorMatches, `txnStep: app.FinalizeTxn: finalize error`)
 
 	<-finishCh // Expect FinishTxn was still called and |finishCh| closed.
+	app.finalizeErr = nil
+
+	// Case: BeforeCommit vetoes the commit.
+	finishCh = app.finishCh
+	app.commitErr = errors.New("commit error")
+
+	sendMsgFixture(msgCh, false, 200)
+	c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil),
+		gc.ErrorMatches, `txnStep: app.BeforeCommit: commit error`)
+
+	<-finishCh
+	// Expect the vetoed checkpoint wasn't flushed.
+	var offsets, _ = r.store.FetchJournalOffsets()
+	c.Check(offsets[sourceA], gc.Not(gc.Equals), int64(200))
+	app.commitErr = nil
 
 	// Case: ConsumeMessage fails.
 	app.consumeErr = errors.New("consume error")
@@ -659,6 +718,142 @@ func (s *LifecycleSuite) TestPumpAndConsume(c *gc.C) {
 	runSomeTransactions(c, r)
 }
 
+func (s *LifecycleSuite) TestExternalOffsetStoreResume(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var app = &externalOffsetsApp{
+		testApplication: r.app.(*testApplication),
+		offsets:         map[pb.Journal]int64{sourceA: 1, sourceB: 789},
+		storedCh:        make(chan map[pb.Journal]int64, 16),
+	}
+
+	// Recover from an empty log. As the Store has no offsets, external
+	// offsets are used (and are lower-bound by the ShardSpec MinOffset).
+	go func() { c.Assert(playLog(r, r.player, r.etcd), gc.IsNil) }()
+
+	var store, offsets, err = completePlayback(r, app, r.player, r.etcd)
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{
+		sourceA: r.spec.Sources[0].MinOffset,
+		sourceB: 789,
+	})
+	r.store = store
+
+	// Consume transactions, and expect their offsets are stored as each commits.
+	var msgCh = make(chan message.Envelope, 128)
+
+	go func() {
+		c.Check(pumpMessages(r, app, sourceA, offsets[sourceA], msgCh), gc.Equals, context.Canceled)
+	}()
+	go func() {
+		c.Check(consumeMessages(r, r.store, app, r.etcd, msgCh, nil), gc.Equals, context.Canceled)
+	}()
+	runSomeTransactions(c, r)
+
+	var checkpoint, _ = r.store.FetchJournalOffsets()
+	for stored := range app.storedCh {
+		if stored[sourceA] == checkpoint[sourceA] {
+			c.Check(stored, gc.DeepEquals, map[pb.Journal]int64{sourceA: checkpoint[sourceA], sourceB: 789})
+			break
+		}
+		c.Check(stored[sourceA] < checkpoint[sourceA], gc.Equals, true)
+	}
+
+	// Upon a later recovery, offsets of the Store take precedence over
+	// those of the external store.
+	app.offsets[sourceA] = 1
+	offsets, err = loadExternalOffsets(r, app, checkpoint)
+	c.Check(err, gc.IsNil)
+	c.Check(offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: checkpoint[sourceA], sourceB: 789})
+}
+
+func (s *LifecycleSuite) TestTxnRecordsLag(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	var lag = func(journal pb.Journal) float64 {
+		var m dto.Metric
+		c.Assert(metrics.GazetteConsumerShardLagBytes.
+			WithLabelValues(r.Spec().Id.String(), journal.String()).Write(&m), gc.IsNil)
+		return m.GetGauge().GetValue()
+	}
+
+	var lagged = make(map[pb.Journal]struct{})
+
+	recordLag(r, &transaction{
+		offsets:    map[pb.Journal]int64{sourceA: 100, sourceB: 200},
+		writeHeads: map[pb.Journal]int64{sourceA: 150, sourceB: 200},
+	}, lagged)
+	c.Check(lag(sourceA), gc.Equals, 50.0)
+	c.Check(lag(sourceB), gc.Equals, 0.0)
+
+	// A later transaction which doesn't read |sourceB| leaves its lag unchanged.
+	// A write head which pre-dates the consumed offset is treated as caught-up.
+	recordLag(r, &transaction{
+		offsets:    map[pb.Journal]int64{sourceA: 175},
+		writeHeads: map[pb.Journal]int64{sourceA: 150},
+	}, lagged)
+	c.Check(lag(sourceA), gc.Equals, 0.0)
+	c.Check(lag(sourceB), gc.Equals, 0.0)
+	c.Check(lagged, gc.HasLen, 2)
+
+	// Dropping lag deletes the gauges of recorded journals.
+	dropLag(r, lagged)
+	c.Check(lagged, gc.HasLen, 0)
+
+	for _, journal := range []pb.Journal{sourceA, sourceB} {
+		c.Check(metrics.GazetteConsumerShardLagBytes.
+			DeleteLabelValues(r.Spec().Id.String(), journal.String()), gc.Equals, false)
+	}
+}
+
+func (s *LifecycleSuite) TestConsumePublishesAcks(c *gc.C) {
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+
+	// Publish acknowledgements of |shardA| into |sourceB|, which it doesn't read.
+	var spec = *r.spec
+	spec.Sources, spec.AckJournal = spec.Sources[:1], sourceB
+	r.spec = &spec
+
+	playAndComplete(c, r)
+	var msgCh = make(chan message.Envelope, 128)
+
+	go func() {
+		var src = r.spec.Sources[0]
+		c.Check(pumpMessages(r, r.app, src.Journal, src.MinOffset, msgCh), gc.Equals, context.Canceled)
+	}()
+	go func() {
+		c.Check(consumeMessages(r, r.store, r.app, r.etcd, msgCh, nil), gc.Equals, context.Canceled)
+	}()
+
+	runSomeTransactions(c, r)
+
+	// Determine the write head of |sourceA|, through which all content was consumed.
+	var rr = client.NewReader(r.ctx, r.JournalClient(), pb.ReadRequest{
+		Journal:      sourceA,
+		Offset:       -1,
+		MetadataOnly: true,
+	})
+	var _, err = rr.Read(nil)
+	c.Check(err, gc.Equals, client.ErrOffsetNotYetAvailable)
+	var writeHead = rr.Response.WriteHead
+
+	// As a producer, read acknowledgements until one covers |writeHead|.
+	var dec = json.NewDecoder(client.NewRetryReader(r.ctx, r.JournalClient(),
+		pb.ReadRequest{Journal: sourceB, Block: true}))
+
+	for last := int64(0); last != writeHead; {
+		var ack Acknowledgement
+		c.Assert(dec.Decode(&ack), gc.IsNil)
+
+		c.Check(ack.Shard, gc.Equals, pc.ShardID(shardA))
+		c.Check(ack.Offsets[sourceA] > last, gc.Equals, true) // Strictly increasing.
+		last = ack.Offsets[sourceA]
+	}
+}
+
 func (s *LifecycleSuite) TestFetchJournalSpec(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -794,6 +989,30 @@ func newTestTimer() (*testTimer, func()) {
 
 func (t testTimer) signal() { t.ch <- t.timepoint }
 
+// externalOffsetsApp is a testApplication which is an ExternalOffsetStore.
+type externalOffsetsApp struct {
+	*testApplication
+	offsets  map[pb.Journal]int64
+	storedCh chan map[pb.Journal]int64
+}
+
+func (a *externalOffsetsApp) LoadOffsets(Shard) (map[pb.Journal]int64, error) {
+	var out = make(map[pb.Journal]int64)
+	for j, o := range a.offsets {
+		out[j] = o
+	}
+	return out, nil
+}
+
+func (a *externalOffsetsApp) StoreOffsets(_ Shard, offsets map[pb.Journal]int64) error {
+	for j, o := range offsets {
+		a.offsets[j] = o
+	}
+	var out, _ = a.LoadOffsets(nil)
+	a.storedCh <- out
+	return nil
+}
+
 func playAndComplete(c *gc.C, r *Replica) {
 	go func() { c.Assert(playLog(r, r.player, r.etcd), gc.IsNil) }()
 
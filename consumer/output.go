@@ -0,0 +1,167 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/message"
+)
+
+// OutputMapping declares the mapping of derived messages, produced by a Shard
+// in the course of consuming its source journals, to output journals. Typical
+// OutputMappings are built from the message package's RandomMapping,
+// ModuloMapping, or RendezvousMapping, often keyed on a computed attribute of
+// the derived message and partitioned over a client.PolledList of journals.
+type OutputMapping message.MappingFunc
+
+// Publish |msg| to the output journal selected by the OutputMapping. The
+// message is appended via the Shard's AsyncJournalClient, and as such the
+// commit of the current consumer transaction is made dependent upon the
+// append: read offsets of the transaction are persisted only after the
+// published message has also been committed to its output journal.
+func (m OutputMapping) Publish(shard Shard, msg message.Message) (*client.AsyncAppend, error) {
+	return message.Publish(shard.JournalClient(), message.MappingFunc(m), msg)
+}
+
+// PublishAll publishes each of |msgs| to the output journal selected by the
+// OutputMapping. See Publish. It returns the first encountered error.
+func (m OutputMapping) PublishAll(shard Shard, msgs ...message.Message) error {
+	for _, msg := range msgs {
+		if _, err := m.Publish(shard, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OutputRateLimiter throttles the rate at which messages are published to
+// each output journal. Each journal has an independent token bucket which
+// refills at Rate messages per second, up to Burst messages.
+//
+// Rather than dropping messages, a publish which exceeds the rate blocks until
+// the journal's bucket permits it. As publishes happen within the consumer
+// transaction, blocking applies backpressure to the consume loop, and input
+// consumption slows correspondingly. Transaction atomicity is unaffected:
+// every published message is still appended, and the transaction commits only
+// after all of its appends have.
+type OutputRateLimiter struct {
+	rate, burst float64
+
+	mu      sync.Mutex
+	buckets map[pb.Journal]*outputBucket
+}
+
+type outputBucket struct {
+	tokens float64
+	at     time.Time
+}
+
+// NewOutputRateLimiter returns an OutputRateLimiter permitting |rate| messages
+// per second to each output journal, with bursts of up to |burst| messages.
+// A |rate| of zero disables limiting.
+func NewOutputRateLimiter(rate float64, burst int) *OutputRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &OutputRateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[pb.Journal]*outputBucket),
+	}
+}
+
+// Wait blocks until a message may be published to |journal|, or until
+// |ctx| is done (in which case its reservation is released, and its error
+// is returned).
+func (l *OutputRateLimiter) Wait(ctx context.Context, journal pb.Journal) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	var delay = l.reserve(journal, time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	var timer = time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(journal)
+		return ctx.Err()
+	}
+}
+
+// reserve a token of |journal|'s bucket at time |now|, returning the delay
+// before the reservation may be used.
+func (l *OutputRateLimiter) reserve(journal pb.Journal, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var b, ok = l.buckets[journal]
+	if !ok {
+		b = &outputBucket{tokens: l.burst, at: now}
+		l.buckets[journal] = b
+	} else if now.After(b.at) {
+		b.tokens += now.Sub(b.at).Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.at = now
+	}
+	// Tokens may go negative, reflecting reservations by earlier waiters
+	// which have yet to be used.
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / l.rate * float64(time.Second))
+}
+
+// release a token of |journal|'s bucket which was reserved but not used.
+func (l *OutputRateLimiter) release(journal pb.Journal) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[journal]; ok {
+		if b.tokens++; b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+	}
+}
+
+// RateLimitedOutput is an OutputMapping whose publishes are throttled by an
+// OutputRateLimiter.
+type RateLimitedOutput struct {
+	Mapping OutputMapping
+	Limiter *OutputRateLimiter
+}
+
+// Publish |msg| to the output journal selected by the Mapping, first waiting
+// for the Limiter to permit a publish to that journal. See OutputMapping.Publish.
+func (o RateLimitedOutput) Publish(shard Shard, msg message.Message) (*client.AsyncAppend, error) {
+	return message.Publish(shard.JournalClient(), func(msg message.Message) (pb.Journal, message.Framing, error) {
+		var journal, framing, err = o.Mapping(msg)
+		if err == nil {
+			err = o.Limiter.Wait(shard.Context(), journal)
+		}
+		return journal, framing, err
+	}, msg)
+}
+
+// PublishAll publishes each of |msgs|. See Publish. It returns the first
+// encountered error.
+func (o RateLimitedOutput) PublishAll(shard Shard, msgs ...message.Message) error {
+	for _, msg := range msgs {
+		if _, err := o.Publish(shard, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
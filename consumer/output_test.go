@@ -0,0 +1,219 @@
+package consumer
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"time"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/labels"
+	"go.gazette.dev/core/message"
+)
+
+type OutputSuite struct{}
+
+func (s *OutputSuite) TestPublishToMappedOutputs(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var outputs = &pb.ListResponse{}
+	for _, name := range []pb.Journal{"output/A", "output/B", "output/C"} {
+		var spec = brokertest.Journal(pb.JournalSpec{
+			Name:     name,
+			LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+		})
+		brokertest.CreateJournals(c, tf.broker, spec)
+		outputs.Journals = append(outputs.Journals, pb.ListResponse_Journal{Spec: *spec})
+	}
+
+	var r = NewReplica(tf.app, tf.ks, tf.etcd, tf.service.Journals)
+	defer r.cancel()
+
+	// Map derived messages on their computed Key.
+	var mapping = OutputMapping(message.ModuloMapping(
+		func(msg message.Message, b []byte) []byte { return append(b, msg.(*testMessage).Key...) },
+		func() *pb.ListResponse { return outputs },
+	))
+
+	var fixtures = []message.Message{
+		&testMessage{Key: "one", Value: "1"},
+		&testMessage{Key: "two", Value: "2"},
+		&testMessage{Key: "three", Value: "3"},
+		&testMessage{Key: "four", Value: "4"},
+		&testMessage{Key: "five", Value: "5"},
+		&testMessage{Key: "six", Value: "6"},
+	}
+	c.Check(mapping.PublishAll(r, fixtures...), gc.IsNil)
+	client.WaitForPendingAppends(r.JournalClient().PendingExcept(""))
+
+	// Expect each message was written to its mapped journal.
+	var expect = make(map[pb.Journal][]testMessage)
+	for _, msg := range fixtures {
+		var journal, _, err = message.MappingFunc(mapping)(msg)
+		c.Assert(err, gc.IsNil)
+		expect[journal] = append(expect[journal], *msg.(*testMessage))
+	}
+	c.Check(len(expect) > 1, gc.Equals, true) // Multiple outputs were used.
+
+	for _, out := range outputs.Journals {
+		var br = bufio.NewReader(client.NewReader(context.Background(), tf.service.Journals,
+			pb.ReadRequest{Journal: out.Spec.Name}))
+
+		var actual []testMessage
+		for {
+			var line, err = message.UnpackLine(br)
+			if err == client.ErrOffsetNotYetAvailable {
+				break
+			}
+			c.Assert(err, gc.IsNil)
+
+			var msg testMessage
+			c.Check(message.JSONFraming.Unmarshal(line, &msg), gc.IsNil)
+			actual = append(actual, msg)
+		}
+		c.Check(actual, gc.DeepEquals, expect[out.Spec.Name])
+	}
+}
+
+func (s *OutputSuite) TestMappingAndValidationErrors(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var r = NewReplica(tf.app, tf.ks, tf.etcd, tf.service.Journals)
+	defer r.cancel()
+
+	var mapping = OutputMapping(message.RandomMapping(
+		func() *pb.ListResponse { return new(pb.ListResponse) }))
+
+	var _, err = mapping.Publish(r, &testMessage{Key: "key"})
+	c.Check(err, gc.Equals, message.ErrEmptyListResponse)
+	c.Check(mapping.PublishAll(r, &testMessage{Key: "key"}), gc.Equals, message.ErrEmptyListResponse)
+}
+
+func (s *OutputSuite) TestRateLimitedPublishSlowsConsumption(c *gc.C) {
+	const rate, burst, count = 50, 5, 30
+
+	var r, cleanup = newLifecycleTestFixture(c)
+	defer cleanup()
+	playAndComplete(c, r)
+
+	// Publish each consumed message to |sourceB|, which is used as an output.
+	var app = &publishingApplication{
+		testApplication: r.app.(*testApplication),
+		output: RateLimitedOutput{
+			Mapping: func(message.Message) (pb.Journal, message.Framing, error) {
+				return sourceB, message.JSONFraming, nil
+			},
+			Limiter: NewOutputRateLimiter(rate, burst),
+		},
+		publishedCh: make(chan struct{}, count),
+	}
+	var msgCh = make(chan message.Envelope)
+	var doneCh = make(chan struct{})
+
+	go func() {
+		c.Check(consumeMessages(r, r.store, app, r.etcd, msgCh, nil), gc.Equals, context.Canceled)
+		close(doneCh)
+	}()
+
+	// Messages are sent to an unbuffered channel, so the input rate is bounded
+	// by the rate at which the consume loop accepts them.
+	var start = time.Now()
+	for i := 0; i != count; i++ {
+		sendMsgFixture(msgCh, false, int64(100+i))
+	}
+	var inputDur = time.Since(start)
+
+	for i := 0; i != count; i++ {
+		<-app.publishedCh
+	}
+	var outputDur = time.Since(start)
+	client.WaitForPendingAppends(r.JournalClient().PendingExcept(""))
+
+	// Beyond the initial burst, output is limited to |rate|, and input slowed
+	// to match: every message but the last was published before the last was
+	// accepted.
+	var minDur = time.Duration(float64(count-burst) / rate * float64(time.Second))
+	c.Check(outputDur >= minDur, gc.Equals, true, gc.Commentf("output %s", outputDur))
+	c.Check(inputDur >= minDur-time.Second/rate, gc.Equals, true, gc.Commentf("input %s", inputDur))
+
+	// Expect all messages were published, in order.
+	var br = bufio.NewReader(client.NewReader(context.Background(), r.JournalClient(),
+		pb.ReadRequest{Journal: sourceB}))
+
+	for i := 0; i != count; i++ {
+		var line, err = message.UnpackLine(br)
+		c.Assert(err, gc.IsNil)
+
+		var msg testMessage
+		c.Check(message.JSONFraming.Unmarshal(line, &msg), gc.IsNil)
+		c.Check(msg.Value, gc.Equals, strconv.Itoa(100+i))
+	}
+
+	r.cancel()
+	<-doneCh
+}
+
+func (s *OutputSuite) TestRateLimiterBuckets(c *gc.C) {
+	var l = NewOutputRateLimiter(10, 2)
+	var now = time.Unix(1500000000, 0)
+
+	// The burst of each journal is available immediately.
+	c.Check(l.reserve("a/journal", now), gc.Equals, time.Duration(0))
+	c.Check(l.reserve("a/journal", now), gc.Equals, time.Duration(0))
+	c.Check(l.reserve("b/journal", now), gc.Equals, time.Duration(0))
+
+	// Further reservations are spaced at |rate|.
+	c.Check(l.reserve("a/journal", now), gc.Equals, 100*time.Millisecond)
+	c.Check(l.reserve("a/journal", now), gc.Equals, 200*time.Millisecond)
+
+	// Tokens refill over time, but not beyond the burst.
+	c.Check(l.reserve("a/journal", now.Add(time.Second)), gc.Equals, time.Duration(0))
+	c.Check(l.reserve("b/journal", now.Add(time.Hour)), gc.Equals, time.Duration(0))
+	c.Check(l.reserve("b/journal", now.Add(time.Hour)), gc.Equals, time.Duration(0))
+	c.Check(l.reserve("b/journal", now.Add(time.Hour)), gc.Equals, 100*time.Millisecond)
+
+	// Wait is aborted by context cancellation.
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	c.Check(l.Wait(ctx, "c/journal"), gc.IsNil) // Within burst.
+	c.Check(l.Wait(ctx, "c/journal"), gc.IsNil)
+	c.Check(l.Wait(ctx, "c/journal"), gc.Equals, context.Canceled)
+
+	// The token reserved by the cancelled Wait was released. Were it not,
+	// the bucket would be indebted by the reservation.
+	c.Check(l.buckets["c/journal"].tokens >= 0, gc.Equals, true)
+	c.Check(l.buckets["c/journal"].tokens < 1, gc.Equals, true)
+
+	// A zero rate is unlimited.
+	l = NewOutputRateLimiter(0, 0)
+	for i := 0; i != 10; i++ {
+		c.Check(l.Wait(ctx, "c/journal"), gc.IsNil)
+	}
+}
+
+// publishingApplication is a testApplication which also publishes each
+// consumed message to a RateLimitedOutput.
+type publishingApplication struct {
+	*testApplication
+	output      RateLimitedOutput
+	publishedCh chan struct{}
+}
+
+func (a *publishingApplication) ConsumeMessage(shard Shard, store Store, env message.Envelope) error {
+	if err := a.testApplication.ConsumeMessage(shard, store, env); err != nil {
+		return err
+	}
+	if _, err := a.output.Publish(shard, env.Message); err != nil {
+		return err
+	}
+	a.publishedCh <- struct{}{}
+	return nil
+}
+
+var _ = gc.Suite(&OutputSuite{})
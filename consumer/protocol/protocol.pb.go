@@ -49,6 +49,10 @@ const (
 	// expect_mod_revision of the UpdateRequest differs from the current
 	// ModRevision of the ShardSpec within the store.
 	Status_ETCD_TRANSACTION_FAILED Status = 4
+	// The shard primary was unable to meet the requested freshness: its state
+	// does not reflect content of its source journals which was available as of
+	// the max_staleness of the request.
+	Status_SHARD_STALE Status = 5
 )
 
 var Status_name = map[int32]string{
@@ -57,6 +61,7 @@ var Status_name = map[int32]string{
 	2: "NO_SHARD_PRIMARY",
 	3: "NOT_SHARD_PRIMARY",
 	4: "ETCD_TRANSACTION_FAILED",
+	5: "SHARD_STALE",
 }
 
 var Status_value = map[string]int32{
@@ -65,6 +70,7 @@ var Status_value = map[string]int32{
 	"NO_SHARD_PRIMARY":        2,
 	"NOT_SHARD_PRIMARY":       3,
 	"ETCD_TRANSACTION_FAILED": 4,
+	"SHARD_STALE":             5,
 }
 
 func (x Status) String() string {
@@ -127,6 +133,8 @@ type ShardSpec struct {
 	Sources []ShardSpec_Source `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources" yaml:",omitempty"`
 	// Prefix of the Journal into which the Shard's `recoverylog` will be recorded.
 	// The complete Journal name is built as "{recovery_log_prefix}/{shard_id}".
+	// If empty, the Shard has no recovery log, and its Store must instead
+	// checkpoint its offsets externally (see ExternalCheckpointStore).
 	RecoveryLogPrefix string `protobuf:"bytes,3,opt,name=recovery_log_prefix,json=recoveryLogPrefix,proto3" json:"recovery_log_prefix,omitempty" yaml:"recovery_log_prefix,omitempty"`
 	// Prefix of Etcd keys into which `recoverylog` FSMHints are written to and
 	// read from. FSMHints allow readers of the `recoverylog` to efficiently
@@ -194,6 +202,13 @@ type ShardSpec struct {
 	// User-defined Labels of this ShardSpec. The label "id" is reserved and may
 	// not be used with a ShardSpec's labels.
 	protocol.LabelSet `protobuf:"bytes,10,opt,name=labels,proto3,embedded=labels" json:"labels" yaml:",omitempty,inline"`
+	// Optional journal into which the Shard publishes acknowledgements of its
+	// consumption progress. Upon each transaction commit, the Shard appends a
+	// JSON-encoded acknowledgement of the source journal offsets through which
+	// it has consumed and durably checkpointed. The acknowledgement is written
+	// only after the checkpoint is durable within the recovery log. Producers
+	// may read this journal to confirm, or throttle upon, the Shard's progress.
+	AckJournal go_gazette_dev_core_broker_protocol.Journal `protobuf:"bytes,11,opt,name=ack_journal,json=ackJournal,proto3,casttype=go.gazette.dev/core/broker/protocol.Journal" json:"ack_journal,omitempty" yaml:"ack_journal,omitempty"`
 }
 
 func (m *ShardSpec) Reset()         { *m = ShardSpec{} }
@@ -247,6 +262,14 @@ type ShardSpec_Source struct {
 	// for shard initialization, directing it to skip over undesired historical
 	// sections of the journal.
 	MinOffset int64 `protobuf:"varint,3,opt,name=min_offset,json=minOffset,proto3" json:"min_offset,omitempty" yaml:"min_offset,omitempty"`
+	// Optional content-type which the journal is expected to have, as declared
+	// by its "content-type" label. If set and the journal's label differs, the
+	// shard fails upon assignment rather than reading mis-framed content.
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty" yaml:"content_type,omitempty"`
+	// Optional message schema which the journal is expected to have, as
+	// declared by its "message-type" label. If set and the journal's label
+	// differs, the shard fails upon assignment.
+	MessageType string `protobuf:"bytes,5,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty" yaml:"message_type,omitempty"`
 }
 
 func (m *ShardSpec_Source) Reset()         { *m = ShardSpec_Source{} }
@@ -624,6 +647,10 @@ type StatRequest struct {
 	Header *protocol.Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	// Shard to Stat.
 	Shard ShardID `protobuf:"bytes,2,opt,name=shard,proto3,casttype=ShardID" json:"shard,omitempty"`
+	// Optional maximum staleness of the shard. If non-zero, the shard primary
+	// must reflect all content of its source journals which was available at
+	// least max_staleness ago, or else Status SHARD_STALE is returned.
+	MaxStaleness time.Duration `protobuf:"bytes,3,opt,name=max_staleness,json=maxStaleness,proto3,stdduration" json:"max_staleness"`
 }
 
 func (m *StatRequest) Reset()         { *m = StatRequest{} }
@@ -701,6 +728,136 @@ func (m *StatResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StatResponse proto.InternalMessageInfo
 
+type LocateRequest struct {
+	// Shards to locate. If empty, all shards are located.
+	Shards []ShardID `protobuf:"bytes,1,rep,name=shards,proto3,casttype=ShardID" json:"shards,omitempty"`
+}
+
+func (m *LocateRequest) Reset()         { *m = LocateRequest{} }
+func (m *LocateRequest) String() string { return proto.CompactTextString(m) }
+func (*LocateRequest) ProtoMessage()    {}
+func (*LocateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6491fb50a1cefedd, []int{9}
+}
+func (m *LocateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LocateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocateRequest.Merge(m, src)
+}
+func (m *LocateRequest) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *LocateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocateRequest proto.InternalMessageInfo
+
+type LocateResponse struct {
+	// Status of the Locate RPC.
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=consumer.Status" json:"status,omitempty"`
+	// Header of the response, having the Etcd revision at which shards were
+	// located.
+	Header protocol.Header `protobuf:"bytes,2,opt,name=header,proto3" json:"header"`
+	// Shards of the response, ordered as the LocateRequest shards (or on
+	// shard ID, if all shards are located).
+	Shards []LocateResponse_Shard `protobuf:"bytes,3,rep,name=shards,proto3" json:"shards"`
+}
+
+func (m *LocateResponse) Reset()         { *m = LocateResponse{} }
+func (m *LocateResponse) String() string { return proto.CompactTextString(m) }
+func (*LocateResponse) ProtoMessage()    {}
+func (*LocateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6491fb50a1cefedd, []int{10}
+}
+func (m *LocateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LocateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocateResponse.Merge(m, src)
+}
+func (m *LocateResponse) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *LocateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocateResponse proto.InternalMessageInfo
+
+// Location of a shard.
+type LocateResponse_Shard struct {
+	// Status of the shard: OK if the shard has a primary, or SHARD_NOT_FOUND,
+	// or NO_SHARD_PRIMARY.
+	Status Status `protobuf:"varint,1,opt,name=status,proto3,enum=consumer.Status" json:"status,omitempty"`
+	// ID of the shard.
+	Id ShardID `protobuf:"bytes,2,opt,name=id,proto3,casttype=ShardID" json:"id,omitempty"`
+	// Route of the shard, including endpoints.
+	Route protocol.Route `protobuf:"bytes,3,opt,name=route,proto3" json:"route"`
+	// ProcessSpec ID of the shard primary. Zero-valued iff status is not OK.
+	Primary protocol.ProcessSpec_ID `protobuf:"bytes,4,opt,name=primary,proto3" json:"primary"`
+	// Status of each replica. Cardinality and ordering matches |route|.
+	Replicas []ReplicaStatus `protobuf:"bytes,5,rep,name=replicas,proto3" json:"replicas"`
+}
+
+func (m *LocateResponse_Shard) Reset()         { *m = LocateResponse_Shard{} }
+func (m *LocateResponse_Shard) String() string { return proto.CompactTextString(m) }
+func (*LocateResponse_Shard) ProtoMessage()    {}
+func (*LocateResponse_Shard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6491fb50a1cefedd, []int{10, 0}
+}
+func (m *LocateResponse_Shard) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LocateResponse_Shard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LocateResponse_Shard.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LocateResponse_Shard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocateResponse_Shard.Merge(m, src)
+}
+func (m *LocateResponse_Shard) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *LocateResponse_Shard) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocateResponse_Shard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocateResponse_Shard proto.InternalMessageInfo
+
 type GetHintsRequest struct {
 	// Shard to fetch hints for.
 	Shard ShardID `protobuf:"bytes,1,opt,name=shard,proto3,casttype=ShardID" json:"shard,omitempty"`
@@ -710,7 +867,7 @@ func (m *GetHintsRequest) Reset()         { *m = GetHintsRequest{} }
 func (m *GetHintsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHintsRequest) ProtoMessage()    {}
 func (*GetHintsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6491fb50a1cefedd, []int{9}
+	return fileDescriptor_6491fb50a1cefedd, []int{11}
 }
 func (m *GetHintsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -756,7 +913,7 @@ func (m *GetHintsResponse) Reset()         { *m = GetHintsResponse{} }
 func (m *GetHintsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHintsResponse) ProtoMessage()    {}
 func (*GetHintsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6491fb50a1cefedd, []int{10}
+	return fileDescriptor_6491fb50a1cefedd, []int{12}
 }
 func (m *GetHintsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -794,7 +951,7 @@ func (m *GetHintsResponse_ResponseHints) Reset()         { *m = GetHintsResponse
 func (m *GetHintsResponse_ResponseHints) String() string { return proto.CompactTextString(m) }
 func (*GetHintsResponse_ResponseHints) ProtoMessage()    {}
 func (*GetHintsResponse_ResponseHints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6491fb50a1cefedd, []int{10, 0}
+	return fileDescriptor_6491fb50a1cefedd, []int{12, 0}
 }
 func (m *GetHintsResponse_ResponseHints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -839,6 +996,9 @@ func init() {
 	proto.RegisterType((*StatRequest)(nil), "consumer.StatRequest")
 	proto.RegisterType((*StatResponse)(nil), "consumer.StatResponse")
 	proto.RegisterMapType((map[go_gazette_dev_core_broker_protocol.Journal]int64)(nil), "consumer.StatResponse.OffsetsEntry")
+	proto.RegisterType((*LocateRequest)(nil), "consumer.LocateRequest")
+	proto.RegisterType((*LocateResponse)(nil), "consumer.LocateResponse")
+	proto.RegisterType((*LocateResponse_Shard)(nil), "consumer.LocateResponse.Shard")
 	proto.RegisterType((*GetHintsRequest)(nil), "consumer.GetHintsRequest")
 	proto.RegisterType((*GetHintsResponse)(nil), "consumer.GetHintsResponse")
 	proto.RegisterType((*GetHintsResponse_ResponseHints)(nil), "consumer.GetHintsResponse.ResponseHints")
@@ -847,95 +1007,109 @@ func init() {
 func init() { proto.RegisterFile("consumer/protocol/protocol.proto", fileDescriptor_6491fb50a1cefedd) }
 
 var fileDescriptor_6491fb50a1cefedd = []byte{
-	// 1399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xb1, 0x6f, 0xdb, 0x46,
-	0x17, 0x37, 0x25, 0x59, 0xb2, 0x1f, 0xe5, 0x44, 0x3e, 0xc7, 0xb1, 0xa2, 0x24, 0x92, 0xcc, 0xe4,
-	0xfb, 0x20, 0x7c, 0x49, 0xa8, 0xc0, 0x5f, 0x03, 0xa4, 0x46, 0x5b, 0x40, 0xb4, 0xec, 0x58, 0x8d,
-	0x62, 0xbb, 0x94, 0x0b, 0xb4, 0x59, 0x08, 0x9a, 0x3c, 0xcb, 0x6c, 0x28, 0x1e, 0x4b, 0x52, 0x86,
-	0xd5, 0xb1, 0x40, 0x97, 0x4e, 0x01, 0xda, 0xa1, 0x63, 0xd1, 0xb9, 0x7f, 0x40, 0x97, 0xee, 0x1e,
-	0x83, 0x4e, 0x45, 0x07, 0x05, 0x8d, 0xfb, 0x17, 0x78, 0xcc, 0x54, 0xf0, 0xee, 0x48, 0x51, 0xb2,
-	0x3c, 0x64, 0xc8, 0x76, 0x7c, 0xef, 0xf7, 0x7e, 0xef, 0xde, 0xef, 0xee, 0xbd, 0x93, 0xa0, 0x6a,
-	0x10, 0xc7, 0xef, 0xf7, 0xb0, 0x57, 0x77, 0x3d, 0x12, 0x10, 0x83, 0xd8, 0xf1, 0x42, 0xa6, 0x0b,
-	0x34, 0x17, 0x21, 0x4a, 0xe5, 0x03, 0x8f, 0xbc, 0xb8, 0x1c, 0x59, 0xfa, 0x6f, 0xcc, 0xe5, 0x61,
-	0x83, 0x1c, 0x63, 0x6f, 0x60, 0x93, 0x2e, 0x5d, 0x7b, 0x26, 0x36, 0x35, 0xe2, 0x72, 0x5c, 0xd9,
-	0x0d, 0x06, 0x2e, 0xf6, 0xeb, 0x66, 0xdf, 0xd3, 0x03, 0x8b, 0x38, 0xf1, 0x82, 0xfb, 0xaf, 0x75,
-	0x49, 0x97, 0xd0, 0x65, 0x3d, 0x5c, 0x31, 0xab, 0xf4, 0x5b, 0x0e, 0xe6, 0x3b, 0x47, 0xba, 0x67,
-	0x76, 0x5c, 0x6c, 0xa0, 0x87, 0x90, 0xb2, 0xcc, 0xa2, 0x50, 0x15, 0x6a, 0xf3, 0x4a, 0xf5, 0x7c,
-	0x58, 0x59, 0x1c, 0xe8, 0x3d, 0x7b, 0x5d, 0xba, 0x4f, 0x7a, 0x56, 0x80, 0x7b, 0x6e, 0x30, 0x90,
-	0xde, 0x0e, 0x2b, 0x39, 0x8a, 0x6f, 0x35, 0xd5, 0x94, 0x65, 0xa2, 0x5d, 0xc8, 0xf9, 0xa4, 0xef,
-	0x19, 0xd8, 0x2f, 0xa6, 0xaa, 0xe9, 0x9a, 0xb8, 0x56, 0x92, 0xa3, 0xfd, 0xca, 0x31, 0xaf, 0xdc,
-	0xa1, 0x10, 0xe5, 0xc6, 0xe9, 0xb0, 0x32, 0x33, 0x95, 0x56, 0x8d, 0x58, 0xd0, 0x17, 0xb0, 0x14,
-	0xd5, 0xa9, 0xd9, 0xa4, 0xab, 0xb9, 0x1e, 0x3e, 0xb4, 0x4e, 0x8a, 0x69, 0xba, 0xa7, 0xda, 0xf9,
-	0xb0, 0x72, 0x97, 0x05, 0x4f, 0x01, 0x25, 0xf9, 0x16, 0x23, 0x7f, 0x9b, 0x74, 0xf7, 0xa8, 0x17,
-	0x35, 0x40, 0x3c, 0xb2, 0x9c, 0x20, 0x62, 0xcc, 0xc4, 0x55, 0xde, 0x62, 0x8c, 0x09, 0x67, 0x92,
-	0x09, 0x42, 0x3b, 0xa7, 0x68, 0x42, 0x9e, 0xa2, 0x0e, 0x74, 0xe3, 0x45, 0xdf, 0xf5, 0x8b, 0xb3,
-	0x55, 0xa1, 0x36, 0xab, 0xac, 0x9e, 0x0f, 0x2b, 0xb7, 0x13, 0x1c, 0xdc, 0x9b, 0x24, 0xa1, 0x99,
-	0x15, 0x66, 0x47, 0x1e, 0x14, 0x7a, 0xfa, 0x89, 0x16, 0x9c, 0x38, 0x5a, 0x74, 0x46, 0xc5, 0x6c,
-	0x55, 0xa8, 0x89, 0x6b, 0x37, 0xe4, 0x2e, 0x21, 0x5d, 0x1b, 0xb3, 0xc3, 0x39, 0xe8, 0x1f, 0xca,
-	0x4d, 0x0e, 0x50, 0x1e, 0x70, 0xed, 0x56, 0x59, 0xa2, 0x49, 0x82, 0x44, 0xb2, 0x9f, 0x5e, 0x57,
-	0x04, 0xf5, 0x4a, 0x4f, 0x3f, 0xd9, 0x3f, 0x71, 0xa2, 0x70, 0x9a, 0xd3, 0x72, 0xc6, 0x73, 0xe6,
-	0xde, 0x35, 0xe7, 0x04, 0xc1, 0xc5, 0x9c, 0x96, 0x93, 0xcc, 0x59, 0x87, 0x9c, 0x69, 0xf9, 0xfa,
-	0x81, 0x8d, 0x8b, 0x73, 0x55, 0xa1, 0x36, 0xa7, 0x2c, 0x5f, 0x72, 0xf6, 0x1c, 0x45, 0xe5, 0x25,
-	0x81, 0xe6, 0x07, 0xba, 0x63, 0x1e, 0x0c, 0xfc, 0xe2, 0x7c, 0x55, 0xa8, 0x2d, 0x8c, 0xc9, 0x9b,
-	0xf0, 0x8e, 0xcb, 0x4b, 0x82, 0x0e, 0xb7, 0xa3, 0x3d, 0xc8, 0xda, 0xfa, 0x01, 0xb6, 0xfd, 0x22,
-	0xd0, 0x02, 0x91, 0x1c, 0x77, 0x54, 0x3b, 0xb4, 0x77, 0x70, 0xa0, 0xdc, 0x0d, 0x2b, 0x7b, 0x35,
-	0xac, 0x08, 0xe7, 0xc3, 0x4a, 0x71, 0x72, 0x47, 0xf7, 0x2d, 0xc7, 0xb6, 0x1c, 0x2c, 0xa9, 0x9c,
-	0xa7, 0xf4, 0x83, 0x00, 0x59, 0x76, 0x85, 0x51, 0x0b, 0x72, 0x5f, 0x91, 0xbe, 0xe7, 0xe8, 0x36,
-	0x6f, 0x93, 0xfa, 0xdb, 0x61, 0xe5, 0x5e, 0x97, 0xc8, 0x5d, 0xfd, 0x1b, 0x1c, 0x04, 0x58, 0x36,
-	0xf1, 0x71, 0xdd, 0x20, 0x1e, 0xae, 0x4f, 0xb4, 0xb5, 0xfc, 0x29, 0x0b, 0x53, 0xa3, 0x78, 0xf4,
-	0x09, 0x40, 0xa8, 0x28, 0x39, 0x3c, 0xf4, 0x71, 0x40, 0x2f, 0x78, 0x5a, 0xa9, 0x9c, 0x0f, 0x2b,
-	0x37, 0x47, 0x6a, 0x33, 0x5f, 0xb2, 0xd2, 0xf9, 0x9e, 0xe5, 0xec, 0x52, 0xab, 0xf4, 0x9d, 0x00,
-	0xf9, 0x0d, 0xde, 0x6b, 0xb4, 0x7b, 0xf7, 0x21, 0xef, 0x7a, 0xc4, 0xc0, 0xbe, 0xaf, 0xf9, 0x2e,
-	0x36, 0xe8, 0x06, 0xc5, 0xb5, 0xe5, 0x51, 0xf9, 0x7b, 0xcc, 0x1b, 0x82, 0x95, 0x52, 0x42, 0x81,
-	0x2b, 0x5c, 0x81, 0xa8, 0x6e, 0xd1, 0x1d, 0x01, 0x51, 0x05, 0x44, 0x3f, 0x6c, 0x64, 0xcd, 0xb6,
-	0x7a, 0x56, 0x50, 0x4c, 0x85, 0x67, 0xa2, 0x02, 0x35, 0xb5, 0x43, 0x8b, 0xf4, 0x8b, 0x00, 0x0b,
-	0x2a, 0x76, 0x6d, 0xcb, 0xd0, 0x3b, 0x81, 0x1e, 0xf4, 0x7d, 0xf4, 0x10, 0x32, 0x06, 0x31, 0x31,
-	0xdd, 0xc0, 0x95, 0xb5, 0x5b, 0xa3, 0x89, 0x30, 0x06, 0x93, 0x37, 0x88, 0x89, 0x55, 0x8a, 0x44,
-	0xd7, 0x21, 0x8b, 0x3d, 0x8f, 0x78, 0x6c, 0x8a, 0xcc, 0xab, 0xfc, 0x4b, 0x7a, 0x02, 0x99, 0x10,
-	0x85, 0xe6, 0x20, 0xd3, 0x6a, 0xb6, 0x37, 0x0b, 0x33, 0x28, 0x0f, 0x73, 0x4a, 0x63, 0xe3, 0xe9,
-	0x56, 0xab, 0xdd, 0x2e, 0x98, 0x28, 0x0f, 0xb9, 0xfd, 0x46, 0xab, 0xdd, 0xda, 0x79, 0x52, 0x38,
-	0x15, 0xc2, 0xaf, 0x3d, 0xb5, 0xf5, 0xac, 0xa1, 0x7e, 0x59, 0xf8, 0x35, 0x85, 0x44, 0xc8, 0x6e,
-	0x35, 0x5a, 0xed, 0xcd, 0x66, 0xe1, 0x65, 0x5a, 0xda, 0x06, 0xb1, 0x6d, 0xf9, 0x81, 0x8a, 0xbf,
-	0xee, 0x63, 0x3f, 0x40, 0x1f, 0xc2, 0x9c, 0x8f, 0x6d, 0x6c, 0x04, 0xc4, 0xe3, 0x32, 0xad, 0x5c,
-	0xb8, 0x25, 0xcc, 0xad, 0x64, 0x42, 0xa1, 0xd4, 0x18, 0x2e, 0xfd, 0x93, 0x82, 0x3c, 0xa3, 0xf2,
-	0x5d, 0xe2, 0xf8, 0x18, 0xd5, 0x20, 0xeb, 0xd3, 0x82, 0x78, 0xbd, 0x85, 0xc4, 0x04, 0xa4, 0x76,
-	0x95, 0xfb, 0x91, 0x0c, 0xd9, 0x23, 0xac, 0x9b, 0xd8, 0xa3, 0x2a, 0x8a, 0x6b, 0x85, 0x51, 0xce,
-	0x6d, 0x6a, 0xe7, 0xc9, 0x38, 0x0a, 0xad, 0x43, 0x96, 0xea, 0xec, 0x17, 0xd3, 0x74, 0xb6, 0x26,
-	0x94, 0x4c, 0xee, 0x80, 0x0d, 0xda, 0x28, 0x96, 0x45, 0x94, 0x7e, 0x17, 0x60, 0x96, 0xda, 0xd1,
-	0x03, 0xc8, 0x24, 0xae, 0xc3, 0xd2, 0x94, 0xf9, 0xcc, 0x43, 0x29, 0x0c, 0xad, 0x42, 0xbe, 0x47,
-	0x4c, 0xcd, 0xc3, 0xc7, 0x96, 0x1f, 0x4e, 0x89, 0x70, 0xab, 0x69, 0x55, 0xec, 0x11, 0x53, 0xe5,
-	0x26, 0x74, 0x0f, 0x66, 0x3d, 0xd2, 0x0f, 0x30, 0xbd, 0xb4, 0xe2, 0xda, 0xd5, 0x51, 0x19, 0x6a,
-	0x68, 0xe6, 0x74, 0x0c, 0x83, 0x1e, 0xc5, 0xf2, 0x64, 0x68, 0x11, 0x2b, 0x97, 0x5c, 0x87, 0x78,
-	0xff, 0xf4, 0x4b, 0xfa, 0x4b, 0x80, 0x7c, 0xc3, 0x75, 0xed, 0x41, 0x74, 0x64, 0x1f, 0x43, 0xce,
-	0x38, 0xd2, 0x9d, 0x2e, 0x0e, 0x75, 0x0e, 0x89, 0x6e, 0x8f, 0x88, 0x92, 0x40, 0x79, 0x83, 0xa2,
-	0x38, 0x5d, 0x14, 0x53, 0xfa, 0x5e, 0x80, 0x2c, 0xf3, 0x20, 0x19, 0x96, 0xf0, 0x89, 0x8b, 0x8d,
-	0x40, 0x1b, 0x2b, 0x54, 0xa0, 0x85, 0x2e, 0x32, 0xd7, 0xb3, 0xb1, 0x72, 0xb3, 0x7d, 0xd7, 0xc7,
-	0x5e, 0xc0, 0x8f, 0x6d, 0x9a, 0x84, 0x2a, 0x87, 0xa0, 0x3b, 0x90, 0x35, 0xb1, 0x8d, 0xb9, 0x38,
-	0xf3, 0x8a, 0x98, 0x7c, 0x31, 0xb9, 0x4b, 0xb2, 0x60, 0x81, 0x6f, 0xf9, 0x7d, 0xdf, 0x21, 0xe9,
-	0x39, 0x88, 0x21, 0x43, 0xa4, 0x62, 0x2d, 0x0e, 0x17, 0xa6, 0x87, 0xc7, 0x97, 0x6f, 0x15, 0x66,
-	0xe9, 0x55, 0xa2, 0x79, 0x26, 0xea, 0x60, 0x1e, 0xe9, 0xc7, 0x14, 0xe4, 0x19, 0xf9, 0x7b, 0x6f,
-	0x05, 0x07, 0x72, 0x6c, 0x18, 0x46, 0xbd, 0x70, 0x67, 0x9c, 0x3a, 0xee, 0x05, 0x36, 0x1c, 0xfd,
-	0x4d, 0x27, 0xf0, 0x06, 0x4a, 0xfd, 0xdb, 0xd7, 0xef, 0x38, 0x9c, 0x79, 0x92, 0xd2, 0x3a, 0xe4,
-	0x93, 0x4c, 0xa8, 0x00, 0xe9, 0x17, 0x78, 0xc0, 0x66, 0xbe, 0x1a, 0x2e, 0xd1, 0x35, 0x98, 0x3d,
-	0xd6, 0xed, 0x3e, 0xe6, 0x0d, 0xc2, 0x3e, 0xd6, 0x53, 0x8f, 0x05, 0xe9, 0x03, 0xb8, 0xfa, 0x04,
-	0x07, 0xdb, 0x96, 0x13, 0xf8, 0x91, 0xec, 0xb1, 0x98, 0xc2, 0xa5, 0x62, 0xfe, 0x91, 0x82, 0xc2,
-	0x28, 0xec, 0xbd, 0x0b, 0xda, 0x81, 0x05, 0xd7, 0xb3, 0x7a, 0xba, 0x37, 0xd0, 0xc2, 0xdf, 0x26,
-	0x3e, 0xef, 0xe5, 0xda, 0x28, 0xc1, 0xe4, 0x66, 0xe4, 0x68, 0x41, 0xad, 0x9c, 0x2e, 0xcf, 0x49,
-	0xa8, 0x0d, 0x7d, 0x06, 0x79, 0xf6, 0xe3, 0x87, 0x73, 0xb2, 0x8e, 0x7f, 0x57, 0x4e, 0x91, 0x71,
-	0x50, 0x53, 0xe9, 0xa3, 0xf0, 0x71, 0x49, 0x60, 0xc2, 0xe1, 0xc3, 0xc8, 0xa3, 0xe7, 0x2d, 0xf1,
-	0xb3, 0x58, 0xde, 0xea, 0x3c, 0x63, 0xfc, 0x0c, 0xf3, 0x3f, 0x02, 0x59, 0xfe, 0x26, 0x65, 0x21,
-	0xb5, 0xfb, 0xb4, 0x30, 0x83, 0x96, 0xe0, 0x6a, 0x67, 0xbb, 0xa1, 0x36, 0xb5, 0x9d, 0xdd, 0x7d,
-	0x6d, 0x6b, 0xf7, 0xf3, 0x9d, 0x66, 0x41, 0x40, 0xd7, 0xa0, 0xb0, 0xb3, 0xab, 0x31, 0x7b, 0xf4,
-	0x82, 0xa4, 0xd0, 0x32, 0x2c, 0x86, 0xa0, 0x71, 0x73, 0x1a, 0xdd, 0x84, 0x95, 0xcd, 0xfd, 0x8d,
-	0xa6, 0xb6, 0xaf, 0x36, 0x76, 0x3a, 0x8d, 0x8d, 0xfd, 0xd6, 0xee, 0x8e, 0xc6, 0x1f, 0x9a, 0xcc,
-	0xda, 0x79, 0x3c, 0x76, 0x1f, 0x41, 0x26, 0x4c, 0x8d, 0x96, 0x27, 0x2f, 0x2a, 0xbd, 0x11, 0xa5,
-	0xeb, 0xd3, 0xef, 0x6f, 0x18, 0x16, 0xce, 0xf6, 0x64, 0x58, 0xe2, 0xe1, 0x4a, 0x86, 0x8d, 0x3d,
-	0x42, 0x8f, 0x61, 0x96, 0x4e, 0x14, 0x74, 0x7d, 0xfa, 0x54, 0x2c, 0xad, 0x5c, 0xb0, 0xf3, 0xc8,
-	0x06, 0xcc, 0x45, 0xa7, 0x82, 0x6e, 0x4c, 0x3b, 0x29, 0x16, 0x5f, 0xba, 0xfc, 0x10, 0x95, 0x8d,
-	0xd3, 0xbf, 0xcb, 0x33, 0xa7, 0x6f, 0xca, 0xc2, 0xab, 0x37, 0x65, 0xe1, 0xe5, 0x59, 0x79, 0xe6,
-	0xe7, 0xb3, 0xb2, 0xf0, 0xea, 0xac, 0x3c, 0xf3, 0xe7, 0x59, 0x79, 0xe6, 0xf9, 0x7f, 0xa6, 0x35,
-	0xe0, 0x85, 0x3f, 0x48, 0x07, 0x59, 0xba, 0xfa, 0xff, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x9d,
-	0x6c, 0xf7, 0x37, 0x3c, 0x0d, 0x00, 0x00,
+	// 1621 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x8f, 0xe3, 0x58,
+	0x11, 0x6f, 0xe7, 0xbb, 0xcb, 0xe9, 0x99, 0xcc, 0x9b, 0x8f, 0xf6, 0x64, 0x76, 0x93, 0x8c, 0x67,
+	0x41, 0x11, 0xbb, 0xeb, 0xac, 0xc2, 0xae, 0x34, 0x0c, 0x0b, 0x52, 0xd2, 0xe9, 0x99, 0x0e, 0x9b,
+	0xe9, 0x0c, 0x4e, 0x90, 0x80, 0x8b, 0xe5, 0xb6, 0xdf, 0x64, 0x4c, 0x3b, 0x7e, 0xc6, 0x76, 0x5a,
+	0x1d, 0x4e, 0x08, 0x89, 0x0b, 0xa7, 0x3d, 0x70, 0x40, 0xe2, 0x00, 0x42, 0x1c, 0x91, 0xb8, 0x71,
+	0xe3, 0xde, 0xc7, 0x11, 0x27, 0xc4, 0x21, 0x2b, 0xb6, 0xf9, 0x03, 0x50, 0x1f, 0x39, 0xa1, 0xf7,
+	0x61, 0xc7, 0x49, 0xa7, 0xb5, 0xdb, 0x87, 0xbe, 0x3d, 0x57, 0xfd, 0xea, 0xf7, 0xea, 0x55, 0xbd,
+	0xaa, 0x7a, 0x09, 0x34, 0x2c, 0xe2, 0x85, 0xb3, 0x29, 0x0e, 0x5a, 0x7e, 0x40, 0x22, 0x62, 0x11,
+	0x37, 0x59, 0x68, 0x6c, 0x81, 0x4a, 0x31, 0xa2, 0x5a, 0x3b, 0x0a, 0xc8, 0xf1, 0xd5, 0xc8, 0xea,
+	0x37, 0x13, 0xae, 0x00, 0x5b, 0xe4, 0x04, 0x07, 0x73, 0x97, 0x4c, 0xd8, 0x3a, 0xb0, 0xb1, 0x6d,
+	0x10, 0x5f, 0xe0, 0x6a, 0x7e, 0x34, 0xf7, 0x71, 0xd8, 0xb2, 0x67, 0x81, 0x19, 0x39, 0xc4, 0x4b,
+	0x16, 0x42, 0x7f, 0x6f, 0x42, 0x26, 0x84, 0x2d, 0x5b, 0x74, 0xc5, 0xa5, 0xea, 0xdf, 0xb6, 0x61,
+	0x7b, 0xf4, 0xc6, 0x0c, 0xec, 0x91, 0x8f, 0x2d, 0xf4, 0x11, 0x64, 0x1c, 0x5b, 0x91, 0x1a, 0x52,
+	0x73, 0xbb, 0xdb, 0xb8, 0x58, 0xd4, 0xef, 0xcc, 0xcd, 0xa9, 0xfb, 0x4c, 0xfd, 0x80, 0x4c, 0x9d,
+	0x08, 0x4f, 0xfd, 0x68, 0xae, 0xfe, 0x6f, 0x51, 0x2f, 0x32, 0x7c, 0xbf, 0xa7, 0x67, 0x1c, 0x1b,
+	0x0d, 0xa1, 0x18, 0x92, 0x59, 0x60, 0xe1, 0x50, 0xc9, 0x34, 0xb2, 0x4d, 0xb9, 0x5d, 0xd5, 0x62,
+	0x7f, 0xb5, 0x84, 0x57, 0x1b, 0x31, 0x48, 0xf7, 0xe1, 0xd9, 0xa2, 0xbe, 0xb5, 0x91, 0x56, 0x8f,
+	0x59, 0xd0, 0x8f, 0xe1, 0x6e, 0x7c, 0x4e, 0xc3, 0x25, 0x13, 0xc3, 0x0f, 0xf0, 0x6b, 0xe7, 0x54,
+	0xc9, 0x32, 0x9f, 0x9a, 0x17, 0x8b, 0xfa, 0x7b, 0xdc, 0x78, 0x03, 0x28, 0xcd, 0x77, 0x27, 0xd6,
+	0x0f, 0xc8, 0xe4, 0x15, 0xd3, 0xa2, 0x0e, 0xc8, 0x6f, 0x1c, 0x2f, 0x8a, 0x19, 0x73, 0xc9, 0x29,
+	0xdf, 0xe1, 0x8c, 0x29, 0x65, 0x9a, 0x09, 0xa8, 0x5c, 0x50, 0xf4, 0xa0, 0xcc, 0x50, 0x47, 0xa6,
+	0x75, 0x3c, 0xf3, 0x43, 0x25, 0xdf, 0x90, 0x9a, 0xf9, 0xee, 0xe3, 0x8b, 0x45, 0xfd, 0xdd, 0x14,
+	0x87, 0xd0, 0xa6, 0x49, 0xd8, 0xce, 0x5d, 0x2e, 0x47, 0x01, 0x54, 0xa6, 0xe6, 0xa9, 0x11, 0x9d,
+	0x7a, 0x46, 0x9c, 0x23, 0xa5, 0xd0, 0x90, 0x9a, 0x72, 0xfb, 0xa1, 0x36, 0x21, 0x64, 0xe2, 0x62,
+	0x9e, 0x9c, 0xa3, 0xd9, 0x6b, 0xad, 0x27, 0x00, 0xdd, 0x0f, 0x45, 0xec, 0x1e, 0xf3, 0x8d, 0xd6,
+	0x09, 0x52, 0x9b, 0xfd, 0xee, 0x8b, 0xba, 0xa4, 0xdf, 0x9a, 0x9a, 0xa7, 0xe3, 0x53, 0x2f, 0x36,
+	0x67, 0x7b, 0x3a, 0xde, 0xea, 0x9e, 0xc5, 0xeb, 0xee, 0xe9, 0x78, 0x5f, 0xb1, 0xa7, 0xe3, 0xa5,
+	0xf7, 0x6c, 0x41, 0xd1, 0x76, 0x42, 0xf3, 0xc8, 0xc5, 0x4a, 0xa9, 0x21, 0x35, 0x4b, 0xdd, 0xfb,
+	0x57, 0xe4, 0x5e, 0xa0, 0x58, 0x78, 0x49, 0x64, 0x84, 0x91, 0xe9, 0xd9, 0x47, 0xf3, 0x50, 0xd9,
+	0x6e, 0x48, 0xcd, 0x9d, 0x95, 0xf0, 0xa6, 0xb4, 0xab, 0xe1, 0x25, 0xd1, 0x48, 0xc8, 0xd1, 0x2b,
+	0x28, 0xb8, 0xe6, 0x11, 0x76, 0x43, 0x05, 0xd8, 0x01, 0x91, 0x96, 0x54, 0xd4, 0x80, 0xca, 0x47,
+	0x38, 0xea, 0xbe, 0x47, 0x4f, 0xf6, 0x76, 0x51, 0x97, 0x2e, 0x16, 0x75, 0x65, 0xdd, 0xa3, 0x0f,
+	0x1c, 0xcf, 0x75, 0x3c, 0xac, 0xea, 0x82, 0x07, 0xf9, 0x20, 0x9b, 0xd6, 0xb1, 0xf1, 0x33, 0x32,
+	0x0b, 0x3c, 0xd3, 0x55, 0x64, 0x76, 0x73, 0x86, 0xcb, 0x9b, 0x93, 0x52, 0xae, 0x96, 0xca, 0xfb,
+	0x13, 0xa2, 0x4d, 0xcc, 0x5f, 0xe0, 0x28, 0xc2, 0x9a, 0x8d, 0x4f, 0x5a, 0x16, 0x09, 0x70, 0x6b,
+	0xad, 0xde, 0xb5, 0x1f, 0x70, 0x4b, 0x1d, 0x4c, 0xeb, 0x58, 0xac, 0xab, 0x7f, 0xc8, 0x40, 0x81,
+	0x17, 0x0d, 0xea, 0x43, 0x31, 0xde, 0x98, 0x17, 0x66, 0xeb, 0xba, 0xc4, 0xb1, 0x3d, 0xfa, 0x3e,
+	0x00, 0xcd, 0x21, 0x79, 0xfd, 0x3a, 0xc4, 0x11, 0x2b, 0xa9, 0x6c, 0xb7, 0x7e, 0xb1, 0xa8, 0x3f,
+	0x5a, 0xe6, 0x97, 0xeb, 0xd2, 0xb1, 0xdd, 0x9e, 0x3a, 0xde, 0x90, 0x49, 0x69, 0x7e, 0x2c, 0xe2,
+	0x45, 0xd8, 0x8b, 0x0c, 0xda, 0x6b, 0x44, 0x09, 0xa5, 0xf2, 0x93, 0xd6, 0xae, 0xe4, 0x47, 0x28,
+	0xc6, 0x73, 0x9f, 0x65, 0x79, 0x8a, 0xc3, 0xd0, 0x9c, 0x60, 0xce, 0x92, 0x5f, 0x67, 0x49, 0x6b,
+	0x57, 0x58, 0x84, 0x82, 0xb2, 0xa8, 0xbf, 0x96, 0xa0, 0xbc, 0x27, 0x3a, 0x0d, 0xeb, 0x5d, 0x63,
+	0x28, 0xfb, 0x01, 0xb1, 0x70, 0x18, 0x1a, 0xa1, 0x8f, 0x2d, 0x16, 0x2c, 0xb9, 0x7d, 0x7f, 0x99,
+	0xfc, 0x57, 0x5c, 0x4b, 0xc1, 0xdd, 0x6a, 0x2a, 0xff, 0xb7, 0x44, 0xfe, 0xe3, 0xac, 0xcb, 0xfe,
+	0x12, 0x88, 0xea, 0x20, 0x87, 0xb4, 0x8d, 0x19, 0xae, 0x33, 0x75, 0x22, 0x25, 0x43, 0x6f, 0xa4,
+	0x0e, 0x4c, 0x34, 0xa0, 0x12, 0xf5, 0x4f, 0x12, 0xec, 0xe8, 0xd8, 0x77, 0x1d, 0xcb, 0x1c, 0x45,
+	0x66, 0x34, 0x0b, 0xd1, 0x47, 0x90, 0xb3, 0x88, 0x8d, 0x99, 0x03, 0xb7, 0xda, 0xef, 0x2c, 0xfb,
+	0xe1, 0x0a, 0x4c, 0xdb, 0x23, 0x36, 0xd6, 0x19, 0x12, 0x3d, 0x80, 0x02, 0x0e, 0x02, 0x12, 0xf0,
+	0x1e, 0xba, 0xad, 0x8b, 0x2f, 0xf5, 0x05, 0xe4, 0x28, 0x0a, 0x95, 0x20, 0xd7, 0xef, 0x0d, 0xf6,
+	0x2b, 0x5b, 0xa8, 0x0c, 0xa5, 0x6e, 0x67, 0xef, 0xb3, 0xe7, 0xfd, 0xc1, 0xa0, 0x62, 0xa3, 0x32,
+	0x14, 0xc7, 0x9d, 0xfe, 0xa0, 0x7f, 0xf8, 0xa2, 0x72, 0x26, 0xd1, 0xaf, 0x57, 0x7a, 0xff, 0x65,
+	0x47, 0xff, 0x49, 0xe5, 0x2f, 0x19, 0x24, 0x43, 0xe1, 0x79, 0xa7, 0x3f, 0xd8, 0xef, 0x55, 0x3e,
+	0xcf, 0xaa, 0x07, 0x20, 0x0f, 0x9c, 0x30, 0xd2, 0xf1, 0xcf, 0x67, 0x38, 0x8c, 0xd0, 0x77, 0xa0,
+	0x14, 0x62, 0x17, 0x5b, 0x11, 0x09, 0x44, 0x98, 0x76, 0x2f, 0xd5, 0x08, 0x57, 0x77, 0x73, 0x34,
+	0x50, 0x7a, 0x02, 0x57, 0xff, 0x93, 0x81, 0x32, 0xa7, 0x0a, 0x7d, 0xe2, 0x85, 0x18, 0x35, 0xa1,
+	0x10, 0xb2, 0x03, 0x89, 0xf3, 0x56, 0x52, 0xfd, 0x9f, 0xc9, 0x75, 0xa1, 0x47, 0x1a, 0x14, 0xde,
+	0x60, 0xd3, 0xc6, 0x01, 0x8b, 0xa2, 0xdc, 0xae, 0x2c, 0xf7, 0x3c, 0x60, 0x72, 0xb1, 0x99, 0x40,
+	0xa1, 0x67, 0x50, 0x60, 0x71, 0x0e, 0x95, 0x2c, 0x9b, 0x2c, 0xa9, 0x48, 0xa6, 0x3d, 0xe0, 0x63,
+	0x26, 0xb6, 0xe5, 0x16, 0xd5, 0xbf, 0x4b, 0x90, 0x67, 0x72, 0xf4, 0x21, 0xe4, 0x52, 0xd7, 0xe1,
+	0xee, 0x86, 0xe9, 0x24, 0x4c, 0x19, 0x0c, 0x3d, 0x86, 0xf2, 0x94, 0xd8, 0x46, 0x80, 0x4f, 0x9c,
+	0x90, 0xf6, 0x48, 0xea, 0x6a, 0x56, 0x97, 0xa7, 0xc4, 0xd6, 0x85, 0x08, 0xbd, 0x0f, 0xf9, 0x80,
+	0xcc, 0x22, 0xcc, 0x0a, 0x48, 0x6e, 0xdf, 0x5e, 0x1e, 0x43, 0xa7, 0x62, 0x41, 0xc7, 0x31, 0xe8,
+	0x93, 0x24, 0x3c, 0x39, 0x76, 0x88, 0xdd, 0x2b, 0xae, 0x43, 0xe2, 0x3f, 0xfb, 0x52, 0xff, 0x25,
+	0x41, 0xb9, 0xe3, 0xfb, 0xee, 0x3c, 0x4e, 0xd9, 0xf7, 0xa0, 0x68, 0xbd, 0x31, 0xbd, 0x09, 0xa6,
+	0x71, 0xa6, 0x44, 0xef, 0x2e, 0x89, 0xd2, 0x40, 0x6d, 0x8f, 0xa1, 0x04, 0x5d, 0x6c, 0x53, 0xfd,
+	0x8d, 0x04, 0x05, 0xae, 0x41, 0x1a, 0xdc, 0xc5, 0xa7, 0x3e, 0xb6, 0x22, 0x63, 0xe5, 0xa0, 0x12,
+	0x3b, 0xe8, 0x1d, 0xae, 0x7a, 0xb9, 0x72, 0xdc, 0xc2, 0xcc, 0x0f, 0x71, 0x10, 0x29, 0x99, 0x2b,
+	0x43, 0xa8, 0x0b, 0x08, 0x7a, 0x02, 0x05, 0x1b, 0xbb, 0x58, 0x04, 0x67, 0xbb, 0x2b, 0xa7, 0xdf,
+	0x0b, 0x42, 0xa5, 0x3a, 0xb0, 0x23, 0x5c, 0xbe, 0xe9, 0x3b, 0xa4, 0xfe, 0x59, 0x02, 0x99, 0x52,
+	0xc4, 0x61, 0x6c, 0x26, 0xf6, 0xd2, 0x66, 0xfb, 0xe4, 0xf6, 0x3d, 0x86, 0x3c, 0xbb, 0x4b, 0x4a,
+	0xe6, 0xf2, 0x41, 0xb8, 0x06, 0x1d, 0xc0, 0x0e, 0x1d, 0xc3, 0x61, 0x64, 0xba, 0xd8, 0xc3, 0x61,
+	0xa8, 0x64, 0xbf, 0x6a, 0xa0, 0x96, 0xa8, 0x73, 0x6c, 0x56, 0x96, 0xa7, 0xe6, 0xe9, 0x28, 0x36,
+	0x54, 0x7f, 0x9b, 0x81, 0x32, 0x77, 0xf3, 0xc6, 0xab, 0xca, 0x83, 0x22, 0xef, 0xf1, 0x71, 0x59,
+	0x3d, 0x59, 0xa5, 0x4e, 0xca, 0x8a, 0xf7, 0xfc, 0x70, 0xdf, 0x8b, 0x82, 0x79, 0xb7, 0xf5, 0xab,
+	0x2f, 0xae, 0x39, 0x73, 0xc4, 0x26, 0xd5, 0x67, 0x50, 0x4e, 0x33, 0xa1, 0x0a, 0x64, 0x8f, 0xf1,
+	0x9c, 0x8f, 0x32, 0x9d, 0x2e, 0xd1, 0x3d, 0xc8, 0x9f, 0x98, 0xee, 0x0c, 0x8b, 0x5a, 0xe3, 0x1f,
+	0xcf, 0x32, 0x4f, 0x25, 0xf5, 0x63, 0xd8, 0x19, 0x10, 0xcb, 0x8c, 0x70, 0x9c, 0xbe, 0x27, 0x49,
+	0x4b, 0xa0, 0x45, 0xb0, 0x7e, 0xbd, 0xb8, 0x4a, 0xfd, 0x7d, 0x16, 0x6e, 0xc5, 0x66, 0x37, 0x1e,
+	0xce, 0x4f, 0xd7, 0x9a, 0x54, 0x2d, 0xd5, 0xa4, 0x56, 0x7c, 0xd8, 0xd8, 0xa6, 0xfe, 0x9b, 0xb4,
+	0xa9, 0xaf, 0xef, 0xe1, 0x23, 0xf6, 0x46, 0xdf, 0x70, 0x2b, 0xe9, 0x73, 0xfc, 0x5a, 0xbd, 0xe9,
+	0x29, 0x14, 0xfd, 0xc0, 0x99, 0x9a, 0xc1, 0x9c, 0x4d, 0x72, 0xb9, 0xad, 0x6c, 0x1c, 0x96, 0x5a,
+	0xbf, 0x17, 0xb7, 0x13, 0x01, 0xa7, 0x03, 0x24, 0xe0, 0xdd, 0x8b, 0xbe, 0x81, 0xbf, 0x46, 0x5f,
+	0x4b, 0xe0, 0xea, 0xc7, 0x70, 0xfb, 0x05, 0x8e, 0x0e, 0x1c, 0x2f, 0x0a, 0xe3, 0xac, 0x26, 0xa5,
+	0x26, 0x5d, 0x55, 0x6a, 0xea, 0x3f, 0x32, 0x50, 0x59, 0x9a, 0xdd, 0x78, 0x56, 0x47, 0xb0, 0x23,
+	0x8e, 0x6a, 0xd0, 0x87, 0x7b, 0x5c, 0xd9, 0xcd, 0xe5, 0x06, 0xeb, 0xce, 0x68, 0xf1, 0x82, 0x49,
+	0x05, 0x5d, 0x59, 0x90, 0x30, 0x19, 0xfa, 0x21, 0x94, 0xf9, 0x2f, 0x03, 0xc1, 0xc9, 0x07, 0xc2,
+	0x75, 0x39, 0x65, 0xce, 0xc1, 0x44, 0xd5, 0x4f, 0x61, 0x67, 0x05, 0x43, 0xf3, 0xcf, 0xc9, 0xe3,
+	0xd7, 0x4f, 0xea, 0x37, 0xa3, 0xf6, 0x7c, 0xf4, 0x92, 0xf3, 0x73, 0xcc, 0xb7, 0x7e, 0x29, 0x41,
+	0x41, 0xbc, 0x59, 0x0a, 0x90, 0x19, 0x7e, 0x56, 0xd9, 0x42, 0x77, 0xe1, 0xf6, 0xe8, 0xa0, 0xa3,
+	0xf7, 0x8c, 0xc3, 0xe1, 0xd8, 0x78, 0x3e, 0xfc, 0xd1, 0x61, 0xaf, 0x22, 0xa1, 0x7b, 0x50, 0x39,
+	0x1c, 0x1a, 0x5c, 0x1e, 0xbf, 0x30, 0x32, 0xe8, 0x3e, 0xdc, 0xa1, 0xa0, 0x55, 0x71, 0x16, 0x3d,
+	0x82, 0xdd, 0xfd, 0xf1, 0x5e, 0xcf, 0x18, 0xeb, 0x9d, 0xc3, 0x51, 0x67, 0x6f, 0xdc, 0x1f, 0x1e,
+	0x1a, 0xe2, 0x21, 0x92, 0x43, 0xb7, 0x41, 0xe6, 0xf8, 0xd1, 0xb8, 0x33, 0xd8, 0xaf, 0xe4, 0xdb,
+	0x7f, 0xcd, 0xc4, 0x05, 0xf0, 0x09, 0xe4, 0xa8, 0x2f, 0xe8, 0xfe, 0x7a, 0x3b, 0x62, 0x77, 0xa4,
+	0xfa, 0x60, 0x73, 0x97, 0xa2, 0x66, 0xf4, 0x31, 0x90, 0x36, 0x4b, 0xbd, 0x74, 0xaa, 0x0f, 0xd6,
+	0xc5, 0xc2, 0xec, 0x29, 0xe4, 0xd9, 0x08, 0x42, 0x0f, 0x36, 0x8f, 0xd1, 0xea, 0xee, 0x25, 0xb9,
+	0xb0, 0xec, 0x40, 0x29, 0xce, 0x13, 0x7a, 0xb8, 0x29, 0x77, 0xdc, 0xbe, 0x7a, 0x75, 0x5a, 0xd1,
+	0x77, 0xa1, 0xc0, 0x7b, 0x03, 0xda, 0xbd, 0xdc, 0x2d, 0xb8, 0xb9, 0x72, 0x55, 0x1b, 0xe9, 0xee,
+	0x9d, 0xfd, 0xbb, 0xb6, 0x75, 0xf6, 0x65, 0x4d, 0x7a, 0xfb, 0x65, 0x4d, 0xfa, 0xfc, 0xbc, 0xb6,
+	0xf5, 0xc7, 0xf3, 0x9a, 0xf4, 0xf6, 0xbc, 0xb6, 0xf5, 0xcf, 0xf3, 0xda, 0xd6, 0x4f, 0xbf, 0xb1,
+	0xa9, 0x47, 0x5f, 0xfa, 0x33, 0xe2, 0xa8, 0xc0, 0x56, 0xdf, 0xfe, 0xff, 0x00, 0x2b, 0xda, 0x8f,
+	0x41, 0xa8, 0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -958,6 +1132,11 @@ type ShardClient interface {
 	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error)
 	// GetHints fetches hints for a shard.
 	GetHints(ctx context.Context, in *GetHintsRequest, opts ...grpc.CallOption) (*GetHintsResponse, error)
+	// Locate returns the Route, primary, and replica status of each named
+	// Shard, allowing clients to dispatch requests directly to shard primaries.
+	// It may be served by any consumer process, and reflects its current view
+	// of Etcd.
+	Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*LocateResponse, error)
 }
 
 type shardClient struct {
@@ -1004,6 +1183,15 @@ func (c *shardClient) GetHints(ctx context.Context, in *GetHintsRequest, opts ..
 	return out, nil
 }
 
+func (c *shardClient) Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*LocateResponse, error) {
+	out := new(LocateResponse)
+	err := c.cc.Invoke(ctx, "/consumer.Shard/Locate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShardServer is the server API for Shard service.
 type ShardServer interface {
 	// Stat returns detailed status of a given Shard.
@@ -1014,6 +1202,11 @@ type ShardServer interface {
 	Apply(context.Context, *ApplyRequest) (*ApplyResponse, error)
 	// GetHints fetches hints for a shard.
 	GetHints(context.Context, *GetHintsRequest) (*GetHintsResponse, error)
+	// Locate returns the Route, primary, and replica status of each named
+	// Shard, allowing clients to dispatch requests directly to shard primaries.
+	// It may be served by any consumer process, and reflects its current view
+	// of Etcd.
+	Locate(context.Context, *LocateRequest) (*LocateResponse, error)
 }
 
 func RegisterShardServer(s *grpc.Server, srv ShardServer) {
@@ -1092,6 +1285,24 @@ func _Shard_GetHints_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Shard_Locate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShardServer).Locate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/consumer.Shard/Locate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShardServer).Locate(ctx, req.(*LocateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Shard_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "consumer.Shard",
 	HandlerType: (*ShardServer)(nil),
@@ -1112,6 +1323,10 @@ var _Shard_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHints",
 			Handler:    _Shard_GetHints_Handler,
 		},
+		{
+			MethodName: "Locate",
+			Handler:    _Shard_Locate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "consumer/protocol/protocol.proto",
@@ -1206,6 +1421,12 @@ func (m *ShardSpec) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n3
+	if len(m.AckJournal) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.AckJournal)))
+		i += copy(dAtA[i:], m.AckJournal)
+	}
 	return i, nil
 }
 
@@ -1235,6 +1456,18 @@ func (m *ShardSpec_Source) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.MinOffset))
 	}
+	if len(m.ContentType) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.ContentType)))
+		i += copy(dAtA[i:], m.ContentType)
+	}
+	if len(m.MessageType) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.MessageType)))
+		i += copy(dAtA[i:], m.MessageType)
+	}
 	return i, nil
 }
 
@@ -1558,6 +1791,14 @@ func (m *StatRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Shard)))
 		i += copy(dAtA[i:], m.Shard)
 	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxStaleness)))
+	n12, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxStaleness, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
 	return i, nil
 }
 
@@ -1584,11 +1825,11 @@ func (m *StatResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n12, err := m.Header.MarshalTo(dAtA[i:])
+	n13, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n12
+	i += n13
 	if len(m.Offsets) > 0 {
 		for k, _ := range m.Offsets {
 			dAtA[i] = 0x1a
@@ -1608,6 +1849,139 @@ func (m *StatResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *LocateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Shards) > 0 {
+		for _, s := range m.Shards {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *LocateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Status != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
+	n14, err := m.Header.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n14
+	if len(m.Shards) > 0 {
+		for _, msg := range m.Shards {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintProtocol(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *LocateResponse_Shard) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LocateResponse_Shard) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Status != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+	}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Route.ProtoSize()))
+	n15, err := m.Route.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n15
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintProtocol(dAtA, i, uint64(m.Primary.ProtoSize()))
+	n16, err := m.Primary.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n16
+	if len(m.Replicas) > 0 {
+		for _, msg := range m.Replicas {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintProtocol(dAtA, i, uint64(msg.ProtoSize()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func (m *GetHintsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -1655,19 +2029,19 @@ func (m *GetHintsResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.Header.ProtoSize()))
-	n13, err := m.Header.MarshalTo(dAtA[i:])
+	n17, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n13
+	i += n17
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintProtocol(dAtA, i, uint64(m.PrimaryHints.ProtoSize()))
-	n14, err := m.PrimaryHints.MarshalTo(dAtA[i:])
+	n18, err := m.PrimaryHints.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n14
+	i += n18
 	if len(m.BackupHints) > 0 {
 		for _, msg := range m.BackupHints {
 			dAtA[i] = 0x22
@@ -1702,11 +2076,11 @@ func (m *GetHintsResponse_ResponseHints) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintProtocol(dAtA, i, uint64(m.Hints.ProtoSize()))
-		n15, err := m.Hints.MarshalTo(dAtA[i:])
+		n19, err := m.Hints.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n19
 	}
 	return i, nil
 }
@@ -1759,6 +2133,10 @@ func (m *ShardSpec) ProtoSize() (n int) {
 	}
 	l = m.LabelSet.ProtoSize()
 	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.AckJournal)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -1775,6 +2153,14 @@ func (m *ShardSpec_Source) ProtoSize() (n int) {
 	if m.MinOffset != 0 {
 		n += 1 + sovProtocol(uint64(m.MinOffset))
 	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.MessageType)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
 	return n
 }
 
@@ -1926,6 +2312,8 @@ func (m *StatRequest) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProtocol(uint64(l))
 	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxStaleness)
+	n += 1 + l + sovProtocol(uint64(l))
 	return n
 }
 
@@ -1951,20 +2339,22 @@ func (m *StatResponse) ProtoSize() (n int) {
 	return n
 }
 
-func (m *GetHintsRequest) ProtoSize() (n int) {
+func (m *LocateRequest) ProtoSize() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Shard)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Shards) > 0 {
+		for _, s := range m.Shards {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
 	}
 	return n
 }
 
-func (m *GetHintsResponse) ProtoSize() (n int) {
+func (m *LocateResponse) ProtoSize() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1975,10 +2365,69 @@ func (m *GetHintsResponse) ProtoSize() (n int) {
 	}
 	l = m.Header.ProtoSize()
 	n += 1 + l + sovProtocol(uint64(l))
-	l = m.PrimaryHints.ProtoSize()
-	n += 1 + l + sovProtocol(uint64(l))
-	if len(m.BackupHints) > 0 {
-		for _, e := range m.BackupHints {
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
+			l = e.ProtoSize()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LocateResponse_Shard) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = m.Route.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = m.Primary.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Replicas) > 0 {
+		for _, e := range m.Replicas {
+			l = e.ProtoSize()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetHintsRequest) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Shard)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *GetHintsResponse) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	l = m.Header.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	l = m.PrimaryHints.ProtoSize()
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.BackupHints) > 0 {
+		for _, e := range m.BackupHints {
 			l = e.ProtoSize()
 			n += 1 + l + sovProtocol(uint64(l))
 		}
@@ -2328,6 +2777,38 @@ func (m *ShardSpec) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckJournal", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AckJournal = go_gazette_dev_core_broker_protocol.Journal(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -2432,6 +2913,70 @@ func (m *ShardSpec_Source) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -3491,6 +4036,39 @@ func (m *StatRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Shard = ShardID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStaleness", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxStaleness, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -3733,6 +4311,434 @@ func (m *StatResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LocateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LocateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LocateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shards = append(m.Shards, ShardID(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LocateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LocateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LocateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= Status(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shards = append(m.Shards, LocateResponse_Shard{})
+			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LocateResponse_Shard) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Shard: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Shard: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= Status(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = ShardID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Route", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Route.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Primary", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Primary.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replicas = append(m.Replicas, ReplicaStatus{})
+			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *GetHintsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"fmt"
+	"mime"
 	"path"
 
 	"go.gazette.dev/core/allocator"
@@ -43,12 +44,8 @@ func (m *ShardSpec) Validate() error {
 		return pb.ExtendContext(err, "Id")
 	} else if len(m.Sources) == 0 {
 		return pb.NewValidationError("Sources cannot be empty")
-	} else if m.RecoveryLogPrefix == "" {
-		return pb.NewValidationError("expected RecoveryLogPrefix")
-	} else if err = m.RecoveryLog().Validate(); err != nil {
-		return pb.ExtendContext(err, "RecoveryLog")
-	} else if !path.IsAbs(m.HintPrefix) || path.Clean(m.HintPrefix) != m.HintPrefix || path.Base(m.HintPrefix) == "" {
-		return pb.NewValidationError("HintPrefix is not an absolute, clean, non-directory path (%v)", m.HintPrefix)
+	} else if err = m.validateRecoveryLog(); err != nil {
+		return err
 	} else if m.HintBackups < 0 {
 		return pb.NewValidationError("invalid HintBackups (%d; expected >= 0)", m.HintBackups)
 	} else if m.MinTxnDuration < 0 {
@@ -61,6 +58,10 @@ func (m *ShardSpec) Validate() error {
 		return pb.ExtendContext(err, "LabelSet")
 	} else if len(m.LabelSet.ValuesOf("id")) != 0 {
 		return pb.NewValidationError(`Labels cannot include label "id"`)
+	} else if m.AckJournal != "" {
+		if err = m.AckJournal.Validate(); err != nil {
+			return pb.ExtendContext(err, "AckJournal")
+		}
 	}
 
 	for i := range m.Sources {
@@ -77,12 +78,35 @@ func (m *ShardSpec) Validate() error {
 	return nil
 }
 
+// validateRecoveryLog returns an error if the ShardSpec has a RecoveryLogPrefix,
+// and its recovery log or HintPrefix are not well-formed. A ShardSpec without
+// a RecoveryLogPrefix has no recovery log, and no hints.
+func (m *ShardSpec) validateRecoveryLog() error {
+	if !m.HasRecoveryLog() {
+		return nil
+	} else if err := m.RecoveryLog().Validate(); err != nil {
+		return pb.ExtendContext(err, "RecoveryLog")
+	} else if !path.IsAbs(m.HintPrefix) || path.Clean(m.HintPrefix) != m.HintPrefix || path.Base(m.HintPrefix) == "" {
+		return pb.NewValidationError("HintPrefix is not an absolute, clean, non-directory path (%v)", m.HintPrefix)
+	}
+	return nil
+}
+
 // Validate returns an error if the ShardSpec_Source is not well-formed.
 func (m *ShardSpec_Source) Validate() error {
 	if err := m.Journal.Validate(); err != nil {
 		return pb.ExtendContext(err, "Journal")
 	} else if m.MinOffset < 0 {
 		return pb.NewValidationError("invalid MinOffset (%d; expected > 0)", m.MinOffset)
+	} else if err = pb.ValidateToken(m.ContentType, 0, maxSourceLabelLen); err != nil {
+		return pb.ExtendContext(err, "ContentType")
+	} else if err = pb.ValidateToken(m.MessageType, 0, maxSourceLabelLen); err != nil {
+		return pb.ExtendContext(err, "MessageType")
+	}
+	if m.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(m.ContentType); err != nil {
+			return pb.NewValidationError("parsing ContentType: %s", err)
+		}
 	}
 	return nil
 }
@@ -131,10 +155,16 @@ func (m *ShardSpec) IsConsistent(assignment keyspace.KeyValue, all keyspace.KeyV
 }
 
 // RecoveryLog returns the Journal to which the Shard's recoverylog is recorded.
+// It's meaningful only if the ShardSpec has a RecoveryLogPrefix (see HasRecoveryLog).
 func (m *ShardSpec) RecoveryLog() pb.Journal {
 	return pb.Journal(m.RecoveryLogPrefix + "/" + m.Id.String())
 }
 
+// HasRecoveryLog returns true iff the Shard records its Store to a recoverylog.
+// Shards without a recovery log checkpoint to a Store of their Application
+// which persists its offsets externally, and have no hints.
+func (m *ShardSpec) HasRecoveryLog() bool { return m.RecoveryLogPrefix != "" }
+
 // HintPrimaryKey returns the Etcd key to which recorded, primary hints are written.
 func (m *ShardSpec) HintPrimaryKey() string { return m.HintPrefix + "/" + m.Id.String() + ".primary" }
 
@@ -175,6 +205,9 @@ func UnionShardSpecs(a, b ShardSpec) ShardSpec {
 	if a.HotStandbys == 0 {
 		a.HotStandbys = b.HotStandbys
 	}
+	if a.AckJournal == "" {
+		a.AckJournal = b.AckJournal
+	}
 	a.LabelSet = pb.UnionLabelSets(a.LabelSet, b.LabelSet, pb.LabelSet{})
 
 	return a
@@ -207,6 +240,9 @@ func IntersectShardSpecs(a, b ShardSpec) ShardSpec {
 	if a.HotStandbys != b.HotStandbys {
 		a.HotStandbys = 0
 	}
+	if a.AckJournal != b.AckJournal {
+		a.AckJournal = ""
+	}
 	a.LabelSet = pb.IntersectLabelSets(a.LabelSet, b.LabelSet, pb.LabelSet{})
 
 	return a
@@ -239,6 +275,9 @@ func SubtractShardSpecs(a, b ShardSpec) ShardSpec {
 	if a.HotStandbys == b.HotStandbys {
 		a.HotStandbys = 0
 	}
+	if a.AckJournal == b.AckJournal {
+		a.AckJournal = ""
+	}
 	a.LabelSet = pb.SubtractLabelSet(a.LabelSet, b.LabelSet, pb.LabelSet{})
 
 	return a
@@ -335,6 +374,8 @@ func (m *StatRequest) Validate() error {
 	}
 	if err := m.Shard.Validate(); err != nil {
 		return pb.ExtendContext(err, "Shard")
+	} else if m.MaxStaleness < 0 {
+		return pb.NewValidationError("invalid MaxStaleness (%s; expected >= 0)", m.MaxStaleness)
 	}
 	return nil
 }
@@ -442,6 +483,60 @@ func (m *ApplyResponse) Validate() error {
 	return nil
 }
 
+// Validate returns an error if the LocateRequest is not well-formed.
+func (m *LocateRequest) Validate() error {
+	for i, id := range m.Shards {
+		if err := id.Validate(); err != nil {
+			return pb.ExtendContext(err, "Shards[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the LocateResponse is not well-formed.
+func (m *LocateResponse) Validate() error {
+	if err := m.Status.Validate(); err != nil {
+		return pb.ExtendContext(err, "Status")
+	} else if err = m.Header.Validate(); err != nil {
+		return pb.ExtendContext(err, "Header")
+	}
+	for i, shard := range m.Shards {
+		if err := shard.Validate(); err != nil {
+			return pb.ExtendContext(err, "Shards[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the LocateResponse_Shard is not well-formed.
+func (m *LocateResponse_Shard) Validate() error {
+	if err := m.Status.Validate(); err != nil {
+		return pb.ExtendContext(err, "Status")
+	} else if err = m.Id.Validate(); err != nil {
+		return pb.ExtendContext(err, "Id")
+	} else if err = m.Route.Validate(); err != nil {
+		return pb.ExtendContext(err, "Route")
+	} else if l1, l2 := len(m.Route.Members), len(m.Replicas); l1 != l2 {
+		return pb.NewValidationError("length of Route.Members and Replicas are not equal (%d vs %d)", l1, l2)
+	}
+	for i, status := range m.Replicas {
+		if err := status.Validate(); err != nil {
+			return pb.ExtendContext(err, "Replicas[%d]", i)
+		}
+	}
+
+	if m.Status == Status_OK {
+		if err := m.Primary.Validate(); err != nil {
+			return pb.ExtendContext(err, "Primary")
+		} else if m.Route.Primary == -1 || m.Route.Members[m.Route.Primary] != m.Primary {
+			return pb.NewValidationError("Primary is not the Route primary (%s)", &m.Primary)
+		}
+	} else if m.Primary != (pb.ProcessSpec_ID{}) {
+		return pb.NewValidationError("unexpected Primary with Status %s (%s)", m.Status, &m.Primary)
+	}
+	return nil
+}
+
 // Validate returns an error if the HintsRequest is not well-formed.
 func (m *GetHintsRequest) Validate() error {
 	if err := m.Shard.Validate(); err != nil {
@@ -490,4 +585,7 @@ func sourcesEq(a, b []ShardSpec_Source) bool {
 
 const (
 	minShardNameLen, maxShardNameLen = 4, 512
+	// Source ContentType and MessageType are compared to journal label values,
+	// and share their maximum length.
+	maxSourceLabelLen = 1024
 )
@@ -52,8 +52,8 @@ func (s *SpecSuite) TestShardSpecValidationCases(c *gc.C) {
 		{Journal: "journal/1", MinOffset: -1},
 	}
 	c.Check(spec.Validate(), gc.ErrorMatches, `RecoveryLog: not a valid token \(bad prefix/a-shard-id\)`)
-	spec.RecoveryLogPrefix = ""
-	c.Check(spec.Validate(), gc.ErrorMatches, `expected RecoveryLogPrefix`)
+	spec.RecoveryLogPrefix = "" // No recovery log; HintPrefix isn't required.
+	c.Check(spec.Validate(), gc.ErrorMatches, `invalid HintBackups \(-1; expected >= 0\)`)
 	spec.RecoveryLogPrefix = "recovery/logs"
 	c.Check(spec.Validate(), gc.ErrorMatches, `HintPrefix is not an absolute, clean, non-directory path \(\)`)
 	spec.HintPrefix = "relative/path"
@@ -77,11 +77,22 @@ func (s *SpecSuite) TestShardSpecValidationCases(c *gc.C) {
 	spec.LabelSet = pb.MustLabelSet("id", "") // Label is rejected even if empty.
 	c.Check(spec.Validate(), gc.ErrorMatches, `Labels cannot include label "id"`)
 	spec.LabelSet = pb.MustLabelSet(labels.Instance, "an-instance", labels.ManagedBy, "a-tool")
+	spec.AckJournal = "bad ack journal"
+	c.Check(spec.Validate(), gc.ErrorMatches, `AckJournal: not a valid token \(bad ack journal\)`)
+	spec.AckJournal = "acks/journal"
 
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[0\].Journal: not a valid token \(journal 2\)`)
 	spec.Sources[0].Journal = "journal/2"
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[1\]: invalid MinOffset \(-1; expected > 0\)`)
 	spec.Sources[1].MinOffset = 1024
+	spec.Sources[1].ContentType = "invalid content type"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[1\].ContentType: not a valid token \(invalid content type\)`)
+	spec.Sources[1].ContentType = "invalid/content/type"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[1\]: parsing ContentType: mime: unexpected content after media subtype`)
+	spec.Sources[1].ContentType = "application/x-ndjson"
+	spec.Sources[1].MessageType = "invalid message type"
+	c.Check(spec.Validate(), gc.ErrorMatches, `Sources\[1\].MessageType: not a valid token \(invalid message type\)`)
+	spec.Sources[1].MessageType = "a.MessageType"
 	c.Check(spec.Validate(), gc.ErrorMatches, `Sources.Journal not in unique, sorted order \(index 1; journal/1 <= journal/2\)`)
 	spec.Sources[0], spec.Sources[1] = spec.Sources[1], spec.Sources[0]
 
@@ -140,6 +151,7 @@ func (s *SpecSuite) TestSetOperations(c *gc.C) {
 		MinTxnDuration:    1 * time.Second,
 		Disable:           true,
 		HotStandbys:       2,
+		AckJournal:        "acks/journal",
 		LabelSet: pb.LabelSet{
 			Labels: []pb.Label{
 				{Name: "aaa", Value: "val"},
@@ -159,6 +171,7 @@ func (s *SpecSuite) TestSetOperations(c *gc.C) {
 		MinTxnDuration:    time.Minute,
 		Disable:           false,
 		HotStandbys:       1,
+		AckJournal:        "other/acks/journal",
 		LabelSet: pb.LabelSet{
 			Labels: []pb.Label{
 				{Name: "aaa", Value: "other"},
@@ -246,6 +259,9 @@ func (s *SpecSuite) TestStatRequestValidationCases(c *gc.C) {
 	req.Header.Etcd.ClusterId = 1234
 	c.Check(req.Validate(), gc.ErrorMatches, `Shard: not a valid token \(invalid shard\)`)
 	req.Shard = "valid-shard"
+	req.MaxStaleness = -time.Second
+	c.Check(req.Validate(), gc.ErrorMatches, `invalid MaxStaleness \(-1s; expected >= 0\)`)
+	req.MaxStaleness = time.Second
 
 	c.Check(req.Validate(), gc.IsNil)
 }
@@ -321,6 +337,47 @@ func (s *SpecSuite) TestListResponseValidationCases(c *gc.C) {
 	c.Check(resp.Validate(), gc.IsNil)
 }
 
+func (s *SpecSuite) TestLocateValidationCases(c *gc.C) {
+	var req = LocateRequest{Shards: []ShardID{"a-shard", "a invalid id"}}
+	c.Check(req.Validate(), gc.ErrorMatches, `Shards\[1\]: not a valid token \(a invalid id\)`)
+	req.Shards[1] = "another-shard"
+	c.Check(req.Validate(), gc.IsNil)
+
+	var id = pb.ProcessSpec_ID{Zone: "zone", Suffix: "suffix"}
+	var resp = LocateResponse{
+		Status: 9101,
+		Header: *badHeaderFixture(),
+		Shards: []LocateResponse_Shard{
+			{
+				Status:  9101,
+				Id:      "a invalid id",
+				Route:   pb.Route{Primary: 0},
+				Primary: id,
+			},
+		},
+	}
+
+	c.Check(resp.Validate(), gc.ErrorMatches, `Status: invalid status \(9101\)`)
+	resp.Status = Status_OK
+	c.Check(resp.Validate(), gc.ErrorMatches, `Header.Etcd: invalid ClusterId .*`)
+	resp.Header.Etcd.ClusterId = 1234
+	c.Check(resp.Validate(), gc.ErrorMatches, `Shards\[0\].Status: invalid status \(9101\)`)
+	resp.Shards[0].Status = Status_NO_SHARD_PRIMARY
+	c.Check(resp.Validate(), gc.ErrorMatches, `Shards\[0\].Id: not a valid token \(.*\)`)
+	resp.Shards[0].Id = "a-valid-id"
+	c.Check(resp.Validate(), gc.ErrorMatches, `Shards\[0\].Route: invalid Primary .*`)
+	resp.Shards[0].Route.Members = []pb.ProcessSpec_ID{id}
+	c.Check(resp.Validate(), gc.ErrorMatches, `Shards\[0\]: length of Route.Members and Replicas are not equal \(1 vs 0\)`)
+	resp.Shards[0].Replicas = []ReplicaStatus{{Code: ReplicaStatus_PRIMARY}}
+	c.Check(resp.Validate(), gc.ErrorMatches, `Shards\[0\]: unexpected Primary with Status NO_SHARD_PRIMARY \(zone:"zone" suffix:"suffix" \)`)
+	resp.Shards[0].Status = Status_OK
+	resp.Shards[0].Route.Primary = -1
+	c.Check(resp.Validate(), gc.ErrorMatches, `Shards\[0\]: Primary is not the Route primary \(.*\)`)
+	resp.Shards[0].Route.Primary = 0
+
+	c.Check(resp.Validate(), gc.IsNil)
+}
+
 func (s *SpecSuite) TestApplyRequestValidationCases(c *gc.C) {
 	var req = ApplyRequest{
 		Changes: []ApplyRequest_Change{
@@ -16,6 +16,7 @@ var (
 	ErrNoSuchLink          = fmt.Errorf("fnode has no such link")
 	ErrNotHintedAuthor     = fmt.Errorf("op author does not match the next hinted author")
 	ErrPropertyExists      = fmt.Errorf("property exists")
+	ErrStaleAuthorEpoch    = fmt.Errorf("op author epoch is less than an applied epoch")
 	ErrWrongSeqNo          = fmt.Errorf("wrong sequence number")
 
 	crcTable = crc32.MakeTable(crc32.Castagnoli)
@@ -51,6 +52,9 @@ type FSM struct {
 	// Expected sequence number and checksum of next operation.
 	NextSeqNo    int64
 	NextChecksum uint32
+	// Largest Author epoch of an applied operation. Operations having a
+	// lesser epoch were written by a fenced-off Recorder, and are rejected.
+	AuthorEpoch uint64
 
 	// Target paths and contents of small files which are managed outside of
 	// regular Fnode tracking. Property updates are triggered upon rename of
@@ -72,6 +76,8 @@ type FSM struct {
 	hintedSegments []Segment
 	// Ordered Fnodes which are still live at |hintedSegments| completion.
 	hintedFnodes []Fnode
+	// AuthorEpoch as-of |hintedSegments| completion.
+	hintedAuthorEpoch uint64
 }
 
 // LiveLogSegments flattens hinted LiveNodes into an ordered list of Fnodes,
@@ -121,6 +127,7 @@ func NewFSM(hints FSMHints) (*FSM, error) {
 	if len(set) != 0 {
 		fsm.NextSeqNo, fsm.NextChecksum = set[0].FirstSeqNo, set[0].FirstChecksum
 		fsm.hintedSegments = []Segment(set)
+		fsm.hintedAuthorEpoch = hints.AuthorEpoch
 	}
 
 	// Flatten hinted properties into |fsm|.
@@ -147,6 +154,12 @@ func (m *FSM) Apply(op *RecordedOp, frame []byte) error {
 		// FSMHints we're re-building.
 		return ErrNotHintedAuthor
 	}
+	// Once hints are exhausted, ensure that op.AuthorEpoch hasn't been fenced by
+	// a later Author. While hints remain, they alone determine applied Authors
+	// (and hinted Segments may well have been written under prior epochs).
+	if len(m.hintedSegments) == 0 && op.AuthorEpoch < m.AuthorEpoch {
+		return ErrStaleAuthorEpoch
+	}
 
 	if op.SeqNo != m.NextSeqNo {
 		return ErrWrongSeqNo
@@ -179,6 +192,10 @@ func (m *FSM) Apply(op *RecordedOp, frame []byte) error {
 	m.NextSeqNo += 1
 	m.NextChecksum = crc32.Update(m.NextChecksum, crcTable, frame)
 
+	if op.AuthorEpoch > m.AuthorEpoch {
+		m.AuthorEpoch = op.AuthorEpoch
+	}
+
 	// If we've exhausted the current hinted Segment, pop and skip to the next.
 	if len(m.hintedSegments) != 0 && m.hintedSegments[0].LastSeqNo < m.NextSeqNo {
 		m.hintedSegments = m.hintedSegments[1:]
@@ -186,6 +203,8 @@ func (m *FSM) Apply(op *RecordedOp, frame []byte) error {
 		if len(m.hintedSegments) != 0 {
 			m.NextSeqNo = m.hintedSegments[0].FirstSeqNo
 			m.NextChecksum = m.hintedSegments[0].FirstChecksum
+		} else if m.hintedAuthorEpoch > m.AuthorEpoch {
+			m.AuthorEpoch = m.hintedAuthorEpoch
 		}
 	}
 	return err
@@ -280,7 +299,7 @@ func (m *FSM) applyProperty(op *Property) error {
 
 // BuildHints constructs FSMHints which enable a future FSM to rebuild this FSM's state.
 func (m *FSM) BuildHints() FSMHints {
-	var hints = FSMHints{Log: m.Log}
+	var hints = FSMHints{Log: m.Log, AuthorEpoch: m.AuthorEpoch}
 
 	// Flatten LiveNodes into deep-copied FnodeSegments.
 	for fnode, state := range m.LiveNodes {
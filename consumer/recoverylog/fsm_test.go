@@ -471,6 +471,51 @@ func (s *FSMSuite) TestUseOfHintedAuthors(c *gc.C) {
 	})
 }
 
+func (s *FSMSuite) TestAuthorEpochFencing(c *gc.C) {
+	s.fsm = s.newFSM(c, FSMHints{Log: aRecoveryLog})
+
+	// Operations of epoch zero (eg, of legacy Recorders) are applied.
+	c.Check(s.create(1, 0x0, 100, "/path/one"), gc.IsNil)
+	c.Check(s.fsm.AuthorEpoch, gc.Equals, uint64(0))
+
+	// An operation of a later epoch is applied, and steps the FSM epoch.
+	c.Check(s.apply(RecordedOp{SeqNo: 2, Checksum: s.fsm.NextChecksum, Author: 200,
+		AuthorEpoch: 2, Write: &RecordedOp_Write{Fnode: 1}}), gc.IsNil)
+	c.Check(s.fsm.AuthorEpoch, gc.Equals, uint64(2))
+
+	// A stale Recorder of a prior epoch is fenced, even though its
+	// operation is otherwise correctly sequenced.
+	c.Check(s.apply(RecordedOp{SeqNo: 3, Checksum: s.fsm.NextChecksum, Author: 100,
+		AuthorEpoch: 1, Write: &RecordedOp_Write{Fnode: 1}}), gc.Equals, ErrStaleAuthorEpoch)
+	c.Check(s.write(3, s.fsm.NextChecksum, 100, 1), gc.Equals, ErrStaleAuthorEpoch)
+	c.Check(s.fsm.NextSeqNo, gc.Equals, int64(3))
+
+	// Operations of the current epoch continue to apply.
+	c.Check(s.apply(RecordedOp{SeqNo: 3, Checksum: s.fsm.NextChecksum, Author: 200,
+		AuthorEpoch: 2, Write: &RecordedOp_Write{Fnode: 1}}), gc.IsNil)
+
+	var hints = s.fsm.BuildHints()
+	c.Check(hints.AuthorEpoch, gc.Equals, uint64(2))
+
+	// An FSM initialized from |hints| plays back hinted Segments of prior
+	// epochs, and thereafter fences operations of epochs prior to the hints.
+	s.offset = 0
+	s.fsm = s.newFSM(c, hints)
+	c.Check(s.fsm.AuthorEpoch, gc.Equals, uint64(0))
+
+	c.Check(s.create(1, 0x0, 100, "/path/one"), gc.IsNil)
+	c.Check(s.apply(RecordedOp{SeqNo: 2, Checksum: s.fsm.NextChecksum, Author: 200,
+		AuthorEpoch: 2, Write: &RecordedOp_Write{Fnode: 1}}), gc.IsNil)
+	c.Check(s.apply(RecordedOp{SeqNo: 3, Checksum: s.fsm.NextChecksum, Author: 200,
+		AuthorEpoch: 2, Write: &RecordedOp_Write{Fnode: 1}}), gc.IsNil)
+	c.Check(s.fsm.hasRemainingHints(), gc.Equals, false)
+
+	c.Check(s.write(4, s.fsm.NextChecksum, 100, 1), gc.Equals, ErrStaleAuthorEpoch)
+	c.Check(s.apply(RecordedOp{SeqNo: 4, Checksum: s.fsm.NextChecksum, Author: 300,
+		AuthorEpoch: 3, Write: &RecordedOp_Write{Fnode: 1}}), gc.IsNil)
+	c.Check(s.fsm.AuthorEpoch, gc.Equals, uint64(3))
+}
+
 func (s *FSMSuite) apply(op RecordedOp) error {
 	// Create a unique "frame" from |offset| for FSM to digest over, in production of checksums.
 	s.offset += 1
@@ -27,6 +27,7 @@ type Player struct {
 	handoffCh chan Author   // Coordinates Player completion (& hand-off to a new Recorder).
 	tailingCh chan struct{} // Closed when Player reaches (and is tailing) the live log.
 	doneCh    chan struct{} // Closed when Player.Play completes.
+	replayFn  func(offset, writeHead int64)
 }
 
 // NewPlayer returns a new Player for recovering a log.
@@ -44,7 +45,7 @@ func NewPlayer() *Player {
 func (p *Player) Play(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJournalClient) error {
 	defer close(p.doneCh)
 
-	if fsm, err := playLog(ctx, hints, dir, ajc, p.tailingCh, p.handoffCh); err != nil {
+	if fsm, err := playLog(ctx, hints, dir, ajc, p.tailingCh, p.handoffCh, p.replayFn); err != nil {
 		return err
 	} else {
 		p.Dir, p.FSM = dir, fsm
@@ -72,6 +73,11 @@ func (p *Player) InjectHandoff(author Author) {
 	close(p.handoffCh)
 }
 
+// OnReplay registers |fn| to be called as Play replays the log, with the
+// offset through which the log has been played and the most recent write
+// head of the log observed by Play. OnReplay must be called before Play.
+func (p *Player) OnReplay(fn func(offset, writeHead int64)) { p.replayFn = fn }
+
 // Tailing returns a channel which selects when Play has reached the log
 // write head, and is tailing new log operations as they arrive.
 func (p *Player) Tailing() <-chan struct{} {
@@ -91,11 +97,12 @@ func (p *Player) Done() <-chan struct{} {
 // near the log write head where the player wishes to complete playback.
 type playerReader struct {
 	rr          *client.RetryReader
-	br          *bufio.Reader   // Wraps |rr|.
-	peekReqCh   chan<- struct{} // Signals to begin a new Peek.
-	peekRespCh  <-chan error    // Signalled with the result of a Peek.
-	pendingPeek bool            // Indicates that a Peek is underway.
-	block       bool            // Block field of ReadRequest. Retained to avoid a data race.
+	hr          *client.WriteHeadReader // Wraps |rr|.
+	br          *bufio.Reader           // Wraps |hr|.
+	peekReqCh   chan<- struct{}         // Signals to begin a new Peek.
+	peekRespCh  <-chan error            // Signalled with the result of a Peek.
+	pendingPeek bool                    // Indicates that a Peek is underway.
+	block       bool                    // Block field of ReadRequest. Retained to avoid a data race.
 }
 
 func newPlayerReader(ctx context.Context, name pb.Journal, ajc client.AsyncJournalClient) *playerReader {
@@ -108,9 +115,12 @@ func newPlayerReader(ctx context.Context, name pb.Journal, ajc client.AsyncJourn
 	var reqCh = make(chan struct{}, 1)
 	var respCh = make(chan error, 1)
 
+	var hr = &client.WriteHeadReader{RetryReader: rr}
+
 	var pr = &playerReader{
 		rr:         rr,
-		br:         bufio.NewReaderSize(rr, 32*1024),
+		hr:         hr,
+		br:         bufio.NewReaderSize(hr, 32*1024),
 		peekReqCh:  reqCh,
 		peekRespCh: respCh,
 		block:      rr.Reader.Request.Block,
@@ -205,9 +215,12 @@ const (
 // and otherwise blocks indefinitely until signalled by |handoffCh|. If signaled
 // with a zero-valued Author, playLog exits upon reaching the log head. Otherwise,
 // playLog exits upon injecting a properly sequenced no-op RecordedOp which encodes
-// the provided Author. The recovered FSM is returned on success.
+// the provided Author. If |replayFn| is non-nil, it's called with the offset
+// through which the log has been played and the log write head, as each
+// operation is played and as playLog reaches the log head. The recovered FSM
+// is returned on success.
 func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJournalClient,
-	tailingCh chan<- struct{}, handoffCh <-chan Author) (fsm *FSM, err error) {
+	tailingCh chan<- struct{}, handoffCh <-chan Author, replayFn func(offset, writeHead int64)) (fsm *FSM, err error) {
 
 	var state = playerStateBackfill
 	var files = make(fnodeFileMap) // Live Fnodes backed by local files.
@@ -313,6 +326,9 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 					Panic("unexpected ErrNotYetAvailable")
 			}
 
+			if replayFn != nil {
+				replayFn(offset, offset)
+			}
 			if tailingCh != nil {
 				// Signal that we've caught up to the approximate log write-head.
 				close(tailingCh)
@@ -335,9 +351,10 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 				var txn = ajc.StartAppend(hints.Log)
 
 				err = txn.Require(message.FixedFraming.Marshal(&RecordedOp{
-					SeqNo:    fsm.NextSeqNo,
-					Checksum: fsm.NextChecksum,
-					Author:   handoff,
+					SeqNo:       fsm.NextSeqNo,
+					Checksum:    fsm.NextChecksum,
+					Author:      handoff,
+					AuthorEpoch: fsm.AuthorEpoch + 1,
 				}, txn.Writer())).Release()
 
 				if err == nil {
@@ -416,6 +433,10 @@ func playLog(ctx context.Context, hints FSMHints, dir string, ajc client.AsyncJo
 		}
 
 		offset = op.LastOffset
+
+		if replayFn != nil {
+			replayFn(offset, reader.hr.WriteHead)
+		}
 	}
 }
 
@@ -472,6 +493,9 @@ func applyOperation(op RecordedOp, frame []byte, fsm *FSM) bool {
 		// The FSM has remaining playback hints, and this operation doesn't match
 		// the next expected Author. This happens frequently during Recorder hand-off;
 		// the operation is a dead branch of the log.
+	} else if err == ErrStaleAuthorEpoch {
+		// A later Author has taken over the log, and this operation was written
+		// by a prior Recorder which hasn't yet noticed. It's a dead branch.
 	} else if err == ErrWrongSeqNo && op.SeqNo < fsm.NextSeqNo {
 		// |op| is prior to the next hinted SeqNo. We may have started reading
 		// from a lower-bound offset, or it may be a duplicated write.
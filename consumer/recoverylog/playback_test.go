@@ -403,8 +403,12 @@ func (s *PlaybackSuite) TestPlayWithFinishAtWriteHead(c *gc.C) {
 	f.RecordWrite([]byte("!"))
 	<-f.WeakBarrier().Done() // Flush all recorded ops.
 
-	// Start a Player from the initial |hints|.
+	// Start a Player from the initial |hints|, which reports its replay progress.
 	var player = NewPlayer()
+	var replayed [][2]int64
+	player.OnReplay(func(offset, writeHead int64) {
+		replayed = append(replayed, [2]int64{offset, writeHead})
+	})
 	go func() {
 		c.Check(player.Play(context.Background(), hints, dir, bk), gc.IsNil)
 	}()
@@ -414,6 +418,15 @@ func (s *PlaybackSuite) TestPlayWithFinishAtWriteHead(c *gc.C) {
 
 	c.Check(player.FSM, gc.NotNil)
 
+	// Expect replay progress was reported in offset order, and that the
+	// Player last reported it had played through the log write head.
+	c.Assert(len(replayed) > 1, gc.Equals, true)
+	for i := 1; i != len(replayed); i++ {
+		c.Check(replayed[i][0] >= replayed[i-1][0], gc.Equals, true)
+	}
+	var last = replayed[len(replayed)-1]
+	c.Check(last[0], gc.Equals, last[1])
+
 	expectFileContent(c, dir+"/foo/bar", "hello world!")
 	expectFileContent(c, dir+"/baz", "bing")
 }
@@ -25,7 +25,7 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 // RecordedOp records states changes occuring within a local file-system.
-// Next tag: 11.
+// Next tag: 12.
 type RecordedOp struct {
 	// Monotonically-increasing sequence number of this operation.
 	SeqNo int64 `protobuf:"varint,1,opt,name=seq_no,json=seqNo,proto3" json:"seq_no,omitempty"`
@@ -40,8 +40,15 @@ type RecordedOp struct {
 	// fields which are not populated in the recorded log (as Recorders cannot
 	// know at what offsets their writes will land in the log). Instead, Players
 	// attach offsets as they deserialize RecordedOps from the committed log.
-	FirstOffset int64              `protobuf:"varint,9,opt,name=first_offset,json=firstOffset,proto3" json:"first_offset,omitempty"`
-	LastOffset  int64              `protobuf:"varint,10,opt,name=last_offset,json=lastOffset,proto3" json:"last_offset,omitempty"`
+	FirstOffset int64 `protobuf:"varint,9,opt,name=first_offset,json=firstOffset,proto3" json:"first_offset,omitempty"`
+	LastOffset  int64 `protobuf:"varint,10,opt,name=last_offset,json=lastOffset,proto3" json:"last_offset,omitempty"`
+	// Epoch of the Author which wrote this RecordedOp. Each Recorder takes an
+	// epoch one greater than the largest epoch it observed during playback, and
+	// a Player ignores operations having an epoch less than one it's already
+	// applied. This fences a stale Recorder which continues to write after a
+	// newer Recorder has taken over the log, even if its operations happen to
+	// be correctly sequenced.
+	AuthorEpoch uint64             `protobuf:"varint,11,opt,name=author_epoch,json=authorEpoch,proto3" json:"author_epoch,omitempty"`
 	Create      *RecordedOp_Create `protobuf:"bytes,4,opt,name=create,proto3" json:"create,omitempty"`
 	Link        *RecordedOp_Link   `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"`
 	Unlink      *RecordedOp_Link   `protobuf:"bytes,6,opt,name=unlink,proto3" json:"unlink,omitempty"`
@@ -373,7 +380,7 @@ var xxx_messageInfo_FnodeSegments proto.InternalMessageInfo
 // a Player to resolve all possible conflicts it could encounter while reading
 // the log, to arrive at a consistent view of file state which exactly matches
 // that of the Recorder producing the FSMHints.
-// Next tag: 4.
+// Next tag: 5.
 type FSMHints struct {
 	// Log is the Journal name holding recorded log content.
 	Log go_gazette_dev_core_broker_protocol.Journal `protobuf:"bytes,1,opt,name=log,proto3,casttype=go.gazette.dev/core/broker/protocol.Journal" json:"log,omitempty"`
@@ -381,6 +388,8 @@ type FSMHints struct {
 	LiveNodes []FnodeSegments `protobuf:"bytes,2,rep,name=live_nodes,json=liveNodes,proto3" json:"live_nodes"`
 	// Property files and contents as-of the generation of these FSMHints.
 	Properties []Property `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties"`
+	// Largest Author epoch applied as-of the generation of these FSMHints.
+	AuthorEpoch uint64 `protobuf:"varint,4,opt,name=author_epoch,json=authorEpoch,proto3" json:"author_epoch,omitempty"`
 }
 
 func (m *FSMHints) Reset()         { *m = FSMHints{} }
@@ -432,49 +441,51 @@ func init() {
 }
 
 var fileDescriptor_8d704f4690064e9d = []byte{
-	// 664 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x6b, 0x13, 0x41,
-	0x14, 0xce, 0x26, 0xbb, 0x9b, 0xe4, 0xc5, 0x7a, 0x18, 0x5a, 0x59, 0x82, 0xee, 0xc6, 0x80, 0x12,
-	0x10, 0x36, 0xda, 0x4a, 0x0f, 0x0a, 0x4a, 0x53, 0x28, 0x22, 0xda, 0xca, 0xf4, 0x20, 0x78, 0x30,
-	0xa4, 0x9b, 0xc9, 0x66, 0xc9, 0x76, 0x27, 0x9d, 0x9d, 0x54, 0xea, 0xaf, 0xf0, 0x27, 0xe8, 0xbf,
-	0xe9, 0xb1, 0xde, 0x3c, 0x05, 0x6d, 0xfe, 0x45, 0xf1, 0x20, 0xf3, 0x76, 0x92, 0x26, 0x6d, 0x4a,
-	0xbd, 0x84, 0x99, 0x6f, 0xbe, 0xef, 0xcd, 0xfb, 0xf2, 0xbe, 0x59, 0x78, 0x1c, 0xf0, 0x24, 0x1d,
-	0x1d, 0x32, 0xd1, 0x14, 0x2c, 0xe0, 0xc7, 0x4c, 0x9c, 0xc4, 0x3c, 0xc4, 0xb5, 0xe8, 0xb2, 0x6e,
-	0x9b, 0x0f, 0xfd, 0xa1, 0xe0, 0x92, 0x93, 0xca, 0xdc, 0x71, 0x75, 0x35, 0xe4, 0x21, 0x47, 0xbc,
-	0xa9, 0x56, 0x19, 0xa5, 0xfe, 0xd7, 0x04, 0xa0, 0x5a, 0xb8, 0x37, 0x24, 0x6b, 0x60, 0xa7, 0xec,
-	0xa8, 0x9d, 0x70, 0xc7, 0xa8, 0x19, 0x8d, 0x02, 0xb5, 0x52, 0x76, 0xb4, 0xcb, 0x49, 0x15, 0x4a,
-	0x41, 0x9f, 0x05, 0x83, 0x74, 0x74, 0xe8, 0xe4, 0x6b, 0x46, 0xa3, 0x48, 0x67, 0x7b, 0x52, 0x07,
-	0xbb, 0x33, 0x92, 0x7d, 0x2e, 0x9c, 0x82, 0x3a, 0x69, 0xc1, 0xc5, 0xd8, 0xb3, 0xb7, 0x10, 0xa1,
-	0xfa, 0x84, 0x3c, 0x84, 0x3b, 0xbd, 0x48, 0xa4, 0xb2, 0xcd, 0x7b, 0xbd, 0x94, 0x49, 0xa7, 0x8c,
-	0xc5, 0x2b, 0x88, 0xed, 0x21, 0x44, 0x3c, 0xa8, 0xc4, 0x9d, 0x4b, 0x06, 0x20, 0x03, 0x14, 0xa4,
-	0x09, 0x9b, 0x60, 0x07, 0x82, 0x75, 0x24, 0x73, 0xcc, 0x9a, 0xd1, 0xa8, 0xac, 0xbb, 0xfe, 0x9c,
-	0x3b, 0xff, 0xd2, 0x83, 0xbf, 0x8d, 0x2c, 0xaa, 0xd9, 0xe4, 0x29, 0x98, 0x71, 0x94, 0x0c, 0x1c,
-	0x0b, 0x55, 0xf7, 0x6f, 0x52, 0xbd, 0x8b, 0x92, 0x01, 0x45, 0x26, 0x79, 0x0e, 0xf6, 0x28, 0x41,
-	0x8d, 0xfd, 0x1f, 0x1a, 0xcd, 0x25, 0x1b, 0x60, 0x7d, 0x11, 0x91, 0x64, 0x4e, 0x11, 0x45, 0x0f,
-	0x6e, 0x12, 0x7d, 0x54, 0x24, 0x9a, 0x71, 0xc9, 0x33, 0x28, 0x0d, 0x05, 0x1f, 0x32, 0x21, 0x4f,
-	0x9c, 0x12, 0xea, 0xd6, 0x16, 0x74, 0x1f, 0xf4, 0x21, 0x9d, 0xd1, 0xaa, 0x75, 0xb0, 0x33, 0x87,
-	0x84, 0x80, 0x39, 0xec, 0xc8, 0x3e, 0x8e, 0xaa, 0x4c, 0x71, 0xfd, 0xc2, 0x3c, 0xfb, 0xe1, 0xe5,
-	0xaa, 0x5b, 0x60, 0xaa, 0xde, 0x88, 0x07, 0x56, 0x2f, 0xe1, 0x5d, 0x96, 0x4d, 0xb3, 0x55, 0xbe,
-	0x18, 0x7b, 0xd6, 0x8e, 0x02, 0x68, 0x86, 0xcf, 0x4a, 0xe4, 0xaf, 0x95, 0xf8, 0x0c, 0x16, 0x76,
-	0x7a, 0x7b, 0x8d, 0x7b, 0x60, 0xeb, 0xa1, 0xe5, 0x71, 0x68, 0x7a, 0xa7, 0xf0, 0x98, 0x25, 0xa1,
-	0xec, 0x63, 0x30, 0x0a, 0x54, 0xef, 0xb2, 0xfa, 0xd9, 0x6f, 0xfd, 0x15, 0x94, 0xa6, 0x16, 0x97,
-	0xd9, 0x21, 0x0e, 0x14, 0x03, 0x9e, 0x48, 0x96, 0x48, 0xdd, 0xe2, 0x74, 0xab, 0xf5, 0xbf, 0x0d,
-	0x28, 0xee, 0xb3, 0xf0, 0x90, 0x25, 0x72, 0x2e, 0x88, 0xc6, 0x8d, 0x41, 0xac, 0x4d, 0x83, 0xa8,
-	0x53, 0x9e, 0x75, 0x0c, 0x88, 0xed, 0x63, 0xd4, 0xaf, 0x46, 0xb5, 0x70, 0x3d, 0xaa, 0x8f, 0xe0,
-	0x6e, 0x46, 0x99, 0xbd, 0x09, 0x13, 0xdf, 0xc4, 0x0a, 0xa2, 0xdb, 0xd3, 0x87, 0xe1, 0xea, 0x44,
-	0xeb, 0xab, 0x2c, 0x2c, 0x54, 0x56, 0x50, 0x76, 0xd3, 0x95, 0xc4, 0xdb, 0x57, 0x13, 0xaf, 0x2d,
-	0x26, 0xb0, 0x82, 0x7f, 0xb7, 0xb6, 0x99, 0xde, 0x3e, 0x90, 0x4d, 0x28, 0xa5, 0x9a, 0xec, 0xe4,
-	0x6b, 0x85, 0x46, 0x65, 0x7d, 0x75, 0x21, 0x54, 0xba, 0x52, 0xcb, 0x3c, 0x1d, 0x7b, 0x39, 0x3a,
-	0xe3, 0xea, 0xfb, 0x7e, 0x1a, 0x50, 0xda, 0xd9, 0x7f, 0xff, 0x26, 0x52, 0x77, 0x6d, 0x41, 0x21,
-	0xe6, 0x61, 0x36, 0x92, 0x56, 0xf3, 0x62, 0xec, 0x3d, 0x09, 0xb9, 0x1f, 0x76, 0xbe, 0x32, 0x29,
-	0x99, 0xdf, 0x65, 0xc7, 0xcd, 0x80, 0x0b, 0xd6, 0x3c, 0x10, 0x7c, 0xc0, 0x44, 0x13, 0xbf, 0x28,
-	0x01, 0x8f, 0xfd, 0xb7, 0x7c, 0x24, 0x92, 0x4e, 0x4c, 0x95, 0x96, 0xbc, 0x06, 0x88, 0xa3, 0x63,
-	0xd6, 0x56, 0xad, 0x4d, 0xfb, 0xa9, 0x2e, 0xf4, 0xb3, 0x60, 0x4f, 0x77, 0x55, 0x56, 0x9a, 0x5d,
-	0x25, 0x21, 0x2f, 0x01, 0x74, 0xf8, 0x23, 0x96, 0x3a, 0x05, 0x2c, 0xb0, 0xfc, 0x95, 0x68, 0xed,
-	0x1c, 0x3d, 0xf3, 0xd4, 0xda, 0x39, 0xfd, 0xe3, 0xe6, 0x4e, 0xcf, 0x5d, 0xe3, 0xec, 0xdc, 0x35,
-	0xbe, 0x4d, 0xdc, 0xdc, 0xf7, 0x89, 0x6b, 0x9c, 0x4d, 0xdc, 0xdc, 0xaf, 0x89, 0x9b, 0xfb, 0xd4,
-	0x58, 0xe6, 0x69, 0xd9, 0x27, 0xf6, 0xc0, 0x46, 0x8b, 0x1b, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0xe5, 0x5d, 0xa8, 0x7c, 0x81, 0x05, 0x00, 0x00,
+	// 689 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0x8d, 0xe3, 0x9f, 0x24, 0xd7, 0x5f, 0xbf, 0xc5, 0xa8, 0x45, 0x56, 0x04, 0xb6, 0x89, 0x04,
+	0x8a, 0x84, 0xe4, 0x40, 0x8b, 0xba, 0x00, 0x09, 0xd4, 0x54, 0x54, 0x08, 0x41, 0x8b, 0xa6, 0x0b,
+	0x24, 0x16, 0x44, 0xae, 0x33, 0x71, 0xac, 0xb8, 0x1e, 0x77, 0x3c, 0x29, 0x2a, 0x4f, 0xc1, 0x1b,
+	0x00, 0x6f, 0xd3, 0x65, 0x97, 0xac, 0x22, 0x68, 0x5e, 0x81, 0x55, 0x57, 0xc8, 0xe3, 0x49, 0x9a,
+	0x34, 0x89, 0xca, 0x26, 0xf2, 0x9c, 0x39, 0xe7, 0xce, 0xfd, 0x3b, 0x81, 0x87, 0x01, 0x4d, 0xb2,
+	0xe1, 0x31, 0x61, 0x2d, 0x46, 0x02, 0x7a, 0x4a, 0xd8, 0x59, 0x4c, 0x43, 0xf1, 0xcd, 0xba, 0xa4,
+	0xdb, 0xa1, 0xa9, 0x97, 0x32, 0xca, 0x29, 0x32, 0x67, 0xae, 0xeb, 0xeb, 0x21, 0x0d, 0xa9, 0xc0,
+	0x5b, 0xf9, 0x57, 0x41, 0x69, 0x7c, 0xd3, 0x01, 0xb0, 0x14, 0x1e, 0xa4, 0x68, 0x03, 0x8c, 0x8c,
+	0x9c, 0x74, 0x12, 0x6a, 0x29, 0xae, 0xd2, 0x54, 0xb1, 0x9e, 0x91, 0x93, 0x7d, 0x8a, 0xea, 0x50,
+	0x0d, 0xfa, 0x24, 0x18, 0x64, 0xc3, 0x63, 0xab, 0xec, 0x2a, 0xcd, 0x0a, 0x9e, 0x9e, 0x51, 0x03,
+	0x0c, 0x7f, 0xc8, 0xfb, 0x94, 0x59, 0x6a, 0x7e, 0xd3, 0x86, 0xab, 0x91, 0x63, 0xec, 0x08, 0x04,
+	0xcb, 0x1b, 0x74, 0x1f, 0xfe, 0xeb, 0x45, 0x2c, 0xe3, 0x1d, 0xda, 0xeb, 0x65, 0x84, 0x5b, 0x35,
+	0x11, 0xdc, 0x14, 0xd8, 0x81, 0x80, 0x90, 0x03, 0x66, 0xec, 0x5f, 0x33, 0x40, 0x30, 0x20, 0xf6,
+	0xa7, 0x84, 0x6d, 0x30, 0x02, 0x46, 0x7c, 0x4e, 0x2c, 0xcd, 0x55, 0x9a, 0xe6, 0xa6, 0xed, 0xcd,
+	0x54, 0xe7, 0x5d, 0xd7, 0xe0, 0xed, 0x0a, 0x16, 0x96, 0x6c, 0xf4, 0x18, 0xb4, 0x38, 0x4a, 0x06,
+	0x96, 0x2e, 0x54, 0x77, 0x57, 0xa9, 0xde, 0x46, 0xc9, 0x00, 0x0b, 0x26, 0x7a, 0x0a, 0xc6, 0x30,
+	0x11, 0x1a, 0xe3, 0x1f, 0x34, 0x92, 0x8b, 0xb6, 0x40, 0xff, 0xcc, 0x22, 0x4e, 0xac, 0x8a, 0x10,
+	0xdd, 0x5b, 0x25, 0xfa, 0x90, 0x93, 0x70, 0xc1, 0x45, 0x4f, 0xa0, 0x9a, 0x32, 0x9a, 0x12, 0xc6,
+	0xcf, 0xac, 0xaa, 0xd0, 0x6d, 0xcc, 0xe9, 0xde, 0xcb, 0x4b, 0x3c, 0xa5, 0xe5, 0xbd, 0x2c, 0xba,
+	0xda, 0x21, 0x29, 0x0d, 0xfa, 0x96, 0xe9, 0x2a, 0x4d, 0x0d, 0x9b, 0x05, 0xf6, 0x2a, 0x87, 0xea,
+	0x0d, 0x30, 0x8a, 0x26, 0x20, 0x04, 0x5a, 0xea, 0xf3, 0xbe, 0x98, 0x66, 0x0d, 0x8b, 0xef, 0x67,
+	0xda, 0xc5, 0x0f, 0xa7, 0x54, 0xdf, 0x01, 0x2d, 0x4f, 0x1f, 0x39, 0xa0, 0xf7, 0x12, 0xda, 0x25,
+	0xc5, 0xc0, 0xdb, 0xb5, 0xab, 0x91, 0xa3, 0xef, 0xe5, 0x00, 0x2e, 0xf0, 0x69, 0x88, 0xf2, 0x42,
+	0x88, 0x4f, 0xa0, 0x8b, 0x62, 0x6e, 0x8f, 0x71, 0x07, 0x0c, 0x39, 0xd7, 0xb2, 0x98, 0xab, 0x3c,
+	0xe5, 0x78, 0x4c, 0x92, 0x90, 0xf7, 0xc5, 0xee, 0xa8, 0x58, 0x9e, 0x8a, 0xf8, 0xc5, 0x6f, 0xe3,
+	0x05, 0x54, 0x27, 0x5d, 0x58, 0x56, 0x0e, 0xb2, 0xa0, 0x12, 0xd0, 0x84, 0x93, 0x84, 0xcb, 0x14,
+	0x27, 0x47, 0xa9, 0xff, 0xa5, 0x40, 0xe5, 0x90, 0x84, 0xc7, 0x24, 0xe1, 0x33, 0xbb, 0xaa, 0xac,
+	0xdc, 0x55, 0x77, 0xb2, 0xab, 0xd2, 0x08, 0x45, 0xc6, 0x20, 0xb0, 0x43, 0xe1, 0x86, 0x9b, 0xdb,
+	0xac, 0x2e, 0x6e, 0xf3, 0x03, 0xf8, 0xbf, 0xa0, 0x4c, 0x6d, 0xa3, 0x09, 0xdb, 0xac, 0x09, 0x74,
+	0x57, 0x82, 0xc8, 0x96, 0x4b, 0x2f, 0x9f, 0xd2, 0x45, 0xa0, 0x5a, 0xec, 0x4f, 0x5e, 0xba, 0x61,
+	0x0a, 0xe3, 0xa6, 0x29, 0x64, 0x89, 0x09, 0xac, 0x89, 0x76, 0xcb, 0x32, 0xb3, 0xdb, 0x07, 0xb2,
+	0x0d, 0xd5, 0x4c, 0x92, 0xad, 0xb2, 0xab, 0x36, 0xcd, 0xcd, 0xf5, 0xb9, 0xbd, 0x93, 0x91, 0xda,
+	0xda, 0xf9, 0xc8, 0x29, 0xe1, 0x29, 0x57, 0xbe, 0xf7, 0x47, 0x81, 0xea, 0xde, 0xe1, 0xbb, 0xd7,
+	0x51, 0xfe, 0xd6, 0x0e, 0xa8, 0x31, 0x0d, 0x8b, 0x91, 0xb4, 0x5b, 0x57, 0x23, 0xe7, 0x51, 0x48,
+	0xbd, 0xd0, 0xff, 0x42, 0x38, 0x27, 0x5e, 0x97, 0x9c, 0xb6, 0x02, 0xca, 0x48, 0xeb, 0x88, 0xd1,
+	0x01, 0x61, 0x2d, 0xf1, 0xa7, 0x13, 0xd0, 0xd8, 0x7b, 0x43, 0x87, 0x2c, 0xf1, 0x63, 0x9c, 0x6b,
+	0xd1, 0x4b, 0x80, 0x38, 0x3a, 0x25, 0x9d, 0x3c, 0xb5, 0x49, 0x3e, 0xf5, 0xb9, 0x7c, 0xe6, 0xca,
+	0x93, 0x59, 0xd5, 0x72, 0xcd, 0x7e, 0x2e, 0x41, 0xcf, 0x01, 0xa4, 0x3f, 0x22, 0x92, 0x59, 0xaa,
+	0xab, 0xae, 0x34, 0x92, 0xd4, 0xce, 0xd0, 0x17, 0x0c, 0xa5, 0x2d, 0x18, 0xaa, 0x28, 0xbb, 0xbd,
+	0x77, 0xfe, 0xdb, 0x2e, 0x9d, 0x5f, 0xda, 0xca, 0xc5, 0xa5, 0xad, 0x7c, 0x1d, 0xdb, 0xa5, 0xef,
+	0x63, 0x5b, 0xb9, 0x18, 0xdb, 0xa5, 0x9f, 0x63, 0xbb, 0xf4, 0xb1, 0xb9, 0xac, 0xec, 0x65, 0x7f,
+	0xd4, 0x47, 0x86, 0xe8, 0xc2, 0xd6, 0xdf, 0x01, 0x00, 0xca, 0x36, 0x5f, 0x7f, 0xc7, 0x05, 0x00,
+	0x00,
 }
 
 func (m *RecordedOp) Marshal() (dAtA []byte, err error) {
@@ -569,6 +580,11 @@ func (m *RecordedOp) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRecordedOp(dAtA, i, uint64(m.LastOffset))
 	}
+	if m.AuthorEpoch != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.AuthorEpoch))
+	}
 	return i, nil
 }
 
@@ -818,6 +834,11 @@ func (m *FSMHints) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.AuthorEpoch != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRecordedOp(dAtA, i, uint64(m.AuthorEpoch))
+	}
 	return i, nil
 }
 
@@ -871,6 +892,9 @@ func (m *RecordedOp) ProtoSize() (n int) {
 	if m.LastOffset != 0 {
 		n += 1 + sovRecordedOp(uint64(m.LastOffset))
 	}
+	if m.AuthorEpoch != 0 {
+		n += 1 + sovRecordedOp(uint64(m.AuthorEpoch))
+	}
 	return n
 }
 
@@ -1005,6 +1029,9 @@ func (m *FSMHints) ProtoSize() (n int) {
 			n += 1 + l + sovRecordedOp(uint64(l))
 		}
 	}
+	if m.AuthorEpoch != 0 {
+		n += 1 + sovRecordedOp(uint64(m.AuthorEpoch))
+	}
 	return n
 }
 
@@ -1307,6 +1334,25 @@ func (m *RecordedOp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthorEpoch", wireType)
+			}
+			m.AuthorEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AuthorEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRecordedOp(dAtA[iNdEx:])
@@ -2131,6 +2177,25 @@ func (m *FSMHints) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AuthorEpoch", wireType)
+			}
+			m.AuthorEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordedOp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AuthorEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRecordedOp(dAtA[iNdEx:])
@@ -32,6 +32,9 @@ type Recorder struct {
 	fsm *FSM
 	// Generated unique ID of this Recorder.
 	id Author
+	// Epoch of this Recorder, which is greater than that of any Author
+	// previously applied to |fsm|.
+	epoch uint64
 	// Prefix length to strip from filenames in recorded operations.
 	stripLen int
 	// Appender to the recovery log. We also rely on AsyncJournalClient to guard the
@@ -58,6 +61,7 @@ func NewRecorder(fsm *FSM, id Author, dir string, cl client.AsyncJournalClient)
 	var recorder = &Recorder{
 		fsm:      fsm,
 		id:       id,
+		epoch:    fsm.AuthorEpoch + 1,
 		stripLen: len(filepath.Clean(dir)),
 		cl:       cl,
 	}
@@ -247,6 +251,7 @@ func (r *Recorder) unlockAndReleaseTxn(txn *client.AsyncAppend) {
 
 func (r *Recorder) process(op RecordedOp, bw *bufio.Writer) {
 	op.Author = r.id
+	op.AuthorEpoch = r.epoch
 	op.SeqNo = r.fsm.NextSeqNo
 	op.Checksum = r.fsm.NextChecksum
 
@@ -43,12 +43,13 @@ func (s *RecorderSuite) TestNewFile(c *gc.C) {
 	// Expect two LiveNodes, with increasing FirstOffset (as the commit completed between operations).
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 1, Segments: []Segment{
 				{Author: anAuthor, FirstSeqNo: 1, FirstOffset: offset, FirstChecksum: 0x00000000, LastSeqNo: 1}}},
 			{Fnode: 2, Segments: []Segment{
-				{Author: anAuthor, FirstSeqNo: 2, FirstOffset: offset + 32, FirstChecksum: 0x568e8e4e, LastSeqNo: 2}}},
+				{Author: anAuthor, FirstSeqNo: 2, FirstOffset: offset + 34, FirstChecksum: 0x7b8e86b3, LastSeqNo: 2}}},
 		},
 	})
 }
@@ -72,7 +73,8 @@ func (s *RecorderSuite) TestDeleteFile(c *gc.C) {
 	// Expect no LiveNodes remain.
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 	})
 }
 
@@ -104,10 +106,11 @@ func (s *RecorderSuite) TestOverwriteExistingFile(c *gc.C) {
 	// Expect one LiveNode of Fnode 3.
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 3, Segments: []Segment{
-				{Author: anAuthor, FirstSeqNo: 3, FirstOffset: offset + 32, FirstChecksum: 0x8ee45505, LastSeqNo: 3}}},
+				{Author: anAuthor, FirstSeqNo: 3, FirstOffset: offset + 34, FirstChecksum: 0xa6a27bc1, LastSeqNo: 3}}},
 		},
 	})
 }
@@ -134,7 +137,8 @@ func (s *RecorderSuite) TestLinkFile(c *gc.C) {
 	// Expect one LiveNode.
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 1, Segments: []Segment{
 				{Author: anAuthor, FirstSeqNo: 1, FirstOffset: offset, FirstChecksum: 0x00000000, LastSeqNo: 2}}},
@@ -181,10 +185,11 @@ func (s *RecorderSuite) TestRenameTargetExists(c *gc.C) {
 	// Expect only Fnode 2 in LiveNodes.
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 2, Segments: []Segment{
-				{Author: anAuthor, FirstSeqNo: 2, FirstOffset: offset + 31, FirstChecksum: 0x5ccbeeaa, LastSeqNo: 5}}},
+				{Author: anAuthor, FirstSeqNo: 2, FirstOffset: offset + 33, FirstChecksum: 0x2f9da1b7, LastSeqNo: 5}}},
 		},
 	})
 }
@@ -217,7 +222,8 @@ func (s *RecorderSuite) TestRenameTargetIsNew(c *gc.C) {
 
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 1, Segments: []Segment{
 				{Author: anAuthor, FirstSeqNo: 1, FirstOffset: offset, FirstChecksum: 0x00000000, LastSeqNo: 3}}},
@@ -263,7 +269,8 @@ func (s *RecorderSuite) TestFileAppends(c *gc.C) {
 
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 1, Segments: []Segment{
 				{Author: anAuthor, FirstSeqNo: 1, FirstOffset: offset, FirstChecksum: 0x00000000, LastSeqNo: 4}}},
@@ -305,8 +312,9 @@ func (s *RecorderSuite) TestPropertyUpdate(c *gc.C) {
 	// Property is tracked under fsm.Properties and produced into FSMHints.
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log:       aRecoveryLog,
-		LiveNodes: nil,
+		Log:         aRecoveryLog,
+		LiveNodes:   nil,
+		AuthorEpoch: 1,
 		Properties: []Property{
 			{
 				Path:    "/IDENTITY",
@@ -339,10 +347,11 @@ func (s *RecorderSuite) TestMixedNewFileWriteAndDelete(c *gc.C) {
 	// Expect Fnode 2 (only) is included in LiveNodes, with its write.
 	var hints, _ = rec.BuildHints()
 	c.Check(hints, gc.DeepEquals, FSMHints{
-		Log: aRecoveryLog,
+		Log:         aRecoveryLog,
+		AuthorEpoch: 1,
 		LiveNodes: []FnodeSegments{
 			{Fnode: 2, Segments: []Segment{
-				{Author: anAuthor, FirstSeqNo: 2, FirstChecksum: 0x950c2859, FirstOffset: offset + 31, LastSeqNo: 3}}}},
+				{Author: anAuthor, FirstSeqNo: 2, FirstChecksum: 0xbacc2fbb, FirstOffset: offset + 33, LastSeqNo: 3}}}},
 	})
 }
 
@@ -40,7 +40,10 @@ type Replica struct {
 	app          Application
 	store        Store
 	storeReadyCh chan struct{} // Closed when |store| is ready.
+	freshness    freshness     // Freshness of the primary |store|, or of standby replay.
+	txnSem       chan struct{} // Held for the duration of each transaction.
 	player       *recoverylog.Player
+	interceptors []Interceptor // Interceptors of transactions.
 	// Clients retained for Replica's use during processing.
 	ks            *keyspace.KeySpace
 	etcd          *clientv3.Client
@@ -59,11 +62,15 @@ func NewReplica(app Application, ks *keyspace.KeySpace, etcd *clientv3.Client, r
 		cancel:        cancel,
 		app:           app,
 		storeReadyCh:  make(chan struct{}),
+		txnSem:        make(chan struct{}, 1),
 		player:        recoverylog.NewPlayer(),
 		ks:            ks,
 		etcd:          etcd,
 		journalClient: client.NewAppendService(ctx, rjc),
 	}
+	r.player.OnReplay(func(offset, writeHead int64) {
+		r.freshness.onReplay(offset, writeHead, timeNow())
+	})
 	return r
 }
 
@@ -99,6 +106,7 @@ var transition = func(r *Replica, spec *pc.ShardSpec, assignment keyspace.KeyVal
 
 	if r.spec == nil && !isSlot0 {
 		r.wg.Add(1) // Transition initial => standby.
+		r.freshness.standby = true
 		go r.serveStandby()
 	} else if r.spec == nil && isSlot0 {
 		r.wg.Add(2) // Transition initial => primary.
@@ -106,6 +114,7 @@ var transition = func(r *Replica, spec *pc.ShardSpec, assignment keyspace.KeyVal
 		go r.servePrimary()
 	} else if r.spec != nil && isSlot0 && !wasSlot0 {
 		r.wg.Add(1) // Transition standby => primary.
+		r.freshness.onPromote(timeNow())
 		go r.servePrimary()
 	}
 	r.spec, r.assignment = spec, assignment
@@ -116,6 +125,12 @@ var transition = func(r *Replica, spec *pc.ShardSpec, assignment keyspace.KeyVal
 func (r *Replica) serveStandby() {
 	defer r.wg.Done()
 
+	if !r.Spec().HasRecoveryLog() {
+		// There's no log to tail. The standby is immediately ready for promotion.
+		tryUpdateStatus(r, r.ks, r.etcd, pc.ReplicaStatus{Code: pc.ReplicaStatus_TAILING})
+		return
+	}
+
 	go func() {
 		tryUpdateStatus(r, r.ks, r.etcd, pc.ReplicaStatus{Code: pc.ReplicaStatus_BACKFILL})
 
@@ -134,14 +149,28 @@ func (r *Replica) serveStandby() {
 	}
 }
 
-// servePrimary completes playback of the recovery log, pumps messages from
-// shard journals, and runs consumer transactions.
+// servePrimary completes playback of the recovery log (or loads the external
+// checkpoint of a Shard having no log), pumps messages from shard journals,
+// and runs consumer transactions.
 func (r *Replica) servePrimary() {
 	defer r.wg.Done()
 
-	var store, offsets, err = completePlayback(r, r.app, r.player, r.etcd)
-	if err != nil {
-		err = r.logFailure(extendErr(err, "completePlayback"))
+	var store Store
+	var offsets map[pb.Journal]int64
+	var err error
+	var hintsCh <-chan time.Time
+
+	if r.Spec().HasRecoveryLog() {
+		if store, offsets, err = completePlayback(r, r.app, r.player, r.etcd); err != nil {
+			err = r.logFailure(extendErr(err, "completePlayback"))
+			tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
+			return
+		}
+		var hintsTicker = time.NewTicker(storeHintsInterval)
+		defer hintsTicker.Stop()
+		hintsCh = hintsTicker.C
+	} else if store, offsets, err = loadCheckpoint(r, r.app); err != nil {
+		err = r.logFailure(extendErr(err, "loadCheckpoint"))
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 		return
 	}
@@ -164,11 +193,8 @@ func (r *Replica) servePrimary() {
 		}(src.Journal, offsets[src.Journal])
 	}
 
-	var hintsTicker = time.NewTicker(storeHintsInterval)
-	defer hintsTicker.Stop()
-
 	// Consume messages from |msgCh| until an error occurs (such as context.Cancelled).
-	if err = consumeMessages(r, r.store, r.app, r.etcd, msgCh, hintsTicker.C); err != nil {
+	if err = consumeMessages(r, r.store, r.app, r.etcd, msgCh, hintsCh); err != nil {
 		err = r.logFailure(extendErr(err, "consumeMessages"))
 		tryUpdateStatus(r, r.ks, r.etcd, newErrorStatus(err))
 	}
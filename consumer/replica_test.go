@@ -3,9 +3,11 @@ package consumer
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	gc "github.com/go-check/check"
 	pc "go.gazette.dev/core/consumer/protocol"
+	"go.gazette.dev/core/labels"
 )
 
 type ReplicaSuite struct{}
@@ -55,6 +57,53 @@ func (s *ReplicaSuite) TestDirectToPrimaryTransition(c *gc.C) {
 	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
 }
 
+func (s *ReplicaSuite) TestExternalCheckpointWithoutRecoveryLog(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var spec = makeShard(shardA)
+	spec.RecoveryLogPrefix, spec.HintPrefix = "", ""
+
+	// Begin as a standby. With no log to play, it's immediately TAILING.
+	tf.allocateShard(c, spec, remoteID, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_TAILING)
+
+	tf.allocateShard(c, spec, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	var res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	c.Check(res.Store, gc.FitsTypeOf, new(ExternalCheckpointStore))
+	c.Check(res.Store.Recorder(), gc.IsNil)
+
+	var finishCh = tf.app.finishCh
+	var aa = res.Shard.JournalClient().StartAppend(sourceA)
+	aa.Writer().WriteString(`{"key":"foo","value":"bar"}` + "\n")
+	c.Check(aa.Release(), gc.IsNil)
+	<-finishCh // Block until txn finishes.
+
+	// Expect the checkpoint was stored to the CheckpointKV.
+	var offsets, _ = res.Store.FetchJournalOffsets()
+	c.Check(offsets[sourceA], gc.Equals, aa.Response().Commit.End)
+
+	b, _ := tf.app.checkpoints.Get(tf.ctx, shardA)
+	c.Check(string(b), gc.Equals, fmt.Sprintf(`{"%s":%d}`, sourceA, aa.Response().Commit.End))
+	res.Done()
+
+	// Re-assign the shard. Expect the new replica resumes from the checkpoint.
+	tf.allocateShard(c, spec)
+	tf.allocateShard(c, spec, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	offsets, _ = res.Store.FetchJournalOffsets()
+	c.Check(offsets[sourceA], gc.Equals, aa.Response().Commit.End)
+	res.Done()
+
+	tf.allocateShard(c, spec) // Cleanup.
+}
+
 func (s *ReplicaSuite) TestPlayRecoveryLogError(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -98,6 +147,23 @@ func (s *ReplicaSuite) TestPumpMessagesError(c *gc.C) {
 	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
 }
 
+func (s *ReplicaSuite) TestSourceLabelMismatch(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var shard = makeShard(shardA)
+	shard.Sources[0].ContentType = labels.ContentType_JSONLines // Matches.
+	shard.Sources[1].ContentType = labels.ContentType_ProtoFixed
+	tf.allocateShard(c, shard, localID)
+
+	// Expect that status transitions to FAILED, with a descriptive error.
+	c.Check(expectStatusCode(c, tf.state, pc.ReplicaStatus_FAILED).Errors[0], gc.Equals,
+		`pumpMessages: source journal source/B label content-type ("application/x-ndjson") `+
+			`doesn't match the shard's declared source content-type ("application/x-protobuf-fixed")`)
+
+	tf.allocateShard(c, makeShard(shardA)) // Cleanup.
+}
+
 func (s *ReplicaSuite) TestConsumeMessagesErrors(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -111,6 +177,11 @@ func (s *ReplicaSuite) TestConsumeMessagesErrors(c *gc.C) {
 			func() { tf.app.consumeErr = errors.New("an error") },
 			`consumeMessages: txnStep: app.ConsumeMessage: an error`,
 		},
+		// Case: BeforeCommit() vetoes the commit.
+		{
+			func() { tf.app.commitErr = errors.New("an error") },
+			`consumeMessages: txnStep: app.BeforeCommit: an error`,
+		},
 		// Case: FinishTxn() fails.
 		{
 			func() { tf.app.finishErr = errors.New("an error") },
@@ -126,7 +197,7 @@ func (s *ReplicaSuite) TestConsumeMessagesErrors(c *gc.C) {
 		},
 	}
 	for _, tc := range cases {
-		tf.app.consumeErr, tf.app.finishErr = nil, nil // Reset fixture.
+		tf.app.consumeErr, tf.app.commitErr, tf.app.finishErr = nil, nil, nil // Reset fixture.
 
 		tf.allocateShard(c, makeShard(shardA), localID)
 		expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
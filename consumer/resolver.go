@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -11,6 +12,8 @@ import (
 	"go.gazette.dev/core/allocator"
 	pb "go.gazette.dev/core/broker/protocol"
 	pc "go.gazette.dev/core/consumer/protocol"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Resolver maps shards to responsible consumer processes, and manages the set
@@ -24,6 +27,8 @@ type Resolver struct {
 	newReplica func() *Replica
 	// wg synchronizes over all running local replicas.
 	wg sync.WaitGroup
+	// Interceptors of Resolve.
+	interceptors []Interceptor
 }
 
 // NewResolver returns a Resolver derived from the allocator.State, which
@@ -50,6 +55,10 @@ type ResolveArgs struct {
 	MayProxy bool
 	// Optional Header attached to the request from a proxy-ing peer.
 	ProxyHeader *pb.Header
+	// Optional maximum staleness of a locally resolved primary. If non-zero,
+	// the primary must reflect all content of its source journals which was
+	// available at least MaxStaleness ago, or Status SHARD_STALE is returned.
+	MaxStaleness time.Duration
 }
 
 // Resolution result of a ShardID.
@@ -69,8 +78,46 @@ type Resolution struct {
 	Done func()
 }
 
+// StatusError maps a non-OK Resolution Status to an error having a gRPC
+// status code, for use by application services which don't otherwise
+// return a Status of their own:
+//
+//   - SHARD_NOT_FOUND maps to codes.NotFound.
+//   - NO_SHARD_PRIMARY maps to codes.Unavailable, as the shard is expected to
+//     be assigned a primary and the request may be retried.
+//   - NOT_SHARD_PRIMARY maps to codes.FailedPrecondition, as it results only
+//     from a request which may not be proxied.
+//   - SHARD_STALE maps to codes.Unavailable, as the shard is expected to catch
+//     up and the request may be retried.
+//   - Other Status codes map to codes.Unknown.
+//
+// StatusError panics if |st| is OK.
+func StatusError(st pc.Status) error {
+	var code codes.Code
+
+	switch st {
+	case pc.Status_OK:
+		panic("unexpected Status_OK")
+	case pc.Status_SHARD_NOT_FOUND:
+		code = codes.NotFound
+	case pc.Status_NO_SHARD_PRIMARY:
+		code = codes.Unavailable
+	case pc.Status_NOT_SHARD_PRIMARY:
+		code = codes.FailedPrecondition
+	case pc.Status_SHARD_STALE:
+		code = codes.Unavailable
+	default:
+		code = codes.Unknown
+	}
+	return status.Error(code, st.String())
+}
+
 // Resolve a ShardID to its Resolution.
 func (r *Resolver) Resolve(args ResolveArgs) (res Resolution, err error) {
+	return interceptedResolve(r.interceptors, args, r.resolve)
+}
+
+func (r *Resolver) resolve(args ResolveArgs) (res Resolution, err error) {
 	var ks = r.state.KS
 
 	defer func() {
@@ -182,6 +229,14 @@ func (r *Resolver) Resolve(args ResolveArgs) (res Resolution, err error) {
 			addTrace(args.Context, "<-replica.storeReadyCh")
 		}
 
+		if args.MaxStaleness != 0 {
+			if s := replica.freshness.staleness(timeNow()); s > args.MaxStaleness {
+				addTrace(args.Context, " ... stale by %s, but want at most %s", s, args.MaxStaleness)
+				res.Status, res.Header.ProcessId = pc.Status_SHARD_STALE, localID
+				return
+			}
+		}
+
 		replica.wg.Add(1)
 		res.Shard = replica
 		res.Store = replica.store
@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"sync"
 
 	"go.etcd.io/etcd/clientv3"
 	"go.gazette.dev/core/allocator"
@@ -32,12 +33,33 @@ type Service struct {
 
 	// stoppingCh is closed when the Service is in the process of shutting down.
 	stoppingCh chan struct{}
+
+	// Application services registered with RegisterService, and the
+	// gRPC server upon which they're registered (once known).
+	registerMu sync.Mutex
+	registered []registeredService
+	grpcServer *grpc.Server
+}
+
+type registeredService struct {
+	desc *grpc.ServiceDesc
+	impl interface{}
 }
 
 // NewService constructs a new Service of the Application, driven by allocator.State.
-func NewService(app Application, state *allocator.State, rjc pb.RoutedJournalClient, lo *grpc.ClientConn, etcd *clientv3.Client) *Service {
+// Optional Interceptors hook shard resolutions and transactions of the Service.
+func NewService(app Application, state *allocator.State, rjc pb.RoutedJournalClient,
+	lo *grpc.ClientConn, etcd *clientv3.Client, interceptors ...Interceptor) *Service {
+
+	var resolver = NewResolver(state, func() *Replica {
+		var r = NewReplica(app, state.KS, etcd, rjc)
+		r.interceptors = interceptors
+		return r
+	})
+	resolver.interceptors = interceptors
+
 	return &Service{
-		Resolver:   NewResolver(state, func() *Replica { return NewReplica(app, state.KS, etcd, rjc) }),
+		Resolver:   resolver,
 		State:      state,
 		Loopback:   lo,
 		Journals:   rjc,
@@ -51,6 +73,15 @@ func NewService(app Application, state *allocator.State, rjc pb.RoutedJournalCli
 // Watch shuts down all local replicas prior to return regardless of
 // error status.
 func (svc *Service) QueueTasks(tasks *task.Group, server *server.Server) {
+	// Register application services with the Server, ahead of its serving.
+	svc.registerMu.Lock()
+	svc.grpcServer = server.GRPCServer
+	for _, r := range svc.registered {
+		svc.grpcServer.RegisterService(r.desc, r.impl)
+	}
+	svc.registered = nil
+	svc.registerMu.Unlock()
+
 	var watchCtx, watchCancel = context.WithCancel(context.Background())
 
 	// Watch the Service KeySpace and manage local shard replicas reflecting
@@ -88,6 +119,47 @@ func (svc *Service) QueueTasks(tasks *task.Group, server *server.Server) {
 	})
 }
 
+// RegisterService registers an application gRPC service implementation with
+// the Server of the Service. Registration is applied once the Service is
+// queued with its Server (or immediately, if it already has been), and must
+// happen before the Server begins serving: typically, from InitApplication.
+//
+// Service implementations are expected to Resolve the shard of each request,
+// serving it if the shard Resolution has a local Store, or otherwise proxying
+// it to the resolved primary via the Loopback, which dispatches using the
+// Header Route of the Resolution:
+//
+//	res, err := svc.Resolver.Resolve(consumer.ResolveArgs{
+//	    Context:     ctx,
+//	    ShardID:     req.Shard,
+//	    MayProxy:    req.Header == nil, // MayProxy if not already proxied.
+//	    ProxyHeader: req.Header,
+//	})
+//	if err != nil {
+//	    return nil, err
+//	} else if res.Status != pc.Status_OK {
+//	    return nil, consumer.StatusError(res.Status)
+//	} else if res.Store == nil {
+//	    req.Header = &res.Header
+//	    return NewMyClient(svc.Loopback).MyMethod(
+//	        pb.WithDispatchRoute(ctx, req.Header.Route, req.Header.ProcessId), req)
+//	}
+//	defer res.Done()
+//
+// Errors of Resolve itself (for example, a cancelled Context or a proxied
+// Header of another Etcd cluster) should be returned as-is. A non-OK
+// Resolution Status maps to a gRPC status by StatusError.
+func (svc *Service) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	svc.registerMu.Lock()
+	defer svc.registerMu.Unlock()
+
+	if svc.grpcServer != nil {
+		svc.grpcServer.RegisterService(desc, impl)
+	} else {
+		svc.registered = append(svc.registered, registeredService{desc: desc, impl: impl})
+	}
+}
+
 // Stopping returns a channel which signals when the Service is in the process
 // of shutting down. Consumer applications with long-lived RPCs should use
 // this signal to begin graceful cleanup of outstanding RPCs.
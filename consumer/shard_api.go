@@ -17,10 +17,11 @@ func (srv *Service) Stat(ctx context.Context, req *pc.StatRequest) (*pc.StatResp
 	var (
 		resp     = new(pc.StatResponse)
 		res, err = srv.Resolver.Resolve(ResolveArgs{
-			Context:     ctx,
-			ShardID:     req.Shard,
-			MayProxy:    req.Header == nil, // MayProxy if request hasn't already been proxied.
-			ProxyHeader: req.Header,
+			Context:      ctx,
+			ShardID:      req.Shard,
+			MayProxy:     req.Header == nil, // MayProxy if request hasn't already been proxied.
+			ProxyHeader:  req.Header,
+			MaxStaleness: req.MaxStaleness,
 		})
 	)
 	resp.Status, resp.Header = res.Status, res.Header
@@ -41,8 +42,13 @@ func (srv *Service) Stat(ctx context.Context, req *pc.StatRequest) (*pc.StatResp
 		// fetched offsets may still be in progress. Block on a WeakBarrier so
 		// that, when we return to the caller, they're assured that all writes
 		// related to processing through the offsets have also committed.
-		var txn = res.Store.Recorder().WeakBarrier()
-		_, err = <-txn.Done(), txn.Err()
+		//
+		// A Store without a Recorder has persisted its offsets only after
+		// related writes committed, and there's nothing to wait for.
+		if rec := res.Store.Recorder(); rec != nil {
+			var txn = rec.WeakBarrier()
+			_, err = <-txn.Done(), txn.Err()
+		}
 	}
 	return resp, err
 }
@@ -180,6 +186,55 @@ func (srv *Service) GetHints(ctx context.Context, req *pc.GetHintsRequest) (*pc.
 	return resp, nil
 }
 
+// Locate dispatches the ShardServer.Locate API.
+func (srv *Service) Locate(ctx context.Context, req *pc.LocateRequest) (*pc.LocateResponse, error) {
+	var s = srv.Resolver.state
+
+	var resp = &pc.LocateResponse{
+		Status: pc.Status_OK,
+		Header: pb.NewUnroutedHeader(s),
+	}
+	if err := req.Validate(); err != nil {
+		return resp, err
+	}
+
+	defer s.KS.Mu.RUnlock()
+	s.KS.Mu.RLock()
+
+	var ids = req.Shards
+	if len(ids) == 0 {
+		for _, kv := range s.Items {
+			ids = append(ids, pc.ShardID(kv.Decoded.(allocator.Item).ID))
+		}
+	}
+
+	for _, id := range ids {
+		var shard = pc.LocateResponse_Shard{Id: id}
+		var assignments = s.KS.KeyValues.Prefixed(
+			allocator.ItemAssignmentsPrefix(s.KS, id.String()))
+
+		shard.Route.Init(assignments)
+		shard.Route.AttachEndpoints(s.KS)
+
+		for _, asn := range assignments {
+			shard.Replicas = append(shard.Replicas,
+				*asn.Decoded.(allocator.Assignment).AssignmentValue.(*pc.ReplicaStatus))
+		}
+
+		// As with Resolve, select a Status and the primary.
+		if _, ok := allocator.LookupItem(s.KS, id.String()); !ok {
+			shard.Status = pc.Status_SHARD_NOT_FOUND
+		} else if shard.Route.Primary == -1 {
+			shard.Status = pc.Status_NO_SHARD_PRIMARY
+		} else {
+			shard.Status = pc.Status_OK
+			shard.Primary = shard.Route.Members[shard.Route.Primary]
+		}
+		resp.Shards = append(resp.Shards, shard)
+	}
+	return resp, nil
+}
+
 // ListShards invokes the List RPC, and maps a validation or !OK status to an error.
 func ListShards(ctx context.Context, sc pc.ShardClient, req *pc.ListRequest) (*pc.ListResponse, error) {
 	if r, err := sc.List(pb.WithDispatchDefault(ctx), req, grpc.FailFast(false)); err != nil {
@@ -266,3 +321,16 @@ func FetchHints(ctx context.Context, sc pc.ShardClient, req *pc.GetHintsRequest)
 		return r, nil
 	}
 }
+
+// LocateShards invokes the Locate RPC, and maps a validation or !OK status to an error.
+func LocateShards(ctx context.Context, sc pc.ShardClient, req *pc.LocateRequest) (*pc.LocateResponse, error) {
+	if r, err := sc.Locate(pb.WithDispatchDefault(ctx), req, grpc.FailFast(false)); err != nil {
+		return r, err
+	} else if err = r.Validate(); err != nil {
+		return r, err
+	} else if r.Status != pc.Status_OK {
+		return r, errors.New(r.Status.String())
+	} else {
+		return r, nil
+	}
+}
@@ -54,6 +54,107 @@ func (s *APISuite) TestStatCases(c *gc.C) {
 	tf.allocateShard(c, spec) // Cleanup.
 }
 
+func (s *APISuite) TestStatWithMaxStaleness(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var spec = makeShard(shardA)
+	tf.allocateShard(c, spec, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	var req = &pc.StatRequest{Shard: shardA, MaxStaleness: 5 * time.Second}
+
+	// Case: a recovered primary is stale until it's caught up.
+	resp, err := tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_SHARD_STALE)
+	c.Check(resp.Header.ProcessId, gc.DeepEquals, localID)
+
+	res, err := tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	defer res.Done()
+
+	// Case: the primary has read through its source and is idle.
+	runSomeTransactions(c, res.Shard)
+
+	resp, err = tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_OK)
+
+	// Case: a primary which is lagging beyond the SLA is stale.
+	var f = &res.Shard.(*Replica).freshness
+	f.onBegin(timeNow().Add(-time.Minute))
+	f.onCommit(&transaction{
+		offsets:    map[pb.Journal]int64{sourceA: 100},
+		writeHeads: map[pb.Journal]int64{sourceA: 200},
+	})
+
+	resp, err = tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_SHARD_STALE)
+
+	// Case: a looser SLA is met by the same primary.
+	req.MaxStaleness = 5 * time.Minute
+	resp, err = tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_OK)
+
+	tf.allocateShard(c, spec) // Cleanup.
+}
+
+func (s *APISuite) TestStatWithMaxStalenessOfPromotedStandby(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var spec = makeShard(shardA)
+	var req = &pc.StatRequest{Shard: shardA, MaxStaleness: 5 * time.Second}
+
+	var standbyReplica = func() *Replica {
+		defer tf.state.KS.Mu.RUnlock()
+		tf.state.KS.Mu.RLock()
+		return tf.resolver.replicas[shardA]
+	}
+
+	// Case: a standby which has replayed through the log write head is
+	// promoted. Its state is fresh, and a tight SLA is met.
+	tf.allocateShard(c, spec, remoteID, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_TAILING)
+	c.Check(standbyReplica().freshness.staleness(timeNow()), gc.Equals, time.Duration(0))
+
+	tf.allocateShard(c, spec, localID, remoteID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	resp, err := tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_OK)
+
+	tf.allocateShard(c, spec) // Cleanup.
+
+	// Case: a standby which lags the log write head is promoted.
+	// It's as stale as its replay, and the tight SLA isn't met.
+	tf.allocateShard(c, spec, remoteID, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_TAILING)
+
+	var f = &standbyReplica().freshness
+	f.onReplay(100, 200, timeNow().Add(-time.Minute))
+	c.Check(f.staleness(timeNow()) >= time.Minute, gc.Equals, true)
+
+	tf.allocateShard(c, spec, localID, remoteID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	resp, err = tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_SHARD_STALE)
+
+	// Case: a looser SLA is met by the same primary.
+	req.MaxStaleness = 5 * time.Minute
+	resp, err = tf.service.Stat(tf.ctx, req)
+	c.Check(err, gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_OK)
+
+	tf.allocateShard(c, spec) // Cleanup.
+}
+
 func (s *APISuite) TestListCases(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -121,6 +222,67 @@ func (s *APISuite) TestListCases(c *gc.C) {
 	tf.allocateShard(c, specC)
 }
 
+func (s *APISuite) TestLocateCases(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	tf.allocateShard(c, makeShard(shardA))
+	tf.allocateShard(c, makeShard(shardB), remoteID)
+	tf.allocateShard(c, makeShard(shardC), localID, remoteID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	type located struct {
+		id       pc.ShardID
+		status   pc.Status
+		primary  pb.ProcessSpec_ID
+		replicas int
+	}
+	var verify = func(resp *pc.LocateResponse, expect ...located) {
+		c.Check(resp.Validate(), gc.IsNil)
+		c.Check(resp.Status, gc.Equals, pc.Status_OK)
+		c.Check(resp.Header.Etcd.Revision, gc.Equals, tf.state.KS.Header.Revision)
+		c.Assert(resp.Shards, gc.HasLen, len(expect))
+
+		for i, exp := range expect {
+			c.Check(resp.Shards[i].Id, gc.Equals, exp.id)
+			c.Check(resp.Shards[i].Status, gc.Equals, exp.status)
+			c.Check(resp.Shards[i].Primary, gc.Equals, exp.primary)
+			c.Check(resp.Shards[i].Replicas, gc.HasLen, exp.replicas)
+			c.Check(resp.Shards[i].Route.Endpoints, gc.HasLen, exp.replicas)
+		}
+	}
+
+	// Case: named shards are located in request order.
+	var resp, err = tf.service.Locate(tf.ctx, &pc.LocateRequest{
+		Shards: []pc.ShardID{shardC, "missing-shard", shardA, shardB},
+	})
+	c.Check(err, gc.IsNil)
+	verify(resp,
+		located{shardC, pc.Status_OK, localID, 2},
+		located{"missing-shard", pc.Status_SHARD_NOT_FOUND, pb.ProcessSpec_ID{}, 0},
+		located{shardA, pc.Status_NO_SHARD_PRIMARY, pb.ProcessSpec_ID{}, 0},
+		located{shardB, pc.Status_OK, remoteID, 1},
+	)
+	// Expect current Etcd-backed status is returned with each replica.
+	c.Check(resp.Shards[0].Replicas[0].Code, gc.Equals, pc.ReplicaStatus_PRIMARY)
+
+	// Case: all shards are located if none are named.
+	resp, err = tf.service.Locate(tf.ctx, &pc.LocateRequest{})
+	c.Check(err, gc.IsNil)
+	verify(resp,
+		located{shardA, pc.Status_NO_SHARD_PRIMARY, pb.ProcessSpec_ID{}, 0},
+		located{shardB, pc.Status_OK, remoteID, 1},
+		located{shardC, pc.Status_OK, localID, 2},
+	)
+
+	// Case: errors on request validation error.
+	_, err = tf.service.Locate(tf.ctx, &pc.LocateRequest{Shards: []pc.ShardID{"invalid shard"}})
+	c.Check(err, gc.ErrorMatches, `Shards\[0\]: not a valid token \(invalid shard\)`)
+
+	tf.allocateShard(c, makeShard(shardB)) // Cleanup.
+	tf.allocateShard(c, makeShard(shardC))
+}
+
 func (s *APISuite) TestApplyCases(c *gc.C) {
 	var tf, cleanup = newTestFixture(c)
 	defer cleanup()
@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// ShardSnapshot is a point-in-time, consistent snapshot of the Store of a
+// primary Shard, captured between consumer transactions.
+type ShardSnapshot struct {
+	StoreIterator
+	// Offsets of the consumer checkpoint which the snapshot reflects.
+	Offsets map[pb.Journal]int64
+}
+
+// SnapshotShard captures a ShardSnapshot of the Store of a locally resolved
+// primary Shard, which must implement Snapshotter. The snapshot is captured
+// between consumer transactions, so that it reflects the state committed by
+// the last transaction together with its checkpointed offsets. SnapshotShard
+// blocks only until an ongoing transaction (if any) completes, and further
+// transactions proceed as the caller iterates the snapshot. Applications may
+// stream a ShardSnapshot to callers via their own service APIs.
+//
+// The checkpoint of a Store having a recovery log may not yet be durable in the
+// log when the snapshot is captured. Callers which must only observe durable
+// checkpoints may await a WeakBarrier of the Store Recorder, as Stat does.
+func SnapshotShard(ctx context.Context, res Resolution) (*ShardSnapshot, error) {
+	var replica, ok = res.Shard.(*Replica)
+	if !ok {
+		return nil, fmt.Errorf("expected a local primary Shard")
+	}
+	snapshotter, ok := res.Store.(Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("Store of shard %s is not a Snapshotter", res.Spec.Id)
+	}
+
+	select {
+	case replica.txnSem <- struct{}{}:
+		defer func() { <-replica.txnSem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-replica.ctx.Done():
+		return nil, replica.ctx.Err()
+	}
+
+	var offsets, err = res.Store.FetchJournalOffsets()
+	if err != nil {
+		return nil, extendErr(err, "fetching journal offsets")
+	}
+	it, err := snapshotter.Snapshot()
+	if err != nil {
+		return nil, extendErr(err, "capturing snapshot")
+	}
+	return &ShardSnapshot{StoreIterator: it, Offsets: offsets}, nil
+}
+
+// acquireTxn is called as a transaction of the Shard begins, and blocks while
+// a snapshot of its Store is being captured.
+func acquireTxn(shard Shard) error {
+	var replica, ok = shard.(*Replica)
+	if !ok {
+		return nil
+	}
+	select {
+	case replica.txnSem <- struct{}{}:
+		return nil
+	case <-shard.Context().Done():
+		return shard.Context().Err()
+	}
+}
+
+// releaseTxn is called as a transaction of the Shard completes.
+func releaseTxn(shard Shard) {
+	if replica, ok := shard.(*Replica); ok {
+		<-replica.txnSem
+	}
+}
@@ -0,0 +1,222 @@
+package consumer
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// ExportSnapshot captures a ShardSnapshot of the locally resolved primary
+// Shard (as does SnapshotShard), and returns an io.ReadCloser of its export.
+// It's intended for backups of shard state which must be consistent with the
+// checkpoint of the Store, unlike out-of-band copies of Store files which
+// race with ongoing transactions. The export may be streamed elsewhere, such
+// as to a backup journal via AppendService.StartAppendReader. The snapshot is
+// encoded as the export is read, and is released upon its completion or Close.
+// The Store must implement Snapshotter (as do JSONFileStore and the RocksDB
+// Store of package store-rocksdb, which exports from a RocksDB snapshot).
+//
+// An export is encoded as:
+//
+//  * The 4-byte magic word "GZS1".
+//  * A uvarint length, followed by a JSON header holding the checkpoint
+//    Offsets of the snapshot. A restoring application should resume reading
+//    each source journal from its Offset.
+//  * A record for each key & value of the snapshot: the byte 0x01, followed by
+//    a uvarint length and the key, followed by a uvarint length and the value.
+//  * A trailer: the byte 0x00, followed by a uvarint count of records.
+//
+// ReadSnapshot decodes an export.
+func ExportSnapshot(ctx context.Context, res Resolution) (io.ReadCloser, error) {
+	var snap, err = SnapshotShard(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	var pr, pw = io.Pipe()
+
+	go func() {
+		var bw = bufio.NewWriter(pw)
+		var err = WriteSnapshot(bw, snap)
+		if err == nil {
+			err = bw.Flush()
+		}
+		snap.Close()
+		_ = pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
+
+// WriteSnapshot writes an export of the ShardSnapshot to |w|, by iterating
+// it through to its end. See ExportSnapshot for a description of the format.
+func WriteSnapshot(w io.Writer, snap *ShardSnapshot) error {
+	var header, err = json.Marshal(snapshotHeader{Offsets: snap.Offsets})
+	if err != nil {
+		return err
+	} else if _, err = w.Write(snapshotMagicWord[:]); err != nil {
+		return err
+	} else if err = writeSnapshotBytes(w, header); err != nil {
+		return err
+	}
+
+	var count uint64
+	for ; ; count++ {
+		var key, value, err = snap.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return extendErr(err, "iterating snapshot")
+		}
+
+		if _, err = w.Write([]byte{snapshotRecordTag}); err != nil {
+			return err
+		} else if err = writeSnapshotBytes(w, key); err != nil {
+			return err
+		} else if err = writeSnapshotBytes(w, value); err != nil {
+			return err
+		}
+	}
+
+	var b [1 + binary.MaxVarintLen64]byte
+	b[0] = snapshotTrailerTag
+	var n = binary.PutUvarint(b[1:], count)
+
+	_, err = w.Write(b[:1+n])
+	return err
+}
+
+// ReadSnapshot reads the header of a snapshot export from |r| (as produced by
+// ExportSnapshot), and returns a ShardSnapshot having its checkpoint Offsets.
+// Key & values of the export are returned by the StoreIterator of the
+// ShardSnapshot. Its Next returns io.EOF only upon reading the export trailer,
+// and an error having cause io.ErrUnexpectedEOF if the export is truncated.
+// Closing the ShardSnapshot doesn't close |r|.
+func ReadSnapshot(r io.Reader) (*ShardSnapshot, error) {
+	var br = bufio.NewReader(r)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return nil, extendErr(err, "reading snapshot magic word")
+	} else if magic != snapshotMagicWord {
+		return nil, fmt.Errorf("not a snapshot export (magic word %x)", magic)
+	}
+
+	var header snapshotHeader
+	if b, err := readSnapshotBytes(br, nil); err != nil {
+		return nil, extendErr(err, "reading snapshot header")
+	} else if err = json.Unmarshal(b, &header); err != nil {
+		return nil, extendErr(err, "decoding snapshot header")
+	}
+	return &ShardSnapshot{
+		StoreIterator: &snapshotReader{br: br},
+		Offsets:       header.Offsets,
+	}, nil
+}
+
+// snapshotHeader is the JSON header of a snapshot export.
+type snapshotHeader struct {
+	Offsets map[pb.Journal]int64
+}
+
+// snapshotReader is a StoreIterator of the records of a snapshot export.
+type snapshotReader struct {
+	br    *bufio.Reader
+	count uint64
+	key   []byte
+	value []byte
+	err   error
+}
+
+// Next implements StoreIterator.
+func (r *snapshotReader) Next() (key, value []byte, err error) {
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	defer func() { r.err = err }()
+
+	var tag byte
+	if tag, err = r.br.ReadByte(); err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+
+	switch tag {
+	case snapshotRecordTag:
+		if r.key, err = readSnapshotBytes(r.br, r.key); err != nil {
+			return nil, nil, extendErr(err, "reading snapshot key")
+		} else if r.value, err = readSnapshotBytes(r.br, r.value); err != nil {
+			return nil, nil, extendErr(err, "reading snapshot value")
+		}
+		r.count++
+		return r.key, r.value, nil
+
+	case snapshotTrailerTag:
+		var count uint64
+		if count, err = binary.ReadUvarint(r.br); err != nil {
+			return nil, nil, extendErr(unexpectedEOF(err), "reading snapshot trailer")
+		} else if count != r.count {
+			return nil, nil, fmt.Errorf("snapshot trailer count mismatch (%d; read %d records)", count, r.count)
+		}
+		return nil, nil, io.EOF
+
+	default:
+		return nil, nil, fmt.Errorf("unexpected snapshot record tag (%x)", tag)
+	}
+}
+
+// Close implements StoreIterator.
+func (r *snapshotReader) Close() {
+	if r.err == nil {
+		r.err = errors.New("snapshot closed")
+	}
+}
+
+func writeSnapshotBytes(w io.Writer, b []byte) error {
+	var l [binary.MaxVarintLen64]byte
+	if _, err := w.Write(l[:binary.PutUvarint(l[:], uint64(len(b)))]); err != nil {
+		return err
+	}
+	var _, err = w.Write(b)
+	return err
+}
+
+// readSnapshotBytes reads uvarint-length-prefixed bytes, re-using |b| if able.
+func readSnapshotBytes(br *bufio.Reader, b []byte) ([]byte, error) {
+	var n, err = binary.ReadUvarint(br)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	} else if n > maxSnapshotBytes {
+		return nil, fmt.Errorf("snapshot length (%d) exceeds maximum (%d)", n, maxSnapshotBytes)
+	}
+	if uint64(cap(b)) < n {
+		b = make([]byte, n)
+	}
+	b = b[:n]
+
+	if _, err = io.ReadFull(br, b); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+// unexpectedEOF maps an io.EOF within the export to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+var snapshotMagicWord = [4]byte{'G', 'Z', 'S', '1'}
+
+const (
+	snapshotTrailerTag byte = 0x00
+	snapshotRecordTag  byte = 0x01
+	// maxSnapshotBytes bounds the length of a snapshot key, value, or header,
+	// guarding against allocation of a corrupt length.
+	maxSnapshotBytes = 1 << 30
+)
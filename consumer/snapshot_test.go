@@ -0,0 +1,135 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+	pb "go.gazette.dev/core/broker/protocol"
+	pc "go.gazette.dev/core/consumer/protocol"
+)
+
+type SnapshotSuite struct{}
+
+func (s *SnapshotSuite) TestSnapshotReflectsCheckpoint(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var spec = makeShard(shardA)
+	tf.allocateShard(c, spec, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	var res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	defer res.Done()
+
+	runSomeTransactions(c, res.Shard)
+
+	// Determine the write head of |sourceA|, through which transactions have consumed.
+	var aa = res.Shard.JournalClient().StartAppend(sourceA)
+	c.Check(aa.Release(), gc.IsNil)
+	<-aa.Done()
+	var expectOffset = aa.Response().Commit.End
+
+	snap, err := SnapshotShard(context.Background(), res)
+	c.Assert(err, gc.IsNil)
+	c.Check(snap.Offsets, gc.DeepEquals, map[pb.Journal]int64{sourceA: expectOffset})
+
+	// Process more transactions while the snapshot is still open.
+	var app = res.Shard.(*Replica).app.(*testApplication)
+	var finishCh = app.finishCh
+
+	aa = res.Shard.JournalClient().StartAppend(sourceA)
+	_, _ = aa.Writer().WriteString(`{"key":"foo","value":"updated"}` + "\n")
+	c.Check(aa.Release(), gc.IsNil)
+	<-finishCh
+
+	// Expect the snapshot reflects the state of its checkpoint, and not the
+	// later transaction.
+	key, value, err := snap.Next()
+	c.Check(err, gc.IsNil)
+	c.Check(key, gc.HasLen, 0)
+	c.Check(string(value), gc.Equals, `{"baz":"bing","foo":"fin","ring":"ting"}`)
+
+	_, _, err = snap.Next()
+	c.Check(err, gc.Equals, io.EOF)
+	snap.Close()
+
+	// A new snapshot reflects the later transaction.
+	snap, err = SnapshotShard(context.Background(), res)
+	c.Assert(err, gc.IsNil)
+	c.Check(snap.Offsets[sourceA] > expectOffset, gc.Equals, true)
+
+	_, value, err = snap.Next()
+	c.Check(err, gc.IsNil)
+	c.Check(string(value), gc.Equals, `{"baz":"bing","foo":"updated","ring":"ting"}`)
+	snap.Close()
+
+	tf.allocateShard(c, spec) // Cleanup.
+}
+
+func (s *SnapshotSuite) TestExportAndReadSnapshot(c *gc.C) {
+	var tf, cleanup = newTestFixture(c)
+	defer cleanup()
+
+	var spec = makeShard(shardA)
+	tf.allocateShard(c, spec, localID)
+	expectStatusCode(c, tf.state, pc.ReplicaStatus_PRIMARY)
+
+	var res, err = tf.resolver.Resolve(ResolveArgs{Context: tf.ctx, ShardID: shardA})
+	c.Assert(err, gc.IsNil)
+	defer res.Done()
+
+	runSomeTransactions(c, res.Shard)
+
+	rc, err := ExportSnapshot(context.Background(), res)
+	c.Assert(err, gc.IsNil)
+	export, err := ioutil.ReadAll(rc)
+	c.Check(err, gc.IsNil)
+	c.Check(rc.Close(), gc.IsNil)
+
+	// Expect a read of the export reflects the snapshot and its checkpoint.
+	var expectOffsets, _ = res.Store.FetchJournalOffsets()
+
+	snap, err := ReadSnapshot(bytes.NewReader(export))
+	c.Assert(err, gc.IsNil)
+	c.Check(snap.Offsets, gc.DeepEquals, expectOffsets)
+
+	key, value, err := snap.Next()
+	c.Check(err, gc.IsNil)
+	c.Check(key, gc.HasLen, 0)
+	c.Check(string(value), gc.Equals, `{"baz":"bing","foo":"fin","ring":"ting"}`)
+	_, _, err = snap.Next()
+	c.Check(err, gc.Equals, io.EOF)
+	_, _, err = snap.Next()
+	c.Check(err, gc.Equals, io.EOF) // Remains at EOF.
+	snap.Close()
+
+	// Case: a truncated export is an unexpected EOF.
+	snap, err = ReadSnapshot(bytes.NewReader(export[:len(export)-4]))
+	c.Assert(err, gc.IsNil)
+	_, _, err = snap.Next()
+	c.Check(errors.Cause(err), gc.Equals, io.ErrUnexpectedEOF)
+
+	// Case: a mismatched trailer count is an error.
+	var bad = append([]byte(nil), export...)
+	bad[len(bad)-1] = 2
+
+	snap, err = ReadSnapshot(bytes.NewReader(bad))
+	c.Assert(err, gc.IsNil)
+	_, _, err = snap.Next()
+	c.Check(err, gc.IsNil)
+	_, _, err = snap.Next()
+	c.Check(err, gc.ErrorMatches, `snapshot trailer count mismatch \(2; read 1 records\)`)
+
+	// Case: content which isn't an export.
+	_, err = ReadSnapshot(bytes.NewReader([]byte("not an export")))
+	c.Check(err, gc.ErrorMatches, `not a snapshot export \(magic word 6e6f7420\)`)
+
+	tf.allocateShard(c, spec) // Cleanup.
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
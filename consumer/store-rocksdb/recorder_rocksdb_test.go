@@ -132,8 +132,8 @@ func TestResolutionOfConflictingWriters(t *testing.T) {
 
 	// |replica1| begins as primary.
 	replica1.startWriting(aRecoveryLog)
-	var hints, _ = replica1.recorder.BuildHints()
-	replica2.startReading(hints)
+	var initialHints, _ = replica1.recorder.BuildHints()
+	replica2.startReading(initialHints)
 	replica1.put("key one", "value one")
 
 	// |replica2| now becomes live. |replica1| and |replica2| intersperse writes.
@@ -156,7 +156,7 @@ func TestResolutionOfConflictingWriters(t *testing.T) {
 	var replica4 = NewTestReplica(t, bk)
 	defer replica4.teardown()
 
-	hints, _ = replica1.recorder.BuildHints()
+	var hints, _ = replica1.recorder.BuildHints()
 	replica3.startReading(hints)
 	replica3.makeLive()
 
@@ -176,6 +176,27 @@ func TestResolutionOfConflictingWriters(t *testing.T) {
 		"rep2 bar":  "value bar",
 		"rep2 bing": "value bing",
 	})
+
+	// |replica2| took over the log with a greater Author epoch than |replica1|.
+	var h1, _ = replica1.recorder.BuildHints()
+	var h2, _ = replica2.recorder.BuildHints()
+	assert.True(t, h1.AuthorEpoch < h2.AuthorEpoch)
+
+	// New |replica5| is hinted only with the log's state prior to |replica2|'s
+	// hand-off, and must itself resolve the conflicting writes of the log.
+	var replica5 = NewTestReplica(t, bk)
+	defer replica5.teardown()
+
+	replica5.startReading(initialHints)
+	replica5.makeLive()
+
+	// Expect |replica5| followed the newer Author, and that operations of the
+	// losing |replica1| which follow the hand-off were ignored.
+	replica5.expectValues(map[string]string{
+		"key one":   "value one",
+		"rep2 bar":  "value bar",
+		"rep2 bing": "value bing",
+	})
 }
 
 func TestPlayThenCancel(t *testing.T) {
@@ -20,6 +20,7 @@
 package store_rocksdb
 
 import (
+	"io"
 	"os"
 
 	"github.com/jgraettinger/cockroach-encoding/encoding"
@@ -27,6 +28,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	rocks "github.com/tecbot/gorocksdb"
 	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/consumer"
 	"go.gazette.dev/core/consumer/recoverylog"
 )
 
@@ -145,6 +147,55 @@ func (s *Store) Flush(offsets map[pb.Journal]int64) error {
 	return nil
 }
 
+// Snapshot implements consumer.Snapshotter. It returns a StoreIterator over
+// all keys and values of a RocksDB snapshot of the DB. Note keys include those
+// of the journal offsets checkpointed by Flush.
+func (s *Store) Snapshot() (consumer.StoreIterator, error) {
+	var snap = s.DB.NewSnapshot()
+	var ro = rocks.NewDefaultReadOptions()
+	ro.SetSnapshot(snap)
+	ro.SetFillCache(false)
+
+	var it = s.DB.NewIterator(ro)
+	it.SeekToFirst()
+
+	return &snapshotIterator{
+		db:   s.DB,
+		snap: snap,
+		ro:   ro,
+		it:   AsArenaIterator(it, make([]byte, 32*1024)),
+	}, nil
+}
+
+type snapshotIterator struct {
+	db      *rocks.DB
+	snap    *rocks.Snapshot
+	ro      *rocks.ReadOptions
+	it      *ArenaIterator
+	started bool
+}
+
+func (s *snapshotIterator) Next() (key, value []byte, err error) {
+	if s.started {
+		s.it.Next()
+	}
+	s.started = true
+
+	if !s.it.Valid() {
+		if err = s.it.Err(); err == nil {
+			err = io.EOF
+		}
+		return nil, nil, err
+	}
+	return s.it.Key(), s.it.Value(), nil
+}
+
+func (s *snapshotIterator) Close() {
+	s.it.Close()
+	s.ro.Destroy()
+	s.db.ReleaseSnapshot(s.snap)
+}
+
 // Destroy the Store.
 func (s *Store) Destroy() {
 	if s.DB != nil {
@@ -0,0 +1,96 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/consumer/recoverylog"
+)
+
+// CheckpointKV is a user-provided, durable key/value store into which an
+// ExternalCheckpointStore persists the consumer checkpoints of shards. It's
+// typically backed by the same transactional database which holds the
+// Application's own state.
+type CheckpointKV interface {
+	// Get returns the value of |key|, or nil if |key| doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put durably stores |value| under |key|.
+	Put(ctx context.Context, key string, value []byte) error
+}
+
+// ExternalCheckpointStore is a Store for Shards which have no recovery log,
+// and which persists only the consumer checkpoint (read-through offsets of
+// source journals) to a CheckpointKV. It's intended for Applications which
+// hold all of their state externally, and which only need Gazette to track
+// their read offsets, as it avoids the overhead of recording a recovery log.
+//
+// As nothing is recorded, Flush is blocked on the commit of all writes of the
+// consumer transaction, and offsets are durable upon its return. Flush provides
+// at-least-once processing: Applications requiring exactly-once updates of
+// their external state should also persist it within the same transaction
+// of the CheckpointKV.
+type ExternalCheckpointStore struct {
+	kv        CheckpointKV
+	key       string
+	ctx       context.Context
+	offsets   map[pb.Journal]int64
+	offsetsMu sync.Mutex
+}
+
+// NewExternalCheckpointStore returns an ExternalCheckpointStore of the
+// Shard, having offsets loaded from the CheckpointKV under the Shard ID.
+func NewExternalCheckpointStore(shard Shard, kv CheckpointKV) (*ExternalCheckpointStore, error) {
+	var store = &ExternalCheckpointStore{
+		kv:      kv,
+		key:     shard.Spec().Id.String(),
+		ctx:     shard.Context(),
+		offsets: make(map[pb.Journal]int64),
+	}
+
+	if b, err := kv.Get(store.ctx, store.key); err != nil {
+		return nil, extendErr(err, "loading checkpoint")
+	} else if b == nil {
+		return store, nil // No checkpoint has been written yet.
+	} else if err = json.Unmarshal(b, &store.offsets); err != nil {
+		return nil, extendErr(err, "decoding checkpoint")
+	}
+	return store, nil
+}
+
+// Recorder returns nil, as the ExternalCheckpointStore records no recovery log.
+func (s *ExternalCheckpointStore) Recorder() *recoverylog.Recorder { return nil }
+
+// FetchJournalOffsets returns offsets of the loaded or last flushed checkpoint.
+func (s *ExternalCheckpointStore) FetchJournalOffsets() (map[pb.Journal]int64, error) {
+	defer s.offsetsMu.Unlock()
+	s.offsetsMu.Lock()
+
+	var offsets = make(map[pb.Journal]int64)
+	for k, o := range s.offsets {
+		offsets[k] = o
+	}
+	return offsets, nil
+}
+
+// Flush merges |offsets| into the checkpoint, and durably stores it to the
+// CheckpointKV.
+func (s *ExternalCheckpointStore) Flush(offsets map[pb.Journal]int64) error {
+	defer s.offsetsMu.Unlock()
+	s.offsetsMu.Lock()
+
+	for k, o := range offsets {
+		s.offsets[k] = o
+	}
+
+	if b, err := json.Marshal(s.offsets); err != nil {
+		return extendErr(err, "encoding checkpoint")
+	} else if err = s.kv.Put(s.ctx, s.key, b); err != nil {
+		return extendErr(err, "storing checkpoint")
+	}
+	return nil
+}
+
+// Destroy is a no-op, as the ExternalCheckpointStore has no local resources.
+func (s *ExternalCheckpointStore) Destroy() {}
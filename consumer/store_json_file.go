@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -107,6 +108,28 @@ func (s *JSONFileStore) Flush(offsets map[pb.Journal]int64) error {
 	return nil
 }
 
+// Snapshot implements Snapshotter. The StoreIterator of a JSONFileStore has
+// a single entry, having an empty key and a value of the JSON-encoded State.
+func (s *JSONFileStore) Snapshot() (StoreIterator, error) {
+	var b, err = json.Marshal(s.State)
+	if err != nil {
+		return nil, extendErr(err, "encoding state")
+	}
+	return &jsonSnapshotIterator{value: b}, nil
+}
+
+type jsonSnapshotIterator struct{ value []byte }
+
+func (it *jsonSnapshotIterator) Next() (key, value []byte, err error) {
+	if it.value == nil {
+		return nil, nil, io.EOF
+	}
+	value, it.value = it.value, nil
+	return nil, value, nil
+}
+
+func (it *jsonSnapshotIterator) Close() { it.value = nil }
+
 // Destroy the JSONFileStore directory and state file.
 func (s *JSONFileStore) Destroy() {
 	if err := os.RemoveAll(s.dir); err != nil {
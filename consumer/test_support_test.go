@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -47,19 +48,28 @@ type testApplication struct {
 	newMsgErr   error
 	consumeErr  error
 	finalizeErr error
+	commitErr   error
 	finishErr   error
 	// Signals when FinishTxn is called.
 	finishCh chan struct{}
+	// Checkpoints of shards having no recovery log.
+	checkpoints *testCheckpointKV
 }
 
 func newTestApplication() *testApplication {
-	return &testApplication{finishCh: make(chan struct{})}
+	return &testApplication{
+		finishCh:    make(chan struct{}),
+		checkpoints: &testCheckpointKV{m: make(map[string][]byte)},
+	}
 }
 
 func (a *testApplication) NewStore(shard Shard, dir string, rec *recoverylog.Recorder) (Store, error) {
 	if a.newStoreErr != nil {
 		return nil, a.newStoreErr
 	}
+	if rec == nil {
+		return NewExternalCheckpointStore(shard, a.checkpoints)
+	}
 	var state = make(map[string]string)
 	return NewJSONFileStore(rec, dir, &state)
 }
@@ -71,14 +81,17 @@ func (a *testApplication) NewMessage(*pb.JournalSpec) (message.Message, error) {
 func (a *testApplication) BeginTxn(shard Shard, store Store) error { return a.beginErr }
 
 func (a *testApplication) ConsumeMessage(shard Shard, store Store, env message.Envelope) error {
-	var js = store.(*JSONFileStore)
-	var msg = env.Message.(*testMessage)
-	(*js.State.(*map[string]string))[msg.Key] = msg.Value
+	if js, ok := store.(*JSONFileStore); ok {
+		var msg = env.Message.(*testMessage)
+		(*js.State.(*map[string]string))[msg.Key] = msg.Value
+	}
 	return a.consumeErr
 }
 
 func (a *testApplication) FinalizeTxn(shard Shard, store Store) error { return a.finalizeErr }
 
+func (a *testApplication) BeforeCommit(Shard, Store, map[pb.Journal]int64) error { return a.commitErr }
+
 func (a *testApplication) FinishTxn(shard Shard, store Store, _ error) error {
 	var ch = a.finishCh
 	a.finishCh = make(chan struct{})
@@ -86,6 +99,25 @@ func (a *testApplication) FinishTxn(shard Shard, store Store, _ error) error {
 	return a.finishErr
 }
 
+// testCheckpointKV is an in-memory CheckpointKV.
+type testCheckpointKV struct {
+	m  map[string][]byte
+	mu sync.Mutex
+}
+
+func (kv *testCheckpointKV) Get(_ context.Context, key string) ([]byte, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	return kv.m[key], nil
+}
+
+func (kv *testCheckpointKV) Put(_ context.Context, key string, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.m[key] = value
+	return nil
+}
+
 type testFixture struct {
 	ctx      context.Context
 	app      *testApplication
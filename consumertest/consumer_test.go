@@ -16,6 +16,9 @@ import (
 	"go.gazette.dev/core/etcdtest"
 	"go.gazette.dev/core/labels"
 	"go.gazette.dev/core/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ConsumerSuite struct{}
@@ -202,6 +205,66 @@ func (s *ConsumerSuite) TestConsumeWithHotStandby(c *gc.C) {
 	c.Check(broker.Tasks.Wait(), gc.IsNil)
 }
 
+func (s *ConsumerSuite) TestRegisteredServiceIsDispatched(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var broker = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, broker,
+		brokertest.Journal(pb.JournalSpec{
+			Name:     "a/journal",
+			LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+		}),
+		brokertest.Journal(pb.JournalSpec{
+			Name:     "recovery/logs/a-shard",
+			LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_RecoveryLog),
+		}),
+	)
+
+	var rjc = pb.NewRoutedJournalClient(broker.Client(), pb.NoopDispatchRouter{})
+	var ctx, cancel = context.WithCancel(pb.WithDispatchDefault(context.Background()))
+	defer cancel()
+
+	// Start two consumers, each registering the application service.
+	var cmr1 = NewConsumer(Args{C: c, Etcd: etcd, Journals: rjc, App: testApp{}, Zone: "zone-1"})
+	cmr1.Service.RegisterService(&testLookupDesc, &testLookup{svc: cmr1.Service})
+	cmr1.Tasks.GoRun()
+
+	CreateShards(c, cmr1, &pc.ShardSpec{
+		Id:                "a-shard",
+		Sources:           []pc.ShardSpec_Source{{Journal: "a/journal"}},
+		RecoveryLogPrefix: "recovery/logs",
+		HintPrefix:        "/hints",
+		MaxTxnDuration:    time.Second,
+	})
+	c.Assert(cmr1.WaitForPrimary(ctx, "a-shard", nil), gc.IsNil)
+
+	var cmr2 = NewConsumer(Args{C: c, Etcd: etcd, Journals: rjc, App: testApp{}, Zone: "zone-2"})
+	cmr2.Service.RegisterService(&testLookupDesc, &testLookup{svc: cmr2.Service})
+	cmr2.Tasks.GoRun()
+
+	// Case: a request of |cmr2| is proxied to the shard primary |cmr1|, and served there.
+	var resp = new(pc.StatResponse)
+	c.Check(cmr2.Service.Loopback.Invoke(ctx, "/consumertest.Lookup/Lookup",
+		&pc.StatRequest{Shard: "a-shard"}, resp), gc.IsNil)
+	c.Check(resp.Status, gc.Equals, pc.Status_OK)
+	c.Check(resp.Header.ProcessId, gc.Equals, pb.ProcessSpec_ID{Zone: "zone-1", Suffix: "consumer"})
+
+	// Case: a request of a missing shard fails with a mapped gRPC status.
+	var err = cmr2.Service.Loopback.Invoke(ctx, "/consumertest.Lookup/Lookup",
+		&pc.StatRequest{Shard: "missing-shard"}, resp)
+	c.Check(status.Code(err), gc.Equals, codes.NotFound)
+	c.Check(err, gc.ErrorMatches, `rpc error: code = NotFound desc = SHARD_NOT_FOUND`)
+
+	cmr1.Tasks.Cancel()
+	cmr2.Tasks.Cancel()
+	c.Check(cmr1.Tasks.Wait(), gc.IsNil)
+	c.Check(cmr2.Tasks.Wait(), gc.IsNil)
+
+	broker.Tasks.Cancel()
+	c.Check(broker.Tasks.Wait(), gc.IsNil)
+}
+
 type testApp struct{}
 
 type testMsg struct{ Key, Value string }
@@ -222,6 +285,48 @@ func (testApp) ConsumeMessage(shard consumer.Shard, store consumer.Store, env me
 
 func (testApp) FinalizeTxn(shard consumer.Shard, store consumer.Store) error { return nil }
 
+// testLookup is an application service which is served by shard primaries.
+type testLookup struct{ svc *consumer.Service }
+
+func (l *testLookup) Lookup(ctx context.Context, req *pc.StatRequest) (*pc.StatResponse, error) {
+	var res, err = l.svc.Resolver.Resolve(consumer.ResolveArgs{
+		Context:     ctx,
+		ShardID:     req.Shard,
+		MayProxy:    req.Header == nil,
+		ProxyHeader: req.Header,
+	})
+	if err != nil {
+		return nil, err
+	} else if res.Status != pc.Status_OK {
+		return nil, consumer.StatusError(res.Status)
+	} else if res.Store == nil {
+		req.Header = &res.Header
+
+		var resp = new(pc.StatResponse)
+		return resp, l.svc.Loopback.Invoke(
+			pb.WithDispatchRoute(ctx, req.Header.Route, req.Header.ProcessId),
+			"/consumertest.Lookup/Lookup", req, resp)
+	}
+	defer res.Done()
+
+	return &pc.StatResponse{Status: pc.Status_OK, Header: res.Header}, nil
+}
+
+var testLookupDesc = grpc.ServiceDesc{
+	ServiceName: "consumertest.Lookup",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Lookup",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			var req = new(pc.StatRequest)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			return srv.(*testLookup).Lookup(ctx, req)
+		},
+	}},
+}
+
 var _ = gc.Suite(&ConsumerSuite{})
 
 func TestT(t *testing.T) { gc.TestingT(t) }
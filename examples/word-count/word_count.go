@@ -79,7 +79,7 @@ func (counter *Counter) InitApplication(args runconsumer.InitArgs) error {
 	)
 	counter.n = N
 
-	RegisterNGramServer(args.Server.GRPCServer, counter)
+	args.Service.RegisterService(&_NGram_serviceDesc, counter)
 	return nil
 }
 
@@ -216,7 +216,7 @@ func (counter *Counter) Query(ctx context.Context, req *QueryRequest) (resp *Que
 	}); err != nil {
 		return
 	} else if res.Status != pc.Status_OK {
-		err = fmt.Errorf(res.Status.String())
+		err = consumer.StatusError(res.Status)
 		return
 	} else if res.Store == nil {
 		req.Header = &res.Header // Proxy to the resolved primary peer.
@@ -19,6 +19,18 @@ const (
 	// ContentType_JSONLines is a ContentType for newline-delimited, JSON-encoded
 	// messages. JSONLines is implemented by message.JSONFraming.
 	ContentType_JSONLines = "application/x-ndjson"
+	// ContentType_GzipJSONLines is a ContentType for newline-delimited messages,
+	// each of which is JSON-encoded, individually gzip-compressed, and then
+	// base64-encoded (such that lines are free of newlines). GzipJSONLines is
+	// implemented by message.GzipJSONFraming.
+	ContentType_GzipJSONLines = "application/x-ndjson-gzip"
+	// ContentType_MessagePack is a ContentType for MessagePack-encoded messages
+	// delimited by the same fixed header as ContentType_ProtoFixed.
+	// MessagePack is implemented by message.MsgPackFraming.
+	ContentType_MessagePack = "application/x-msgpack"
+	// ContentType_CSV is a ContentType for newline-delimited, comma-separated
+	// value records. CSV is implemented by message.CSVFraming.
+	ContentType_CSV = "text/csv"
 	// ContentType_RecoveryLog is a ContentType for Gazette's recovery log encoding.
 	// RecoveryLog is implemented by package `recoverylog`. To serve as a shard
 	// recovery log, a JournalSpec must be labeled with ContentType_RecoveryLog.
@@ -68,6 +80,9 @@ var SingleValueLabels = map[string]struct{}{
 // a message.Framing. To serve as a ShardSpec.Source, a JournalSpec must be
 // labeled from among these ContentTypes.
 var FramedContentTypes = map[string]struct{}{
-	ContentType_JSONLines:  {},
-	ContentType_ProtoFixed: {},
+	ContentType_CSV:           {},
+	ContentType_GzipJSONLines: {},
+	ContentType_JSONLines:     {},
+	ContentType_MessagePack:   {},
+	ContentType_ProtoFixed:    {},
 }
@@ -0,0 +1,121 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/pkg/errors"
+)
+
+// ChecksummedContentTypeSuffix is appended to the ContentType of a Framing
+// wrapped by ChecksummedFraming.
+const ChecksummedContentTypeSuffix = "+crc32c"
+
+// ChecksummedFraming returns a Framing which wraps |inner|, guarding the inner
+// frame of each message with a CRC-32C checksum. Where the SHA1 sum of a
+// Fragment verifies content as a whole, a ChecksummedFraming verifies each
+// message individually as it's read, independent of the Fragment which holds
+// it and of the way it was read.
+//
+// Frames use the fixed-length header of FixedFraming: a 4-byte magic word for
+// de-synchronization detection, followed by a little-endian uint32 length,
+// followed by the payload. The payload is a little-endian uint32 CRC-32C
+// (Castagnoli) of the inner frame, followed by the inner frame itself.
+//
+// Checksums are verified by Unmarshal, which returns ErrChecksumMismatch for
+// a corrupt frame. As Unpack delimits frames using only the frame header, a
+// corrupt frame doesn't de-synchronize the reader, and the caller may proceed
+// with the next message.
+//
+// The ContentType of a ChecksummedFraming is that of |inner|, having suffix
+// ChecksummedContentTypeSuffix, for example "application/x-ndjson+crc32c".
+// FramingByContentType and JournalSpec label validation understand this suffix.
+func ChecksummedFraming(inner Framing) Framing {
+	return &checksummedFraming{inner: inner}
+}
+
+type checksummedFraming struct {
+	inner Framing
+}
+
+// ContentType returns the ContentType of the inner Framing, with suffix
+// ChecksummedContentTypeSuffix.
+func (f *checksummedFraming) ContentType() string {
+	return f.inner.ContentType() + ChecksummedContentTypeSuffix
+}
+
+// Marshal implements Framing.
+func (f *checksummedFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var buf = bytes.NewBuffer(bufferPool.Get().([]byte))
+	defer func() { bufferPool.Put(buf.Bytes()[:0]) }()
+
+	// Reserve the frame header and checksum, which are filled once the inner
+	// frame is known.
+	buf.Write(make([]byte, FixedFrameHeaderLength+checksumLength))
+
+	var iw = bufio.NewWriter(buf)
+	if err := f.inner.Marshal(msg, iw); err != nil {
+		return err
+	} else if err = iw.Flush(); err != nil {
+		return err
+	}
+	var b = buf.Bytes()
+	var inner = b[FixedFrameHeaderLength+checksumLength:]
+
+	copy(b[0:4], magicWord[:])
+	binary.LittleEndian.PutUint32(b[4:8], uint32(len(b)-FixedFrameHeaderLength))
+	binary.LittleEndian.PutUint32(b[8:12], crc32.Checksum(inner, crc32cTable))
+
+	_, _ = bw.Write(b)
+	return nil
+}
+
+// Unpack returns the next fixed frame of content from the Reader.
+// See UnpackFixed.
+//
+// It implements Framing.
+func (*checksummedFraming) Unpack(r *bufio.Reader) ([]byte, error) { return UnpackFixed(r) }
+
+// UnpackLimit returns the next fixed frame of content from the Reader, having
+// a maximum payload length of |limit|. See UnpackFixedLimit.
+//
+// It implements LimitedUnpacker.
+func (*checksummedFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackFixedLimit(r, limit)
+}
+
+// Unmarshal verifies the frame header and the checksum of the inner frame,
+// and unmarshals it into Message using the inner Framing. If the frame header
+// indicates a desync occurred, ErrDesyncDetected is returned. If the checksum
+// doesn't match, ErrChecksumMismatch is returned.
+//
+// It implements Framing.
+func (f *checksummedFraming) Unmarshal(b []byte, msg Message) error {
+	if len(b) < FixedFrameHeaderLength+checksumLength || !matchesFixedHeader(b) {
+		return ErrDesyncDetected
+	}
+	var inner = b[FixedFrameHeaderLength+checksumLength:]
+
+	if binary.LittleEndian.Uint32(b[8:12]) != crc32.Checksum(inner, crc32cTable) {
+		return ErrChecksumMismatch
+	}
+	var frame, err = f.inner.Unpack(bufio.NewReader(bytes.NewReader(inner)))
+	if err != nil {
+		return fmt.Errorf("unpacking checksummed frame: %s", err)
+	}
+	return f.inner.Unmarshal(frame, msg)
+}
+
+var (
+	// ErrChecksumMismatch is returned by the Unmarshal of a ChecksummedFraming
+	// upon a frame having content which doesn't match its checksum.
+	ErrChecksumMismatch = errors.New("message checksum mismatch")
+
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// checksumLength is the length of the CRC-32C checksum of a checksummed frame.
+const checksumLength = 4
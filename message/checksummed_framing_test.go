@@ -0,0 +1,80 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+
+	gc "github.com/go-check/check"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/labels"
+)
+
+type ChecksummedFramingSuite struct{}
+
+func (s *ChecksummedFramingSuite) TestRoundTripAndCorruption(c *gc.C) {
+	var framing = ChecksummedFraming(JSONFraming)
+	c.Check(framing.ContentType(), gc.Equals, labels.ContentType_JSONLines+ChecksummedContentTypeSuffix)
+
+	var fixtures = []compressedFixture{
+		{Seq: 1, Blob: "first"},
+		{Seq: 2, Blob: "second"},
+		{Seq: 3, Blob: "third"},
+	}
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	for _, fixture := range fixtures {
+		c.Check(framing.Marshal(fixture, bw), gc.IsNil)
+	}
+	c.Check(bw.Flush(), gc.IsNil)
+
+	// Flip a bit of the second message's inner frame.
+	var raw = buf.Bytes()
+	var ind = bytes.Index(raw, []byte("second"))
+	raw[ind] ^= 0x01
+
+	// Expect the corrupt message fails with ErrChecksumMismatch, and that
+	// reading continues with the next message.
+	var br = testReader(raw)
+	for i, expect := range fixtures {
+		var frame, err = framing.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var msg compressedFixture
+		if err = framing.Unmarshal(frame, &msg); i == 1 {
+			c.Check(err, gc.Equals, ErrChecksumMismatch)
+		} else {
+			c.Check(err, gc.IsNil)
+			c.Check(msg, gc.DeepEquals, expect)
+		}
+	}
+	c.Check(framing.Unmarshal([]byte("garbage!"), new(compressedFixture)), gc.Equals, ErrDesyncDetected)
+}
+
+func (s *ChecksummedFramingSuite) TestComposesAndIsBuiltByContentType(c *gc.C) {
+	var compressed, err = CompressedFraming(JSONFraming, pb.CompressionCodec_SNAPPY)
+	c.Assert(err, gc.IsNil)
+	var framing = ChecksummedFraming(compressed)
+	c.Check(framing.ContentType(), gc.Equals, labels.ContentType_JSONLines+"+snappy+crc32c")
+
+	// FramingByContentType builds an equivalent Framing.
+	built, err := FramingByContentType(framing.ContentType())
+	c.Assert(err, gc.IsNil)
+	c.Check(built, gc.DeepEquals, framing)
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	c.Check(framing.Marshal(compressedFixture{Seq: 1, Blob: "blob"}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	frame, err := built.Unpack(testReader(buf.Bytes()))
+	c.Check(err, gc.IsNil)
+
+	var msg compressedFixture
+	c.Check(built.Unmarshal(frame, &msg), gc.IsNil)
+	c.Check(msg, gc.DeepEquals, compressedFixture{Seq: 1, Blob: "blob"})
+
+	_, err = FramingByContentType("invalid" + ChecksummedContentTypeSuffix)
+	c.Check(err, gc.ErrorMatches, `unrecognized .* \(invalid\)`)
+}
+
+var _ = gc.Suite(&ChecksummedFramingSuite{})
@@ -0,0 +1,116 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"go.gazette.dev/core/broker/codecs"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// CompressedFraming returns a Framing which wraps |inner|, compressing the
+// inner frame of each message individually with the CompressionCodec. Unlike
+// compression of whole Fragments, each message may be decompressed in isolation
+// from any other, which suits journals of large messages which are randomly
+// accessed.
+//
+// Frames use the fixed-length header of FixedFraming: a 4-byte magic word for
+// de-synchronization detection, followed by a little-endian uint32 length,
+// followed by the payload. The payload is a single byte of the
+// CompressionCodec, followed by the compressed inner frame. As the codec is
+// carried by each frame, Unmarshal decodes frames of any codec.
+//
+// The ContentType of a CompressedFraming is that of |inner|, having a suffix
+// of the lower-cased codec name, for example "application/x-ndjson+snappy".
+// FramingByContentType and JournalSpec label validation understand this suffix.
+func CompressedFraming(inner Framing, codec pb.CompressionCodec) (Framing, error) {
+	if err := codec.Validate(); err != nil {
+		return nil, err
+	} else if codec == pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION {
+		return nil, fmt.Errorf("%s is not supported by CompressedFraming", codec)
+	}
+	return &compressedFraming{inner: inner, codec: codec}, nil
+}
+
+type compressedFraming struct {
+	inner Framing
+	codec pb.CompressionCodec
+}
+
+// ContentType returns the ContentType of the inner Framing, with a suffix
+// of the compression codec.
+func (f *compressedFraming) ContentType() string {
+	return f.inner.ContentType() + "+" + strings.ToLower(f.codec.String())
+}
+
+// Marshal implements Framing.
+func (f *compressedFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var buf = bytes.NewBuffer(bufferPool.Get().([]byte))
+	defer func() { bufferPool.Put(buf.Bytes()[:0]) }()
+
+	// Reserve the frame header, which is filled once the payload length is known.
+	buf.Write(make([]byte, FixedFrameHeaderLength))
+	buf.WriteByte(byte(f.codec))
+
+	var cw, err = codecs.NewCodecWriter(buf, f.codec)
+	if err != nil {
+		return err
+	}
+	var iw = bufio.NewWriter(cw)
+
+	if err = f.inner.Marshal(msg, iw); err != nil {
+		return err
+	} else if err = iw.Flush(); err != nil {
+		return err
+	} else if err = cw.Close(); err != nil {
+		return err
+	}
+	var b = buf.Bytes()
+
+	copy(b[0:4], magicWord[:])
+	binary.LittleEndian.PutUint32(b[4:8], uint32(len(b)-FixedFrameHeaderLength))
+
+	_, _ = bw.Write(b)
+	return nil
+}
+
+// Unpack returns the next fixed frame of content from the Reader.
+// See UnpackFixed.
+//
+// It implements Framing.
+func (*compressedFraming) Unpack(r *bufio.Reader) ([]byte, error) { return UnpackFixed(r) }
+
+// UnpackLimit returns the next fixed frame of content from the Reader, having
+// a maximum payload length of |limit|. See UnpackFixedLimit.
+//
+// It implements LimitedUnpacker.
+func (*compressedFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackFixedLimit(r, limit)
+}
+
+// Unmarshal verifies the frame header, decompresses the inner frame, and
+// unmarshals it into Message using the inner Framing. If the frame header
+// indicates a desync occurred, ErrDesyncDetected is returned.
+//
+// It implements Framing.
+func (f *compressedFraming) Unmarshal(b []byte, msg Message) error {
+	if len(b) <= FixedFrameHeaderLength || !matchesFixedHeader(b) {
+		return ErrDesyncDetected
+	}
+	var codec = pb.CompressionCodec(b[FixedFrameHeaderLength])
+
+	var dr, err = codecs.NewCodecReader(bytes.NewReader(b[FixedFrameHeaderLength+1:]), codec)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+
+	frame, err := f.inner.Unpack(bufio.NewReader(dr))
+	if err != nil {
+		return fmt.Errorf("unpacking compressed frame: %s", err)
+	}
+	return f.inner.Unmarshal(frame, msg)
+}
@@ -0,0 +1,106 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/labels"
+)
+
+type CompressedFramingSuite struct{}
+
+func (s *CompressedFramingSuite) TestRoundTripAndIsolatedRead(c *gc.C) {
+	var framing, err = CompressedFraming(JSONFraming, pb.CompressionCodec_GZIP)
+	c.Assert(err, gc.IsNil)
+	c.Check(framing.ContentType(), gc.Equals, labels.ContentType_JSONLines+"+gzip")
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	var offsets []int
+
+	var fixtures = []compressedFixture{
+		{Seq: 1, Blob: strings.Repeat("compressible ", 1000)},
+		{Seq: 2, Blob: strings.Repeat("content ", 1000)},
+		{Seq: 3, Blob: "short"},
+	}
+	for _, fixture := range fixtures {
+		offsets = append(offsets, buf.Len()+bw.Buffered())
+		c.Check(framing.Marshal(fixture, bw), gc.IsNil)
+	}
+	c.Check(bw.Flush(), gc.IsNil)
+
+	// Expect frames use the FixedFraming header, and messages were compressed.
+	c.Check(buf.Bytes()[:4], gc.DeepEquals, magicWord[:])
+	c.Check(buf.Bytes()[FixedFrameHeaderLength], gc.Equals, byte(pb.CompressionCodec_GZIP))
+	c.Check(offsets[1] < 1000, gc.Equals, true)
+
+	// All messages may be read in sequence.
+	var br = testReader(buf.Bytes())
+	for _, expect := range fixtures {
+		var frame, err = framing.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var msg compressedFixture
+		c.Check(framing.Unmarshal(frame, &msg), gc.IsNil)
+		c.Check(msg, gc.DeepEquals, expect)
+	}
+	_, err = framing.Unpack(br)
+	c.Check(errors.Cause(err), gc.Equals, io.EOF)
+
+	// A message may be read in isolation, from its offset, by a CompressedFraming
+	// of another codec.
+	other, err := FramingByContentType(labels.ContentType_JSONLines + "+snappy")
+	c.Assert(err, gc.IsNil)
+
+	frame, err := other.Unpack(testReader(buf.Bytes()[offsets[1]:]))
+	c.Check(err, gc.IsNil)
+
+	var msg compressedFixture
+	c.Check(other.Unmarshal(frame, &msg), gc.IsNil)
+	c.Check(msg, gc.DeepEquals, fixtures[1])
+}
+
+func (s *CompressedFramingSuite) TestErrorCases(c *gc.C) {
+	// Case: unsupported codecs.
+	var _, err = CompressedFraming(JSONFraming, pb.CompressionCodec_GZIP_OFFLOAD_DECOMPRESSION)
+	c.Check(err, gc.ErrorMatches, `GZIP_OFFLOAD_DECOMPRESSION is not supported by CompressedFraming`)
+	_, err = CompressedFraming(JSONFraming, pb.CompressionCodec_INVALID)
+	c.Check(err, gc.NotNil)
+	// A ContentType naming an unsupported codec is rejected, as it is by
+	// JournalSpec label validation.
+	_, err = FramingByContentType(labels.ContentType_JSONLines + "+gzip_offload_decompression")
+	c.Check(err, gc.ErrorMatches, `GZIP_OFFLOAD_DECOMPRESSION is not supported by CompressedFraming`)
+
+	// Case: the inner ContentType is unknown.
+	_, err = FramingByContentType("wrong+snappy")
+	c.Check(err, gc.ErrorMatches, `unrecognized `+labels.ContentType+` \(wrong\)`)
+
+	framing, err := CompressedFraming(JSONFraming, pb.CompressionCodec_SNAPPY)
+	c.Assert(err, gc.IsNil)
+
+	var msg compressedFixture
+
+	// Case: frame is de-synchronized.
+	c.Check(framing.Unmarshal([]byte("garbage!!"), &msg), gc.Equals, ErrDesyncDetected)
+	c.Check(framing.Unmarshal(magicWord[:], &msg), gc.Equals, ErrDesyncDetected)
+
+	// Case: frame has an invalid codec.
+	var frame = append(append(magicWord[:], 1, 0, 0, 0), 0xff)
+	c.Check(framing.Unmarshal(frame, &msg), gc.ErrorMatches, `unsupported codec .*`)
+
+	// Case: the compressed payload is corrupt.
+	frame = append(append(magicWord[:], 4, 0, 0, 0), byte(pb.CompressionCodec_SNAPPY), 'b', 'a', 'd')
+	c.Check(framing.Unmarshal(frame, &msg), gc.ErrorMatches, `unpacking compressed frame: .*`)
+}
+
+type compressedFixture struct {
+	Seq  int
+	Blob string
+}
+
+var _ = gc.Suite(&CompressedFramingSuite{})
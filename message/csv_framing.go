@@ -0,0 +1,308 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"go.gazette.dev/core/labels"
+)
+
+// CSVFraming is a Framing implementation which encodes messages as records of
+// comma-separated values, one record per line. Fields which contain the
+// delimiter, quotes, or newlines are quoted, and Unpack respects quoted fields
+// which span multiple lines.
+//
+// Messages may implement CSVMarshaler and CSVUnmarshaler to control their own
+// record encoding. Otherwise, messages must be structs (or pointers thereto),
+// and record columns map to exported struct fields of string, boolean, or
+// numeric type, or of types implementing encoding.TextMarshaler and
+// encoding.TextUnmarshaler. A column is named by the "csv" tag of its field,
+// or by the field name if there is no tag. Fields tagged `csv:"-"` are skipped.
+type CSVFraming struct {
+	// Comma is the field delimiter. If zero, ',' is used.
+	Comma rune
+	// Header is the ordered column names of each record. If empty, records have
+	// a column for each exported field of the message struct, in field order.
+	// If set, Unmarshal of a record equal to Header returns ErrCSVHeaderRecord,
+	// allowing a header row written by MarshalHeader to be skipped by readers.
+	Header []string
+}
+
+// CSVMarshaler is implemented by messages able to encode themselves as a record.
+type CSVMarshaler interface {
+	MarshalCSV() ([]string, error)
+}
+
+// CSVUnmarshaler is implemented by messages able to decode themselves from a record.
+type CSVUnmarshaler interface {
+	UnmarshalCSV([]string) error
+}
+
+// ErrCSVHeaderRecord is returned by CSVFraming.Unmarshal of a header record.
+var ErrCSVHeaderRecord = errors.New("record is a CSV header")
+
+// ContentType returns labels.ContentType_CSV.
+func (*CSVFraming) ContentType() string { return labels.ContentType_CSV }
+
+// MarshalHeader writes the Header record to the bufio.Writer.
+func (f *CSVFraming) MarshalHeader(bw *bufio.Writer) error {
+	if len(f.Header) == 0 {
+		return errors.New("CSVFraming has no Header")
+	}
+	return f.writeRecord(f.Header, bw)
+}
+
+// Marshal implements Framing.
+func (f *CSVFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var record, err = f.encode(msg)
+	if err != nil {
+		return err
+	}
+	return f.writeRecord(record, bw)
+}
+
+// Unpack returns the next record line, including its trailing newline. As
+// quoted fields may contain newlines, lines are read until all quotes of the
+// record are balanced.
+//
+// It implements Framing.
+func (f *CSVFraming) Unpack(r *bufio.Reader) ([]byte, error) {
+	return f.UnpackLimit(r, MaxFrameLength)
+}
+
+// UnpackLimit is Unpack with a maximum record length of |limit| bytes. If the
+// record is longer, ErrFrameTooLarge is returned. Lines of the record which
+// follow the limit aren't discarded, and are unpacked as further (likely
+// invalid) records.
+//
+// It implements LimitedUnpacker.
+func (*CSVFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	var line, err = UnpackLineLimit(r, limit)
+
+	for err == nil && bytes.Count(line, []byte{'"'})%2 != 0 {
+		// The record continues with a quoted newline. Copy, as |line| may
+		// reference an internal buffer of the Reader.
+		line = append([]byte(nil), line...)
+
+		var rest []byte
+		if rest, err = UnpackLineLimit(r, limit-len(line)); err == nil || err == io.ErrUnexpectedEOF {
+			line = append(line, rest...)
+		}
+		if err == io.EOF {
+			// If we read at least one byte, then an EOF is unexpected (it should
+			// occur only on whole-record boundaries).
+			err = io.ErrUnexpectedEOF
+		} else if err == ErrFrameTooLarge {
+			return nil, err
+		}
+	}
+	return line, err
+}
+
+// Unmarshal implements Framing.
+func (f *CSVFraming) Unmarshal(line []byte, msg Message) error {
+	var r = csv.NewReader(bytes.NewReader(line))
+	r.Comma = f.comma()
+	r.FieldsPerRecord = -1
+
+	var record, err = r.Read()
+	if err != nil {
+		return err
+	} else if len(f.Header) != 0 && equalRecords(record, f.Header) {
+		return ErrCSVHeaderRecord
+	}
+
+	if u, ok := msg.(CSVUnmarshaler); ok {
+		err = u.UnmarshalCSV(record)
+	} else {
+		err = f.decode(record, msg)
+	}
+	if err != nil {
+		return err
+	} else if fx, ok := msg.(Fixupable); ok {
+		return fx.Fixup()
+	}
+	return nil
+}
+
+func (f *CSVFraming) comma() rune {
+	if f.Comma == 0 {
+		return ','
+	}
+	return f.Comma
+}
+
+func (f *CSVFraming) writeRecord(record []string, bw *bufio.Writer) error {
+	var w = csv.NewWriter(bw)
+	w.Comma = f.comma()
+
+	if err := w.Write(record); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// encode |msg| as a record, via CSVMarshaler or its struct fields.
+func (f *CSVFraming) encode(msg Message) ([]string, error) {
+	if m, ok := msg.(CSVMarshaler); ok {
+		return m.MarshalCSV()
+	}
+	var v = reflect.Indirect(reflect.ValueOf(msg))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not CSV-frameable (must be a struct or implement CSVMarshaler)", msg)
+	}
+	var fields, err = f.fields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	var record = make([]string, len(fields))
+	for i, fi := range fields {
+		if record[i], err = formatCSVField(v.Field(fi)); err != nil {
+			return nil, fmt.Errorf("field %s: %s", v.Type().Field(fi).Name, err)
+		}
+	}
+	return record, nil
+}
+
+// decode |record| into struct fields of |msg|.
+func (f *CSVFraming) decode(record []string, msg Message) error {
+	var v = reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%T is not CSV-frameable (must be a struct pointer or implement CSVUnmarshaler)", msg)
+	}
+	v = v.Elem()
+
+	var fields, err = f.fields(v.Type())
+	if err != nil {
+		return err
+	} else if len(record) != len(fields) {
+		return fmt.Errorf("record has %d columns (expected %d)", len(record), len(fields))
+	}
+
+	for i, fi := range fields {
+		if err = parseCSVField(record[i], v.Field(fi)); err != nil {
+			return fmt.Errorf("field %s: %s", v.Type().Field(fi).Name, err)
+		}
+	}
+	return nil
+}
+
+// fields returns indices of struct fields of |t| for each record column.
+func (f *CSVFraming) fields(t reflect.Type) ([]int, error) {
+	var names []string
+	var indices = make(map[string]int)
+
+	for i := 0; i != t.NumField(); i++ {
+		var sf = t.Field(i)
+		var name = sf.Name
+
+		if sf.PkgPath != "" {
+			continue // Unexported.
+		} else if tag, ok := sf.Tag.Lookup("csv"); ok && tag == "-" {
+			continue
+		} else if ok {
+			name = tag
+		}
+		names = append(names, name)
+		indices[name] = i
+	}
+
+	if len(f.Header) == 0 {
+		var out = make([]int, len(names))
+		for i, name := range names {
+			out[i] = indices[name]
+		}
+		return out, nil
+	}
+
+	var out = make([]int, len(f.Header))
+	for i, name := range f.Header {
+		var ind, ok = indices[name]
+		if !ok {
+			return nil, fmt.Errorf("%s has no field for CSV column %q", t, name)
+		}
+		out[i] = ind
+	}
+	return out, nil
+}
+
+func formatCSVField(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		var b, err = m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func parseCSVField(s string, v reflect.Value) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		var b, err = strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i, err = strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u, err = strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f, err = strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func equalRecords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
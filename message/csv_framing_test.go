@@ -0,0 +1,133 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"time"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/labels"
+)
+
+type CSVFramingSuite struct{}
+
+func (s *CSVFramingSuite) TestImplementsFraming(c *gc.C) {
+	// Verified by the compiler.
+	var _ Framing = new(CSVFraming)
+	c.Check(new(CSVFraming).ContentType(), gc.Equals, labels.ContentType_CSV)
+}
+
+func (s *CSVFramingSuite) TestStructRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	var f = &CSVFraming{Comma: ';'}
+
+	var fixtures = []csvFixture{
+		{Name: "plain", Count: 42, Ratio: 0.5, OK: true, At: time.Unix(1500000000, 0).UTC()},
+		{Name: "has;delimiter \"quotes\"\nand a newline", Count: -1, Ratio: 1e10},
+	}
+	for _, fixture := range fixtures {
+		c.Check(f.Marshal(fixture, bw), gc.IsNil)
+	}
+	c.Check(bw.Flush(), gc.IsNil)
+
+	c.Check(buf.String(), gc.Equals,
+		"plain;42;0.5;true;2017-07-14T02:40:00Z\n"+
+			"\"has;delimiter \"\"quotes\"\"\nand a newline\";-1;1e+10;false;0001-01-01T00:00:00Z\n")
+
+	var br = testReader(buf.Bytes())
+	for _, expect := range fixtures {
+		var frame, err = f.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var msg csvFixture
+		c.Check(f.Unmarshal(frame, &msg), gc.IsNil)
+		c.Check(msg, gc.DeepEquals, expect)
+	}
+	var _, err = f.Unpack(br)
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *CSVFramingSuite) TestHeaderColumns(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	var f = &CSVFraming{Header: []string{"count", "Name"}}
+
+	c.Check(f.MarshalHeader(bw), gc.IsNil)
+	c.Check(f.Marshal(&csvFixture{Name: "foo", Count: 32}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	// Only Header columns are encoded, in Header order.
+	c.Check(buf.String(), gc.Equals, "count,Name\n32,foo\n")
+
+	var br = testReader(buf.Bytes())
+	var msg csvFixture
+
+	// The header record is identified as such.
+	var frame, err = f.Unpack(br)
+	c.Check(err, gc.IsNil)
+	c.Check(f.Unmarshal(frame, &msg), gc.Equals, ErrCSVHeaderRecord)
+
+	frame, err = f.Unpack(br)
+	c.Check(err, gc.IsNil)
+	c.Check(f.Unmarshal(frame, &msg), gc.IsNil)
+	c.Check(msg, gc.DeepEquals, csvFixture{Name: "foo", Count: 32})
+
+	// A Header column not matching a field is an error.
+	f.Header = []string{"missing"}
+	c.Check(f.Marshal(&msg, bw), gc.ErrorMatches, `message.csvFixture has no field for CSV column "missing"`)
+	c.Check(new(CSVFraming).MarshalHeader(bw), gc.ErrorMatches, "CSVFraming has no Header")
+}
+
+func (s *CSVFramingSuite) TestMarshalerInterfaces(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	var f = new(CSVFraming)
+
+	c.Check(f.Marshal(csvRecord{"a", "b,c"}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+	c.Check(buf.String(), gc.Equals, "a,\"b,c\"\n")
+
+	var msg csvRecord
+	c.Check(f.Unmarshal(buf.Bytes(), &msg), gc.IsNil)
+	c.Check(msg, gc.DeepEquals, csvRecord{"a", "b,c"})
+}
+
+func (s *CSVFramingSuite) TestErrorCases(c *gc.C) {
+	var f = new(CSVFraming)
+	var bw = bufio.NewWriter(new(bytes.Buffer))
+
+	c.Check(f.Marshal("not a struct", bw), gc.ErrorMatches, `string is not CSV-frameable .*`)
+	c.Check(f.Marshal(struct{ C chan int }{}, bw), gc.ErrorMatches, `field C: unsupported type chan int`)
+
+	var msg csvFixture
+	c.Check(f.Unmarshal([]byte("foo,bar\n"), &msg), gc.ErrorMatches, `record has 2 columns \(expected 5\)`)
+	c.Check(f.Unmarshal([]byte("foo,bar,1,true,\n"), &msg), gc.ErrorMatches, `field Count: .* invalid syntax`)
+	c.Check(f.Unmarshal([]byte("foo,1,1,true,\n"), msg), gc.ErrorMatches, `.* is not CSV-frameable .*`)
+
+	// A quoted newline without a closing quote is an unexpected EOF.
+	var _, err = f.Unpack(bufio.NewReader(strings.NewReader("\"foo\nbar\n")))
+	c.Check(err, gc.Equals, io.ErrUnexpectedEOF)
+}
+
+type csvFixture struct {
+	Name    string
+	Count   int `csv:"count"`
+	Ratio   float64
+	OK      bool
+	At      time.Time
+	Ignored int `csv:"-"`
+	private int
+}
+
+type csvRecord []string
+
+func (r csvRecord) MarshalCSV() ([]string, error) { return r, nil }
+func (r *csvRecord) UnmarshalCSV(record []string) error {
+	*r = record
+	return nil
+}
+
+var _ = gc.Suite(&CSVFramingSuite{})
@@ -0,0 +1,101 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// Divergence describes the first point at which two message streams differ.
+type Divergence struct {
+	// Index is the zero-based position of the diverging message within each stream.
+	Index int64
+	// Offsets of the diverging message within each journal. If a stream ended
+	// before the divergence, its offset is that of the end of the stream.
+	OffsetA, OffsetB int64
+	// Framed messages of each stream, or nil if the stream ended.
+	MessageA, MessageB []byte
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("message %d diverges (offset %d: %q vs offset %d: %q)",
+		d.Index, d.OffsetA, d.MessageA, d.OffsetB, d.MessageB)
+}
+
+// DiffJournals reads committed messages of journals |a| and |b|, from their
+// respective ReadRequest offsets through to their current write heads, and
+// compares the framed message streams. Messages are aligned by their position
+// within each stream, and are equivalent if their framed content is equal
+// (regardless of the journal offsets at which each was written). This makes
+// DiffJournals useful for verifying migrated or mirrored journals, which may
+// begin at differing offsets.
+//
+// DiffJournals returns the number of messages which matched. If the streams
+// diverge, or one ends before the other, it also returns a Divergence
+// describing the first difference. The Block option of the ReadRequests is
+// ignored: reads always end at the journal write head.
+func DiffJournals(ctx context.Context, rjc pb.RoutedJournalClient, framing Framing,
+	a, b pb.ReadRequest) (matched int64, div *Divergence, err error) {
+
+	a.Block, b.Block = false, false
+
+	var sa = newDiffStream(ctx, rjc, a)
+	defer sa.rr.Cancel()
+	var sb = newDiffStream(ctx, rjc, b)
+	defer sb.rr.Cancel()
+
+	for {
+		var ma, mb []byte
+
+		if ma, err = sa.next(framing); err != nil {
+			return
+		} else if mb, err = sb.next(framing); err != nil {
+			return
+		}
+
+		if ma == nil && mb == nil {
+			return // Both streams ended without divergence.
+		} else if ma == nil || mb == nil || !bytes.Equal(ma, mb) {
+			div = &Divergence{
+				Index:    matched,
+				OffsetA:  sa.offset,
+				OffsetB:  sb.offset,
+				MessageA: ma,
+				MessageB: mb,
+			}
+			return
+		}
+		matched++
+	}
+}
+
+// diffStream reads framed messages of a journal for DiffJournals.
+type diffStream struct {
+	rr     *client.RetryReader
+	br     *bufio.Reader
+	offset int64 // Offset of the last-read message, or of the stream end.
+}
+
+func newDiffStream(ctx context.Context, rjc pb.RoutedJournalClient, req pb.ReadRequest) *diffStream {
+	var rr = client.NewRetryReader(ctx, rjc, req)
+	return &diffStream{rr: rr, br: bufio.NewReader(rr), offset: rr.Offset()}
+}
+
+// next returns the next framed message, or nil if the stream has ended.
+func (s *diffStream) next(framing Framing) ([]byte, error) {
+	s.offset = s.rr.AdjustedOffset(s.br)
+
+	var frame, err = framing.Unpack(s.br)
+	if errors.Cause(err) == client.ErrOffsetNotYetAvailable && len(frame) == 0 {
+		return nil, nil // Read through to the journal write head.
+	} else if err != nil {
+		return nil, errors.WithMessagef(err, "reading %s at offset %d", s.rr.Journal(), s.offset)
+	}
+	// Copy, as |frame| may reference the internal buffer of |s.br|.
+	return append([]byte(nil), frame...), nil
+}
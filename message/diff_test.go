@@ -0,0 +1,95 @@
+package message
+
+import (
+	"context"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/etcdtest"
+)
+
+type DiffSuite struct{}
+
+func (s *DiffSuite) TestDiffJournals(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	brokertest.CreateJournals(c, bk,
+		brokertest.Journal(pb.JournalSpec{Name: "a/journal"}),
+		brokertest.Journal(pb.JournalSpec{Name: "b/journal"}),
+		brokertest.Journal(pb.JournalSpec{Name: "c/journal"}),
+		brokertest.Journal(pb.JournalSpec{Name: "d/journal"}),
+	)
+
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	var write = func(journal pb.Journal, content string) {
+		var a = client.NewAppender(ctx, rjc, pb.AppendRequest{Journal: journal})
+		var _, err = a.Write([]byte(content))
+		c.Assert(err, gc.IsNil)
+		c.Assert(a.Close(), gc.IsNil)
+	}
+	// |b| mirrors |a|, but begins at a different offset.
+	write("a/journal", "one\ntwo\nthree\n")
+	write("b/journal", "prefix\none\ntwo\nthree\n")
+	// |c| diverges from |a| at its second message.
+	write("c/journal", "one\nTWO!\nthree\n")
+	// |d| is a truncated |a|.
+	write("d/journal", "one\ntwo\n")
+
+	var diff = func(a, b pb.ReadRequest) (int64, *Divergence) {
+		var matched, div, err = DiffJournals(ctx, rjc, JSONFraming, a, b)
+		c.Assert(err, gc.IsNil)
+		return matched, div
+	}
+
+	// Case: identical journals.
+	var matched, div = diff(pb.ReadRequest{Journal: "a/journal"}, pb.ReadRequest{Journal: "a/journal"})
+	c.Check(matched, gc.Equals, int64(3))
+	c.Check(div, gc.IsNil)
+
+	// Case: equivalent content at different offsets.
+	matched, div = diff(pb.ReadRequest{Journal: "a/journal"}, pb.ReadRequest{Journal: "b/journal", Offset: 7})
+	c.Check(matched, gc.Equals, int64(3))
+	c.Check(div, gc.IsNil)
+
+	// Case: without adjusting offsets, |b| diverges at its first message.
+	matched, div = diff(pb.ReadRequest{Journal: "a/journal"}, pb.ReadRequest{Journal: "b/journal"})
+	c.Check(matched, gc.Equals, int64(0))
+	c.Check(div, gc.DeepEquals, &Divergence{
+		MessageA: []byte("one\n"),
+		MessageB: []byte("prefix\n"),
+	})
+
+	// Case: the first divergent message is found.
+	matched, div = diff(pb.ReadRequest{Journal: "a/journal"}, pb.ReadRequest{Journal: "c/journal"})
+	c.Check(matched, gc.Equals, int64(1))
+	c.Check(div, gc.DeepEquals, &Divergence{
+		Index:    1,
+		OffsetA:  4,
+		OffsetB:  4,
+		MessageA: []byte("two\n"),
+		MessageB: []byte("TWO!\n"),
+	})
+	c.Check(div.String(), gc.Equals,
+		`message 1 diverges (offset 4: "two\n" vs offset 4: "TWO!\n")`)
+
+	// Case: one stream ends before the other.
+	matched, div = diff(pb.ReadRequest{Journal: "a/journal"}, pb.ReadRequest{Journal: "d/journal"})
+	c.Check(matched, gc.Equals, int64(2))
+	c.Check(div, gc.DeepEquals, &Divergence{
+		Index:    2,
+		OffsetA:  8,
+		OffsetB:  8,
+		MessageA: []byte("three\n"),
+	})
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+var _ = gc.Suite(&DiffSuite{})
@@ -0,0 +1,113 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+)
+
+// EncryptedContentTypeSuffix is appended to the ContentType of a Framing
+// wrapped by EncryptedFraming.
+const EncryptedContentTypeSuffix = "+encrypted"
+
+// EncryptedFraming returns a Framing which wraps |inner|, encrypting the inner
+// frame of each message with the client-managed AEAD cipher (eg, AES-GCM).
+// Brokers store only ciphertext, and only clients holding the key are able to
+// read messages. Frames are also authenticated: a frame which was modified is
+// rejected by Unmarshal.
+//
+// Frames use the fixed-length header of FixedFraming: a 4-byte magic word for
+// de-synchronization detection, followed by a little-endian uint32 length,
+// followed by the payload. The payload is a random nonce of the cipher's
+// NonceSize, followed by the sealed inner frame. The frame header is used as
+// additional authenticated data.
+//
+// The ContentType of an EncryptedFraming is that of |inner|, having suffix
+// EncryptedContentTypeSuffix, for example "application/x-ndjson+encrypted".
+// FramingByContentType is unable to build an EncryptedFraming, as it requires
+// a key, and returns an error for such ContentTypes. Inner Framings may be
+// composed, for example to compress and then encrypt with CompressedFraming.
+func EncryptedFraming(inner Framing, aead cipher.AEAD) Framing {
+	return &encryptedFraming{inner: inner, aead: aead}
+}
+
+type encryptedFraming struct {
+	inner Framing
+	aead  cipher.AEAD
+}
+
+// ContentType returns the ContentType of the inner Framing, with suffix
+// EncryptedContentTypeSuffix.
+func (f *encryptedFraming) ContentType() string {
+	return f.inner.ContentType() + EncryptedContentTypeSuffix
+}
+
+// Marshal implements Framing.
+func (f *encryptedFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var buf = bytes.NewBuffer(bufferPool.Get().([]byte))
+	defer func() { bufferPool.Put(buf.Bytes()[:0]) }()
+
+	var iw = bufio.NewWriter(buf)
+	if err := f.inner.Marshal(msg, iw); err != nil {
+		return err
+	} else if err = iw.Flush(); err != nil {
+		return err
+	}
+
+	var nonceSize = f.aead.NonceSize()
+	var frame = make([]byte, FixedFrameHeaderLength+nonceSize,
+		FixedFrameHeaderLength+nonceSize+buf.Len()+f.aead.Overhead())
+
+	copy(frame[0:4], magicWord[:])
+	binary.LittleEndian.PutUint32(frame[4:8], uint32(cap(frame)-FixedFrameHeaderLength))
+
+	var nonce = frame[FixedFrameHeaderLength:]
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating nonce: %s", err)
+	}
+	frame = f.aead.Seal(frame, nonce, buf.Bytes(), frame[:FixedFrameHeaderLength])
+
+	_, _ = bw.Write(frame)
+	return nil
+}
+
+// Unpack returns the next fixed frame of content from the Reader.
+// See UnpackFixed.
+//
+// It implements Framing.
+func (*encryptedFraming) Unpack(r *bufio.Reader) ([]byte, error) { return UnpackFixed(r) }
+
+// UnpackLimit returns the next fixed frame of content from the Reader, having
+// a maximum payload length of |limit|. See UnpackFixedLimit.
+//
+// It implements LimitedUnpacker.
+func (*encryptedFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackFixedLimit(r, limit)
+}
+
+// Unmarshal verifies the frame header, decrypts and authenticates the inner
+// frame, and unmarshals it into Message using the inner Framing. If the frame
+// header indicates a desync occurred, ErrDesyncDetected is returned.
+//
+// It implements Framing.
+func (f *encryptedFraming) Unmarshal(b []byte, msg Message) error {
+	var nonceSize = f.aead.NonceSize()
+
+	if len(b) < FixedFrameHeaderLength+nonceSize || !matchesFixedHeader(b) {
+		return ErrDesyncDetected
+	}
+	var nonce = b[FixedFrameHeaderLength : FixedFrameHeaderLength+nonceSize]
+
+	var plain, err = f.aead.Open(nil, nonce, b[FixedFrameHeaderLength+nonceSize:], b[:FixedFrameHeaderLength])
+	if err != nil {
+		return fmt.Errorf("decrypting frame: %s", err)
+	}
+	frame, err := f.inner.Unpack(bufio.NewReader(bytes.NewReader(plain)))
+	if err != nil {
+		return fmt.Errorf("unpacking decrypted frame: %s", err)
+	}
+	return f.inner.Unmarshal(frame, msg)
+}
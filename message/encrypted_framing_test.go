@@ -0,0 +1,115 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"io/ioutil"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/etcdtest"
+	"go.gazette.dev/core/labels"
+)
+
+type EncryptedFramingSuite struct{}
+
+func (s *EncryptedFramingSuite) TestRoundTripThroughBroker(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	var framing = EncryptedFraming(JSONFraming, newTestAEAD(c, "0123456789abcdef"))
+	c.Check(framing.ContentType(), gc.Equals, labels.ContentType_JSONLines+EncryptedContentTypeSuffix)
+
+	brokertest.CreateJournals(c, bk, brokertest.Journal(pb.JournalSpec{
+		Name:     "a/encrypted/journal",
+		LabelSet: pb.MustLabelSet(labels.ContentType, framing.ContentType()),
+	}))
+
+	var fixtures = []compressedFixture{
+		{Seq: 1, Blob: "top secret"},
+		{Seq: 2, Blob: "classified"},
+	}
+	var as = client.NewAppendService(ctx, rjc)
+	var aa = as.StartAppend("a/encrypted/journal")
+	for _, fixture := range fixtures {
+		c.Check(framing.Marshal(fixture, aa.Writer()), gc.IsNil)
+	}
+	c.Assert(aa.Release(), gc.IsNil)
+	<-aa.Done()
+
+	// Expect the broker holds only ciphertext.
+	var r = client.NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/encrypted/journal"})
+	var raw, _ = ioutil.ReadAll(r)
+	c.Check(len(raw), gc.Equals, int(aa.Response().Commit.End))
+
+	for _, fixture := range fixtures {
+		c.Check(bytes.Contains(raw, []byte(fixture.Blob)), gc.Equals, false)
+	}
+
+	// Messages round-trip with the key.
+	var br = bufio.NewReader(bytes.NewReader(raw))
+	for _, expect := range fixtures {
+		var frame, err = framing.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var msg compressedFixture
+		c.Check(framing.Unmarshal(frame, &msg), gc.IsNil)
+		c.Check(msg, gc.DeepEquals, expect)
+	}
+
+	// Frames fail to decrypt with a different key, or if modified.
+	var frame, _ = framing.Unpack(bufio.NewReader(bytes.NewReader(raw)))
+	var other = EncryptedFraming(JSONFraming, newTestAEAD(c, "fedcba9876543210"))
+	c.Check(other.Unmarshal(frame, new(compressedFixture)), gc.ErrorMatches,
+		`decrypting frame: cipher: message authentication failed`)
+
+	frame[len(frame)-1] ^= 0xff
+	c.Check(framing.Unmarshal(frame, new(compressedFixture)), gc.ErrorMatches,
+		`decrypting frame: cipher: message authentication failed`)
+	c.Check(framing.Unmarshal([]byte("garbage!"), new(compressedFixture)), gc.Equals, ErrDesyncDetected)
+
+	// Encrypted ContentTypes can't be framed without a key.
+	var _, err = FramingByContentType(framing.ContentType())
+	c.Check(err, gc.ErrorMatches, `.* requires a client-managed key \(see EncryptedFraming\)`)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *EncryptedFramingSuite) TestComposesWithCompression(c *gc.C) {
+	var compressed, err = CompressedFraming(JSONFraming, pb.CompressionCodec_SNAPPY)
+	c.Assert(err, gc.IsNil)
+	var framing = EncryptedFraming(compressed, newTestAEAD(c, "0123456789abcdef"))
+	c.Check(framing.ContentType(), gc.Equals, labels.ContentType_JSONLines+"+snappy+encrypted")
+
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	c.Check(framing.Marshal(compressedFixture{Seq: 1, Blob: "secret"}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	frame, err := framing.Unpack(testReader(buf.Bytes()))
+	c.Check(err, gc.IsNil)
+
+	var msg compressedFixture
+	c.Check(framing.Unmarshal(frame, &msg), gc.IsNil)
+	c.Check(msg, gc.DeepEquals, compressedFixture{Seq: 1, Blob: "secret"})
+}
+
+func newTestAEAD(c *gc.C, key string) cipher.AEAD {
+	var block, err = aes.NewCipher([]byte(key))
+	c.Assert(err, gc.IsNil)
+	aead, err := cipher.NewGCM(block)
+	c.Assert(err, gc.IsNil)
+	return aead
+}
+
+var _ = gc.Suite(&EncryptedFramingSuite{})
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"encoding/binary"
 	"fmt"
-	"io"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -66,67 +65,23 @@ func (*fixedFraming) Encode(msg Message, b []byte) ([]byte, error) {
 	return b, nil
 }
 
-// Unpack returns the next fixed frame of content from the Reader, including
-// the frame header. If the magic word is not detected (indicating a desync),
-// Unpack attempts to continue reading until the next magic word, returning
-// the interleaved but desynchronized content.
+// Unpack returns the next fixed frame of content from the Reader.
+// See UnpackFixed.
 //
 // It implements Framing.
-func (*fixedFraming) Unpack(r *bufio.Reader) ([]byte, error) {
-	var b, err = r.Peek(FixedFrameHeaderLength)
-
-	if err != nil {
-		// If buffer just contains a trailing newline, return EOF.
-		// TODO(johnny): Can we remove this?
-		if err == io.EOF && len(b) == 1 && b[0] == 0x0a {
-			return nil, io.EOF
-		}
-		if err == io.EOF && len(b) != 0 {
-			// If we read at least one byte, then an EOF is unexpected (it should
-			// occur only on whole-message boundaries).
-			err = io.ErrUnexpectedEOF
-		} else {
-			err = errors.Wrap(err, "Peek(FixedFrameHeaderLength)")
-		}
-		return nil, err
-	}
-
-	if !matchesMagicWord(b) {
-		// We are not at the expected frame boundary. Scan forward within the buffered
-		// region to the beginning of the next magic word. Return the intermediate
-		// jumbled frame (this will produce an ErrDesyncDetected on a later Unmarshal).
-		b, _ = r.Peek(r.Buffered())
-
-		var i, j = 1, 1 + len(b) - len(magicWord)
-		for ; i != j; i++ {
-			if matchesMagicWord(b[i:]) {
-				break
-			}
-		}
-		_, _ = r.Discard(i)
-		return b[:i], nil
-	}
-
-	// Next 4 bytes are encoded size. Combine with header for full frame size.
-	var size = FixedFrameHeaderLength + int(binary.LittleEndian.Uint32(b[4:]))
+func (*fixedFraming) Unpack(r *bufio.Reader) ([]byte, error) { return UnpackFixed(r) }
 
-	// Fast path: check if the full frame is available in buffer. Return the
-	// buffer internal slice without copying. It is invalidated by the next
-	// Unpack (or other Reader operation).
-	if b, err = r.Peek(size); err == nil {
-		_, _ = r.Discard(size)
-		return b, nil
-	}
-
-	// Slow path. Allocate and attempt to Read the full frame.
-	b = make([]byte, size)
-	_, err = io.ReadFull(r, b)
-	return b, errors.Wrap(err, "io.ReadFull")
+// UnpackLimit returns the next fixed frame of content from the Reader, having
+// a maximum payload length of |limit|. See UnpackFixedLimit.
+//
+// It implements LimitedUnpacker.
+func (*fixedFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackFixedLimit(r, limit)
 }
 
 // Unmarshal verifies the frame header and unpacks Message content. If the frame
-// header indicates a desync occurred (incorrect magic word), ErrDesyncDetected
-// is returned.
+// header indicates a desync occurred (incorrect magic word or length),
+// ErrDesyncDetected is returned.
 //
 // It implements Framing.
 func (*fixedFraming) Unmarshal(b []byte, msg Message) error {
@@ -136,7 +91,7 @@ func (*fixedFraming) Unmarshal(b []byte, msg Message) error {
 
 	if !ok {
 		return fmt.Errorf("%+v is not fixed-frameable (must implement Unmarshal)", msg)
-	} else if !matchesMagicWord(b) {
+	} else if !matchesFixedHeader(b) {
 		return ErrDesyncDetected
 	} else if err := p.Unmarshal(b[FixedFrameHeaderLength:]); err != nil {
 		return err
@@ -146,6 +101,14 @@ func (*fixedFraming) Unmarshal(b []byte, msg Message) error {
 	return nil
 }
 
+// matchesFixedHeader returns whether frame |b| begins with the magic word,
+// followed by a length which matches that of the frame payload. Content
+// returned by UnpackFixed upon a desync doesn't match.
+func matchesFixedHeader(b []byte) bool {
+	return len(b) >= FixedFrameHeaderLength && matchesMagicWord(b) &&
+		binary.LittleEndian.Uint32(b[4:]) == uint32(len(b)-FixedFrameHeaderLength)
+}
+
 func matchesMagicWord(b []byte) bool {
 	return b[0] == magicWord[0] && b[1] == magicWord[1] && b[2] == magicWord[2] && b[3] == magicWord[3]
 }
@@ -155,6 +118,15 @@ var (
 	ErrDesyncDetected = errors.New("detected de-synchronization")
 	// magicWord precedes all fixedFraming encodings.
 	magicWord = [4]byte{0x66, 0x33, 0x93, 0x36}
+	// ErrFrameTooLarge is returned by Unpack of a line-oriented frame which
+	// is longer than the maximum frame length (by default, MaxFrameLength).
+	// Fixed frames of excessive length are instead presumed to be corrupt.
+	ErrFrameTooLarge = errors.New("frame exceeds maximum length")
+	// MaxFrameLength is the default maximum length of an unpacked frame,
+	// used by UnpackLine and UnpackFixed (and the Framings which use them).
+	// It guards against unbounded allocation when reading corrupt content.
+	// A Framing may use a different limit: see WithMaxFrameLength.
+	MaxFrameLength = 1 << 30
 	// bufferPool pools buffers used for MarshalTo encodings.
 	bufferPool = sync.Pool{New: func() interface{} { return make([]byte, 0, 1024) }}
 )
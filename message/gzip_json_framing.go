@@ -0,0 +1,85 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"go.gazette.dev/core/labels"
+)
+
+// GzipJSONFraming is a Framing implementation which encodes messages as
+// newline-delimited, individually gzip-compressed JSON. Each message is JSON
+// encoded, compressed as a gzip stream of its own, and base64-encoded (with
+// standard padded encoding) such that the encoded line has no newlines. It
+// suits upstreams which produce compressed messages, and unlike compression
+// of whole Fragments (or CompressedFraming, which uses fixed frames), lines
+// remain readable by line-oriented tools. Messages must be encode-able by
+// the encoding/json package.
+//
+// A line which fails to decode, decompress, or unmarshal is an error of
+// Unmarshal for that message only: as lines are delimited without regard to
+// their content, the following message is unaffected.
+var GzipJSONFraming = new(gzipJSONFraming)
+
+type gzipJSONFraming struct{}
+
+// ContentType returns labels.ContentType_GzipJSONLines.
+func (*gzipJSONFraming) ContentType() string { return labels.ContentType_GzipJSONLines }
+
+// Marshal implements Framing.
+func (*gzipJSONFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var buf = bytes.NewBuffer(bufferPool.Get().([]byte))
+	defer func() { bufferPool.Put(buf.Bytes()[:0]) }()
+
+	var gz = gzip.NewWriter(buf)
+	if err := json.NewEncoder(gz).Encode(msg); err != nil {
+		return err
+	} else if err = gz.Close(); err != nil {
+		return err
+	}
+
+	var enc = base64.NewEncoder(base64.StdEncoding, bw)
+	_, _ = enc.Write(buf.Bytes())
+	_ = enc.Close()
+	return bw.WriteByte('\n')
+}
+
+// Unpack implements Framing.
+func (*gzipJSONFraming) Unpack(r *bufio.Reader) ([]byte, error) {
+	return UnpackLine(r)
+}
+
+// UnpackLimit implements LimitedUnpacker.
+func (*gzipJSONFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackLineLimit(r, limit)
+}
+
+// Unmarshal decodes and decompresses the line, and unmarshals its JSON
+// into the Message.
+//
+// It implements Framing.
+func (*gzipJSONFraming) Unmarshal(line []byte, msg Message) error {
+	line = bytes.TrimRight(line, "\r\n")
+
+	var gz, err = gzip.NewReader(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(line)))
+	if err != nil {
+		return fmt.Errorf("decoding gzip line: %s", err)
+	}
+	defer gz.Close()
+
+	// Read through to the gzip EOF, which verifies the stream checksum.
+	b, err := ioutil.ReadAll(gz)
+	if err != nil {
+		return fmt.Errorf("decoding gzip line: %s", err)
+	} else if err = json.Unmarshal(b, msg); err != nil {
+		return err
+	} else if f, ok := msg.(Fixupable); ok {
+		return f.Fixup()
+	}
+	return nil
+}
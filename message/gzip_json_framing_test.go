@@ -0,0 +1,106 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/labels"
+)
+
+type GzipJSONFramingSuite struct{}
+
+func (s *GzipJSONFramingSuite) TestMarshalAndUnmarshalRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	type msg struct {
+		A int
+		B string
+	}
+	c.Check(GzipJSONFraming.Marshal(msg{42, "the answer"}, bw), gc.IsNil)
+	c.Check(GzipJSONFraming.Marshal(msg{52, "line\nbreak"}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	// Expect each message is a single line, which gunzips to its JSON encoding.
+	var lines = bytes.SplitAfter(buf.Bytes(), []byte("\n"))
+	c.Assert(lines, gc.HasLen, 3)
+	c.Check(lines[2], gc.HasLen, 0)
+	c.Check(gunzipLine(c, lines[0]), gc.Equals, `{"A":42,"B":"the answer"}`+"\n")
+
+	var br = testReader(buf.Bytes())
+	for _, expect := range []msg{{42, "the answer"}, {52, "line\nbreak"}} {
+		var frame, err = GzipJSONFraming.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var out msg
+		c.Check(GzipJSONFraming.Unmarshal(frame, &out), gc.IsNil)
+		c.Check(out, gc.Equals, expect)
+	}
+	c.Check(GzipJSONFraming.ContentType(), gc.Equals, labels.ContentType_GzipJSONLines)
+}
+
+func (s *GzipJSONFramingSuite) TestDecodeErrorsArePerMessage(c *gc.C) {
+	var valid bytes.Buffer
+	var bw = bufio.NewWriter(&valid)
+	c.Check(GzipJSONFraming.Marshal(struct{ A int }{42}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	// Build a copy of the valid line having a corrupted gzip CRC.
+	var raw, _ = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(valid.Bytes())))
+	raw[len(raw)-8] ^= 0xff
+
+	var fixture bytes.Buffer
+	fixture.WriteString(base64.StdEncoding.EncodeToString(raw) + "\n")
+	fixture.WriteString("not base64!\n")
+	fixture.WriteString(base64.StdEncoding.EncodeToString([]byte("not gzip")) + "\n")
+	fixture.WriteString(gzipLine(c, `{"A": "missing quote}`) + "\n")
+	fixture.Write(valid.Bytes())
+
+	var br = testReader(fixture.Bytes())
+	var expect = []string{
+		`decoding gzip line: gzip: invalid checksum`,
+		`decoding gzip line: illegal base64 data at input byte 3`,
+		`decoding gzip line: unexpected EOF`,
+		`unexpected end of JSON input`,
+	}
+	for _, e := range expect {
+		var frame, err = GzipJSONFraming.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var out struct{ A int }
+		c.Check(GzipJSONFraming.Unmarshal(frame, &out), gc.ErrorMatches, e)
+	}
+
+	// The reader remains valid, and the final message decodes.
+	var frame, err = GzipJSONFraming.Unpack(br)
+	c.Check(err, gc.IsNil)
+
+	var out struct{ A int }
+	c.Check(GzipJSONFraming.Unmarshal(frame, &out), gc.IsNil)
+	c.Check(out.A, gc.Equals, 42)
+}
+
+func gzipLine(c *gc.C, s string) string {
+	var buf bytes.Buffer
+	var gz = gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(s))
+	c.Assert(gz.Close(), gc.IsNil)
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func gunzipLine(c *gc.C, line []byte) string {
+	var raw, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(line)))
+	c.Assert(err, gc.IsNil)
+	gz, err := gzip.NewReader(bytes.NewReader(raw))
+	c.Assert(err, gc.IsNil)
+
+	var out bytes.Buffer
+	_, err = out.ReadFrom(gz)
+	c.Assert(err, gc.IsNil)
+	return out.String()
+}
+
+var _ = gc.Suite(&GzipJSONFramingSuite{})
@@ -19,6 +19,7 @@ type Envelope struct {
 	Fragment    *protocol.Fragment
 	JournalSpec *protocol.JournalSpec
 	NextOffset  int64 // Offset of the next Message within the Journal.
+	WriteHead   int64 // Write head of the Journal, as last reported by the broker.
 }
 
 // Framing specifies the serialization used to encode Messages within a topic.
@@ -40,6 +41,14 @@ type Framing interface {
 	Unmarshal([]byte, Message) error
 }
 
+// LimitedUnpacker is an optional interface of a Framing which is able to
+// Unpack frames having a maximum length other than MaxFrameLength. All
+// Framings of this package implement it. See WithMaxFrameLength.
+type LimitedUnpacker interface {
+	// UnpackLimit is Unpack with a maximum frame length of |limit| bytes.
+	UnpackLimit(r *bufio.Reader, limit int) ([]byte, error)
+}
+
 // Fixupable is an optional Message type capable of being "fixed up" after
 // decoding. This provides an opportunity to apply custom migrations or
 // initialization after a generic or code-generated unmarshal has completed.
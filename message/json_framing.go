@@ -26,6 +26,11 @@ func (*jsonFraming) Unpack(r *bufio.Reader) ([]byte, error) {
 	return UnpackLine(r)
 }
 
+// UnpackLimit implements LimitedUnpacker.
+func (*jsonFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackLineLimit(r, limit)
+}
+
 // Unmarshal implements Framing.
 func (*jsonFraming) Unmarshal(line []byte, msg Message) error {
 	if err := json.Unmarshal(line, msg); err != nil {
@@ -0,0 +1,84 @@
+package message
+
+// KeyDeduper filters duplicate Messages by an application-provided key, such
+// as a business event ID, for producers which aren't able to otherwise
+// identify re-publications of a Message. A Message is a duplicate if a
+// Message of the same key was previously observed.
+//
+// KeyDeduper retains a bounded window of the most recently observed keys, and
+// duplicates of Messages older than the window are not detected. Memory
+// use is linear in the window size and the length of retained keys: a window of
+// one million 16-byte keys requires on the order of 100MB. Applications should
+// size the window to cover the span over which producers may re-publish
+// a Message (eg, a retry interval multiplied by the message rate).
+//
+// Observed keys are pending until Commit, and a Rollback discards keys observed
+// since the last Commit. A consumer transaction may thus Commit upon its
+// successful completion, and Rollback if it fails so that its Messages
+// are not treated as duplicates when they're re-read. As KeyDeduper isn't
+// itself durable, a consumer which recovers from a prior checkpoint should also
+// rebuild its window by observing Messages read from a suitable prior offset.
+//
+// KeyDeduper is not safe for concurrent use.
+type KeyDeduper struct {
+	key MappingKeyFunc
+	buf []byte
+
+	// Committed keys, and a FIFO ring of their insertion order.
+	seen map[string]struct{}
+	ring []string
+	next int
+	// Keys observed since the last Commit.
+	pending map[string]struct{}
+}
+
+// NewKeyDeduper returns a KeyDeduper which extracts keys of Messages using
+// the MappingKeyFunc, and which retains a window of |size| committed keys.
+func NewKeyDeduper(key MappingKeyFunc, size int) *KeyDeduper {
+	if size <= 0 {
+		panic("size must be > 0")
+	}
+	return &KeyDeduper{
+		key:     key,
+		seen:    make(map[string]struct{}, size),
+		ring:    make([]string, 0, size),
+		pending: make(map[string]struct{}),
+	}
+}
+
+// Observe returns true if the Message is a duplicate of a previously observed
+// Message. Otherwise, its key is recorded as pending and false is returned.
+func (d *KeyDeduper) Observe(msg Message) bool {
+	d.buf = d.key(msg, d.buf[:0])
+
+	if _, ok := d.seen[string(d.buf)]; ok {
+		return true
+	} else if _, ok = d.pending[string(d.buf)]; ok {
+		return true
+	}
+	d.pending[string(d.buf)] = struct{}{}
+	return false
+}
+
+// Commit pending keys into the window, evicting the oldest committed keys
+// as required.
+func (d *KeyDeduper) Commit() {
+	for key := range d.pending {
+		if len(d.ring) != cap(d.ring) {
+			d.ring = append(d.ring, key)
+		} else {
+			delete(d.seen, d.ring[d.next])
+			d.ring[d.next] = key
+			d.next = (d.next + 1) % len(d.ring)
+		}
+		d.seen[key] = struct{}{}
+		delete(d.pending, key)
+	}
+}
+
+// Rollback discards keys observed since the last Commit.
+func (d *KeyDeduper) Rollback() {
+	for key := range d.pending {
+		delete(d.pending, key)
+	}
+}
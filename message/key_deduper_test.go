@@ -0,0 +1,97 @@
+package message
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/etcdtest"
+	"go.gazette.dev/core/labels"
+)
+
+type KeyDeduperSuite struct{}
+
+type dedupEvent struct {
+	ID    string
+	Value int
+}
+
+func dedupEventKey(msg Message, b []byte) []byte { return append(b, msg.(dedupEvent).ID...) }
+
+func (s *KeyDeduperSuite) TestDedupOfRepublishedMessages(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(ctx, rjc)
+
+	var spec = brokertest.Journal(pb.JournalSpec{
+		Name:     "a/events",
+		LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	})
+	brokertest.CreateJournals(c, bk, spec)
+
+	var mapping = func(Message) (pb.Journal, Framing, error) { return spec.Name, JSONFraming, nil }
+
+	// Publish events, where a producer re-publishes some events (eg, because
+	// it retried after failing to observe their commit).
+	for _, id := range []int{1, 2, 2, 3, 1, 4, 4, 4, 5} {
+		var _, err = Publish(as, mapping, dedupEvent{ID: fmt.Sprintf("evt-%d", id), Value: id})
+		c.Assert(err, gc.IsNil)
+	}
+	client.WaitForPendingAppends(as.PendingExcept(""))
+
+	var d = NewKeyDeduper(dedupEventKey, 16)
+	var br = bufio.NewReader(client.NewReader(ctx, rjc, pb.ReadRequest{Journal: spec.Name}))
+	var values []int
+
+	for {
+		var frame, err = JSONFraming.Unpack(br)
+		if err != nil {
+			c.Check(err, gc.ErrorMatches, client.ErrOffsetNotYetAvailable.Error())
+			break
+		}
+		var msg dedupEvent
+		c.Check(JSONFraming.Unmarshal(frame, &msg), gc.IsNil)
+
+		if !d.Observe(msg) {
+			values = append(values, msg.Value)
+		}
+		d.Commit()
+	}
+	c.Check(values, gc.DeepEquals, []int{1, 2, 3, 4, 5})
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *KeyDeduperSuite) TestCommitRollbackAndEviction(c *gc.C) {
+	var d = NewKeyDeduper(dedupEventKey, 2)
+	var evt = func(id string) Message { return dedupEvent{ID: id} }
+
+	// Pending keys are duplicates within the transaction.
+	c.Check(d.Observe(evt("a")), gc.Equals, false)
+	c.Check(d.Observe(evt("a")), gc.Equals, true)
+	// Which are discarded on Rollback.
+	d.Rollback()
+	c.Check(d.Observe(evt("a")), gc.Equals, false)
+	d.Commit()
+	c.Check(d.Observe(evt("a")), gc.Equals, true)
+
+	c.Check(d.Observe(evt("b")), gc.Equals, false)
+	d.Commit()
+	c.Check(d.Observe(evt("c")), gc.Equals, false)
+	d.Commit() // Evicts "a".
+
+	c.Check(d.Observe(evt("b")), gc.Equals, true)
+	c.Check(d.Observe(evt("c")), gc.Equals, true)
+	c.Check(d.Observe(evt("a")), gc.Equals, false)
+}
+
+var _ = gc.Suite(&KeyDeduperSuite{})
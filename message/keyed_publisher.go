@@ -0,0 +1,149 @@
+package message
+
+import (
+	"context"
+	"sync"
+
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// KeyedPublisher publishes keyed Messages to journals selected by
+// RendezvousMapping over a PartitionsFunc, while guaranteeing that Messages
+// of a given key are committed in the order in which they were published.
+//
+// So long as a key maps to the same journal, ordering is provided by the
+// AsyncJournalClient, which sequences appends to a journal in the order they
+// were started. When the partitions change and a key is re-mapped to a
+// different journal, KeyedPublisher orders the append to the new journal
+// after the commit of the key's last append to its prior journal.
+// Per-key ordering is thus preserved across changes of the partition set,
+// without requiring that publishers quiesce while partitions are updated.
+//
+// KeyedPublisher is safe for concurrent use. Publish calls are serialized,
+// and the relative order of concurrent Publish calls is undefined.
+type KeyedPublisher struct {
+	ajc     client.AsyncJournalClient
+	key     MappingKeyFunc
+	mapping MappingFunc
+
+	// Last AsyncAppend of each key. Entries are pruned once committed.
+	last      map[string]*client.AsyncAppend
+	lastPrune int
+	// Last AsyncAppend started by the KeyedPublisher of each journal.
+	pending map[pb.Journal]*client.AsyncAppend
+	mu      sync.Mutex
+}
+
+// NewKeyedPublisher returns a KeyedPublisher which appends to journals of the
+// PartitionsFunc via the AsyncJournalClient, mapping and ordering on the key
+// extracted by MappingKeyFunc.
+func NewKeyedPublisher(ajc client.AsyncJournalClient, key MappingKeyFunc, partitions PartitionsFunc) *KeyedPublisher {
+	return &KeyedPublisher{
+		ajc:     ajc,
+		key:     key,
+		mapping: RendezvousMapping(key, partitions),
+		last:    make(map[string]*client.AsyncAppend),
+		pending: make(map[pb.Journal]*client.AsyncAppend),
+	}
+}
+
+// Publish maps the Message to its target journal and begins an Append of the
+// Message's marshaled content under the mapped journal framing. If the prior
+// Message of the same key was mapped to a different journal, and has not yet
+// committed, the returned AsyncAppend depends upon its commit. If Message
+// implements Validate, the message is first validated and any error returned.
+func (p *KeyedPublisher) Publish(msg Message) (*client.AsyncAppend, error) {
+	if v, ok := msg.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	var journal, framing, err = p.mapping(msg)
+	if err != nil {
+		return nil, err
+	}
+	var key = string(p.key(msg, make([]byte, 0, 32)))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var aa *client.AsyncAppend
+	if prev, ok := p.last[key]; ok && prev.Request().Journal != journal && !isDone(prev) {
+		aa = p.ajc.StartAppend(journal, prev)
+	} else {
+		aa = p.ajc.StartAppend(journal)
+	}
+	aa.Require(framing.Marshal(msg, aa.Writer()))
+
+	if err = aa.Release(); err != nil {
+		return nil, err
+	}
+	p.last[key] = aa
+	p.pending[journal] = aa
+	p.prune()
+
+	return aa, nil
+}
+
+// Flush waits for all Messages published by this KeyedPublisher to commit,
+// or for |ctx| to be done, and returns the first encountered error. Unlike
+// awaiting the PendingExcept("") appends of the AsyncJournalClient, only
+// appends started by this KeyedPublisher are awaited, and not unrelated
+// appends of other writers sharing the AsyncJournalClient. As appends of a
+// journal commit in the order they were started, it's sufficient for Flush
+// to await the last append of each journal to which it has published.
+//
+// Each Publish is a committed append of its Message, and once Flush returns
+// nil all Messages published prior to the call are durable and visible to
+// readers. Messages published concurrently with Flush may or may not be
+// awaited.
+func (p *KeyedPublisher) Flush(ctx context.Context) error {
+	p.mu.Lock()
+	var pending = make([]*client.AsyncAppend, 0, len(p.pending))
+	for journal, aa := range p.pending {
+		if isDone(aa) && aa.Err() == nil {
+			delete(p.pending, journal)
+		} else {
+			pending = append(pending, aa)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, aa := range pending {
+		select {
+		case <-aa.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for _, aa := range pending {
+		if err := aa.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune committed AsyncAppends from |last|, amortizing the cost of pruning
+// by doing so only after the number of tracked keys doubles.
+func (p *KeyedPublisher) prune() {
+	if len(p.last) < 2*p.lastPrune || len(p.last) < 1024 {
+		return
+	}
+	for key, aa := range p.last {
+		if isDone(aa) {
+			delete(p.last, key)
+		}
+	}
+	p.lastPrune = len(p.last)
+}
+
+func isDone(aa *client.AsyncAppend) bool {
+	select {
+	case <-aa.Done():
+		return true
+	default:
+		return false
+	}
+}
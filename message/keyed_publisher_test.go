@@ -0,0 +1,177 @@
+package message
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"time"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/etcdtest"
+	"go.gazette.dev/core/labels"
+)
+
+type KeyedPublisherSuite struct{}
+
+func (s *KeyedPublisherSuite) TestPerKeyOrderingAcrossRemapping(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(ctx, rjc)
+
+	var all, some = new(pb.ListResponse), new(pb.ListResponse)
+	for i := 0; i != 4; i++ {
+		var spec = brokertest.Journal(pb.JournalSpec{
+			Name:     pb.Journal(fmt.Sprintf("a/topic/part-%03d", i)),
+			LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+		})
+		brokertest.CreateJournals(c, bk, spec)
+
+		all.Journals = append(all.Journals, pb.ListResponse_Journal{Spec: *spec})
+		if i < 2 {
+			some.Journals = append(some.Journals, pb.ListResponse_Journal{Spec: *spec})
+		}
+	}
+
+	type keyed struct {
+		Key string
+		Seq int
+	}
+	var parts = some
+	var pub = NewKeyedPublisher(as,
+		func(msg Message, b []byte) []byte { return append(b, msg.(keyed).Key...) },
+		func() *pb.ListResponse { return parts },
+	)
+
+	// Publish a sequence of messages for each key. Midway through, partitions
+	// are expanded and a portion of keys are re-mapped to new journals.
+	var mapped = make(map[string]map[pb.Journal]bool)
+	var last *client.AsyncAppend
+
+	for seq := 0; seq != 10; seq++ {
+		if seq == 5 {
+			parts = all
+		}
+		for k := 0; k != 20; k++ {
+			var key = fmt.Sprintf("key-%02d", k)
+
+			var aa, err = pub.Publish(keyed{Key: key, Seq: seq})
+			c.Assert(err, gc.IsNil)
+
+			if mapped[key] == nil {
+				mapped[key] = make(map[pb.Journal]bool)
+			}
+			mapped[key][aa.Request().Journal] = true
+			last = aa
+		}
+	}
+	<-last.Done()
+	client.WaitForPendingAppends(as.PendingExcept(""))
+
+	// Expect some keys were re-mapped.
+	var remapped int
+	for _, journals := range mapped {
+		if len(journals) > 1 {
+			remapped++
+		}
+	}
+	c.Check(remapped > 0, gc.Equals, true)
+
+	// Expect each journal holds messages of its keys in publication order.
+	var count int
+	for _, j := range all.Journals {
+		var r = client.NewReader(ctx, rjc, pb.ReadRequest{Journal: j.Spec.Name})
+		var br = bufio.NewReader(r)
+		var seqs = make(map[string]int)
+
+		for {
+			var frame, err = JSONFraming.Unpack(br)
+			if err != nil {
+				c.Check(err, gc.ErrorMatches, client.ErrOffsetNotYetAvailable.Error())
+				break
+			}
+			var msg keyed
+			c.Check(JSONFraming.Unmarshal(frame, &msg), gc.IsNil)
+			c.Check(mapped[msg.Key][j.Spec.Name], gc.Equals, true)
+
+			if prev, ok := seqs[msg.Key]; ok {
+				c.Check(msg.Seq > prev, gc.Equals, true)
+			}
+			seqs[msg.Key] = msg.Seq
+			count++
+		}
+	}
+	c.Check(count, gc.Equals, 200)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *KeyedPublisherSuite) TestFlush(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx, cancel = context.WithCancel(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(ctx, rjc)
+
+	var spec = brokertest.Journal(pb.JournalSpec{
+		Name:     "a/topic/part-000",
+		LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	})
+	brokertest.CreateJournals(c, bk, spec)
+
+	// |missing| is listed as a partition, but doesn't exist.
+	var missing = *spec
+	missing.Name = "a/topic/missing"
+
+	var keyFn = func(msg Message, b []byte) []byte { return append(b, msg.(string)...) }
+	var partsFn = func(specs ...pb.JournalSpec) PartitionsFunc {
+		var resp = new(pb.ListResponse)
+		for _, s := range specs {
+			resp.Journals = append(resp.Journals, pb.ListResponse_Journal{Spec: s})
+		}
+		return func() *pb.ListResponse { return resp }
+	}
+	var pub = NewKeyedPublisher(as, keyFn, partsFn(*spec))
+	var stuck = NewKeyedPublisher(as, keyFn, partsFn(missing))
+
+	// An append of |stuck| never commits, as its journal doesn't exist.
+	var _, err = stuck.Publish("stuck")
+	c.Assert(err, gc.IsNil)
+
+	var published []*client.AsyncAppend
+	for i := 0; i != 10; i++ {
+		var aa, err = pub.Publish(fmt.Sprintf("key-%d", i))
+		c.Assert(err, gc.IsNil)
+		published = append(published, aa)
+	}
+
+	// Expect Flush awaits appends of |pub|, but not the unrelated append of |stuck|.
+	c.Check(pub.Flush(context.Background()), gc.IsNil)
+	for _, aa := range published {
+		c.Check(isDone(aa), gc.Equals, true)
+	}
+	c.Check(pub.Flush(context.Background()), gc.IsNil) // Nothing pending.
+
+	// Flush of |stuck| returns an error of its Context.
+	var flushCtx, flushCancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	c.Check(stuck.Flush(flushCtx), gc.Equals, context.DeadlineExceeded)
+	flushCancel()
+
+	// Once the AppendService is cancelled, Flush returns the append's error.
+	cancel()
+	c.Check(stuck.Flush(context.Background()), gc.Equals, context.Canceled)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+var _ = gc.Suite(&KeyedPublisherSuite{})
@@ -0,0 +1,69 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+
+	"github.com/vmihailenco/msgpack"
+	"go.gazette.dev/core/labels"
+)
+
+// MsgPackFraming is a Framing implementation which encodes messages as
+// MessagePack. Messages must be encode-able by the msgpack package. As
+// MessagePack is a binary encoding, it cannot be line-delimited and frames
+// instead use the fixed-length header of FixedFraming: a 4-byte magic word
+// for de-synchronization detection, followed by a little-endian uint32
+// length, followed by the MessagePack payload.
+var MsgPackFraming = new(msgPackFraming)
+
+type msgPackFraming struct{}
+
+// ContentType returns labels.ContentType_MessagePack.
+func (*msgPackFraming) ContentType() string { return labels.ContentType_MessagePack }
+
+// Marshal implements Framing.
+func (*msgPackFraming) Marshal(msg Message, bw *bufio.Writer) error {
+	var buf = bytes.NewBuffer(bufferPool.Get().([]byte))
+	defer func() { bufferPool.Put(buf.Bytes()[:0]) }()
+
+	// Reserve the frame header, which is filled once the payload length is known.
+	buf.Write(make([]byte, FixedFrameHeaderLength))
+
+	if err := msgpack.NewEncoder(buf).Encode(msg); err != nil {
+		return err
+	}
+	var b = buf.Bytes()
+
+	copy(b[0:4], magicWord[:])
+	binary.LittleEndian.PutUint32(b[4:8], uint32(len(b)-FixedFrameHeaderLength))
+
+	_, _ = bw.Write(b)
+	return nil
+}
+
+// Unpack implements Framing.
+func (*msgPackFraming) Unpack(r *bufio.Reader) ([]byte, error) {
+	return UnpackFixed(r)
+}
+
+// UnpackLimit implements LimitedUnpacker.
+func (*msgPackFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return UnpackFixedLimit(r, limit)
+}
+
+// Unmarshal verifies the frame header and unpacks Message content. If the frame
+// header indicates a desync occurred (incorrect magic word), ErrDesyncDetected
+// is returned.
+//
+// It implements Framing.
+func (*msgPackFraming) Unmarshal(b []byte, msg Message) error {
+	if len(b) < FixedFrameHeaderLength || !matchesFixedHeader(b) {
+		return ErrDesyncDetected
+	} else if err := msgpack.Unmarshal(b[FixedFrameHeaderLength:], msg); err != nil {
+		return err
+	} else if f, ok := msg.(Fixupable); ok {
+		return f.Fixup()
+	}
+	return nil
+}
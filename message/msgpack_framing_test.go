@@ -0,0 +1,96 @@
+package message
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
+)
+
+type MsgPackFramingSuite struct{}
+
+func (s *MsgPackFramingSuite) TestMarshalAndUnpackRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+
+	c.Check(MsgPackFraming.Marshal(&msgPackFixture{A: 42, B: "the answer"}, bw), gc.IsNil)
+	c.Check(MsgPackFraming.Marshal(&msgPackFixture{A: 53, B: "another"}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	// Expect frames use the FixedFraming header.
+	c.Check(buf.Bytes()[:4], gc.DeepEquals, magicWord[:])
+
+	var br = testReader(buf.Bytes())
+
+	for _, expect := range []msgPackFixture{
+		{A: 42, B: "the answer", fixedUp: true},
+		{A: 53, B: "another", fixedUp: true},
+	} {
+		var frame, err = MsgPackFraming.Unpack(br)
+		c.Check(err, gc.IsNil)
+
+		var msg msgPackFixture
+		c.Check(MsgPackFraming.Unmarshal(frame, &msg), gc.IsNil)
+		c.Check(msg, gc.DeepEquals, expect)
+	}
+	var _, err = MsgPackFraming.Unpack(br)
+	c.Check(errors.Cause(err), gc.Equals, io.EOF)
+}
+
+func (s *MsgPackFramingSuite) TestMarshalError(c *gc.C) {
+	var err = MsgPackFraming.Marshal(struct {
+		Unencodable chan struct{}
+	}{}, nil)
+
+	c.Check(err, gc.ErrorMatches, "msgpack: Encode\\(unsupported chan struct {}\\)")
+}
+
+func (s *MsgPackFramingSuite) TestUnmarshalErrorCases(c *gc.C) {
+	var buf bytes.Buffer
+	var bw = bufio.NewWriter(&buf)
+	c.Check(MsgPackFraming.Marshal(&msgPackFixture{A: 42, B: "the answer"}, bw), gc.IsNil)
+	c.Check(bw.Flush(), gc.IsNil)
+
+	var msg msgPackFixture
+
+	// Case: frame is de-synchronized.
+	c.Check(MsgPackFraming.Unmarshal([]byte("garbage!!"), &msg), gc.Equals, ErrDesyncDetected)
+	c.Check(MsgPackFraming.Unmarshal([]byte("g"), &msg), gc.Equals, ErrDesyncDetected)
+
+	// Case: frame is shorter than its header length.
+	c.Check(MsgPackFraming.Unmarshal(buf.Bytes()[:buf.Len()-2], &msg), gc.Equals, ErrDesyncDetected)
+
+	// Case: payload is truncated.
+	var truncated = append([]byte(nil), buf.Bytes()[:buf.Len()-2]...)
+	binary.LittleEndian.PutUint32(truncated[4:8], uint32(len(truncated)-FixedFrameHeaderLength))
+	c.Check(MsgPackFraming.Unmarshal(truncated, &msg), gc.ErrorMatches, "unexpected EOF")
+
+	// Case: Fixup fails.
+	var frame = buf.Bytes()
+	var failing = msgPackFixture{failFixup: true}
+	c.Check(MsgPackFraming.Unmarshal(frame, &failing), gc.ErrorMatches, "fixup failed")
+}
+
+type msgPackFixture struct {
+	A int
+	B string
+
+	fixedUp   bool
+	failFixup bool
+}
+
+func (m *msgPackFixture) Fixup() error {
+	if m.failFixup {
+		return errors.New("fixup failed")
+	}
+	m.fixedUp = true
+	return nil
+}
+
+var (
+	_ Framing = MsgPackFraming
+	_         = gc.Suite(&MsgPackFramingSuite{})
+)
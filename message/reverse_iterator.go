@@ -0,0 +1,161 @@
+package message
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// ReverseIterator reads the Messages of a journal in reverse order, from
+// newest to oldest. It's intended for uses such as displaying the last N
+// messages of a journal, where reading forward through the entire journal
+// would be wasteful.
+//
+// Framings are forward-only, and a ReverseIterator reads each Fragment of
+// the journal in turn, beginning with the newest, and buffers the frames of
+// the Fragment in memory before returning its Messages in reverse. A caller
+// must be prepared for the buffering of a complete Fragment's content.
+//
+// Fragments are supplied by the caller (eg, from client.ListAllFragments)
+// and the ReverseIterator reads only content which they cover. Messages
+// committed after the Fragments were listed aren't returned, and the first
+// Message returned is that ending at the End of the newest Fragment. Where
+// Fragments overlap, overlapped content is read only from the newer Fragment.
+// Where Fragments don't cover a portion of the journal (eg, because it was
+// deleted), the ReverseIterator continues with the next older Fragment.
+//
+// Messages are returned as read, without any de-duplication or ordering
+// other than the order of their journal offsets. In particular, sequencing
+// of Messages for exactly-once processing (which orders Messages forward,
+// as they're read) cannot apply in reverse.
+type ReverseIterator struct {
+	ctx       context.Context
+	rjc       pb.RoutedJournalClient
+	spec      *pb.JournalSpec
+	newMsg    func(*pb.JournalSpec) (Message, error)
+	fragments []pb.Fragment // Fragments yet to be read, ordered on Begin.
+	end       int64         // Offset through which Fragments remain to be read.
+
+	framing  Framing
+	fragment *pb.Fragment    // Fragment of |frames|.
+	frames   []reversedFrame // Buffered frames of |fragment|, consumed from the end.
+}
+
+type reversedFrame struct {
+	frame      []byte
+	nextOffset int64
+}
+
+// NewReverseIterator returns a ReverseIterator of Messages of the journal
+// described by |spec|, which are read from |fragments| of that journal and
+// constructed by |newMsg|. |fragments| are sorted by the ReverseIterator,
+// and may have been listed by any means (eg, client.ListAllFragments).
+func NewReverseIterator(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	spec *pb.JournalSpec,
+	fragments []pb.Fragment,
+	newMsg func(*pb.JournalSpec) (Message, error),
+) *ReverseIterator {
+	var sorted = append([]pb.Fragment(nil), fragments...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Begin != sorted[j].Begin {
+			return sorted[i].Begin < sorted[j].Begin
+		}
+		return sorted[i].End < sorted[j].End
+	})
+
+	var it = &ReverseIterator{
+		ctx:       ctx,
+		rjc:       rjc,
+		spec:      spec,
+		newMsg:    newMsg,
+		fragments: sorted,
+	}
+	if l := len(sorted); l != 0 {
+		it.end = sorted[l-1].End
+	}
+	return it
+}
+
+// Next returns the Envelope of the next older Message of the journal, or
+// io.EOF once the oldest Message has been returned. An error of
+// unmarshalling the Message doesn't invalidate the ReverseIterator, and Next
+// may be called again to continue with the next older Message. Other errors
+// (eg, of reading or unpacking a Fragment) invalidate the ReverseIterator.
+func (it *ReverseIterator) Next() (Envelope, error) {
+	for len(it.frames) == 0 {
+		if len(it.fragments) == 0 {
+			return Envelope{}, io.EOF
+		} else if err := it.readFragment(); err != nil {
+			it.fragments = nil
+			return Envelope{}, err
+		}
+	}
+	var f = it.frames[len(it.frames)-1]
+	it.frames = it.frames[:len(it.frames)-1]
+
+	var msg, err = it.newMsg(it.spec)
+	if err != nil {
+		return Envelope{}, errors.WithMessagef(err, "NewMessage (%s)", it.spec.Name)
+	} else if err = it.framing.Unmarshal(f.frame, msg); err != nil {
+		return Envelope{}, errors.WithMessagef(err, "unmarshal message (%s:%d)", it.spec.Name, f.nextOffset)
+	}
+	return Envelope{
+		Message:     msg,
+		Fragment:    it.fragment,
+		JournalSpec: it.spec,
+		NextOffset:  f.nextOffset,
+	}, nil
+}
+
+// readFragment pops and reads the newest remaining Fragment, buffering its
+// frames in |frames|.
+func (it *ReverseIterator) readFragment() error {
+	var frag = it.fragments[len(it.fragments)-1]
+	it.fragments = it.fragments[:len(it.fragments)-1]
+
+	var begin, end = frag.Begin, frag.End
+	if end > it.end {
+		end = it.end // Trim content already read from a newer Fragment.
+	}
+	if begin >= end {
+		return nil // Fragment is entirely overlapped.
+	}
+	it.end = begin
+
+	var framing, err = FramingOfFragment(it.spec, &frag)
+	if err != nil {
+		return err
+	}
+	it.framing, it.fragment, it.frames = framing, &frag, it.frames[:0]
+
+	var r = client.NewReader(it.ctx, it.rjc, pb.ReadRequest{
+		Journal: it.spec.Name,
+		Offset:  begin,
+	})
+	r.EndOffset = end
+	var br = bufio.NewReader(r)
+
+	for {
+		var frame, err = framing.Unpack(br)
+		if errors.Cause(err) == io.EOF {
+			return nil
+		} else if errors.Cause(err) == client.ErrOffsetJump {
+			// Content of the Fragment was removed. Continue at the jumped-to offset,
+			// which is that of a Fragment and thus begins a message.
+			continue
+		} else if err != nil {
+			return errors.WithMessagef(err, "unpacking frame (%s:%d)", it.spec.Name, r.AdjustedOffset(br))
+		}
+		it.frames = append(it.frames, reversedFrame{
+			frame:      append([]byte(nil), frame...),
+			nextOffset: r.AdjustedOffset(br),
+		})
+	}
+}
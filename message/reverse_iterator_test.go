@@ -0,0 +1,77 @@
+package message
+
+import (
+	"context"
+	"io"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/etcdtest"
+	"go.gazette.dev/core/labels"
+)
+
+type ReverseIteratorSuite struct{}
+
+func (s *ReverseIteratorSuite) TestReverseAcrossOverlappingFragments(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(ctx, rjc)
+
+	var spec = brokertest.Journal(pb.JournalSpec{
+		Name:     "a/journal",
+		LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	})
+	brokertest.CreateJournals(c, bk, spec)
+
+	type msg struct{ N int }
+
+	// Append messages, and a malformed message, each in its own commit.
+	var ends []int64
+	for _, line := range []string{`{"N":0}`, `{"N":1}`, `not JSON`, `{"N":3}`, `{"N":4}`} {
+		var aa = as.StartAppend(spec.Name)
+		_, _ = aa.Writer().WriteString(line + "\n")
+		c.Assert(aa.Release(), gc.IsNil)
+		<-aa.Done()
+		ends = append(ends, aa.Response().Commit.End)
+	}
+
+	// Use fixtures of Fragments which overlap, and which have a gap.
+	var fragments = []pb.Fragment{
+		{Journal: spec.Name, Begin: ends[2], End: ends[4]},
+		{Journal: spec.Name, Begin: 0, End: ends[0]},
+		{Journal: spec.Name, Begin: ends[1], End: ends[3]},
+	}
+	var it = NewReverseIterator(ctx, rjc, spec, fragments,
+		func(*pb.JournalSpec) (Message, error) { return new(msg), nil })
+
+	var expect = func(n int, next int64) {
+		var env, err = it.Next()
+		c.Assert(err, gc.IsNil)
+		c.Check(env.Message, gc.DeepEquals, &msg{N: n})
+		c.Check(env.NextOffset, gc.Equals, next)
+		c.Check(env.JournalSpec, gc.Equals, spec)
+	}
+	expect(4, ends[4])
+	expect(3, ends[3])
+
+	// Expect the malformed message fails to unmarshal, but iteration continues.
+	var _, err = it.Next()
+	c.Check(err, gc.ErrorMatches, `unmarshal message \(a/journal:\d+\): invalid character .*`)
+
+	// Message 1 isn't covered by a Fragment, and is skipped.
+	expect(0, ends[0])
+
+	_, err = it.Next()
+	c.Check(err, gc.Equals, io.EOF)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+var _ = gc.Suite(&ReverseIteratorSuite{})
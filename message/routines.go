@@ -2,12 +2,17 @@ package message
 
 import (
 	"bufio"
+	"encoding/binary"
 	"fmt"
 	"hash/fnv"
 	"io"
+	"math"
 	"math/rand"
+	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/pkg/errors"
 	"go.gazette.dev/core/broker/client"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/labels"
@@ -36,36 +41,146 @@ func Publish(broker client.AsyncJournalClient, mapping MappingFunc, msg Message)
 }
 
 // FramingByContentType returns the Framing having the corresponding |contentType|,
-// or returns an error if none match.
+// or returns an error if none match. A ContentType having a "+codec" suffix
+// which names a CompressionCodec selects a CompressedFraming of that codec,
+// and one having suffix ChecksummedContentTypeSuffix selects a
+// ChecksummedFraming. EncryptedFraming requires a key, and an error is
+// returned for a ContentType having its suffix.
 func FramingByContentType(contentType string) (Framing, error) {
+	if strings.HasSuffix(contentType, EncryptedContentTypeSuffix) {
+		return nil, fmt.Errorf("%s %s requires a client-managed key (see EncryptedFraming)",
+			labels.ContentType, contentType)
+	}
+	if strings.HasSuffix(contentType, ChecksummedContentTypeSuffix) {
+		var inner, err = FramingByContentType(strings.TrimSuffix(contentType, ChecksummedContentTypeSuffix))
+		if err != nil {
+			return nil, err
+		}
+		return ChecksummedFraming(inner), nil
+	}
+	if ind := strings.LastIndexByte(contentType, '+'); ind != -1 {
+		var codec = pb.CompressionCodec(pb.CompressionCodec_value[strings.ToUpper(contentType[ind+1:])])
+
+		if codec != pb.CompressionCodec_INVALID {
+			var inner, err = FramingByContentType(contentType[:ind])
+			if err != nil {
+				return nil, err
+			}
+			return CompressedFraming(inner, codec)
+		}
+	}
+
 	switch contentType {
 	case labels.ContentType_ProtoFixed:
 		return FixedFraming, nil
 	case labels.ContentType_JSONLines:
 		return JSONFraming, nil
+	case labels.ContentType_GzipJSONLines:
+		return GzipJSONFraming, nil
+	case labels.ContentType_MessagePack:
+		return MsgPackFraming, nil
+	case labels.ContentType_CSV:
+		return new(CSVFraming), nil
 	default:
 		return nil, fmt.Errorf(`unrecognized %s (%s)`, labels.ContentType, contentType)
 	}
 }
 
+// FramingOfFragment returns the Framing of content within Fragment |frag| of
+// the journal described by |spec|. If the Fragment has a ContentType (because
+// it was written by Appends which override the ContentType of the journal),
+// the Framing of that ContentType is returned. Otherwise, the Framing of the
+// journal's ContentType label is returned.
+func FramingOfFragment(spec *pb.JournalSpec, frag *pb.Fragment) (Framing, error) {
+	if frag.ContentType != "" {
+		return FramingByContentType(frag.ContentType)
+	}
+	return FramingByContentType(spec.LabelSet.ValueOf(labels.ContentType))
+}
+
+// FragmentFraming resolves the Framing of each frame of a journal read through
+// a WriteHeadReader and bufio.Reader, as the FramingOfFragment of the Fragment
+// which contains the frame. Appends may override the ContentType of the
+// journal, in which case their content is written to distinct Fragments.
+type FragmentFraming struct {
+	spec        *pb.JournalSpec
+	hr          *client.WriteHeadReader
+	br          *bufio.Reader
+	framing     Framing
+	contentType string // Fragment ContentType of |framing|.
+}
+
+// NewFragmentFraming returns a FragmentFraming of journal |spec|, which is
+// read by |hr| through |br|. It returns an error if the ContentType label of
+// the journal doesn't have a Framing.
+func NewFragmentFraming(spec *pb.JournalSpec, hr *client.WriteHeadReader, br *bufio.Reader) (*FragmentFraming, error) {
+	var framing, err = FramingByContentType(spec.LabelSet.ValueOf(labels.ContentType))
+	if err != nil {
+		return nil, err
+	}
+	return &FragmentFraming{spec: spec, hr: hr, br: br, framing: framing}, nil
+}
+
+// Next returns the Framing of the next frame to be read from the
+// bufio.Reader. If no content is buffered, Next first blocks for further
+// content (and the Fragment of that content), and returns an error of
+// that read.
+func (f *FragmentFraming) Next() (Framing, error) {
+	if f.br.Buffered() == 0 {
+		if _, err := f.br.Peek(1); err != nil {
+			return nil, err
+		}
+	}
+	// Buffered content may remain of a prior Fragment, which was resolved
+	// before reading the current one.
+	var frag = f.hr.Fragment
+
+	if frag != nil && frag.Begin <= f.hr.AdjustedOffset(f.br) && frag.ContentType != f.contentType {
+		var framing, err = FramingOfFragment(f.spec, frag)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "determining framing (%s:%d)", f.spec.Name, frag.Begin)
+		}
+		f.framing, f.contentType = framing, frag.ContentType
+	}
+	return f.framing, nil
+}
+
 // UnpackLine returns bytes through to the first encountered newline "\n". If
 // the complete line is in the Reader buffer, no alloc or copy is needed.
-func UnpackLine(r *bufio.Reader) ([]byte, error) {
+// Lines longer than MaxFrameLength return ErrFrameTooLarge. See UnpackLineLimit.
+func UnpackLine(r *bufio.Reader) ([]byte, error) { return UnpackLineLimit(r, MaxFrameLength) }
+
+// UnpackLineLimit is UnpackLine with a maximum line length of |limit| bytes,
+// inclusive of the trailing newline. If the line is longer, remaining content
+// of the line is read and discarded (without being buffered) and
+// ErrFrameTooLarge is returned, such that a following UnpackLineLimit resumes
+// with the next line.
+func UnpackLineLimit(r *bufio.Reader, limit int) ([]byte, error) {
 	// Fast path: a line is fully contained in the buffer.
 	var line, err = r.ReadSlice('\n')
 
-	if err == bufio.ErrBufferFull {
+	if err == bufio.ErrBufferFull && len(line) <= limit {
 		// Slow path: the line spills across multiple buffer fills.
-		err = nil
-
 		line = append([]byte(nil), line...) // Copy as |line| references an internal buffer.
-		var rest []byte
 
-		if rest, err = r.ReadBytes('\n'); err == nil {
+		for err == bufio.ErrBufferFull && len(line) <= limit {
+			var rest []byte
+			rest, err = r.ReadSlice('\n')
 			line = append(line, rest...)
 		}
 	}
 
+	if len(line) > limit {
+		// Discard through the end of the line, without buffering it.
+		for err == bufio.ErrBufferFull {
+			_, err = r.ReadSlice('\n')
+		}
+		if err == nil || err == io.EOF {
+			err = ErrFrameTooLarge
+		}
+		return nil, err
+	}
+
 	if err == io.EOF && len(line) != 0 {
 		// If we read at least one byte, then an EOF is unexpected (it should
 		// occur only on whole-message boundaries).
@@ -74,6 +189,114 @@ func UnpackLine(r *bufio.Reader) ([]byte, error) {
 	return line, err
 }
 
+// UnpackFixed returns the next fixed frame of content from the Reader,
+// including its frame header of a magic word and little-endian uint32 payload
+// length (as produced by FixedFraming). It's suitable for use by any Framing
+// which uses this header. A frame length greater than MaxFrameLength is
+// presumed to be corrupt. See UnpackFixedLimit.
+func UnpackFixed(r *bufio.Reader) ([]byte, error) { return UnpackFixedLimit(r, MaxFrameLength) }
+
+// UnpackFixedLimit is UnpackFixed with a maximum payload length of |limit|.
+//
+// If the magic word is not detected, or if the frame length is greater than
+// |limit| (both indicating a desync or corruption), UnpackFixedLimit scans
+// forward within buffered content to the next magic word, and returns the
+// interleaved but desynchronized content. Unmarshal of that content should
+// then fail with ErrDesyncDetected, and the following UnpackFixedLimit
+// resumes from the next frame.
+//
+// An EOF is returned only if it occurs at a frame boundary: an EOF partway
+// through a frame is returned as io.ErrUnexpectedEOF. If the complete frame is
+// in the Reader buffer, no alloc or copy is needed.
+func UnpackFixedLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	var b, err = r.Peek(FixedFrameHeaderLength)
+
+	if err != nil {
+		// If buffer just contains a trailing newline, return EOF.
+		// TODO(johnny): Can we remove this?
+		if err == io.EOF && len(b) == 1 && b[0] == 0x0a {
+			return nil, io.EOF
+		}
+		if err == io.EOF && len(b) != 0 {
+			// If we read at least one byte, then an EOF is unexpected (it should
+			// occur only on whole-message boundaries).
+			err = io.ErrUnexpectedEOF
+		} else {
+			err = errors.Wrap(err, "Peek(FixedFrameHeaderLength)")
+		}
+		return nil, err
+	}
+
+	// Next 4 bytes are encoded size. Combine with header for full frame size.
+	var length = binary.LittleEndian.Uint32(b[4:])
+
+	if !matchesMagicWord(b) || int64(length) > int64(limit) {
+		// We are not at the expected frame boundary. Scan forward within the buffered
+		// region to the beginning of the next magic word. Return the intermediate
+		// jumbled frame (this will produce an ErrDesyncDetected on a later Unmarshal).
+		b, _ = r.Peek(r.Buffered())
+
+		var i, j = 1, 1 + len(b) - len(magicWord)
+		for ; i != j; i++ {
+			if matchesMagicWord(b[i:]) {
+				break
+			}
+		}
+		_, _ = r.Discard(i)
+		return b[:i], nil
+	}
+	var size = FixedFrameHeaderLength + int(length)
+
+	// Fast path: check if the full frame is available in buffer. Return the
+	// buffer internal slice without copying. It is invalidated by the next
+	// Unpack (or other Reader operation).
+	if b, err = r.Peek(size); err == nil {
+		_, _ = r.Discard(size)
+		return b, nil
+	}
+
+	// Slow path. Allocate and attempt to Read the full frame.
+	b = make([]byte, size)
+	_, err = io.ReadFull(r, b)
+	return b, errors.Wrap(err, "io.ReadFull")
+}
+
+// WithMaxFrameLength returns a Framing which wraps Framing |f|, and which
+// Unpacks frames having a maximum length of |limit| rather than MaxFrameLength.
+// |f| must implement LimitedUnpacker, as do all Framings of this package.
+func WithMaxFrameLength(f Framing, limit int) Framing {
+	var lu, ok = f.(LimitedUnpacker)
+	if !ok {
+		panic(fmt.Sprintf("Framing %s doesn't implement LimitedUnpacker", f.ContentType()))
+	}
+	return &limitedFraming{Framing: f, lu: lu, limit: limit}
+}
+
+// limitedFraming is a Framing which Unpacks using a specific frame limit.
+type limitedFraming struct {
+	Framing
+	lu    LimitedUnpacker
+	limit int
+}
+
+// Unpack implements Framing.
+func (f *limitedFraming) Unpack(r *bufio.Reader) ([]byte, error) { return f.lu.UnpackLimit(r, f.limit) }
+
+// UnpackLimit implements LimitedUnpacker.
+func (f *limitedFraming) UnpackLimit(r *bufio.Reader, limit int) ([]byte, error) {
+	return f.lu.UnpackLimit(r, limit)
+}
+
+// Unmarshal implements Framing. A frame having more than |limit| bytes of
+// payload can be returned by an UnpackLimit which scanned through it to the
+// next magic word, and ErrDesyncDetected is returned for it.
+func (f *limitedFraming) Unmarshal(b []byte, msg Message) error {
+	if len(b) > FixedFrameHeaderLength+f.limit {
+		return ErrDesyncDetected
+	}
+	return f.Framing.Unmarshal(b, msg)
+}
+
 // RandomMapping returns a MappingFunc which maps a Message to a randomly
 // selected Journal of the PartitionsFunc.
 func RandomMapping(partitions PartitionsFunc) MappingFunc {
@@ -175,3 +398,104 @@ func RendezvousMapping(key MappingKeyFunc, partitions PartitionsFunc) MappingFun
 		return
 	}
 }
+
+// WeightedRendezvousMapping returns a MappingFunc which maps a Message into a
+// stable Journal of the PartitionsFunc, selected via weighted Highest Random
+// Weight (aka "rendezvous") hashing of the MappingKeyFunc. Each journal is
+// weighted by |weight|, and receives a share of keys which is proportional to
+// its weight. Like RendezvousMapping, alterations of the journal set or of a
+// journal's weight re-map only those keys which must move: increasing the
+// weight of a journal draws keys only to that journal, and removing a journal
+// moves only its own keys. Journals of non-positive weight are never selected.
+// See also WeightFromLabel.
+func WeightedRendezvousMapping(key MappingKeyFunc, partitions PartitionsFunc, weight func(*pb.JournalSpec) float64) MappingFunc {
+	// As with RendezvousMapping, derived hashes and weights are cached
+	// so long as the PartitionsFunc result is pointer-equal.
+	var lastLR *pb.ListResponse
+	var lastHashes []uint64
+	var lastWeights []float64
+	var mu sync.Mutex
+
+	var partitionsAndWeights = func() (lr *pb.ListResponse, hashes []uint64, weights []float64) {
+		lr = partitions()
+
+		mu.Lock()
+		if lr != lastLR {
+			lastLR = lr
+			lastHashes = make([]uint64, len(lr.Journals))
+			lastWeights = make([]float64, len(lr.Journals))
+
+			for i := range lr.Journals {
+				var h = fnv.New64a()
+				_, _ = h.Write([]byte(lr.Journals[i].Spec.Name))
+				lastHashes[i] = h.Sum64()
+				lastWeights[i] = weight(&lr.Journals[i].Spec)
+			}
+		}
+		hashes, weights = lastHashes, lastWeights
+		mu.Unlock()
+
+		return
+	}
+
+	return func(msg Message) (journal pb.Journal, framing Framing, err error) {
+		var lr, hashes, weights = partitionsAndWeights()
+
+		var h = fnv.New64a()
+		_, _ = h.Write(key(msg, make([]byte, 0, 32)))
+		var sum = h.Sum64()
+
+		var hrw = math.Inf(-1)
+		var ind = -1
+
+		for i := range lr.Journals {
+			if weights[i] <= 0 {
+				continue
+			}
+			// Map the combined hash to a uniform value in the range (0, 1),
+			// and score the journal as -weight / ln(value). The probability that
+			// a journal has the highest score is proportional to its weight.
+			var u = (float64(mix64(sum^hashes[i])>>11) + 0.5) / (1 << 53)
+
+			if w := -weights[i] / math.Log(u); w > hrw {
+				hrw, ind = w, i
+			}
+		}
+		if ind == -1 {
+			err = ErrEmptyListResponse
+			return
+		}
+		journal = lr.Journals[ind].Spec.Name
+
+		var ct = lr.Journals[ind].Spec.LabelSet.ValueOf(labels.ContentType)
+		framing, err = FramingByContentType(ct)
+		return
+	}
+}
+
+// WeightFromLabel returns a weight function for WeightedRendezvousMapping,
+// which parses the weight of a journal from the floating-point value of its
+// |label|. Journals not having the label, or having a malformed label value,
+// are weighted as one.
+func WeightFromLabel(label string) func(*pb.JournalSpec) float64 {
+	return func(spec *pb.JournalSpec) float64 {
+		if v := spec.LabelSet.ValueOf(label); v == "" {
+			return 1
+		} else if w, err := strconv.ParseFloat(v, 64); err != nil {
+			return 1
+		} else {
+			return w
+		}
+	}
+}
+
+// mix64 is the finalizer of the 64-bit MurmurHash3, which well-distributes
+// bits of its input (where XOR-ed FNV-1a hashes alone would not).
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
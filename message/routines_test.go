@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"strings"
 	"testing"
 
 	gc "github.com/go-check/check"
+	"github.com/pkg/errors"
 	"go.gazette.dev/core/broker/client"
 	pb "go.gazette.dev/core/broker/protocol"
 	"go.gazette.dev/core/brokertest"
@@ -47,15 +49,100 @@ func (s *RoutinesSuite) TestPublishSuccess(c *gc.C) {
 	c.Check(bk.Tasks.Wait(), gc.IsNil)
 }
 
+func (s *RoutinesSuite) TestMixedContentTypes(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var spec = brokertest.Journal(pb.JournalSpec{
+		Name:     "a/journal",
+		LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	})
+	brokertest.CreateJournals(c, bk, spec)
+
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+
+	// Append data messages under the journal framing, interleaved with a
+	// control message which overrides the journal ContentType.
+	type data struct{ Data string }
+	type control struct{ Control int }
+
+	var appendMsg = func(contentType string, framing Framing, msg Message) {
+		var a = client.NewAppender(ctx, rjc, pb.AppendRequest{Journal: "a/journal", ContentType: contentType})
+		var bw = bufio.NewWriter(a)
+		c.Assert(framing.Marshal(msg, bw), gc.IsNil)
+		c.Assert(bw.Flush(), gc.IsNil)
+		c.Assert(a.Close(), gc.IsNil)
+		c.Check(a.Response.Commit.ContentType, gc.Equals, contentType)
+	}
+	appendMsg("", JSONFraming, data{"one"})
+	appendMsg("", JSONFraming, data{"two"})
+	appendMsg(labels.ContentType_MessagePack, MsgPackFraming, control{42})
+	appendMsg("", JSONFraming, data{"three"})
+
+	// Read each Fragment, dispatching its Framing by its ContentType.
+	var out []interface{}
+	for offset := int64(0); true; {
+		var r = client.NewReader(ctx, rjc, pb.ReadRequest{Journal: "a/journal", Offset: offset})
+		if _, err := r.Read(nil); err == client.ErrOffsetNotYetAvailable {
+			break
+		} else {
+			c.Assert(err, gc.IsNil)
+		}
+		var frag = *r.Response.Fragment
+		r.EndOffset = frag.End
+
+		var framing, err = FramingOfFragment(spec, &frag)
+		c.Assert(err, gc.IsNil)
+
+		for br := bufio.NewReader(r); true; {
+			var frame, err = framing.Unpack(br)
+			if errors.Cause(err) == io.EOF {
+				break
+			}
+			c.Assert(err, gc.IsNil)
+
+			if framing == JSONFraming {
+				var msg data
+				c.Check(framing.Unmarshal(frame, &msg), gc.IsNil)
+				out = append(out, msg)
+			} else {
+				var msg control
+				c.Check(framing.Unmarshal(frame, &msg), gc.IsNil)
+				out = append(out, msg)
+			}
+		}
+		offset = frag.End
+	}
+	c.Check(out, gc.DeepEquals, []interface{}{
+		data{"one"}, data{"two"}, control{42}, data{"three"}})
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
 func (s *RoutinesSuite) TestFramingDetermination(c *gc.C) {
 	var f, err = FramingByContentType(labels.ContentType_JSONLines)
 	c.Check(err, gc.IsNil)
 	c.Check(f, gc.Equals, JSONFraming)
 
+	f, err = FramingByContentType(labels.ContentType_GzipJSONLines)
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.Equals, GzipJSONFraming)
+
 	f, err = FramingByContentType(labels.ContentType_ProtoFixed)
 	c.Check(err, gc.IsNil)
 	c.Check(f, gc.Equals, FixedFraming)
 
+	f, err = FramingByContentType(labels.ContentType_MessagePack)
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.Equals, MsgPackFraming)
+
+	f, err = FramingByContentType(labels.ContentType_CSV)
+	c.Check(err, gc.IsNil)
+	c.Check(f, gc.DeepEquals, new(CSVFraming))
+
 	_, err = FramingByContentType(labels.ContentType_RecoveryLog) // Not a valid message framing.
 	c.Check(err, gc.ErrorMatches, `unrecognized `+labels.ContentType+` \(`+labels.ContentType_RecoveryLog+`\)`)
 }
@@ -89,6 +176,159 @@ func (s *RoutinesSuite) TestLineUnpackingCases(c *gc.C) {
 	c.Check(err, gc.Equals, io.EOF)
 }
 
+func (s *RoutinesSuite) TestLineUnpackingLimits(c *gc.C) {
+	const bsize = 16
+	var long = strings.Repeat("x", bsize*5/2)
+	var buf = bytes.NewBufferString("short\n" + long + "\n" + "more\n" + long)
+	var br = bufio.NewReaderSize(buf, bsize)
+
+	// Case 1: a line within the limit is returned.
+	var line, err = UnpackLineLimit(br, bsize*2)
+	c.Check(err, gc.IsNil)
+	c.Check(string(line), gc.Equals, "short\n")
+
+	// Case 2: a line spanning buffer fills which exceeds the limit is
+	// discarded, and ErrFrameTooLarge returned.
+	line, err = UnpackLineLimit(br, bsize*2)
+	c.Check(err, gc.Equals, ErrFrameTooLarge)
+	c.Check(line, gc.IsNil)
+
+	// Case 3: unpacking resumes with the next line.
+	line, err = UnpackLineLimit(br, 5)
+	c.Check(err, gc.IsNil)
+	c.Check(string(line), gc.Equals, "more\n")
+
+	// Case 4: a line within the buffer can also exceed the limit.
+	br = bufio.NewReaderSize(bytes.NewBufferString("a longer line\nok\n"), bsize)
+	_, err = UnpackLineLimit(br, 4)
+	c.Check(err, gc.Equals, ErrFrameTooLarge)
+	line, err = UnpackLineLimit(br, 4)
+	c.Check(err, gc.IsNil)
+	c.Check(string(line), gc.Equals, "ok\n")
+}
+
+func (s *RoutinesSuite) TestFixedUnpackingCases(c *gc.C) {
+	var frame = []byte{0x66, 0x33, 0x93, 0x36, 0x03, 0x0, 0x0, 0x0, 'f', 'o', 'o'}
+	// A corrupted header having a magic word, but an implausible length.
+	var corrupt = []byte{0x66, 0x33, 0x93, 0x36, 0xff, 0xff, 0xff, 0xff, 'x'}
+
+	var fixture = append(append(append([]byte(nil), frame...), corrupt...), frame...)
+	var br = bufio.NewReader(bytes.NewReader(fixture[:len(fixture)-1]))
+
+	// Case 1: a complete frame is returned.
+	var b, err = UnpackFixed(br)
+	c.Check(err, gc.IsNil)
+	c.Check(b, gc.DeepEquals, frame)
+
+	// Case 2: content is returned through to the next magic word.
+	b, err = UnpackFixed(br)
+	c.Check(err, gc.IsNil)
+	c.Check(b, gc.DeepEquals, corrupt)
+
+	// Which fails to Unmarshal with ErrDesyncDetected.
+	c.Check(FixedFraming.Unmarshal(b, new(pb.Fragment)), gc.Equals, ErrDesyncDetected)
+
+	// Case 4: EOF partway through a frame is mapped to ErrUnexpectedEOF.
+	_, err = UnpackFixed(br)
+	c.Check(errors.Cause(err), gc.Equals, io.ErrUnexpectedEOF)
+
+	// Case 5: EOF at a frame boundary is passed through.
+	_, err = UnpackFixed(bufio.NewReader(bytes.NewReader(frame[:0])))
+	c.Check(errors.Cause(err), gc.Equals, io.EOF)
+
+	// Case 6: a well-formed frame which is larger than the limit is
+	// treated as corrupt.
+	br = bufio.NewReader(bytes.NewReader(append(append([]byte(nil), frame...), frame...)))
+	b, err = UnpackFixedLimit(br, 2)
+	c.Check(err, gc.IsNil)
+	c.Check(b, gc.DeepEquals, frame)
+	b, err = UnpackFixedLimit(br, 3)
+	c.Check(err, gc.IsNil)
+	c.Check(b, gc.DeepEquals, frame)
+}
+
+func (s *RoutinesSuite) TestFramingWithMaxFrameLength(c *gc.C) {
+	// A line-oriented Framing discards lines exceeding its limit.
+	var f = WithMaxFrameLength(JSONFraming, 8)
+	c.Check(f.ContentType(), gc.Equals, labels.ContentType_JSONLines)
+
+	var br = bufio.NewReader(strings.NewReader(`{"a":"a long value"}` + "\n" + `{"b":1}` + "\n"))
+	var _, err = f.Unpack(br)
+	c.Check(err, gc.Equals, ErrFrameTooLarge)
+	line, err := f.Unpack(br)
+	c.Check(err, gc.IsNil)
+	c.Check(string(line), gc.Equals, `{"b":1}`+"\n")
+
+	// A fixed Framing treats a frame exceeding its limit as corrupt.
+	var frame = []byte{0x66, 0x33, 0x93, 0x36, 0x03, 0x0, 0x0, 0x0, 'f', 'o', 'o'}
+	f = WithMaxFrameLength(FixedFraming, 2)
+
+	b, err := f.Unpack(bufio.NewReader(bytes.NewReader(frame)))
+	c.Check(err, gc.IsNil)
+	c.Check(f.Unmarshal(b, new(pb.Fragment)), gc.Equals, ErrDesyncDetected)
+
+	// So do Framings which wrap a fixed frame header, and a following frame
+	// within the limit is read.
+	compressed, err := CompressedFraming(JSONFraming, pb.CompressionCodec_SNAPPY)
+	c.Assert(err, gc.IsNil)
+
+	// Build a large and poorly-compressible message.
+	var blob string
+	for i := 0; i != 64; i++ {
+		blob += fmt.Sprintf("%d,", i*i*7919%10007)
+	}
+
+	for _, inner := range []Framing{
+		ChecksummedFraming(JSONFraming),
+		compressed,
+		EncryptedFraming(JSONFraming, newTestAEAD(c, "0123456789abcdef")),
+		MsgPackFraming,
+	} {
+		var buf bytes.Buffer
+		var bw = bufio.NewWriter(&buf)
+		c.Check(inner.Marshal(compressedFixture{Seq: 1, Blob: blob}, bw), gc.IsNil)
+		c.Check(inner.Marshal(compressedFixture{Seq: 2, Blob: "ok"}, bw), gc.IsNil)
+		c.Check(bw.Flush(), gc.IsNil)
+
+		f = WithMaxFrameLength(inner, 100)
+		br = bufio.NewReader(bytes.NewReader(buf.Bytes()))
+
+		b, err = f.Unpack(br)
+		c.Check(err, gc.IsNil)
+		c.Check(f.Unmarshal(b, new(compressedFixture)), gc.Equals, ErrDesyncDetected)
+
+		// Content scanned from an oversized frame is a desync of the inner Framing.
+		var size = len(b)
+		b, err = UnpackFixedLimit(bufio.NewReader(bytes.NewReader(buf.Bytes()[:size])), 100)
+		c.Check(err, gc.IsNil)
+		c.Check(len(b) < size, gc.Equals, true)
+		c.Check(inner.Unmarshal(b, new(compressedFixture)), gc.Equals, ErrDesyncDetected)
+
+		var msg compressedFixture
+		b, err = f.Unpack(br)
+		c.Check(err, gc.IsNil)
+		c.Check(f.Unmarshal(b, &msg), gc.IsNil)
+		c.Check(msg, gc.DeepEquals, compressedFixture{Seq: 2, Blob: "ok"})
+	}
+
+	// A CSV record limit spans the quoted newlines of the record.
+	f = WithMaxFrameLength(new(CSVFraming), 12)
+	br = bufio.NewReader(strings.NewReader("\"foo\nbar\"\n\"foo\nbar baz\"\nok\n"))
+
+	line, err = f.Unpack(br)
+	c.Check(err, gc.IsNil)
+	c.Check(string(line), gc.Equals, "\"foo\nbar\"\n")
+	_, err = f.Unpack(br)
+	c.Check(err, gc.Equals, ErrFrameTooLarge)
+	line, err = f.Unpack(br)
+	c.Check(err, gc.IsNil)
+	c.Check(string(line), gc.Equals, "ok\n")
+
+	// Framings which don't implement LimitedUnpacker are rejected.
+	c.Check(func() { WithMaxFrameLength(struct{ Framing }{JSONFraming}, 8) }, gc.PanicMatches,
+		`Framing application/x-ndjson doesn't implement LimitedUnpacker`)
+}
+
 func buildPartitionsFuncFixture(count int) PartitionsFunc {
 	var parts = &pb.ListResponse{
 		Journals: make([]pb.ListResponse_Journal, count),
@@ -160,6 +400,70 @@ func (s *RoutinesSuite) TestRendezvousMappingRegressionFixtures(c *gc.C) {
 	verify(RendezvousMapping(mappingKey, buildPartitionsFuncFixture(500)))
 }
 
+func (s *RoutinesSuite) TestWeightedRendezvousMapping(c *gc.C) {
+	var mappingKey = func(msg Message, b []byte) []byte { return append(b, msg.(string)...) }
+	const weightLabel = "example/weight"
+
+	var buildParts = func(weights ...string) PartitionsFunc {
+		var lr = buildPartitionsFuncFixture(len(weights))()
+		for i, w := range weights {
+			if w != "" {
+				lr.Journals[i].Spec.LabelSet.AddValue(weightLabel, w)
+			}
+		}
+		return func() *pb.ListResponse { return lr }
+	}
+	var assign = func(mapping MappingFunc) (out map[string]pb.Journal, counts map[pb.Journal]int) {
+		out, counts = make(map[string]pb.Journal), make(map[pb.Journal]int)
+		for i := 0; i != 10000; i++ {
+			var key = fmt.Sprintf("key-%d", i)
+			var j, f, err = mapping(key)
+			c.Assert(err, gc.IsNil)
+			c.Check(f, gc.Equals, JSONFraming)
+			out[key] = j
+			counts[j]++
+		}
+		return
+	}
+	var checkShare = func(n int, expect float64) {
+		c.Check(math.Abs(float64(n)/10000-expect) < 0.02, gc.Equals, true,
+			gc.Commentf("count %d, expected share %f", n, expect))
+	}
+
+	// Equal weights (where the label is absent, or is malformed).
+	var before, counts = assign(WeightedRendezvousMapping(mappingKey,
+		buildParts("", "1", "bad!", "1"), WeightFromLabel(weightLabel)))
+	for i := 0; i != 4; i++ {
+		checkShare(counts[pb.Journal(fmt.Sprintf("a/topic/part-%03d", i))], 0.25)
+	}
+
+	// Increase the weight of part-000. Expect it draws a proportional share of
+	// keys, and that keys move only to part-000.
+	after, counts := assign(WeightedRendezvousMapping(mappingKey,
+		buildParts("4", "1", "1", "1"), WeightFromLabel(weightLabel)))
+	checkShare(counts["a/topic/part-000"], 4.0/7)
+	checkShare(counts["a/topic/part-001"], 1.0/7)
+
+	var moved int
+	for key, j := range after {
+		if j != before[key] {
+			c.Check(j, gc.Equals, pb.Journal("a/topic/part-000"))
+			moved++
+		}
+	}
+	checkShare(moved, 4.0/7-0.25)
+
+	// Journals of zero weight are never selected.
+	_, counts = assign(WeightedRendezvousMapping(mappingKey,
+		buildParts("0", "1", "1", "1"), WeightFromLabel(weightLabel)))
+	c.Check(counts["a/topic/part-000"], gc.Equals, 0)
+
+	// If no journal has positive weight, ErrEmptyListResponse is returned.
+	var _, _, err = WeightedRendezvousMapping(mappingKey,
+		buildParts("0", "-1"), WeightFromLabel(weightLabel))("key")
+	c.Check(err, gc.Equals, ErrEmptyListResponse)
+}
+
 var _ = gc.Suite(&RoutinesSuite{})
 
 func Test(t *testing.T) { gc.TestingT(t) }
@@ -0,0 +1,318 @@
+package message
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+)
+
+// Tail reads Messages of the journal of |req|, beginning at its offset, and
+// streams their Envelopes over the returned channel. Messages are constructed
+// by |newMsg| and unmarshalled using the Framing of the journal's ContentType
+// label. |req| is read with Block set, and Tail continues to wait for and
+// stream further Messages as they're appended to the journal.
+//
+// Tail runs until an error is encountered, including an error of |ctx| (eg,
+// context.Canceled) or of unmarshalling a Message. The error is delivered on
+// the returned error channel, after which both channels are closed and Tail's
+// goroutine exits. A caller which stops receiving Envelopes must cancel |ctx|
+// to release the goroutine.
+//
+// Messages are returned as read, and in particular aren't de-duplicated or
+// sequenced. Tail is a convenience for uses such as CLI tools and tests which
+// would otherwise compose a RetryReader, a Framing, and decoding loop.
+func Tail(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	req pb.ReadRequest,
+	newMsg func(*pb.JournalSpec) (Message, error),
+) (<-chan Envelope, <-chan error) {
+	var envCh = make(chan Envelope)
+	var errCh = make(chan error, 1)
+
+	go func() {
+		errCh <- tail(ctx, rjc, req, newMsg, envCh)
+		close(envCh)
+		close(errCh)
+	}()
+	return envCh, errCh
+}
+
+func tail(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	req pb.ReadRequest,
+	newMsg func(*pb.JournalSpec) (Message, error),
+	envCh chan<- Envelope,
+) error {
+	var lr, err = client.ListAllJournals(ctx, rjc, pb.ListRequest{
+		Selector: pb.LabelSelector{
+			Include: pb.LabelSet{Labels: []pb.Label{{Name: "name", Value: req.Journal.String()}}},
+		},
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "listing JournalSpec (%s)", req.Journal)
+	} else if len(lr.Journals) == 0 {
+		return errors.Errorf("named journal does not exist (%s)", req.Journal)
+	}
+	_, err = tailJournal(ctx, rjc, &lr.Journals[0].Spec, req, newMsg, envCh)
+	return err
+}
+
+// tailJournal streams Envelopes of the journal |spec|, read from |req|. It
+// returns the NextOffset of the last streamed Envelope (or, if none were
+// streamed, the offset of |req|) at which a later read may resume.
+func tailJournal(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	spec *pb.JournalSpec,
+	req pb.ReadRequest,
+	newMsg func(*pb.JournalSpec) (Message, error),
+	envCh chan<- Envelope,
+) (resume int64, _ error) {
+	resume = req.Offset
+
+	req.Block = true
+	var rr = client.NewRetryReader(ctx, rjc, req)
+	var hr = &client.WriteHeadReader{RetryReader: rr}
+	var br = bufio.NewReader(hr)
+
+	var ff, err = NewFragmentFraming(spec, hr, br)
+	if err != nil {
+		return resume, errors.WithMessagef(err, "determining framing (%s)", spec.Name)
+	}
+
+	for offset := rr.Offset(); ; offset = rr.AdjustedOffset(br) {
+		var framing Framing
+		var frame []byte
+		var msg Message
+
+		if framing, err = ff.Next(); err == nil {
+			frame, err = framing.Unpack(br)
+		}
+		if errors.Cause(err) == io.ErrNoProgress {
+			// Swallow ErrNoProgress from our bufio.Reader. client.RetryReader
+			// surfaces empty reads, and a journal with no active appends can
+			// cause our bufio.Reader to give up, though no error has occurred.
+			continue
+		} else if errors.Cause(err) == client.ErrOffsetJump {
+			// Content was removed. Continue at the jumped-to offset, which is
+			// that of a Fragment and thus begins a message.
+			continue
+		} else if err != nil && ctx.Err() != nil {
+			return resume, ctx.Err() // Tail was cancelled.
+		} else if err != nil {
+			return resume, errors.WithMessagef(err, "unpacking frame (%s:%d)", spec.Name, offset)
+		}
+
+		if msg, err = newMsg(spec); err != nil {
+			return resume, errors.WithMessagef(err, "NewMessage (%s)", spec.Name)
+		} else if err = framing.Unmarshal(frame, msg); err != nil {
+			return resume, errors.WithMessagef(err, "unmarshal message (%s:%d)", spec.Name, offset)
+		}
+		var next = rr.AdjustedOffset(br)
+
+		select {
+		case envCh <- Envelope{
+			Message:     msg,
+			Fragment:    rr.Reader.Response.Fragment,
+			JournalSpec: spec,
+			NextOffset:  next,
+			WriteHead:   hr.WriteHead,
+		}:
+			resume = next
+		case <-ctx.Done():
+			return resume, ctx.Err()
+		}
+	}
+}
+
+// TailSelector reads Messages of all journals matched by |sel|, such as the
+// partitions of a topic, and streams their merged Envelopes over the returned
+// channel. Matched journals are discovered by a PolledList which refreshes
+// with interval |dur|, and each is read from its beginning by a Tail of the
+// journal. As topic membership changes, journals which newly match |sel| are
+// added to the TailSelector and journals which no longer match are removed.
+// A removed journal which later matches again resumes from the offset through
+// which it was streamed, once its prior Tail has exited.
+//
+// Envelopes of a journal are streamed in the order they were read, but no
+// ordering is imposed across journals. TailSelector runs until an error is
+// encountered, including an error of |ctx| or of reading any current member
+// of the topic. The error is delivered on the returned error channel, after
+// which both channels are closed.
+func TailSelector(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	sel pb.LabelSelector,
+	dur time.Duration,
+	newMsg func(*pb.JournalSpec) (Message, error),
+) (<-chan Envelope, <-chan error) {
+	var envCh = make(chan Envelope)
+	var errCh = make(chan error, 1)
+
+	go func() {
+		errCh <- tailSelector(ctx, rjc, sel, dur, newMsg, envCh)
+		close(envCh)
+		close(errCh)
+	}()
+	return envCh, errCh
+}
+
+func tailSelector(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	sel pb.LabelSelector,
+	dur time.Duration,
+	newMsg func(*pb.JournalSpec) (Message, error),
+	envCh chan<- Envelope,
+) error {
+	var listCtx, listCancel = context.WithCancel(ctx)
+	defer listCancel()
+
+	var list, err = client.NewPolledList(listCtx, rjc, dur, pb.ListRequest{Selector: sel})
+	if err != nil {
+		return errors.WithMessagef(err, "listing journals (%s)", sel.String())
+	}
+
+	// tailPartition is a running Tail of a journal of the topic.
+	type tailPartition struct {
+		journal pb.Journal
+		cancel  context.CancelFunc
+		removed bool  // Whether the partition was removed from the topic.
+		resume  int64 // Offset at which a later Tail of the journal resumes.
+		err     error
+	}
+	var (
+		partitions = make(map[pb.Journal]*tailPartition) // Running partitions of the topic.
+		exiting    = make(map[pb.Journal]*tailPartition) // Removed partitions yet to exit.
+		offsets    = make(map[pb.Journal]int64)          // Resume offsets of exited partitions.
+		members    map[pb.Journal]pb.JournalSpec         // Current members of the topic.
+		running    int
+		doneCh     = make(chan *tailPartition)
+	)
+	var start = func(spec pb.JournalSpec) *tailPartition {
+		var subCtx, cancel = context.WithCancel(ctx)
+		var p = &tailPartition{journal: spec.Name, cancel: cancel}
+		var req = pb.ReadRequest{Journal: spec.Name, Offset: offsets[spec.Name]}
+
+		go func() {
+			p.resume, p.err = tailJournal(subCtx, rjc, &spec, req, newMsg, envCh)
+			doneCh <- p
+		}()
+		running++
+		return p
+	}
+	// Cancel and wait for remaining partitions upon return.
+	defer func() {
+		for _, p := range partitions {
+			p.cancel()
+		}
+		for ; running != 0; running-- {
+			<-doneCh
+		}
+	}()
+
+	for {
+		members = make(map[pb.Journal]pb.JournalSpec, len(list.List().Journals))
+		for _, j := range list.List().Journals {
+			members[j.Spec.Name] = j.Spec
+		}
+		// Remove partitions which are no longer members of the topic.
+		for name, p := range partitions {
+			if _, ok := members[name]; !ok {
+				p.removed = true
+				p.cancel()
+				exiting[name] = p
+				delete(partitions, name)
+			}
+		}
+		// Start partitions which are new members of the topic. A member
+		// which is still exiting is started once it exits.
+		for name, spec := range members {
+			if _, ok := partitions[name]; ok {
+				continue // Partition is already running.
+			} else if _, ok = exiting[name]; ok {
+				continue
+			}
+			partitions[name] = start(spec)
+		}
+		tailSelectorReconciled(members)
+
+	LOOP:
+		for {
+			select {
+			case <-list.UpdateCh():
+				break LOOP
+			case p := <-doneCh:
+				running--
+
+				if p.removed {
+					// Expected cancellation of a removed partition. If it has
+					// since re-joined the topic, resume it where it left off.
+					delete(exiting, p.journal)
+					offsets[p.journal] = p.resume
+
+					if spec, ok := members[p.journal]; ok {
+						partitions[p.journal] = start(spec)
+					}
+					continue
+				} else if ctx.Err() != nil {
+					return ctx.Err() // TailSelector was cancelled.
+				}
+				return p.err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// tailSelectorReconciled is called by TailSelector with the current members
+// of the topic, after its running partitions are reconciled with them.
+// It's a hook for tests.
+var tailSelectorReconciled = func(members map[pb.Journal]pb.JournalSpec) {}
+
+// ReadUncommittedIter is an iterator over the merged Messages of all journals
+// matched by a LabelSelector, such as the partitions of a topic. It adapts a
+// TailSelector for use where an iterator of Envelopes is expected. As with
+// TailSelector, Messages are read uncommitted: they're returned as read, and
+// aren't de-duplicated or sequenced.
+type ReadUncommittedIter struct {
+	envCh <-chan Envelope
+	errCh <-chan error
+	err   error
+}
+
+// NewReadUncommittedIter returns a ReadUncommittedIter over Messages of all
+// journals matched by |sel|, which is backed by a TailSelector. Arguments are
+// as for TailSelector. The caller must cancel |ctx| once it's done with the
+// ReadUncommittedIter, to release the TailSelector.
+func NewReadUncommittedIter(
+	ctx context.Context,
+	rjc pb.RoutedJournalClient,
+	sel pb.LabelSelector,
+	dur time.Duration,
+	newMsg func(*pb.JournalSpec) (Message, error),
+) *ReadUncommittedIter {
+	var envCh, errCh = TailSelector(ctx, rjc, sel, dur, newMsg)
+	return &ReadUncommittedIter{envCh: envCh, errCh: errCh}
+}
+
+// Next blocks until the next Message of any matched journal is available,
+// and returns its Envelope. If the TailSelector fails or |ctx| is cancelled,
+// Next returns the terminal error, as do all further calls to Next.
+func (it *ReadUncommittedIter) Next() (Envelope, error) {
+	if it.err != nil {
+		return Envelope{}, it.err
+	} else if env, ok := <-it.envCh; ok {
+		return env, nil
+	}
+	it.err = <-it.errCh
+	return Envelope{}, it.err
+}
@@ -0,0 +1,307 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	gc "github.com/go-check/check"
+	"go.gazette.dev/core/broker/client"
+	pb "go.gazette.dev/core/broker/protocol"
+	"go.gazette.dev/core/brokertest"
+	"go.gazette.dev/core/etcdtest"
+	"go.gazette.dev/core/labels"
+)
+
+type TailSuite struct{}
+
+func (s *TailSuite) TestTailUntilCancelled(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx, cancel = context.WithCancel(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(context.Background(), rjc)
+
+	var spec = brokertest.Journal(pb.JournalSpec{
+		Name:     "a/journal",
+		LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	})
+	brokertest.CreateJournals(c, bk, spec)
+
+	type msg struct{ N int }
+
+	var envCh, errCh = Tail(ctx, rjc, pb.ReadRequest{Journal: spec.Name},
+		func(*pb.JournalSpec) (Message, error) { return new(msg), nil })
+
+	// Expect messages are streamed as they're appended.
+	for n := 0; n != 3; n++ {
+		var aa = as.StartAppend(spec.Name)
+		_, _ = fmt.Fprintf(aa.Writer(), "{\"N\":%d}\n", n)
+		c.Assert(aa.Release(), gc.IsNil)
+		<-aa.Done()
+
+		var env = <-envCh
+		c.Check(env.Message, gc.DeepEquals, &msg{N: n})
+		c.Check(env.NextOffset, gc.Equals, aa.Response().Commit.End)
+		c.Check(env.JournalSpec.Name, gc.Equals, spec.Name)
+	}
+
+	// Cancellation stops the Tail, and closes both channels.
+	cancel()
+	c.Check(<-errCh, gc.Equals, context.Canceled)
+
+	var _, ok = <-envCh
+	c.Check(ok, gc.Equals, false)
+	_, ok = <-errCh
+	c.Check(ok, gc.Equals, false)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *TailSuite) TestTailErrors(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx = context.Background()
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(ctx, rjc)
+
+	var spec = brokertest.Journal(pb.JournalSpec{
+		Name:     "a/journal",
+		LabelSet: pb.MustLabelSet(labels.ContentType, labels.ContentType_JSONLines),
+	})
+	brokertest.CreateJournals(c, bk, spec)
+
+	var newMsg = func(*pb.JournalSpec) (Message, error) { return new(struct{ N int }), nil }
+
+	// Case: the journal doesn't exist.
+	var envCh, errCh = Tail(ctx, rjc, pb.ReadRequest{Journal: "does/not/exist"}, newMsg)
+	c.Check(<-errCh, gc.ErrorMatches, `named journal does not exist \(does/not/exist\)`)
+	var _, ok = <-envCh
+	c.Check(ok, gc.Equals, false)
+
+	// Case: a message fails to unmarshal.
+	var aa = as.StartAppend(spec.Name)
+	_, _ = aa.Writer().WriteString("not JSON\n")
+	c.Assert(aa.Release(), gc.IsNil)
+	<-aa.Done()
+
+	envCh, errCh = Tail(ctx, rjc, pb.ReadRequest{Journal: spec.Name}, newMsg)
+	c.Check(<-errCh, gc.ErrorMatches, `unmarshal message \(a/journal:0\): invalid character .*`)
+	_, ok = <-envCh
+	c.Check(ok, gc.Equals, false)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *TailSuite) TestTailSelectorMembershipChanges(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx, cancel = context.WithCancel(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(context.Background(), rjc)
+
+	var partition = func(name pb.Journal, topic string) *pb.JournalSpec {
+		return brokertest.Journal(pb.JournalSpec{
+			Name: name,
+			LabelSet: pb.MustLabelSet(
+				labels.ContentType, labels.ContentType_JSONLines,
+				"topic", topic,
+			),
+		})
+	}
+	brokertest.CreateJournals(c, bk,
+		partition("a/part-000", "a"),
+		partition("a/part-001", "a"),
+		partition("b/part-000", "b"),
+	)
+
+	type msg struct{ N int }
+
+	var appendTo = func(journal pb.Journal, n int) {
+		var aa = as.StartAppend(journal)
+		_, _ = fmt.Fprintf(aa.Writer(), "{\"N\":%d}\n", n)
+		c.Assert(aa.Release(), gc.IsNil)
+		<-aa.Done()
+	}
+	// Receive |count| Envelopes, verifying each is in-order for its journal.
+	var nextN = make(map[pb.Journal]int)
+	var receive = func(envCh <-chan Envelope, count int) {
+		for ; count != 0; count-- {
+			var env = <-envCh
+			c.Check(env.Message, gc.DeepEquals, &msg{N: nextN[env.JournalSpec.Name]})
+			nextN[env.JournalSpec.Name]++
+		}
+	}
+
+	// Track members of the topic, as most recently reconciled by the TailSelector.
+	var mu sync.Mutex
+	var reconciled map[pb.Journal]pb.JournalSpec
+
+	tailSelectorReconciled = func(members map[pb.Journal]pb.JournalSpec) {
+		mu.Lock()
+		reconciled = members
+		mu.Unlock()
+	}
+	defer func() { tailSelectorReconciled = func(map[pb.Journal]pb.JournalSpec) {} }()
+
+	var envCh, errCh = TailSelector(ctx, rjc,
+		pb.LabelSelector{Include: pb.MustLabelSet("topic", "a")},
+		10*time.Millisecond,
+		func(*pb.JournalSpec) (Message, error) { return new(msg), nil })
+
+	// Expect messages of each partition are streamed in order,
+	// and messages of other topics aren't streamed.
+	for n := 0; n != 3; n++ {
+		appendTo("a/part-000", n)
+		appendTo("a/part-001", n)
+		appendTo("b/part-000", n)
+	}
+	receive(envCh, 6)
+
+	// A partition is added to the topic. It's read from its beginning.
+	brokertest.CreateJournals(c, bk, partition("a/part-002", "a"))
+	appendTo("a/part-002", 0)
+	appendTo("a/part-002", 1)
+	receive(envCh, 2)
+
+	// A partition is removed from the topic. Its Tail is cancelled, and
+	// remaining partitions continue to be streamed.
+	var lr, err = client.ListAllJournals(ctx, rjc, pb.ListRequest{
+		Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", "a/part-000")},
+	})
+	c.Assert(err, gc.IsNil)
+	_, err = client.ApplyJournals(ctx, rjc, &pb.ApplyRequest{
+		Changes: []pb.ApplyRequest_Change{{
+			Delete:            "a/part-000",
+			ExpectModRevision: lr.Journals[0].ModRevision,
+		}},
+	})
+	c.Assert(err, gc.IsNil)
+
+	appendTo("a/part-001", 3)
+	appendTo("a/part-002", 2)
+	receive(envCh, 2)
+
+	// A partition leaves the topic and later re-joins it. It resumes from the
+	// offset through which it was read, and messages aren't re-streamed.
+	var relabel = func(topic string) {
+		lr, err = client.ListAllJournals(ctx, rjc, pb.ListRequest{
+			Selector: pb.LabelSelector{Include: pb.MustLabelSet("name", "a/part-002")},
+		})
+		c.Assert(err, gc.IsNil)
+
+		var spec = lr.Journals[0].Spec
+		spec.LabelSet = partition(spec.Name, topic).LabelSet
+
+		_, err = client.ApplyJournals(ctx, rjc, &pb.ApplyRequest{
+			Changes: []pb.ApplyRequest_Change{{
+				Upsert:            &spec,
+				ExpectModRevision: lr.Journals[0].ModRevision,
+			}},
+		})
+		c.Assert(err, gc.IsNil)
+
+		// Wait for the TailSelector to reconcile the partition's membership.
+		for {
+			mu.Lock()
+			var _, ok = reconciled[spec.Name]
+			mu.Unlock()
+
+			if ok == (topic == "a") {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	relabel("b")
+	appendTo("a/part-002", 3)
+	relabel("a")
+	appendTo("a/part-002", 4)
+	receive(envCh, 2)
+
+	c.Check(nextN, gc.DeepEquals, map[pb.Journal]int{
+		"a/part-000": 3,
+		"a/part-001": 4,
+		"a/part-002": 5,
+	})
+
+	// Cancellation stops the TailSelector, and closes both channels.
+	cancel()
+	c.Check(<-errCh, gc.Equals, context.Canceled)
+
+	var _, ok = <-envCh
+	c.Check(ok, gc.Equals, false)
+	_, ok = <-errCh
+	c.Check(ok, gc.Equals, false)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+func (s *TailSuite) TestReadUncommittedIter(c *gc.C) {
+	var etcd = etcdtest.TestClient()
+	defer etcdtest.Cleanup()
+
+	var bk = brokertest.NewBroker(c, etcd, "local", "broker")
+	var ctx, cancel = context.WithCancel(context.Background())
+	var rjc = pb.NewRoutedJournalClient(bk.Client(), pb.NoopDispatchRouter{})
+	var as = client.NewAppendService(context.Background(), rjc)
+
+	var partition = func(name pb.Journal) *pb.JournalSpec {
+		return brokertest.Journal(pb.JournalSpec{
+			Name: name,
+			LabelSet: pb.MustLabelSet(
+				labels.ContentType, labels.ContentType_JSONLines,
+				"topic", "a",
+			),
+		})
+	}
+	brokertest.CreateJournals(c, bk, partition("a/part-000"), partition("a/part-001"))
+
+	type msg struct{ N int }
+
+	for n := 0; n != 2; n++ {
+		for _, journal := range []pb.Journal{"a/part-000", "a/part-001"} {
+			var aa = as.StartAppend(journal)
+			_, _ = fmt.Fprintf(aa.Writer(), "{\"N\":%d}\n", n)
+			c.Assert(aa.Release(), gc.IsNil)
+			<-aa.Done()
+		}
+	}
+
+	var it = NewReadUncommittedIter(ctx, rjc,
+		pb.LabelSelector{Include: pb.MustLabelSet("topic", "a")},
+		10*time.Millisecond,
+		func(*pb.JournalSpec) (Message, error) { return new(msg), nil })
+
+	// Expect each partition's messages are iterated in order.
+	var nextN = make(map[pb.Journal]int)
+	for i := 0; i != 4; i++ {
+		var env, err = it.Next()
+		c.Assert(err, gc.IsNil)
+		c.Check(env.Message, gc.DeepEquals, &msg{N: nextN[env.JournalSpec.Name]})
+		nextN[env.JournalSpec.Name]++
+	}
+	c.Check(nextN, gc.DeepEquals, map[pb.Journal]int{"a/part-000": 2, "a/part-001": 2})
+
+	// Cancellation is returned by Next, as it is by all further calls.
+	cancel()
+	var _, err = it.Next()
+	c.Check(err, gc.Equals, context.Canceled)
+	_, err = it.Next()
+	c.Check(err, gc.Equals, context.Canceled)
+
+	bk.Tasks.Cancel()
+	c.Check(bk.Tasks.Wait(), gc.IsNil)
+}
+
+var _ = gc.Suite(&TailSuite{})
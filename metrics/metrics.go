@@ -15,9 +15,18 @@ const (
 	AllocatorNumItemSlotsKey            = "gazette_allocator_desired_replication_slots"
 	AllocatorNumItemsKey                = "gazette_allocator_items"
 	AllocatorNumMembersKey              = "gazette_allocator_members"
+	AppendFSMReadBarrierSecondsKey      = "gazette_append_fsm_read_barrier_seconds"
+	AppendFSMStateSecondsKey            = "gazette_append_fsm_state_seconds"
+	AppendFSMStatesKey                  = "gazette_append_fsm_states"
+	AppendFSMStateTransitionsTotalKey   = "gazette_append_fsm_state_transitions_total"
 	CommitsTotalKey                     = "gazette_commits_total"
 	CommittedBytesTotalKey              = "gazette_committed_bytes_total"
 	JournalServerResponseTimeSecondsKey = "gazette_journal_server_response_time_seconds"
+	PersisterQueuedBytesKey             = "gazette_persister_queued_bytes"
+	PersisterQueuedSpoolsKey            = "gazette_persister_queued_spools"
+	PipelinePeerAckSecondsKey           = "gazette_pipeline_peer_ack_seconds"
+	PipelinePeerErrorsTotalKey          = "gazette_pipeline_peer_errors_total"
+	PipelinePeerSendSecondsKey          = "gazette_pipeline_peer_send_seconds"
 	RecoveryLogRecoveredBytesTotalKey   = "gazette_recoverylog_recovered_bytes_total"
 	StorePersistedBytesTotalKey         = "gazette_store_persisted_bytes_total"
 	StoreRequestsTotalKey               = "gazette_store_requests_total"
@@ -60,6 +69,24 @@ var (
 		Name: AllocatorNumMembersKey,
 		Help: "Number of members known to the allocator.",
 	})
+	AppendFSMReadBarrierSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    AppendFSMReadBarrierSecondsKey,
+		Help:    "Duration appends waited on the pipeline read barrier for prior appends to read their acknowledgements.",
+		Buckets: appendFSMBuckets,
+	}, []string{"journal"})
+	AppendFSMStateSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    AppendFSMStateSecondsKey,
+		Help:    "Duration appends spent in each appendFSM state, by terminal outcome.",
+		Buckets: appendFSMBuckets,
+	}, []string{"state", "outcome", "journal"})
+	AppendFSMStates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: AppendFSMStatesKey,
+		Help: "Number of appends currently in each appendFSM state.",
+	}, []string{"state"})
+	AppendFSMStateTransitionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: AppendFSMStateTransitionsTotalKey,
+		Help: "Cumulative number of appendFSM transitions into each state.",
+	}, []string{"state"})
 	CommittedBytesTotal = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: CommittedBytesTotalKey,
 		Help: "Cumulative number of bytes committed to journals.",
@@ -84,8 +111,34 @@ var (
 		Name: JournalServerResponseTimeSecondsKey,
 		Help: "Response time of JournalServer.Append.",
 	}, []string{"operation", "status"})
+	PersisterQueuedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: PersisterQueuedBytesKey,
+		Help: "Content bytes of completed fragments which are queued for persistence.",
+	})
+	PersisterQueuedSpools = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: PersisterQueuedSpoolsKey,
+		Help: "Number of completed fragments which are queued for persistence.",
+	})
+	PipelinePeerAckSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    PipelinePeerAckSecondsKey,
+		Help:    "Duration from the send of an acknowledged replication proposal, until its acknowledgement by each pipeline peer.",
+		Buckets: appendFSMBuckets,
+	}, []string{"peer"})
+	PipelinePeerErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: PipelinePeerErrorsTotalKey,
+		Help: "Cumulative number of replication pipeline errors, by pipeline peer and operation.",
+	}, []string{"peer", "operation"})
+	PipelinePeerSendSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    PipelinePeerSendSecondsKey,
+		Help:    "Duration of replication request sends to each pipeline peer.",
+		Buckets: appendFSMBuckets,
+	}, []string{"peer"})
 )
 
+// appendFSMBuckets range from 100µs to ~26s, as read barrier stalls of
+// healthy pipelines are typically well under a millisecond.
+var appendFSMBuckets = prometheus.ExponentialBuckets(0.0001, 4, 10)
+
 // GazetteBrokerCollectors lists collectors used by the gazette broker.
 func GazetteBrokerCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
@@ -97,9 +150,18 @@ func GazetteBrokerCollectors() []prometheus.Collector {
 		AllocatorNumItemSlots,
 		AllocatorNumItems,
 		AllocatorNumMembers,
+		AppendFSMReadBarrierSeconds,
+		AppendFSMStateSeconds,
+		AppendFSMStates,
+		AppendFSMStateTransitionsTotal,
 		CommitsTotal,
 		CommittedBytesTotal,
 		JournalServerResponseTimeSeconds,
+		PersisterQueuedBytes,
+		PersisterQueuedSpools,
+		PipelinePeerAckSeconds,
+		PipelinePeerErrorsTotal,
+		PipelinePeerSendSeconds,
 		StorePersistedBytesTotal,
 		StoreRequestTotal,
 	}
@@ -162,21 +224,51 @@ func GazretentionCollectors() []prometheus.Collector {
 
 // Keys for gazette.Client and gazette.WriteService metrics.
 const (
-	GazetteDiscardBytesTotalKey         = "gazette_discard_bytes_total"
-	GazetteReadBytesTotalKey            = "gazette_read_bytes_total"
-	GazetteWriteBytesTotalKey           = "gazette_write_bytes_total"
-	GazetteWriteCountTotalKey           = "gazette_write_count_total"
-	GazetteWriteDurationSecondsTotalKey = "gazette_write_duration_seconds_total"
-	GazetteWriteFailureTotalKey         = "gazette_write_failure_total"
+	GazetteDecompressionInputBytesTotalKey  = "gazette_decompression_input_bytes_total"
+	GazetteDecompressionOutputBytesTotalKey = "gazette_decompression_output_bytes_total"
+	GazetteDecompressionSecondsTotalKey     = "gazette_decompression_seconds_total"
+	GazetteDiscardBytesTotalKey             = "gazette_discard_bytes_total"
+	GazetteFragmentCircuitOpenKey           = "gazette_fragment_circuit_open"
+	GazetteFragmentCircuitRejectedTotalKey  = "gazette_fragment_circuit_rejected_total"
+	GazetteFragmentCircuitTripsTotalKey     = "gazette_fragment_circuit_trips_total"
+	GazetteReadBytesTotalKey                = "gazette_read_bytes_total"
+	GazetteWriteBytesTotalKey               = "gazette_write_bytes_total"
+	GazetteWriteCountTotalKey               = "gazette_write_count_total"
+	GazetteWriteDurationSecondsTotalKey     = "gazette_write_duration_seconds_total"
+	GazetteWriteFailureTotalKey             = "gazette_write_failure_total"
 )
 
 // Collectors for gazette.Client and gazette.WriteService metrics.
 // TODO(rupert): Should prefix be GazetteClient-, "gazette_client_-"?
 var (
+	GazetteDecompressionInputBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: GazetteDecompressionInputBytesTotalKey,
+		Help: "Cumulative number of compressed fragment bytes read by instrumented FragmentReaders.",
+	}, []string{"codec"})
+	GazetteDecompressionOutputBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: GazetteDecompressionOutputBytesTotalKey,
+		Help: "Cumulative number of decompressed fragment bytes produced by instrumented FragmentReaders.",
+	}, []string{"codec"})
+	GazetteDecompressionSecondsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: GazetteDecompressionSecondsTotalKey,
+		Help: "Cumulative number of seconds spent decompressing by instrumented FragmentReaders.",
+	}, []string{"codec"})
 	GazetteDiscardBytesTotal = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: GazetteDiscardBytesTotalKey,
 		Help: "Cumulative number of bytes read but discarded.",
 	})
+	GazetteFragmentCircuitOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: GazetteFragmentCircuitOpenKey,
+		Help: "Whether the fragment fetch circuit of a store host has tripped, and not since been reset by a successful fetch.",
+	}, []string{"host"})
+	GazetteFragmentCircuitRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: GazetteFragmentCircuitRejectedTotalKey,
+		Help: "Cumulative number of fragment fetches rejected by an open circuit of the store host.",
+	}, []string{"host"})
+	GazetteFragmentCircuitTripsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: GazetteFragmentCircuitTripsTotalKey,
+		Help: "Cumulative number of trips of the fragment fetch circuit of a store host.",
+	}, []string{"host"})
 	GazetteReadBytesTotal = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: GazetteReadBytesTotalKey,
 		Help: "Cumulative number of bytes read.",
@@ -203,7 +295,13 @@ var (
 // gazette.WriteService.
 func GazetteClientCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
+		GazetteDecompressionInputBytesTotal,
+		GazetteDecompressionOutputBytesTotal,
+		GazetteDecompressionSecondsTotal,
 		GazetteDiscardBytesTotal,
+		GazetteFragmentCircuitOpen,
+		GazetteFragmentCircuitRejectedTotal,
+		GazetteFragmentCircuitTripsTotal,
 		GazetteReadBytesTotal,
 		GazetteWriteBytesTotal,
 		GazetteWriteCountTotal,
@@ -221,6 +319,7 @@ const (
 	GazetteConsumerTxFlushSecondsTotalKey   = "gazette_consumer_tx_flush_seconds_total"
 	GazetteConsumerTxSyncSecondsTotalKey    = "gazette_consumer_tx_sync_seconds_total"
 	GazetteConsumerConsumedBytesTotalKey    = "gazette_consumer_consumed_bytes_total"
+	GazetteConsumerShardLagBytesKey         = "gazette_consumer_shard_lag_bytes"
 )
 
 // Collectors for consumer.Runner metrics.
@@ -257,6 +356,10 @@ var (
 		Name: GazetteConsumerConsumedBytesTotalKey,
 		Help: "Cumulative number of bytes consumed.",
 	})
+	GazetteConsumerShardLagBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: GazetteConsumerShardLagBytesKey,
+		Help: "Bytes between the journal write head and the last consumed offset, by shard and source journal.",
+	}, []string{"shard", "journal"})
 )
 
 // GazetteConsumerCollectors returns the metrics used by the consumer package.
@@ -269,5 +372,6 @@ func GazetteConsumerCollectors() []prometheus.Collector {
 		GazetteConsumerTxStalledSecondsTotal,
 		GazetteConsumerTxFlushSecondsTotal,
 		GazetteConsumerBytesConsumedTotal,
+		GazetteConsumerShardLagBytes,
 	}
 }